@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BooleanOutput controls how values of boolean columns are returned. PostgreSQL reports
+// booleans as true/false while MySQL, where BOOLEAN is an alias for TINYINT(1), reports
+// them as 1/0; the bool and int modes make both drivers return the same representation.
+type BooleanOutput string
+
+const (
+	// BooleanNative returns boolean values as the driver reports them
+	BooleanNative BooleanOutput = "native"
+
+	// BooleanBool returns boolean values as true/false
+	BooleanBool BooleanOutput = "bool"
+
+	// BooleanInt returns boolean values as 1/0
+	BooleanInt BooleanOutput = "int"
+)
+
+// ParseBooleanOutput converts a configured boolean output name into a BooleanOutput.
+// Names are case-insensitive; an empty name selects BooleanNative.
+func ParseBooleanOutput(output string) (BooleanOutput, error) {
+	switch normalized := BooleanOutput(strings.ToLower(strings.TrimSpace(output))); normalized {
+	case "":
+		return BooleanNative, nil
+	case BooleanNative, BooleanBool, BooleanInt:
+		return normalized, nil
+	default:
+		return BooleanNative, fmt.Errorf("invalid boolean output: %s (valid values: native, bool, int)", output)
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import "testing"
+
+func TestParseBooleanOutput(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    BooleanOutput
+		wantErr bool
+	}{
+		{"", BooleanNative, false},
+		{"native", BooleanNative, false},
+		{"BOOL", BooleanBool, false},
+		{" int ", BooleanInt, false},
+		{"boolean", BooleanNative, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseBooleanOutput(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBooleanOutput(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBooleanOutput(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -5,11 +5,30 @@ package config
 import (
 	"fmt"
 	"slices"
+	"time"
 )
 
 // Config represents the complete configuration for the database MCP server.
 type Config struct {
-	Database DatabaseConfig `json:"database"` // Database connection configuration
+	Database    DatabaseConfig            `json:"database"`    // Default database connection configuration
+	Connections map[string]DatabaseConfig `json:"connections"` // All configured connections by name, including DefaultConnection
+	Server      ServerConfig              `json:"server"`      // MCP server behaviour configuration
+}
+
+// DefaultConnection is the name of the connection configured by the unprefixed DB_* variables.
+const DefaultConnection = "default"
+
+// NamedConnectionPrefix is the environment variable prefix for additional named connections.
+// DB_CONNECTION_STRING_ANALYTICS=postgresql://... configures a connection named "analytics".
+const NamedConnectionPrefix = "DB_CONNECTION_STRING_"
+
+// ServerConfig contains settings for the MCP server itself, independent of the database connection.
+type ServerConfig struct {
+	AuditLog     bool   `json:"audit_log" envconfig:"MCP_AUDIT_LOG"`           // Record every executed query as a JSON line
+	AuditLogPath string `json:"audit_log_path" envconfig:"MCP_AUDIT_LOG_PATH"` // Audit log file (empty means stderr)
+	MetricsPort  int    `json:"metrics_port" envconfig:"METRICS_PORT"`         // Port serving Prometheus metrics on /metrics (0 disables metrics)
+	LogFormat    string `json:"log_format" envconfig:"LOG_FORMAT"`             // Log output format: "text" or "json"
+	LogLevel     string `json:"log_level" envconfig:"LOG_LEVEL"`               // Minimum log level: "debug", "info", "warn" or "error"
 }
 
 // DatabaseConfig contains all settings required to connect to a database.
@@ -26,12 +45,128 @@ type DatabaseConfig struct {
 	Database string `json:"database" envconfig:"DB_NAME"`     // Primary database name to connect to
 	Username string `json:"username" envconfig:"DB_USER"`     // Database username
 	Password string `json:"password" envconfig:"DB_PASSWORD"` // Database password
-	SSLMode  string `json:"ssl_mode" envconfig:"DB_SSL_MODE"` // SSL/TLS mode: "none", "prefer", or "require"
+	SSLMode  string `json:"ssl_mode" envconfig:"DB_SSL_MODE"` // SSL/TLS mode: "none", "prefer", "require", "verify-ca" or "verify-full"
+
+	// Certificate files for mutual TLS (apply to both approaches)
+	SSLCert     string `json:"ssl_cert" envconfig:"DB_SSL_CERT"`           // PEM client certificate presented to the server
+	SSLKey      string `json:"ssl_key" envconfig:"DB_SSL_KEY"`             // PEM private key of the client certificate
+	SSLRootCert string `json:"ssl_root_cert" envconfig:"DB_SSL_ROOT_CERT"` // PEM CA certificates used to verify the server
+	SSLVerify   bool   `json:"ssl_verify" envconfig:"DB_SSL_VERIFY"`       // Verify the server certificate and host name, as with SSL mode verify-full
 
 	// Additional configuration (applies to both approaches)
-	AllowedDatabases []string `json:"allowed_databases" envconfig:"DB_ALLOWED_NAMES"` // List of allowed database names (empty means all allowed)
-	MaxConns         int      `json:"max_conns" envconfig:"DB_MAX_CONNS"`             // Maximum number of open connections
-	MaxIdleConns     int      `json:"max_idle_conns" envconfig:"DB_MAX_IDLE_CONNS"`   // Maximum number of idle connections
+	AllowedDatabases     []string      `json:"allowed_databases" envconfig:"DB_ALLOWED_NAMES"`               // List of allowed database names (empty means all allowed)
+	MaxConns             int           `json:"max_conns" envconfig:"DB_MAX_CONNS"`                           // Maximum number of open connections
+	MaxIdleConns         int           `json:"max_idle_conns" envconfig:"DB_MAX_IDLE_CONNS"`                 // Maximum number of idle connections
+	ConnMaxLifetime      time.Duration `json:"conn_max_lifetime" envconfig:"DB_CONN_MAX_LIFETIME"`           // Longest time a connection is reused before it is closed (0 keeps connections forever)
+	ConnMaxIdleTime      time.Duration `json:"conn_max_idle_time" envconfig:"DB_CONN_MAX_IDLE_TIME"`         // Longest time a connection may sit idle before it is closed (0 keeps idle connections)
+	StatementPrefix      string        `json:"statement_prefix" envconfig:"DB_STATEMENT_PREFIX"`             // Comment prepended to every executed statement (e.g. proxy routing hints)
+	Schema               string        `json:"schema" envconfig:"DB_SCHEMA"`                                 // PostgreSQL schema to introspect, or "*" for every non-system schema
+	MaxResultRows        int           `json:"max_result_rows" envconfig:"DB_MAX_RESULT_ROWS"`               // Maximum number of rows returned by a single SELECT
+	MaxResultBytes       int           `json:"max_result_bytes" envconfig:"DB_MAX_RESULT_BYTES"`             // Largest result, in bytes of row data, read before a query is aborted (0 disables the limit)
+	ReadOnly             bool          `json:"read_only" envconfig:"DB_READ_ONLY"`                           // Reject all DML and DDL statements when true
+	AutoReadOnly         bool          `json:"auto_read_only" envconfig:"DB_AUTO_READ_ONLY"`                 // Enable ReadOnly on connect when the server is a replica in recovery or has read_only set
+	AllowedStatements    []string      `json:"allowed_statements" envconfig:"DB_ALLOWED_STATEMENTS"`         // Statement types to permit: select, insert, update, delete, ddl (empty permits all)
+	QueryTimeout         time.Duration `json:"query_timeout" envconfig:"DB_QUERY_TIMEOUT"`                   // Maximum execution time per query (e.g. "30s"); zero disables the timeout
+	IsolationLevel       string        `json:"isolation_level" envconfig:"DB_ISOLATION_LEVEL"`               // Default isolation level for transactions (e.g. "read-committed")
+	BlockedPatterns      []string      `json:"blocked_patterns" envconfig:"DB_BLOCKED_PATTERNS"`             // Additional query patterns to reject, on top of the built-in list
+	AllowedPatterns      []string      `json:"allowed_patterns" envconfig:"DB_ALLOWED_PATTERNS"`             // Built-in blocked patterns to permit (e.g. "SP_")
+	AllowComments        bool          `json:"allow_comments" envconfig:"DB_ALLOW_COMMENTS"`                 // Permit SQL comments ("--", "/* */") in queries
+	MaxQueryLength       int           `json:"max_query_length" envconfig:"DB_MAX_QUERY_LENGTH"`             // Longest query, in bytes, accepted for validation and execution
+	MaxSubqueries        int           `json:"max_subqueries" envconfig:"DB_MAX_SUBQUERIES"`                 // Most subqueries (SELECTs beyond the first) a query may contain
+	MaxJoins             int           `json:"max_joins" envconfig:"DB_MAX_JOINS"`                           // Most JOINs a query may contain
+	RequireParams        bool          `json:"require_params" envconfig:"DB_REQUIRE_PARAMS"`                 // Reject inline literals in WHERE clauses, requiring bound parameters
+	RowFilters           string        `json:"row_filters" envconfig:"DB_ROW_FILTERS"`                       // Per-table predicates ANDed into every query, e.g. "orders: tenant_id = :tenant"
+	RowFilterParams      string        `json:"row_filter_params" envconfig:"DB_ROW_FILTER_PARAMS"`           // Values bound to the row filter parameters, e.g. "tenant=42"
+	StatementCacheSize   int           `json:"statement_cache_size" envconfig:"DB_STATEMENT_CACHE_SIZE"`     // Number of prepared statements cached per connection pool (0 disables caching)
+	ConnectRetries       int           `json:"connect_retries" envconfig:"DB_CONNECT_RETRIES"`               // Number of times a failed initial connection is retried (0 fails immediately)
+	ConnectRetryInterval time.Duration `json:"connect_retry_interval" envconfig:"DB_CONNECT_RETRY_INTERVAL"` // Delay before the first retry (e.g. "1s"); doubles on each further retry
+	StatisticsMaxRows    int64         `json:"statistics_max_rows" envconfig:"DB_STATISTICS_MAX_ROWS"`       // Largest table (in rows) the table_statistics tool will profile
+	ProfileMaxRows       int64         `json:"profile_max_rows" envconfig:"DB_PROFILE_MAX_ROWS"`             // Largest table (in rows) the profile_table tool will profile
+	MaxInsertRows        int           `json:"max_insert_rows" envconfig:"DB_MAX_INSERT_ROWS"`               // Most rows a single insert_rows call may insert
+	ZeroDateBehavior     string        `json:"zero_date_behavior" envconfig:"DB_ZERO_DATE_BEHAVIOR"`         // How MySQL zero/invalid dates are returned: "null", "string" or "error"
+	BooleanOutput        string        `json:"boolean_output" envconfig:"DB_BOOLEAN_OUTPUT"`                 // How boolean column values are returned: "native", "bool" or "int"
+	TrimStrings          bool          `json:"trim_strings" envconfig:"DB_TRIM_STRINGS"`                     // Trim leading and trailing whitespace from string column values in results
+	MySQLAuth            []string      `json:"mysql_auth" envconfig:"DB_MYSQL_AUTH"`                         // MySQL password methods to allow besides caching_sha2_password: "native", "cleartext", "old"
+}
+
+// DefaultSchema is the PostgreSQL schema used when DB_SCHEMA is not set.
+const DefaultSchema = "public"
+
+// AllSchemas as DB_SCHEMA lists the tables of every non-system PostgreSQL schema, qualified
+// with their schema name.
+const AllSchemas = "*"
+
+// DefaultMaxResultRows is the SELECT row cap used when DB_MAX_RESULT_ROWS is not set.
+const DefaultMaxResultRows = 10000
+
+// DefaultMaxQueryLength is the query length limit used when DB_MAX_QUERY_LENGTH is not set.
+const DefaultMaxQueryLength = 1 << 20
+
+// Query complexity limits used when DB_MAX_SUBQUERIES and DB_MAX_JOINS are not set.
+const (
+	DefaultMaxSubqueries = 5
+	DefaultMaxJoins      = 10
+)
+
+// MaxPatternLength is the longest entry accepted in DB_BLOCKED_PATTERNS and DB_ALLOWED_PATTERNS.
+const MaxPatternLength = 256
+
+// DefaultStatisticsMaxRows is the table_statistics row threshold used when DB_STATISTICS_MAX_ROWS is not set.
+const DefaultStatisticsMaxRows = 1000000
+
+// DefaultProfileMaxRows is the profile_table row threshold used when DB_PROFILE_MAX_ROWS is not set.
+const DefaultProfileMaxRows = 500000
+
+// DefaultMaxInsertRows is the insert_rows row cap used when DB_MAX_INSERT_ROWS is not set.
+const DefaultMaxInsertRows = 1000
+
+// Connection retry defaults used when DB_CONNECT_RETRIES and DB_CONNECT_RETRY_INTERVAL are not set.
+const (
+	DefaultConnectRetries       = 3
+	DefaultConnectRetryInterval = time.Second
+)
+
+// Connection lifetime defaults used when DB_CONN_MAX_LIFETIME and DB_CONN_MAX_IDLE_TIME are not set.
+const (
+	DefaultConnMaxLifetime = 5 * time.Minute
+	DefaultConnMaxIdleTime = 30 * time.Second
+)
+
+// PoolDefaults are the connection pool sizes used for a database type when
+// DB_MAX_CONNS or DB_MAX_IDLE_CONNS are not set.
+type PoolDefaults struct {
+	MaxConns     int // Default maximum number of open connections
+	MaxIdleConns int // Default maximum number of idle connections
+}
+
+// defaultPoolSizes holds the per-engine pool defaults. PostgreSQL gets a larger pool
+// since it is commonly deployed behind a pooler such as PgBouncer, where client
+// connections are cheap; MySQL keeps the historical defaults.
+var defaultPoolSizes = map[string]PoolDefaults{
+	"mysql":    {MaxConns: 10, MaxIdleConns: 5},
+	"postgres": {MaxConns: 20, MaxIdleConns: 10},
+}
+
+// DefaultPoolSize returns the pool defaults for dbType, falling back to the
+// MySQL defaults for unknown types.
+func DefaultPoolSize(dbType string) PoolDefaults {
+	if defaults, ok := defaultPoolSizes[dbType]; ok {
+		return defaults
+	}
+	return defaultPoolSizes["mysql"]
+}
+
+// ApplyPoolDefaults fills in MaxConns and MaxIdleConns from the engine defaults
+// when they are not configured. Configured values are always kept; a defaulted
+// MaxIdleConns never exceeds MaxConns.
+func (cfg *DatabaseConfig) ApplyPoolDefaults() {
+	defaults := DefaultPoolSize(cfg.Type)
+	if cfg.MaxConns == 0 {
+		cfg.MaxConns = defaults.MaxConns
+	}
+	if cfg.MaxIdleConns == 0 {
+		cfg.MaxIdleConns = min(defaults.MaxIdleConns, cfg.MaxConns)
+	}
 }
 
 // IsDatabaseAllowed checks if a database name is allowed to be accessed.
@@ -54,7 +189,11 @@ func (cfg *DatabaseConfig) IsDatabaseAllowed(databaseName string) bool {
 
 // ValidateSSLMode checks if the configured SSL mode is valid and returns
 // the parsed SSLMode. If no SSL mode is configured, it returns SSLModePrefer as default.
+// DB_SSL_VERIFY raises the mode to SSLModeVerifyFull.
 func (cfg *DatabaseConfig) ValidateSSLMode() (SSLMode, error) {
+	if cfg.SSLVerify {
+		return SSLModeVerifyFull, nil
+	}
 	if cfg.SSLMode == "" {
 		return SSLModePrefer, nil
 	}
@@ -201,3 +201,31 @@ func TestDatabaseConfig_ApplyConnectionStringDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabaseConfig_ApplyPoolDefaults(t *testing.T) {
+	tests := []struct {
+		name             string
+		cfg              DatabaseConfig
+		wantMaxConns     int
+		wantMaxIdleConns int
+	}{
+		{"postgres unset", DatabaseConfig{Type: "postgres"}, 20, 10},
+		{"mysql unset", DatabaseConfig{Type: "mysql"}, 10, 5},
+		{"unknown type uses mysql defaults", DatabaseConfig{Type: "other"}, 10, 5},
+		{"both set", DatabaseConfig{Type: "postgres", MaxConns: 7, MaxIdleConns: 3}, 7, 3},
+		{"only max conns set", DatabaseConfig{Type: "postgres", MaxConns: 50}, 50, 10},
+		{"idle default capped", DatabaseConfig{Type: "postgres", MaxConns: 4}, 4, 4},
+		{"only idle set", DatabaseConfig{Type: "mysql", MaxIdleConns: 2}, 10, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.ApplyPoolDefaults()
+			if cfg.MaxConns != tt.wantMaxConns || cfg.MaxIdleConns != tt.wantMaxIdleConns {
+				t.Errorf("ApplyPoolDefaults() = %d/%d, want %d/%d",
+					cfg.MaxConns, cfg.MaxIdleConns, tt.wantMaxConns, tt.wantMaxIdleConns)
+			}
+		})
+	}
+}
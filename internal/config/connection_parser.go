@@ -90,6 +90,10 @@ func ParseConnectionString(connectionString string) (*ConnectionInfo, error) {
 	// Extract SSL mode from query parameters
 	queryParams := parsedURL.Query()
 	if sslMode := queryParams.Get("sslmode"); sslMode != "" {
+		// disable is PostgreSQL's name for none
+		if !SSLMode(sslMode).IsValid() && sslMode != "disable" {
+			return nil, fmt.Errorf("invalid sslmode '%s' in connection string, valid options are: none, disable, prefer, require, verify-ca, verify-full", sslMode)
+		}
 		info.SSLMode = sslMode
 	} else {
 		// Set default SSL modes
@@ -0,0 +1,34 @@
+// Package config provides transaction isolation level configuration.
+package config
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// isolationLevels maps normalized isolation level names to their database/sql values.
+var isolationLevels = map[string]sql.IsolationLevel{
+	"read-uncommitted": sql.LevelReadUncommitted,
+	"read-committed":   sql.LevelReadCommitted,
+	"repeatable-read":  sql.LevelRepeatableRead,
+	"serializable":     sql.LevelSerializable,
+}
+
+// ParseIsolationLevel converts a configured isolation level name into a sql.IsolationLevel.
+// Names are case-insensitive and may separate words with spaces, hyphens or underscores
+// (e.g. "read committed", "REPEATABLE_READ"). An empty name maps to sql.LevelDefault,
+// which leaves the database's own default in effect.
+func ParseIsolationLevel(level string) (sql.IsolationLevel, error) {
+	normalized := strings.ToLower(strings.TrimSpace(level))
+	if normalized == "" {
+		return sql.LevelDefault, nil
+	}
+
+	normalized = strings.NewReplacer(" ", "-", "_", "-").Replace(normalized)
+	if isolation, ok := isolationLevels[normalized]; ok {
+		return isolation, nil
+	}
+
+	return sql.LevelDefault, fmt.Errorf("invalid isolation level: %s (valid levels: read-uncommitted, read-committed, repeatable-read, serializable)", level)
+}
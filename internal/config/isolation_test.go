@@ -0,0 +1,34 @@
+package config
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestParseIsolationLevel(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    sql.IsolationLevel
+		wantErr bool
+	}{
+		{input: "", want: sql.LevelDefault},
+		{input: "read-committed", want: sql.LevelReadCommitted},
+		{input: "READ COMMITTED", want: sql.LevelReadCommitted},
+		{input: "read_uncommitted", want: sql.LevelReadUncommitted},
+		{input: "Repeatable-Read", want: sql.LevelRepeatableRead},
+		{input: "serializable", want: sql.LevelSerializable},
+		{input: "snapshot", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseIsolationLevel(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseIsolationLevel(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseIsolationLevel(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
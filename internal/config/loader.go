@@ -3,6 +3,10 @@ package config
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -22,9 +26,19 @@ func Load() (*Config, error) {
 	// Create config with minimal defaults (only for values that don't come from connection strings)
 	cfg := &Config{
 		Database: DatabaseConfig{
-			AllowedDatabases: []string{}, // Empty means only primary database allowed
-			MaxConns:         10,
-			MaxIdleConns:     5,
+			AllowedDatabases:     []string{}, // Empty means only primary database allowed
+			Schema:               DefaultSchema,
+			MaxResultRows:        DefaultMaxResultRows,
+			MaxQueryLength:       DefaultMaxQueryLength,
+			MaxSubqueries:        DefaultMaxSubqueries,
+			MaxJoins:             DefaultMaxJoins,
+			ConnectRetries:       DefaultConnectRetries,
+			ConnectRetryInterval: DefaultConnectRetryInterval,
+			ConnMaxLifetime:      DefaultConnMaxLifetime,
+			ConnMaxIdleTime:      DefaultConnMaxIdleTime,
+			StatisticsMaxRows:    DefaultStatisticsMaxRows,
+			ProfileMaxRows:       DefaultProfileMaxRows,
+			MaxInsertRows:        DefaultMaxInsertRows,
 		},
 	}
 
@@ -32,6 +46,13 @@ func Load() (*Config, error) {
 	if err := envconfig.Process("", &cfg.Database); err != nil {
 		return nil, fmt.Errorf("error processing database config: %w", err)
 	}
+	if err := cfg.Database.applyLegacyRetrySettings(); err != nil {
+		return nil, fmt.Errorf("error processing database config: %w", err)
+	}
+
+	if err := envconfig.Process("", &cfg.Server); err != nil {
+		return nil, fmt.Errorf("error processing server config: %w", err)
+	}
 
 	// Apply connection string values for any fields that weren't set by env vars
 	if err := cfg.Database.ApplyConnectionStringDefaults(); err != nil {
@@ -52,6 +73,19 @@ func Load() (*Config, error) {
 		cfg.Database.SSLMode = "prefer"
 	}
 
+	named, err := LoadNamedConnections(cfg.Database, os.Environ())
+	if err != nil {
+		return nil, fmt.Errorf("error processing named connections: %w", err)
+	}
+
+	// Pool sizes left unset get the defaults for each connection's own database type
+	cfg.Database.ApplyPoolDefaults()
+	cfg.Connections = map[string]DatabaseConfig{DefaultConnection: cfg.Database}
+	for name, conn := range named {
+		conn.ApplyPoolDefaults()
+		cfg.Connections[name] = conn
+	}
+
 	if err := Validate(cfg); err != nil {
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
@@ -59,64 +93,296 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// applyLegacyRetrySettings honors DB_CONNECT_RETRY_COUNT and DB_CONNECT_RETRY_DELAY_MS, the
+// names the startup retry settings had before DB_CONNECT_RETRIES and DB_CONNECT_RETRY_INTERVAL.
+// The current names take precedence when both are set.
+func (db *DatabaseConfig) applyLegacyRetrySettings() error {
+	if _, set := os.LookupEnv("DB_CONNECT_RETRIES"); !set {
+		if value, ok := os.LookupEnv("DB_CONNECT_RETRY_COUNT"); ok {
+			retries, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("invalid DB_CONNECT_RETRY_COUNT %q: %w", value, err)
+			}
+			db.ConnectRetries = retries
+		}
+	}
+
+	if _, set := os.LookupEnv("DB_CONNECT_RETRY_INTERVAL"); !set {
+		if value, ok := os.LookupEnv("DB_CONNECT_RETRY_DELAY_MS"); ok {
+			delay, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("invalid DB_CONNECT_RETRY_DELAY_MS %q: %w", value, err)
+			}
+			db.ConnectRetryInterval = time.Duration(delay) * time.Millisecond
+		}
+	}
+	return nil
+}
+
+// LoadNamedConnections builds the additional connections declared by DB_CONNECTION_STRING_<NAME>
+// entries in environ. Each connection takes its server, credentials and SSL mode from its
+// connection string and inherits every other setting (pool sizes, security, timeouts) from base.
+// Pool sizes that base leaves unset are not defaulted here; see DatabaseConfig.ApplyPoolDefaults.
+// Connection names are lowercased, so DB_CONNECTION_STRING_ANALYTICS defines "analytics".
+func LoadNamedConnections(base DatabaseConfig, environ []string) (map[string]DatabaseConfig, error) {
+	connections := make(map[string]DatabaseConfig)
+
+	for _, entry := range environ {
+		key, value, found := strings.Cut(entry, "=")
+		if !found || !strings.HasPrefix(key, NamedConnectionPrefix) || value == "" {
+			continue
+		}
+
+		name := strings.ToLower(strings.TrimPrefix(key, NamedConnectionPrefix))
+		if name == "" {
+			continue
+		}
+		if name == DefaultConnection {
+			return nil, fmt.Errorf("connection name %q is reserved for the DB_* variables", DefaultConnection)
+		}
+
+		conn := base
+		conn.ConnectionString = value
+		conn.Type = ""
+		conn.Host = ""
+		conn.Port = 0
+		conn.Database = ""
+		conn.Username = ""
+		conn.Password = ""
+		conn.SSLMode = ""
+		conn.AllowedDatabases = []string{}
+
+		if err := conn.ApplyConnectionStringDefaults(); err != nil {
+			return nil, fmt.Errorf("connection %q: %w", name, err)
+		}
+		if conn.SSLMode == "" {
+			conn.SSLMode = "prefer"
+		}
+
+		connections[name] = conn
+	}
+
+	return connections, nil
+}
+
 // Validate checks the configuration for required fields and valid values.
 // It ensures database type is supported, connection parameters are valid,
 // and SSL modes are appropriate for the selected database type.
 // Supports both connection string and individual parameter configuration.
+// Named connections other than DefaultConnection are validated the same way.
 // Returns an error describing any validation failures.
 func Validate(cfg *Config) error {
+	if err := validateDatabaseConfig(&cfg.Database); err != nil {
+		return err
+	}
+
+	if cfg.Server.MetricsPort < 0 || cfg.Server.MetricsPort > 65535 {
+		return fmt.Errorf("metrics port must be between 0 and 65535, got %d", cfg.Server.MetricsPort)
+	}
+
+	if _, err := ParseLogFormat(cfg.Server.LogFormat); err != nil {
+		return err
+	}
+
+	if _, err := ParseLogLevel(cfg.Server.LogLevel); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(cfg.Connections))
+	for name := range cfg.Connections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == DefaultConnection {
+			continue
+		}
+		conn := cfg.Connections[name]
+		if err := validateDatabaseConfig(&conn); err != nil {
+			return fmt.Errorf("connection %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// regexConstructs are regular expression fragments that never occur in a sensible literal
+// query pattern: escapes, wildcards and quantified groups.
+var regexConstructs = []string{`\`, ".*", ".+", ")+", ")*", "]+", "]*"}
+
+// validatePattern rejects DB_BLOCKED_PATTERNS and DB_ALLOWED_PATTERNS entries that are too long
+// or that look like regular expressions. Patterns are matched as literal substrings, so regex
+// syntax such as "DROP\s+TABLE" or "(A+)+$" would never match and silently block nothing.
+func validatePattern(pattern string) error {
+	if len(pattern) > MaxPatternLength {
+		return fmt.Errorf("query pattern %.20q... is longer than %d characters", pattern, MaxPatternLength)
+	}
+	for _, construct := range regexConstructs {
+		if strings.Contains(pattern, construct) {
+			return fmt.Errorf("query pattern %q looks like a regular expression; patterns are matched as literal text", pattern)
+		}
+	}
+	return nil
+}
+
+// validateDatabaseConfig checks a single connection's settings.
+func validateDatabaseConfig(db *DatabaseConfig) error {
 	// Check if we have either a connection string or individual parameters
-	if cfg.Database.ConnectionString == "" {
+	if db.ConnectionString == "" {
 		// Validate individual parameters approach
-		if cfg.Database.Type == "" {
+		if db.Type == "" {
 			return fmt.Errorf("database type is required (either via connection string or DB_TYPE)")
 		}
-		if cfg.Database.Host == "" {
+		if db.Host == "" {
 			return fmt.Errorf("database host is required (either via connection string or DB_HOST)")
 		}
-		if cfg.Database.Database == "" {
+		if db.Database == "" {
 			return fmt.Errorf("database name is required (either via connection string or DB_NAME)")
 		}
-		if cfg.Database.Username == "" {
+		if db.Username == "" {
 			return fmt.Errorf("database username is required (either via connection string or DB_USER)")
 		}
 	}
 
 	// Validate database type (should be populated by now)
-	if cfg.Database.Type != "mysql" && cfg.Database.Type != "postgres" {
-		return fmt.Errorf("database type must be 'mysql' or 'postgres', got '%s'", cfg.Database.Type)
+	if db.Type != "mysql" && db.Type != "postgres" {
+		return fmt.Errorf("database type must be 'mysql' or 'postgres', got '%s'", db.Type)
 	}
 
-	if cfg.Database.Host == "" {
+	if db.Host == "" {
 		return fmt.Errorf("database host is required")
 	}
 
-	if cfg.Database.Port <= 0 || cfg.Database.Port > 65535 {
-		return fmt.Errorf("database port must be between 1 and 65535, got %d", cfg.Database.Port)
+	if db.Port <= 0 || db.Port > 65535 {
+		return fmt.Errorf("database port must be between 1 and 65535, got %d", db.Port)
 	}
 
-	if cfg.Database.Database == "" {
+	if db.Database == "" {
 		return fmt.Errorf("database name is required")
 	}
 
-	if cfg.Database.Username == "" {
+	if db.Username == "" {
 		return fmt.Errorf("database username is required")
 	}
 
-	if cfg.Database.MaxConns < 1 {
-		return fmt.Errorf("max connections must be at least 1, got %d", cfg.Database.MaxConns)
+	if db.MaxConns < 1 {
+		return fmt.Errorf("max connections must be at least 1, got %d", db.MaxConns)
 	}
 
-	if cfg.Database.MaxIdleConns < 0 {
-		return fmt.Errorf("max idle connections cannot be negative, got %d", cfg.Database.MaxIdleConns)
+	if db.MaxIdleConns < 0 {
+		return fmt.Errorf("max idle connections cannot be negative, got %d", db.MaxIdleConns)
 	}
 
-	if cfg.Database.MaxIdleConns > cfg.Database.MaxConns {
+	if db.MaxIdleConns > db.MaxConns {
 		return fmt.Errorf("max idle connections (%d) cannot exceed max connections (%d)",
-			cfg.Database.MaxIdleConns, cfg.Database.MaxConns)
+			db.MaxIdleConns, db.MaxConns)
+	}
+
+	if db.MaxResultRows < 0 {
+		return fmt.Errorf("max result rows cannot be negative, got %d", db.MaxResultRows)
+	}
+
+	if db.MaxResultBytes < 0 {
+		return fmt.Errorf("max result bytes cannot be negative, got %d", db.MaxResultBytes)
+	}
+
+	if db.MaxQueryLength < 0 {
+		return fmt.Errorf("max query length cannot be negative, got %d", db.MaxQueryLength)
+	}
+
+	if db.MaxSubqueries < 0 {
+		return fmt.Errorf("max subqueries cannot be negative, got %d", db.MaxSubqueries)
+	}
+
+	if db.MaxJoins < 0 {
+		return fmt.Errorf("max joins cannot be negative, got %d", db.MaxJoins)
+	}
+
+	for _, pattern := range append(append([]string(nil), db.BlockedPatterns...), db.AllowedPatterns...) {
+		if err := validatePattern(pattern); err != nil {
+			return err
+		}
+	}
+
+	if db.QueryTimeout < 0 {
+		return fmt.Errorf("query timeout cannot be negative, got %s", db.QueryTimeout)
+	}
+
+	if db.StatementCacheSize < 0 {
+		return fmt.Errorf("statement cache size cannot be negative, got %d", db.StatementCacheSize)
+	}
+
+	if db.ConnectRetries < 0 {
+		return fmt.Errorf("connect retries cannot be negative, got %d", db.ConnectRetries)
+	}
+
+	if db.ConnectRetryInterval < 0 {
+		return fmt.Errorf("connect retry interval cannot be negative, got %s", db.ConnectRetryInterval)
+	}
+
+	if db.ConnMaxLifetime < 0 {
+		return fmt.Errorf("connection max lifetime cannot be negative, got %s", db.ConnMaxLifetime)
+	}
+
+	if db.ConnMaxIdleTime < 0 {
+		return fmt.Errorf("connection max idle time cannot be negative, got %s", db.ConnMaxIdleTime)
+	}
+
+	if db.StatisticsMaxRows < 0 {
+		return fmt.Errorf("statistics max rows cannot be negative, got %d", db.StatisticsMaxRows)
+	}
+
+	if db.ProfileMaxRows < 0 {
+		return fmt.Errorf("profile max rows cannot be negative, got %d", db.ProfileMaxRows)
+	}
+
+	if db.MaxInsertRows < 0 {
+		return fmt.Errorf("max insert rows cannot be negative, got %d", db.MaxInsertRows)
+	}
+
+	if _, err := ParseIsolationLevel(db.IsolationLevel); err != nil {
+		return err
+	}
+
+	if _, err := ParseZeroDateBehavior(db.ZeroDateBehavior); err != nil {
+		return err
+	}
+
+	if _, err := ParseBooleanOutput(db.BooleanOutput); err != nil {
+		return err
+	}
+
+	if _, err := ParseRowFilters(db.RowFilters); err != nil {
+		return err
+	}
+
+	if _, err := ParseRowFilterParams(db.RowFilterParams); err != nil {
+		return err
+	}
+
+	if _, err := ParseMySQLAuth(db.MySQLAuth); err != nil {
+		return err
+	}
+
+	if _, err := ParseAllowedStatements(db.AllowedStatements); err != nil {
+		return err
+	}
+
+	if (db.SSLCert == "") != (db.SSLKey == "") {
+		return fmt.Errorf("DB_SSL_CERT and DB_SSL_KEY must be set together")
+	}
+	if db.HasTLSFiles() && (db.SSLMode == string(SSLModeNone) || db.SSLMode == "disable") {
+		return fmt.Errorf("TLS certificate files are set but DB_SSL_MODE is %s; use prefer, require, verify-ca or verify-full", db.SSLMode)
+	}
+	if db.SSLVerify && (db.SSLMode == string(SSLModeNone) || db.SSLMode == "disable") {
+		return fmt.Errorf("DB_SSL_VERIFY is set but DB_SSL_MODE is %s; unset one of them", db.SSLMode)
+	}
+	if err := db.CheckTLSFiles(); err != nil {
+		return err
 	}
 
-	if cfg.Database.Type == "postgres" {
+	if db.Type == "postgres" {
 		validSSLModes := map[string]bool{
 			"disable":     true,
 			"require":     true,
@@ -124,8 +390,8 @@ func Validate(cfg *Config) error {
 			"verify-full": true,
 			"prefer":      true,
 		}
-		if !validSSLModes[cfg.Database.SSLMode] {
-			return fmt.Errorf("invalid SSL mode for postgres: %s", cfg.Database.SSLMode)
+		if !validSSLModes[db.SSLMode] {
+			return fmt.Errorf("invalid SSL mode for postgres: %s", db.SSLMode)
 		}
 	}
 
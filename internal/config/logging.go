@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// LogFormat selects how the server writes its log records to stderr.
+type LogFormat string
+
+const (
+	// LogFormatText writes plain text lines through the standard log package
+	LogFormatText LogFormat = "text"
+
+	// LogFormatJSON writes one JSON object per record, for log aggregation tools
+	LogFormatJSON LogFormat = "json"
+)
+
+// ParseLogFormat converts a configured log format name into a LogFormat.
+// Names are case-insensitive; an empty name selects LogFormatText.
+func ParseLogFormat(format string) (LogFormat, error) {
+	switch normalized := LogFormat(strings.ToLower(strings.TrimSpace(format))); normalized {
+	case "":
+		return LogFormatText, nil
+	case LogFormatText, LogFormatJSON:
+		return normalized, nil
+	default:
+		return LogFormatText, fmt.Errorf("invalid log format: %s (valid values: text, json)", format)
+	}
+}
+
+// ParseLogLevel converts a configured log level name (debug, info, warn or error) into
+// a slog.Level. Names are case-insensitive; an empty name selects slog.LevelInfo.
+func ParseLogLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "debug":
+		return slog.LevelDebug, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("invalid log level: %s (valid values: debug, info, warn, error)", level)
+	}
+}
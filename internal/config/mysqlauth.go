@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MySQLAuthMethod names a MySQL password authentication method that DB_MYSQL_AUTH allows
+// besides caching_sha2_password and sha256_password, which are always available.
+type MySQLAuthMethod string
+
+const (
+	// MySQLAuthNative allows mysql_native_password, the default before MySQL 8.0
+	MySQLAuthNative MySQLAuthMethod = "native"
+
+	// MySQLAuthCleartext allows mysql_clear_password, used by PAM and LDAP authentication;
+	// the password is sent unencrypted unless the connection uses TLS
+	MySQLAuthCleartext MySQLAuthMethod = "cleartext"
+
+	// MySQLAuthOld allows the insecure pre-4.1 password hashing
+	MySQLAuthOld MySQLAuthMethod = "old"
+)
+
+// ParseMySQLAuth converts the configured DB_MYSQL_AUTH method names into MySQLAuthMethods.
+// Names are case-insensitive and duplicates are dropped. An empty list keeps the driver
+// defaults, which allow mysql_native_password; a non-empty list allows only the methods it names.
+func ParseMySQLAuth(methods []string) ([]MySQLAuthMethod, error) {
+	var parsed []MySQLAuthMethod
+	seen := make(map[MySQLAuthMethod]bool)
+	for _, method := range methods {
+		normalized := MySQLAuthMethod(strings.ToLower(strings.TrimSpace(method)))
+		switch normalized {
+		case "":
+			continue
+		case MySQLAuthNative, MySQLAuthCleartext, MySQLAuthOld:
+		default:
+			return nil, fmt.Errorf("invalid MySQL auth method: %s (valid values: native, cleartext, old)", method)
+		}
+		if !seen[normalized] {
+			seen[normalized] = true
+			parsed = append(parsed, normalized)
+		}
+	}
+	return parsed, nil
+}
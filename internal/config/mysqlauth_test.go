@@ -0,0 +1,33 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMySQLAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []string
+		want    []MySQLAuthMethod
+		wantErr bool
+	}{
+		{name: "unset", input: nil, want: nil},
+		{name: "single method", input: []string{"cleartext"}, want: []MySQLAuthMethod{MySQLAuthCleartext}},
+		{name: "case and spaces", input: []string{" Native", "OLD "}, want: []MySQLAuthMethod{MySQLAuthNative, MySQLAuthOld}},
+		{name: "duplicates and empty entries", input: []string{"native", "", "native"}, want: []MySQLAuthMethod{MySQLAuthNative}},
+		{name: "unknown method", input: []string{"native", "kerberos"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMySQLAuth(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMySQLAuth(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMySQLAuth(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
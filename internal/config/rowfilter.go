@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseRowFilters parses a DB_ROW_FILTERS specification of the form
+// "orders: tenant_id = :tenant; invoices: account_id = :tenant" into a map from
+// lowercased table name to SQL predicate. Predicates may reference request parameters
+// as :name. Table names are unqualified and match the table in any schema. An empty
+// specification yields an empty map.
+func ParseRowFilters(spec string) (map[string]string, error) {
+	filters := make(map[string]string)
+
+	for _, entry := range strings.Split(spec, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		table, predicate, found := strings.Cut(entry, ":")
+		table = strings.ToLower(strings.TrimSpace(table))
+		predicate = strings.TrimSpace(predicate)
+		if !found || !isRowFilterTable(table) || predicate == "" {
+			return nil, fmt.Errorf("invalid row filter %q (expected \"table: predicate\")", strings.TrimSpace(entry))
+		}
+		if _, exists := filters[table]; exists {
+			return nil, fmt.Errorf("duplicate row filter for table %s", table)
+		}
+		filters[table] = predicate
+	}
+
+	return filters, nil
+}
+
+// ParseRowFilterParams parses a DB_ROW_FILTER_PARAMS specification of the form
+// "tenant=42; region=eu" into the values bound to the :name placeholders of the row filter
+// predicates. Values are bound as text, which both databases convert to the column type
+// they are compared with. An empty specification yields an empty map.
+func ParseRowFilterParams(spec string) (map[string]any, error) {
+	params := make(map[string]any)
+
+	for _, entry := range strings.Split(spec, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		name, value, found := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !found || !isRowFilterParam(name) {
+			return nil, fmt.Errorf("invalid row filter parameter %q (expected \"name=value\")", strings.TrimSpace(entry))
+		}
+		if _, exists := params[name]; exists {
+			return nil, fmt.Errorf("duplicate row filter parameter %s", name)
+		}
+		params[name] = strings.TrimSpace(value)
+	}
+
+	return params, nil
+}
+
+// isRowFilterParam reports whether name can follow the colon of a :name placeholder.
+func isRowFilterParam(name string) bool {
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// isRowFilterTable reports whether name is a valid unqualified table name for a row filter.
+func isRowFilterTable(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '$' {
+			return false
+		}
+	}
+	return true
+}
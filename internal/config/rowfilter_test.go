@@ -0,0 +1,78 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRowFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "empty", spec: "", want: map[string]string{}},
+		{
+			name: "single filter",
+			spec: "orders: tenant_id = :tenant",
+			want: map[string]string{"orders": "tenant_id = :tenant"},
+		},
+		{
+			name: "several filters with casts and spacing",
+			spec: " Orders : tenant_id = :tenant ; invoices: account_id = :account::int; ",
+			want: map[string]string{"orders": "tenant_id = :tenant", "invoices": "account_id = :account::int"},
+		},
+		{name: "missing predicate", spec: "orders:", wantErr: true},
+		{name: "missing separator", spec: "orders tenant_id = 1", wantErr: true},
+		{name: "table name with spaces", spec: "orders tenant_id = :tenant", wantErr: true},
+		{name: "qualified table name", spec: "public.orders: tenant_id = :tenant", wantErr: true},
+		{name: "duplicate table", spec: "orders: a = :a; ORDERS: b = :b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRowFilters(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRowFilters(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRowFilters(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRowFilterParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[string]any
+		wantErr bool
+	}{
+		{name: "empty", spec: "", want: map[string]any{}},
+		{name: "single parameter", spec: "tenant=42", want: map[string]any{"tenant": "42"}},
+		{
+			name: "several parameters with spacing",
+			spec: " tenant = 42 ; region=eu west; ",
+			want: map[string]any{"tenant": "42", "region": "eu west"},
+		},
+		{name: "empty value", spec: "tenant=", want: map[string]any{"tenant": ""}},
+		{name: "missing separator", spec: "tenant 42", wantErr: true},
+		{name: "invalid name", spec: "tenant-id=42", wantErr: true},
+		{name: "name starting with a digit", spec: "1tenant=42", wantErr: true},
+		{name: "duplicate parameter", spec: "tenant=1; tenant=2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRowFilterParams(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRowFilterParams(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRowFilterParams(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,12 @@
 // Package config provides SSL/TLS configuration mapping for different database drivers.
 package config
 
-import "fmt"
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
 
 // SSLMode represents the common SSL/TLS configuration options that work across
 // different database types. These values are mapped to database-specific SSL modes.
@@ -16,31 +21,42 @@ const (
 
 	// SSLModeRequire mandates SSL/TLS encryption and fails if unavailable
 	SSLModeRequire SSLMode = "require"
+
+	// SSLModeVerifyCA mandates SSL/TLS and verifies that the server certificate is signed
+	// by a trusted CA, without checking the host name
+	SSLModeVerifyCA SSLMode = "verify-ca"
+
+	// SSLModeVerifyFull mandates SSL/TLS and verifies both the server certificate chain and
+	// that the certificate matches the host name
+	SSLModeVerifyFull SSLMode = "verify-full"
 )
 
 // ValidSSLModes returns a list of all valid SSL mode values
 func ValidSSLModes() []SSLMode {
-	return []SSLMode{SSLModeNone, SSLModePrefer, SSLModeRequire}
+	return []SSLMode{SSLModeNone, SSLModePrefer, SSLModeRequire, SSLModeVerifyCA, SSLModeVerifyFull}
 }
 
 // IsValid checks if the given SSL mode string is valid
 func (s SSLMode) IsValid() bool {
 	switch s {
-	case SSLModeNone, SSLModePrefer, SSLModeRequire:
+	case SSLModeNone, SSLModePrefer, SSLModeRequire, SSLModeVerifyCA, SSLModeVerifyFull:
 		return true
 	default:
 		return false
 	}
 }
 
-// ToMySQLSSLMode converts a common SSL mode to MySQL-specific SSL configuration
+// ToMySQLSSLMode converts a common SSL mode to MySQL-specific SSL configuration. The
+// driver's "true" mode verifies the certificate chain and host name. It has no built-in mode
+// for verify-ca, which needs the custom TLS configuration returned by ClientTLSConfig; the
+// "true" returned for it is the closest built-in mode.
 func (s SSLMode) ToMySQLSSLMode() (string, error) {
 	switch s {
 	case SSLModeNone:
 		return "false", nil
 	case SSLModePrefer:
 		return "preferred", nil
-	case SSLModeRequire:
+	case SSLModeRequire, SSLModeVerifyCA, SSLModeVerifyFull:
 		return "true", nil
 	default:
 		return "", fmt.Errorf("invalid SSL mode: %s", s)
@@ -56,6 +72,10 @@ func (s SSLMode) ToPostgreSQLSSLMode() (string, error) {
 		return "prefer", nil
 	case SSLModeRequire:
 		return "require", nil
+	case SSLModeVerifyCA:
+		return "verify-ca", nil
+	case SSLModeVerifyFull:
+		return "verify-full", nil
 	default:
 		return "", fmt.Errorf("invalid SSL mode: %s", s)
 	}
@@ -65,7 +85,102 @@ func (s SSLMode) ToPostgreSQLSSLMode() (string, error) {
 func ParseSSLMode(mode string) (SSLMode, error) {
 	sslMode := SSLMode(mode)
 	if !sslMode.IsValid() {
-		return "", fmt.Errorf("invalid SSL mode '%s', valid options are: none, prefer, require", mode)
+		return "", fmt.Errorf("invalid SSL mode '%s', valid options are: none, prefer, require, verify-ca, verify-full", mode)
 	}
 	return sslMode, nil
 }
+
+// HasTLSFiles reports whether a client certificate, key or root certificate is configured
+// (DB_SSL_CERT, DB_SSL_KEY, DB_SSL_ROOT_CERT).
+func (cfg *DatabaseConfig) HasTLSFiles() bool {
+	return cfg.SSLCert != "" || cfg.SSLKey != "" || cfg.SSLRootCert != ""
+}
+
+// CheckTLSFiles verifies that every configured certificate file exists and can be read, so
+// a missing or unreadable file is reported when configuration is loaded rather than on the
+// first connection attempt.
+func (cfg *DatabaseConfig) CheckTLSFiles() error {
+	files := []struct{ env, path string }{
+		{"DB_SSL_CERT", cfg.SSLCert},
+		{"DB_SSL_KEY", cfg.SSLKey},
+		{"DB_SSL_ROOT_CERT", cfg.SSLRootCert},
+	}
+	for _, file := range files {
+		if file.path == "" {
+			continue
+		}
+		f, err := os.Open(file.path)
+		if err != nil {
+			return fmt.Errorf("cannot read %s file: %w", file.env, err)
+		}
+		info, err := f.Stat()
+		f.Close()
+		if err == nil && info.IsDir() {
+			return fmt.Errorf("%s must be a file, %s is a directory", file.env, file.path)
+		}
+	}
+	return nil
+}
+
+// ClientTLSConfig builds the TLS configuration for the configured certificate files. The
+// client certificate and key are presented to the server for mutual TLS, and the server
+// certificate is verified against SSLRootCert when set, otherwise against the system roots.
+// In prefer mode without a root certificate the server certificate is not verified, matching
+// the driver's own preferred mode. In verify-ca mode the certificate chain is verified but
+// the host name is not. DB_SSL_VERIFY always verifies both (see ValidateSSLMode).
+func (cfg *DatabaseConfig) ClientTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{ServerName: cfg.Host, MinVersion: tls.VersionTLS12}
+	mode, _ := cfg.ValidateSSLMode()
+
+	if cfg.SSLCert != "" || cfg.SSLKey != "" {
+		certificate, err := tls.LoadX509KeyPair(cfg.SSLCert, cfg.SSLKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate (DB_SSL_CERT, DB_SSL_KEY): %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	switch {
+	case cfg.SSLRootCert != "":
+		pem, err := os.ReadFile(cfg.SSLRootCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read root certificate (DB_SSL_ROOT_CERT): %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in root certificate %s (DB_SSL_ROOT_CERT)", cfg.SSLRootCert)
+		}
+		tlsConfig.RootCAs = roots
+	case mode == SSLModePrefer:
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if mode == SSLModeVerifyCA {
+		// crypto/tls always checks the host name, so the chain is verified by hand instead
+		roots := tlsConfig.RootCAs
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			return verifyCertificateChain(state.PeerCertificates, roots)
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// verifyCertificateChain verifies that the server certificate chains to one of roots, or to
+// the system roots when roots is nil, without checking the host name.
+func verifyCertificateChain(certificates []*x509.Certificate, roots *x509.CertPool) error {
+	if len(certificates) == 0 {
+		return fmt.Errorf("server presented no certificate")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	_, err := certificates[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+	if err != nil {
+		return fmt.Errorf("failed to verify server certificate: %w", err)
+	}
+	return nil
+}
@@ -1,6 +1,20 @@
 package config
 
-import "testing"
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestSSLMode_IsValid(t *testing.T) {
 	tests := []struct {
@@ -11,6 +25,8 @@ func TestSSLMode_IsValid(t *testing.T) {
 		{"none is valid", SSLModeNone, true},
 		{"prefer is valid", SSLModePrefer, true},
 		{"require is valid", SSLModeRequire, true},
+		{"verify-ca is valid", SSLModeVerifyCA, true},
+		{"verify-full is valid", SSLModeVerifyFull, true},
 		{"invalid mode", SSLMode("invalid"), false},
 		{"empty mode", SSLMode(""), false},
 		{"case sensitive - None", SSLMode("None"), false},
@@ -36,6 +52,8 @@ func TestSSLMode_ToMySQLSSLMode(t *testing.T) {
 		{"none to MySQL", SSLModeNone, "false", false},
 		{"prefer to MySQL", SSLModePrefer, "preferred", false},
 		{"require to MySQL", SSLModeRequire, "true", false},
+		{"verify-ca to MySQL", SSLModeVerifyCA, "true", false},
+		{"verify-full to MySQL", SSLModeVerifyFull, "true", false},
 		{"invalid mode", SSLMode("invalid"), "", true},
 	}
 
@@ -72,6 +90,8 @@ func TestSSLMode_ToPostgreSQLSSLMode(t *testing.T) {
 		{"none to PostgreSQL", SSLModeNone, "disable", false},
 		{"prefer to PostgreSQL", SSLModePrefer, "prefer", false},
 		{"require to PostgreSQL", SSLModeRequire, "require", false},
+		{"verify-ca to PostgreSQL", SSLModeVerifyCA, "verify-ca", false},
+		{"verify-full to PostgreSQL", SSLModeVerifyFull, "verify-full", false},
 		{"invalid mode", SSLMode("invalid"), "", true},
 	}
 
@@ -108,6 +128,8 @@ func TestParseSSLMode(t *testing.T) {
 		{"parse none", "none", SSLModeNone, false},
 		{"parse prefer", "prefer", SSLModePrefer, false},
 		{"parse require", "require", SSLModeRequire, false},
+		{"parse verify-ca", "verify-ca", SSLModeVerifyCA, false},
+		{"parse verify-full", "verify-full", SSLModeVerifyFull, false},
 		{"parse invalid", "invalid", "", true},
 		{"parse empty", "", "", true},
 		{"parse case sensitive", "None", "", true},
@@ -139,11 +161,11 @@ func TestParseSSLMode(t *testing.T) {
 func TestValidSSLModes(t *testing.T) {
 	modes := ValidSSLModes()
 
-	if len(modes) != 3 {
-		t.Errorf("ValidSSLModes() returned %d modes, expected 3", len(modes))
+	if len(modes) != 5 {
+		t.Errorf("ValidSSLModes() returned %d modes, expected 5", len(modes))
 	}
 
-	expected := []SSLMode{SSLModeNone, SSLModePrefer, SSLModeRequire}
+	expected := []SSLMode{SSLModeNone, SSLModePrefer, SSLModeRequire, SSLModeVerifyCA, SSLModeVerifyFull}
 	for i, expected := range expected {
 		if i >= len(modes) || modes[i] != expected {
 			t.Errorf("ValidSSLModes()[%d] = %v, want %v", i, modes[i], expected)
@@ -162,6 +184,7 @@ func TestDatabaseConfig_ValidateSSLMode(t *testing.T) {
 		{"valid none", "none", SSLModeNone, false},
 		{"valid prefer", "prefer", SSLModePrefer, false},
 		{"valid require", "require", SSLModeRequire, false},
+		{"valid verify-full", "verify-full", SSLModeVerifyFull, false},
 		{"invalid mode", "invalid", "", true},
 	}
 
@@ -191,3 +214,222 @@ func TestDatabaseConfig_ValidateSSLMode(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabaseConfig_ValidateSSLMode_SSLVerify(t *testing.T) {
+	for _, sslMode := range []string{"", "prefer", "require", "verify-ca"} {
+		cfg := &DatabaseConfig{SSLMode: sslMode, SSLVerify: true}
+		if got, err := cfg.ValidateSSLMode(); err != nil || got != SSLModeVerifyFull {
+			t.Errorf("ValidateSSLMode() with SSL mode %q and DB_SSL_VERIFY = %v, %v, want %v", sslMode, got, err, SSLModeVerifyFull)
+		}
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate and its private key as PEM files
+// in a temporary directory and returns their paths.
+func writeTestCertificate(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "mcp-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "client.crt")
+	keyFile = filepath.Join(dir, "client.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestDatabaseConfig_ClientTLSConfig(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+	notPEM := filepath.Join(t.TempDir(), "ca.txt")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		cfg              DatabaseConfig
+		wantCertificates int
+		wantRoots        bool
+		wantSkipVerify   bool
+		wantVerifyChain  bool
+		wantErr          string
+	}{
+		{
+			name:             "mutual TLS with root certificate",
+			cfg:              DatabaseConfig{Host: "db.internal", SSLMode: "require", SSLCert: certFile, SSLKey: keyFile, SSLRootCert: certFile},
+			wantCertificates: 1,
+			wantRoots:        true,
+		},
+		{
+			name: "root certificate only",
+			cfg:  DatabaseConfig{Host: "db.internal", SSLMode: "prefer", SSLRootCert: certFile},
+			// A root certificate is always verified, even in prefer mode
+			wantRoots: true,
+		},
+		{
+			name:             "prefer without root certificate",
+			cfg:              DatabaseConfig{Host: "db.internal", SSLMode: "prefer", SSLCert: certFile, SSLKey: keyFile},
+			wantCertificates: 1,
+			wantSkipVerify:   true,
+		},
+		{
+			name:             "require verifies against system roots",
+			cfg:              DatabaseConfig{Host: "db.internal", SSLMode: "require", SSLCert: certFile, SSLKey: keyFile},
+			wantCertificates: 1,
+		},
+		{
+			name:            "verify-ca checks the chain but not the host name",
+			cfg:             DatabaseConfig{Host: "db.internal", SSLMode: "verify-ca", SSLRootCert: certFile},
+			wantRoots:       true,
+			wantSkipVerify:  true,
+			wantVerifyChain: true,
+		},
+		{
+			name:      "verify-full checks the host name",
+			cfg:       DatabaseConfig{Host: "db.internal", SSLMode: "verify-full", SSLRootCert: certFile},
+			wantRoots: true,
+		},
+		{
+			name:             "DB_SSL_VERIFY overrides prefer",
+			cfg:              DatabaseConfig{Host: "db.internal", SSLMode: "prefer", SSLVerify: true, SSLCert: certFile, SSLKey: keyFile},
+			wantCertificates: 1,
+		},
+		{
+			name:    "missing key file",
+			cfg:     DatabaseConfig{Host: "db.internal", SSLMode: "require", SSLCert: certFile, SSLKey: keyFile + ".missing"},
+			wantErr: "failed to load client certificate",
+		},
+		{
+			name:    "root certificate without PEM data",
+			cfg:     DatabaseConfig{Host: "db.internal", SSLMode: "require", SSLRootCert: notPEM},
+			wantErr: "no PEM certificates found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlsConfig, err := tt.cfg.ClientTLSConfig()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ClientTLSConfig() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ClientTLSConfig() error = %v", err)
+			}
+
+			if tlsConfig.ServerName != "db.internal" {
+				t.Errorf("ServerName = %q, want db.internal", tlsConfig.ServerName)
+			}
+			if len(tlsConfig.Certificates) != tt.wantCertificates {
+				t.Errorf("Expected %d client certificates, got %d", tt.wantCertificates, len(tlsConfig.Certificates))
+			}
+			if (tlsConfig.RootCAs != nil) != tt.wantRoots {
+				t.Errorf("RootCAs set = %v, want %v", tlsConfig.RootCAs != nil, tt.wantRoots)
+			}
+			if tlsConfig.InsecureSkipVerify != tt.wantSkipVerify {
+				t.Errorf("InsecureSkipVerify = %v, want %v", tlsConfig.InsecureSkipVerify, tt.wantSkipVerify)
+			}
+			if (tlsConfig.VerifyConnection != nil) != tt.wantVerifyChain {
+				t.Errorf("VerifyConnection set = %v, want %v", tlsConfig.VerifyConnection != nil, tt.wantVerifyChain)
+			}
+		})
+	}
+}
+
+func TestDatabaseConfig_ClientTLSConfig_VerifyCA(t *testing.T) {
+	caFile, _ := writeTestCertificate(t)
+	otherFile, _ := writeTestCertificate(t)
+
+	readCertificate := func(path string) *x509.Certificate {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block, _ := pem.Decode(data)
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return certificate
+	}
+
+	cfg := DatabaseConfig{Host: "db.internal", SSLMode: "verify-ca", SSLRootCert: caFile}
+	tlsConfig, err := cfg.ClientTLSConfig()
+	if err != nil {
+		t.Fatalf("ClientTLSConfig() error = %v", err)
+	}
+
+	// The certificate is issued to mcp-client, not db.internal, which verify-ca accepts
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{readCertificate(caFile)}}
+	if err := tlsConfig.VerifyConnection(state); err != nil {
+		t.Errorf("VerifyConnection() error = %v, want the trusted certificate to be accepted", err)
+	}
+
+	state = tls.ConnectionState{PeerCertificates: []*x509.Certificate{readCertificate(otherFile)}}
+	if err := tlsConfig.VerifyConnection(state); err == nil || !strings.Contains(err.Error(), "failed to verify server certificate") {
+		t.Errorf("VerifyConnection() error = %v, want an untrusted certificate error", err)
+	}
+
+	if err := tlsConfig.VerifyConnection(tls.ConnectionState{}); err == nil {
+		t.Error("VerifyConnection() accepted a connection without a server certificate")
+	}
+}
+
+func TestDatabaseConfig_CheckTLSFiles(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.crt")
+
+	tests := []struct {
+		name    string
+		cfg     DatabaseConfig
+		wantErr string
+	}{
+		{name: "no files"},
+		{name: "readable files", cfg: DatabaseConfig{SSLCert: certFile, SSLKey: keyFile, SSLRootCert: certFile}},
+		{name: "missing root certificate", cfg: DatabaseConfig{SSLRootCert: missing}, wantErr: "cannot read DB_SSL_ROOT_CERT file"},
+		{name: "missing key", cfg: DatabaseConfig{SSLCert: certFile, SSLKey: missing}, wantErr: "cannot read DB_SSL_KEY file"},
+		{name: "directory", cfg: DatabaseConfig{SSLCert: dir, SSLKey: keyFile}, wantErr: "DB_SSL_CERT must be a file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.CheckTLSFiles()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckTLSFiles() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckTLSFiles() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StatementTypes are the statement types DB_ALLOWED_STATEMENTS can name, as classified by
+// the query validator.
+var StatementTypes = []string{"select", "insert", "update", "delete", "ddl"}
+
+// ParseAllowedStatements converts the configured DB_ALLOWED_STATEMENTS entries into the set
+// of permitted statement types. Names are case-insensitive. An empty list returns nil,
+// which permits every statement type.
+func ParseAllowedStatements(statements []string) (map[string]bool, error) {
+	var allowed map[string]bool
+	for _, statement := range statements {
+		normalized := strings.ToLower(strings.TrimSpace(statement))
+		if normalized == "" {
+			continue
+		}
+		if !isStatementType(normalized) {
+			return nil, fmt.Errorf("invalid statement type: %s (valid values: %s)", statement, strings.Join(StatementTypes, ", "))
+		}
+		if allowed == nil {
+			allowed = make(map[string]bool)
+		}
+		allowed[normalized] = true
+	}
+	return allowed, nil
+}
+
+// isStatementType reports whether name is one of StatementTypes.
+func isStatementType(name string) bool {
+	for _, statementType := range StatementTypes {
+		if name == statementType {
+			return true
+		}
+	}
+	return false
+}
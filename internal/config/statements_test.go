@@ -0,0 +1,33 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAllowedStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []string
+		want    map[string]bool
+		wantErr bool
+	}{
+		{name: "unset", input: nil, want: nil},
+		{name: "only empty entries", input: []string{"", " "}, want: nil},
+		{name: "select only", input: []string{"select"}, want: map[string]bool{"select": true}},
+		{name: "case and spaces", input: []string{" SELECT", "Insert "}, want: map[string]bool{"select": true, "insert": true}},
+		{name: "unknown type", input: []string{"select", "explain"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAllowedStatements(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAllowedStatements(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAllowedStatements(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Package config provides MySQL zero-date handling configuration.
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ZeroDateBehavior controls how MySQL DATE, DATETIME and TIMESTAMP values that cannot be
+// represented as a time, such as '0000-00-00' or '2024-02-30', are returned.
+type ZeroDateBehavior string
+
+const (
+	// ZeroDateNull returns zero and invalid dates as NULL
+	ZeroDateNull ZeroDateBehavior = "null"
+
+	// ZeroDateString returns zero and invalid dates as the text MySQL sent (e.g. "0000-00-00 00:00:00")
+	ZeroDateString ZeroDateBehavior = "string"
+
+	// ZeroDateError keeps the driver's strict time parsing, which fails on invalid dates
+	ZeroDateError ZeroDateBehavior = "error"
+)
+
+// ParseZeroDateBehavior converts a configured zero-date behavior name into a ZeroDateBehavior.
+// Names are case-insensitive; an empty name selects ZeroDateNull.
+func ParseZeroDateBehavior(behavior string) (ZeroDateBehavior, error) {
+	switch normalized := ZeroDateBehavior(strings.ToLower(strings.TrimSpace(behavior))); normalized {
+	case "":
+		return ZeroDateNull, nil
+	case ZeroDateNull, ZeroDateString, ZeroDateError:
+		return normalized, nil
+	default:
+		return ZeroDateNull, fmt.Errorf("invalid zero date behavior: %s (valid values: null, string, error)", behavior)
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import "testing"
+
+func TestParseZeroDateBehavior(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ZeroDateBehavior
+		wantErr bool
+	}{
+		{"", ZeroDateNull, false},
+		{"null", ZeroDateNull, false},
+		{"STRING", ZeroDateString, false},
+		{" error ", ZeroDateError, false},
+		{"convertToNull", ZeroDateNull, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseZeroDateBehavior(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseZeroDateBehavior(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseZeroDateBehavior(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
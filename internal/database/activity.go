@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+// MaxActiveQueryLength is the number of characters of a statement's text that
+// GetActiveConnections reports; longer statements are cut off.
+const MaxActiveQueryLength = 200
+
+// readActiveConnections scans rows of pid, user, host, database, state, query text and
+// duration in seconds into ActiveConnections, truncating the query text.
+func readActiveConnections(rows *sql.Rows) ([]ActiveConnection, error) {
+	connections := []ActiveConnection{}
+	for rows.Next() {
+		var connection ActiveConnection
+		err := rows.Scan(&connection.PID, &connection.User, &connection.Host, &connection.Database,
+			&connection.State, &connection.QueryText, &connection.DurationSeconds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan connection: %w", err)
+		}
+		connection.QueryText = truncateQueryText(connection.QueryText, MaxActiveQueryLength)
+		connections = append(connections, connection)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading connections: %w", err)
+	}
+	return connections, nil
+}
+
+// killConnection terminates session pid on a single connection from db, so the session
+// checked is the one running kill. lookup must return one row for pid, or none when it
+// does not exist, with a boolean telling whether pid is that connection's own session.
+// permissionHint names the privilege needed when the server refuses.
+func killConnection(ctx context.Context, db *sql.DB, pid int64, lookup string, kill func(*sql.Conn) error, permissionHint string) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get a connection: %w", err)
+	}
+	defer conn.Close()
+
+	var self bool
+	err = conn.QueryRowContext(ctx, lookup, pid).Scan(&self)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("connection %d does not exist", pid)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up connection %d: %w", pid, err)
+	}
+	if self {
+		return fmt.Errorf("refusing to terminate connection %d: it is this server's own connection", pid)
+	}
+
+	if err := kill(conn); err != nil {
+		if isPermissionDenied(err) {
+			return fmt.Errorf("insufficient privileges to terminate connection %d (%s): %w", pid, permissionHint, err)
+		}
+		return fmt.Errorf("failed to terminate connection %d: %w", pid, err)
+	}
+	return nil
+}
+
+// truncateQueryText returns the first limit characters of text, marking a cut with "...".
+func truncateQueryText(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:limit]) + "..."
+}
+
+// isPermissionDenied reports whether err is the server refusing an operation because the
+// user lacks a privilege: SQLSTATE 42501 on PostgreSQL, or MySQL errors 1044 (database
+// access denied), 1095 (not the owner of a thread to kill), 1142 (table access denied)
+// and 1227 (privilege required).
+func isPermissionDenied(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "42501"
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case 1044, 1095, 1142, 1227:
+			return true
+		}
+		return false
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+func TestGetActiveConnections(t *testing.T) {
+	longQuery := "SELECT " + strings.Repeat("x", 300)
+	columns := []string{"pid", "user", "host", "database", "state", "query", "duration"}
+
+	tests := []struct {
+		name      string
+		dbType    string
+		wantQuery string
+		row       []driver.Value
+		want      ActiveConnection
+	}{
+		{
+			name:      "postgres",
+			dbType:    "postgres",
+			wantQuery: "FROM pg_stat_activity",
+			row:       []driver.Value{int64(4242), "app", "10.0.0.7/32", "shop", "active", longQuery, 12.5},
+			want: ActiveConnection{
+				PID: 4242, User: "app", Host: "10.0.0.7/32", Database: "shop", State: "active",
+				QueryText: longQuery[:MaxActiveQueryLength] + "...", DurationSeconds: 12.5,
+			},
+		},
+		{
+			name:      "mysql",
+			dbType:    "mysql",
+			wantQuery: "FROM INFORMATION_SCHEMA.PROCESSLIST",
+			row:       []driver.Value{int64(17), "app", "10.0.0.7:51234", "shop", "Query", "SELECT SLEEP(60)", int64(40)},
+			want: ActiveConnection{
+				PID: 17, User: "app", Host: "10.0.0.7:51234", Database: "shop", State: "Query",
+				QueryText: "SELECT SLEEP(60)", DurationSeconds: 40,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []string
+			sqlDB := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+				queries = append(queries, query)
+				return columns, [][]driver.Value{tt.row}
+			})
+			defer sqlDB.Close()
+
+			var db Database
+			if tt.dbType == "mysql" {
+				db = &MySQL{db: sqlDB, config: NewTestConfig("mysql")}
+			} else {
+				db = &PostgreSQL{db: sqlDB, config: NewTestConfig("postgres")}
+			}
+
+			connections, err := db.GetActiveConnections(context.Background())
+			if err != nil {
+				t.Fatalf("GetActiveConnections() error = %v", err)
+			}
+			if len(queries) != 1 || !strings.Contains(queries[0], tt.wantQuery) {
+				t.Errorf("Expected a query %s, got %v", tt.wantQuery, queries)
+			}
+			if !reflect.DeepEqual(connections, []ActiveConnection{tt.want}) {
+				t.Errorf("GetActiveConnections() = %+v, want %+v", connections, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateQueryText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "SELECT 1", want: "SELECT 1"},
+		{text: "SELECT 12", want: "SELECT 12"},
+		{text: "SELECT 123", want: "SELECT 12..."},
+		{text: "SELECT 'äöü'", want: "SELECT 'ä..."},
+	}
+
+	for _, tt := range tests {
+		if got := truncateQueryText(tt.text, 9); got != tt.want {
+			t.Errorf("truncateQueryText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestIsPermissionDenied(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "postgres insufficient privilege", err: &pq.Error{Code: "42501"}, want: true},
+		{name: "postgres other error", err: &pq.Error{Code: "42P01"}, want: false},
+		{name: "mysql privilege required", err: &mysql.MySQLError{Number: 1227}, want: true},
+		{name: "mysql table access denied", err: fmt.Errorf("query failed: %w", &mysql.MySQLError{Number: 1142}), want: true},
+		{name: "mysql other error", err: &mysql.MySQLError{Number: 1064}, want: false},
+		{name: "plain error", err: errors.New("permission denied"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPermissionDenied(tt.err); got != tt.want {
+				t.Errorf("isPermissionDenied(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKillConnection(t *testing.T) {
+	tests := []struct {
+		name       string
+		dbType     string
+		self       []driver.Value // Row returned by the lookup; nil when the PID does not exist
+		terminated bool
+		wantKill   string
+		wantErr    string
+	}{
+		{
+			name:       "postgres",
+			dbType:     "postgres",
+			self:       []driver.Value{false},
+			terminated: true,
+			wantKill:   "SELECT pg_terminate_backend($1)",
+		},
+		{
+			name:     "mysql",
+			dbType:   "mysql",
+			self:     []driver.Value{false},
+			wantKill: "KILL CONNECTION 4242",
+		},
+		{
+			name:    "connection already gone",
+			dbType:  "postgres",
+			wantErr: "connection 4242 does not exist",
+		},
+		{
+			name:    "own connection",
+			dbType:  "mysql",
+			self:    []driver.Value{true},
+			wantErr: "this server's own connection",
+		},
+		{
+			name:    "backend exited before it was signalled",
+			dbType:  "postgres",
+			self:    []driver.Value{false},
+			wantErr: "exited before it could be signalled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []string
+			sqlDB := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+				queries = append(queries, query)
+				switch {
+				case strings.Contains(query, "pg_terminate_backend"):
+					return []string{"terminated"}, [][]driver.Value{{tt.terminated}}
+				case strings.HasPrefix(query, "KILL"):
+					return nil, nil
+				case tt.self == nil:
+					return []string{"self"}, nil
+				default:
+					return []string{"self"}, [][]driver.Value{tt.self}
+				}
+			})
+			defer sqlDB.Close()
+
+			var db Database = &PostgreSQL{db: sqlDB, config: NewTestConfig(tt.dbType)}
+			if tt.dbType == "mysql" {
+				db = &MySQL{db: sqlDB, config: NewTestConfig(tt.dbType)}
+			}
+
+			err := db.KillConnection(context.Background(), 4242)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("KillConnection() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				for _, query := range queries {
+					if strings.HasPrefix(query, "KILL") {
+						t.Errorf("Expected no KILL to be issued, got %q", query)
+					}
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("KillConnection() error = %v", err)
+			}
+			if len(queries) != 2 || queries[1] != tt.wantKill {
+				t.Errorf("Expected the lookup followed by %q, got %q", tt.wantKill, queries)
+			}
+		})
+	}
+}
+
+func TestKillConnection_PermissionDenied(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantHint bool
+	}{
+		{name: "postgres", err: &pq.Error{Code: "42501", Message: "must be a member of the role"}, wantHint: true},
+		{name: "mysql", err: &mysql.MySQLError{Number: 1095, Message: "You are not owner of thread 4242"}, wantHint: true},
+		{name: "other error", err: errors.New("lost connection")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB := NewRowsDB(func(string) ([]string, [][]driver.Value) {
+				return []string{"self"}, [][]driver.Value{{false}}
+			})
+			defer sqlDB.Close()
+
+			err := killConnection(context.Background(), sqlDB, 4242, "SELECT lookup",
+				func(*sql.Conn) error { return tt.err }, "requires a privilege")
+			if err == nil || !errors.Is(err, tt.err) {
+				t.Fatalf("Expected the server error to be wrapped, got %v", err)
+			}
+			if hinted := strings.Contains(err.Error(), "insufficient privileges"); hinted != tt.wantHint {
+				t.Errorf("Expected a privileges hint: %v, got %v", tt.wantHint, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"strings"
+
+	"github.com/jhoffmann/go-database-mcp/internal/config"
+)
+
+// IsBooleanType reports whether columns of the given database type hold booleans. PostgreSQL
+// reports BOOL. MySQL's BOOLEAN is an alias for TINYINT(1), and the driver does not report a
+// column's display width, so every TINYINT column is treated as boolean.
+func IsBooleanType(typeName string) bool {
+	switch strings.ToUpper(typeName) {
+	case "BOOL", "BOOLEAN", "TINYINT":
+		return true
+	default:
+		return false
+	}
+}
+
+// NormalizeBoolean converts a value read from a boolean column (see IsBooleanType) into the
+// representation selected by output: true/false for BooleanBool or 1/0 for BooleanInt.
+// With BooleanNative, and for NULL or integers other than 0 and 1 (which a TINYINT column
+// may hold), the value is returned unchanged.
+func NormalizeBoolean(value any, output config.BooleanOutput) any {
+	if output == config.BooleanNative {
+		return value
+	}
+
+	// Unprepared MySQL queries return integers as text
+	if b, ok := value.([]byte); ok {
+		switch string(b) {
+		case "0":
+			value = int64(0)
+		case "1":
+			value = int64(1)
+		}
+	}
+
+	switch output {
+	case config.BooleanBool:
+		if n, ok := value.(int64); ok && (n == 0 || n == 1) {
+			return n == 1
+		}
+	case config.BooleanInt:
+		if b, ok := value.(bool); ok {
+			if b {
+				return int64(1)
+			}
+			return int64(0)
+		}
+	}
+	return value
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/jhoffmann/go-database-mcp/internal/config"
+)
+
+func TestIsBooleanType(t *testing.T) {
+	tests := []struct {
+		typeName string
+		want     bool
+	}{
+		{"BOOL", true},
+		{"bool", true},
+		{"TINYINT", true},
+		{"INT", false},
+		{"BIT", false},
+		{"VARCHAR", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typeName, func(t *testing.T) {
+			if got := IsBooleanType(tt.typeName); got != tt.want {
+				t.Errorf("IsBooleanType(%q) = %v, want %v", tt.typeName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeBoolean(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  any
+		output config.BooleanOutput
+		want   any
+	}{
+		{"native keeps postgres bool", true, config.BooleanNative, true},
+		{"native keeps mysql int", int64(1), config.BooleanNative, int64(1)},
+		{"bool from postgres", false, config.BooleanBool, false},
+		{"bool from mysql int", int64(1), config.BooleanBool, true},
+		{"bool from mysql text", []byte("0"), config.BooleanBool, false},
+		{"bool keeps other integers", int64(7), config.BooleanBool, int64(7)},
+		{"int from postgres true", true, config.BooleanInt, int64(1)},
+		{"int from postgres false", false, config.BooleanInt, int64(0)},
+		{"int from mysql text", []byte("1"), config.BooleanInt, int64(1)},
+		{"null", nil, config.BooleanBool, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeBoolean(tt.value, tt.output); got != tt.want {
+				t.Errorf("NormalizeBoolean(%#v, %q) = %#v, want %#v", tt.value, tt.output, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,21 +3,52 @@ package database
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jhoffmann/go-database-mcp/internal/config"
+	"github.com/jhoffmann/go-database-mcp/internal/security"
 	_ "github.com/lib/pq"
 )
 
 // Manager handles database connections and provides a factory for creating database instances.
 // It supports both MySQL and PostgreSQL databases with connection pooling and SSL configuration.
+// A Manager owns the default connection and, optionally, additional named connections
+// added with AddConnection, which are connected and closed along with it.
 type Manager struct {
 	config   config.DatabaseConfig // Database configuration settings
 	database Database              // Active database connection instance
+	managers map[string]*Manager   // Additional named connections, keyed by connection name
+
+	newDatabase func(config.DatabaseConfig) (Database, error) // Creates the database instance for config
+
+	mu             sync.Mutex // Guards database, attempts, lastErr, serverReadOnly and closed once Connect runs
+	attempts       int        // Number of connection attempts made by Connect and Reconnect
+	lastErr        error      // Error of the most recent failed attempt, cleared once connected
+	serverReadOnly bool       // Whether Connect found the server to accept only reads
+	closed         bool       // Set by Close, after which Reconnect stops
 }
 
+// ConnectionStatus reports whether a connection is established and, if it is not, why the
+// last attempt failed, so that clients can diagnose connection problems without log access.
+type ConnectionStatus struct {
+	Name      string `json:"name"`                 // Connection name
+	Type      string `json:"type"`                 // Database type: "mysql" or "postgres"
+	Connected bool   `json:"connected"`            // Whether the connection is established
+	Attempts  int    `json:"attempts"`             // Number of connection attempts made so far
+	LastError string `json:"last_error,omitempty"` // Error of the last failed attempt, with the password masked, while not connected
+}
+
+// maxConnectRetryDelay caps the exponential backoff between connection attempts.
+const maxConnectRetryDelay = 30 * time.Second
+
 // NewManager creates a new database manager with the given configuration.
 // It validates the configuration but does not establish a connection until Connect is called.
 // Returns an error if the configuration is invalid.
@@ -27,60 +58,359 @@ func NewManager(cfg config.DatabaseConfig) (*Manager, error) {
 	}
 
 	return &Manager{
-		config: cfg,
+		config:      cfg,
+		newDatabase: newDatabase,
 	}, nil
 }
 
+// newDatabase creates the database instance (MySQL or PostgreSQL) for the configured database type.
+func newDatabase(cfg config.DatabaseConfig) (Database, error) {
+	switch cfg.Type {
+	case "mysql":
+		return NewMySQL(cfg)
+	case "postgres":
+		return NewPostgreSQL(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported database type: %s", cfg.Type)
+	}
+}
+
 // Connect establishes a connection to the database based on the configured database type.
 // It creates the appropriate database instance (MySQL or PostgreSQL) and connects to it.
-// Returns an error if the database type is unsupported or if the connection fails.
+// A failed connection is retried up to ConnectRetries times with exponential backoff,
+// starting at ConnectRetryInterval and capped at 30 seconds, unless ctx is cancelled first.
+// Named connections are connected even if an earlier connection fails, and every failure
+// is recorded for Status; see Reconnect for retrying them afterwards.
+// Returns an error if the database type is unsupported or if every connection attempt of
+// any connection fails; the errors of all failed connections are joined.
 func (m *Manager) Connect(ctx context.Context) error {
-	var db Database
-	var err error
+	var errs []error
+	if err := m.connect(ctx); err != nil {
+		errs = append(errs, err)
+	}
 
-	switch m.config.Type {
-	case "mysql":
-		db, err = NewMySQL(m.config)
-	case "postgres":
-		db, err = NewPostgreSQL(m.config)
-	default:
-		return fmt.Errorf("unsupported database type: %s", m.config.Type)
+	for _, name := range m.namedConnections() {
+		if err := m.managers[name].Connect(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("connection %q: %w", name, err))
+		}
 	}
 
+	return errors.Join(errs...)
+}
+
+// connect establishes the manager's own connection, leaving named connections alone.
+func (m *Manager) connect(ctx context.Context) error {
+	db, err := m.newDatabase(m.config)
 	if err != nil {
-		return fmt.Errorf("failed to create database instance: %w", err)
+		err = fmt.Errorf("failed to create database instance: %w", err)
+		m.recordAttempt(err)
+		return err
 	}
 
-	if err := db.Connect(ctx); err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+	if err := m.connectWithRetry(ctx, db); err != nil {
+		return err
 	}
+	m.checkServerReadOnly(ctx, db)
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		db.Close()
+		return fmt.Errorf("connection manager is closed")
+	}
 	m.database = db
 	return nil
 }
 
+// Reconnect retries, in the background, every connection that is not established, until
+// it connects, ctx is cancelled or the manager is closed. Each connection is retried with
+// the exponential backoff of Connect, without a limit on the number of attempts, and every
+// attempt is recorded for Status, so a failed connection is reported as recovered once it
+// succeeds. Reconnect returns immediately.
+func (m *Manager) Reconnect(ctx context.Context) {
+	managers := []*Manager{m}
+	for _, name := range m.namedConnections() {
+		managers = append(managers, m.managers[name])
+	}
+
+	for _, manager := range managers {
+		if manager.GetDatabase() == nil {
+			go manager.reconnect(ctx)
+		}
+	}
+}
+
+// reconnect retries the manager's own connection until it succeeds, ctx is cancelled or
+// the manager is closed.
+func (m *Manager) reconnect(ctx context.Context) {
+	delay := m.config.ConnectRetryInterval
+	if delay <= 0 {
+		delay = config.DefaultConnectRetryInterval
+	}
+
+	for {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		m.mu.Lock()
+		closed := m.closed
+		m.mu.Unlock()
+		if closed {
+			return
+		}
+
+		db, err := m.newDatabase(m.config)
+		if err == nil {
+			err = db.Connect(ctx)
+			m.recordAttempt(err)
+		}
+		if err == nil {
+			m.checkServerReadOnly(ctx, db)
+
+			m.mu.Lock()
+			if m.closed {
+				m.mu.Unlock()
+				db.Close()
+				return
+			}
+			m.database = db
+			m.mu.Unlock()
+
+			slog.Info("Database connection recovered", "database", m.config.Database, "attempts", m.Status().Attempts)
+			return
+		}
+
+		slog.Warn("Database reconnection attempt failed", "database", m.config.Database, "error", err, "retry_in", delay.String())
+		delay = min(delay*2, maxConnectRetryDelay)
+	}
+}
+
+// connectWithRetry calls db.Connect, retrying up to ConnectRetries more times on failure.
+// The delay between attempts starts at ConnectRetryInterval and doubles after each retry,
+// up to maxConnectRetryDelay. Every attempt is recorded for Status.
+func (m *Manager) connectWithRetry(ctx context.Context, db Database) error {
+	attempts := m.config.ConnectRetries + 1
+	delay := m.config.ConnectRetryInterval
+
+	for attempt := 1; ; attempt++ {
+		err := db.Connect(ctx)
+		m.recordAttempt(err)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= attempts {
+			if attempts > 1 {
+				return fmt.Errorf("failed to connect to database after %d attempts: %w", attempts, err)
+			}
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+
+		slog.Warn("Database connection attempt failed",
+			"attempt", attempt, "attempts", attempts, "error", err, "retry_in", delay.String())
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("failed to connect to database after %d attempts (%v): %w", attempt, ctx.Err(), err)
+		case <-timer.C:
+		}
+
+		delay = min(delay*2, maxConnectRetryDelay)
+	}
+}
+
+// SetDatabaseFactory replaces the function that creates the database instances of the
+// manager and its named connections, for example with a test double. It must be called
+// before Connect.
+func (m *Manager) SetDatabaseFactory(newDatabase func(config.DatabaseConfig) (Database, error)) {
+	m.newDatabase = newDatabase
+	for _, manager := range m.managers {
+		manager.newDatabase = newDatabase
+	}
+}
+
+// checkServerReadOnly detects whether the newly connected server only accepts reads and
+// records the result for ReadOnly. With DB_AUTO_READ_ONLY, read-only mode then applies to
+// the connection so that writes are rejected up front; otherwise a warning is logged, since
+// writes would fail at the server.
+func (m *Manager) checkServerReadOnly(ctx context.Context, db Database) {
+	readOnly, err := db.IsServerReadOnly(ctx)
+	if err != nil {
+		slog.Warn("Could not determine whether the database server is read-only", "error", err)
+		readOnly = false
+	}
+
+	m.mu.Lock()
+	m.serverReadOnly = readOnly
+	m.mu.Unlock()
+
+	if !readOnly || m.config.ReadOnly {
+		return
+	}
+
+	if m.config.AutoReadOnly {
+		slog.Info("Database server is read-only; enabled read-only mode", "database", m.config.Database)
+		return
+	}
+	slog.Warn("Database server is read-only (replica or in recovery); writes will fail",
+		"database", m.config.Database, "hint", "set DB_READ_ONLY or DB_AUTO_READ_ONLY")
+}
+
+// recordAttempt counts a connection attempt and remembers its error, if it failed.
+func (m *Manager) recordAttempt(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.attempts++
+	m.lastErr = err
+}
+
+// Status reports whether the manager's connection is established, how many connection
+// attempts were made and, while it is not connected, the error of the last failed attempt
+// with the password masked. The name is left for the caller to fill in.
+func (m *Manager) Status() ConnectionStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := ConnectionStatus{
+		Type:      m.config.Type,
+		Connected: m.database != nil,
+		Attempts:  m.attempts,
+	}
+	if !status.Connected && m.lastErr != nil {
+		status.LastError = redactPassword(m.lastErr, m.config.Password).Error()
+	}
+	return status
+}
+
+// AddConnection registers an additional named connection. It is connected by Connect
+// and released by Close together with the default connection.
+// Returns an error if the name is already in use or the configuration is invalid.
+func (m *Manager) AddConnection(name string, cfg config.DatabaseConfig) error {
+	if name == "" || name == config.DefaultConnection {
+		return fmt.Errorf("invalid connection name: %q", name)
+	}
+	if _, exists := m.managers[name]; exists {
+		return fmt.Errorf("connection %q already exists", name)
+	}
+
+	manager, err := NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("connection %q: %w", name, err)
+	}
+
+	if m.managers == nil {
+		m.managers = make(map[string]*Manager)
+	}
+	m.managers[name] = manager
+	return nil
+}
+
+// Connection returns the manager for the named connection. An empty name or
+// config.DefaultConnection returns m itself.
+func (m *Manager) Connection(name string) (*Manager, error) {
+	if name == "" || name == config.DefaultConnection {
+		return m, nil
+	}
+
+	manager, exists := m.managers[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown connection: %s (available: %s)",
+			name, strings.Join(m.ConnectionNames(), ", "))
+	}
+	return manager, nil
+}
+
+// ConnectionNames returns the names of all connections, starting with
+// config.DefaultConnection followed by the named connections in sorted order.
+func (m *Manager) ConnectionNames() []string {
+	return append([]string{config.DefaultConnection}, m.namedConnections()...)
+}
+
+// namedConnections returns the names of the additional connections in sorted order.
+func (m *Manager) namedConnections() []string {
+	names := make([]string, 0, len(m.managers))
+	for name := range m.managers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SafeDSN returns the connection string of the default connection with the password
+// masked, for use in logs.
+func (m *Manager) SafeDSN() string {
+	switch m.config.Type {
+	case "mysql":
+		return (&MySQL{config: m.config}).SafeDSN()
+	case "postgres":
+		return (&PostgreSQL{config: m.config}).SafeDSN()
+	}
+	return ""
+}
+
+// Config returns the configuration of the manager's connection.
+func (m *Manager) Config() *config.DatabaseConfig {
+	return &m.config
+}
+
+// ReadOnly reports whether read-only mode applies to the manager's connection: either
+// DB_READ_ONLY is set, or DB_AUTO_READ_ONLY is set and Connect found the server read-only.
+// The configuration returned by Config is left as loaded.
+func (m *Manager) ReadOnly() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.config.ReadOnly || (m.config.AutoReadOnly && m.serverReadOnly)
+}
+
 // GetDatabase returns the active database connection instance.
 // Returns nil if no connection has been established yet.
 func (m *Manager) GetDatabase() Database {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.database
 }
 
-// Close closes the database connection and releases associated resources.
-// It's safe to call even if no connection has been established.
+// Close closes the database connection, and any named connections, and releases associated resources.
+// It's safe to call even if no connection has been established, and more than once: a closed
+// connection is reported as not connected and is not closed again.
+// Every connection is closed even if an earlier one fails; the first error is returned.
 func (m *Manager) Close() error {
-	if m.database != nil {
-		return m.database.Close()
+	var firstErr error
+	for _, name := range m.namedConnections() {
+		if err := m.managers[name].Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("connection %q: %w", name, err)
+		}
 	}
-	return nil
+
+	m.mu.Lock()
+	db := m.database
+	m.database = nil
+	m.closed = true
+	m.mu.Unlock()
+
+	if db != nil {
+		if err := db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
 }
 
 // Ping verifies the database connection is still alive and accessible.
 // Returns an error if no connection has been established or if the database is unreachable.
 func (m *Manager) Ping(ctx context.Context) error {
-	if m.database == nil {
+	db := m.GetDatabase()
+	if db == nil {
 		return fmt.Errorf("no database connection established")
 	}
-	return m.database.Ping(ctx)
+	return db.Ping(ctx)
 }
 
 // validateConfig validates the database configuration settings.
@@ -110,24 +440,178 @@ func validateConfig(cfg config.DatabaseConfig) error {
 }
 
 // configureConnectionPool sets up connection pooling parameters for the database connection.
-// It uses configuration values if provided, otherwise applies sensible defaults:
-// - MaxOpenConns: 25 connections
-// - MaxIdleConns: 5 connections
-// - ConnMaxLifetime: 5 minutes
-// - ConnMaxIdleTime: 30 seconds
+// It uses configuration values if provided, otherwise applies the engine-specific defaults
+// from config.DefaultPoolSize. Connection lifetimes are taken from DB_CONN_MAX_LIFETIME and
+// DB_CONN_MAX_IDLE_TIME, which default to 5 minutes and 30 seconds when loaded; zero
+// disables the limit, as in database/sql.
 func configureConnectionPool(db *sql.DB, cfg config.DatabaseConfig) {
-	if cfg.MaxConns > 0 {
-		db.SetMaxOpenConns(cfg.MaxConns)
-	} else {
-		db.SetMaxOpenConns(25)
+	cfg.ApplyPoolDefaults()
+	db.SetMaxOpenConns(cfg.MaxConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
+
+// beginTx starts a transaction on db, applying the configured default isolation level
+// when the caller does not request a specific one.
+func beginTx(ctx context.Context, db *sql.DB, defaultIsolation string, opts *sql.TxOptions) (*sql.Tx, error) {
+	if db == nil {
+		return nil, fmt.Errorf("no database connection")
+	}
+
+	txOpts := sql.TxOptions{}
+	if opts != nil {
+		txOpts = *opts
+	}
+
+	if txOpts.Isolation == sql.LevelDefault {
+		isolation, err := config.ParseIsolationLevel(defaultIsolation)
+		if err != nil {
+			return nil, err
+		}
+		txOpts.Isolation = isolation
 	}
 
-	if cfg.MaxIdleConns > 0 {
-		db.SetMaxIdleConns(cfg.MaxIdleConns)
-	} else {
-		db.SetMaxIdleConns(5)
+	tx, err := db.BeginTx(ctx, &txOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// explainAnalyze runs an EXPLAIN ANALYZE statement, which executes the explained query,
+// inside a transaction that is always rolled back, so any rows the query inserts, updates
+// or deletes are discarded. Effects outside the transaction, such as sequence increments,
+// are not undone.
+func explainAnalyze(ctx context.Context, db *sql.DB, defaultIsolation, statement string, args ...any) (string, error) {
+	tx, err := beginTx(ctx, db, defaultIsolation, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var plan string
+	if err := tx.QueryRowContext(ctx, statement, args...).Scan(&plan); err != nil {
+		return "", err
+	}
+	return plan, nil
+}
+
+// redactedPassword replaces the password in connection strings shown by SafeDSN.
+const redactedPassword = "****"
+
+// maskPassword returns the placeholder shown instead of password, or "" when there is no password.
+func maskPassword(password string) string {
+	if password == "" {
+		return ""
+	}
+	return redactedPassword
+}
+
+// redactedError is an error whose message has had the connection password masked.
+// It unwraps to the original error so errors.Is and errors.As keep working.
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e *redactedError) Error() string { return e.message }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// redactPassword masks every occurrence of password in the message of err, which may come
+// from a driver that echoes (part of) the DSN it was given.
+func redactPassword(err error, password string) error {
+	if err == nil || password == "" || !strings.Contains(err.Error(), password) {
+		return err
+	}
+	return &redactedError{message: strings.ReplaceAll(err.Error(), password, redactedPassword), err: err}
+}
+
+// PrepareStatement returns the statement and arguments to send to the database in place of
+// query and args: the configured row filters are applied (see applyRowFilters) and the
+// configured statement prefix is prepended. Query, QueryRow and Exec use it, as must code
+// running statements on a transaction obtained from BeginTx. On error the returned
+// statement is still safe to run; it matches no rows or is empty.
+func PrepareStatement(cfg *config.DatabaseConfig, query string, args []any) (string, []any, error) {
+	query, args, err := applyRowFilters(cfg, query, args)
+	return applyStatementPrefix(cfg.StatementPrefix, query), args, err
+}
+
+// errorRow returns a row whose Scan reports err, for QueryRow to fail with the reason a
+// statement was rejected before it reached the database. database/sql only creates rows
+// itself, so the row comes from a pool whose connections fail to open with err.
+func errorRow(err error) *sql.Row {
+	db := sql.OpenDB(errorConnector{err: err})
+	defer db.Close()
+	return db.QueryRowContext(context.Background(), "")
+}
+
+// errorConnector is a driver.Connector whose connections fail to open with err.
+type errorConnector struct {
+	err error
+}
+
+func (c errorConnector) Connect(context.Context) (driver.Conn, error) { return nil, c.err }
+func (c errorConnector) Driver() driver.Driver                        { return errorDriver{c.err} }
+
+// errorDriver is the driver.Driver of an errorConnector.
+type errorDriver struct {
+	err error
+}
+
+func (d errorDriver) Open(string) (driver.Conn, error) { return nil, d.err }
+
+// applyRowFilters restricts query to the rows allowed by the configured DB_ROW_FILTERS,
+// binding the predicates' parameters from DB_ROW_FILTER_PARAMS. The parameters are part of
+// the server's configuration so that clients cannot choose whose rows they see. It returns
+// the query and arguments to run in place of query and args, which are unchanged when no
+// row filters are configured.
+func applyRowFilters(cfg *config.DatabaseConfig, query string, args []any) (string, []any, error) {
+	if cfg.RowFilters == "" {
+		return query, args, nil
+	}
+	filters, err := config.ParseRowFilters(cfg.RowFilters)
+	if err != nil {
+		return query, args, err
+	}
+	params, err := config.ParseRowFilterParams(cfg.RowFilterParams)
+	if err != nil {
+		return query, args, err
+	}
+	return security.ApplyRowFilters(query, args, filters, params, cfg.Type)
+}
+
+// applyStatementPrefix prepends the configured statement prefix to a query as a block comment.
+// The prefix is sanitized so that it cannot terminate the comment early or span multiple lines,
+// which means it can never be used to smuggle additional statements into the query.
+// Returns the query unchanged if the prefix is empty after sanitization.
+func applyStatementPrefix(prefix, query string) string {
+	sanitized := sanitizeStatementPrefix(prefix)
+	if sanitized == "" {
+		return query
+	}
+	return fmt.Sprintf("/* %s */ %s", sanitized, query)
+}
+
+// sanitizeStatementPrefix removes comment delimiters and control characters from a prefix.
+// Delimiters are removed repeatedly since removing one can create another (e.g. "**//").
+func sanitizeStatementPrefix(prefix string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, prefix)
+
+	for {
+		cleaned := strings.ReplaceAll(sanitized, "*/", "")
+		cleaned = strings.ReplaceAll(cleaned, "/*", "")
+		if cleaned == sanitized {
+			break
+		}
+		sanitized = cleaned
 	}
 
-	db.SetConnMaxLifetime(5 * time.Minute)
-	db.SetConnMaxIdleTime(30 * time.Second)
+	return strings.TrimSpace(sanitized)
 }
@@ -2,6 +2,10 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/jhoffmann/go-database-mcp/internal/config"
@@ -206,6 +210,29 @@ func TestManager_Ping_BeforeConnect(t *testing.T) {
 	}
 }
 
+func TestManager_Ping_DuringConnect(t *testing.T) {
+	manager, err := NewManager(NewTestConfig("postgres"))
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	manager.newDatabase = func(config.DatabaseConfig) (Database, error) { return &MockDatabase{}, nil }
+
+	// Ping reads the connection under the manager's lock, so it may race with Connect
+	done := make(chan error, 1)
+	go func() { done <- manager.Connect(context.Background()) }()
+	for range 100 {
+		if err := manager.Ping(context.Background()); err != nil && !contains(err.Error(), "no database connection established") {
+			t.Errorf("Ping() unexpected error = %v", err)
+		}
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	if err := manager.Ping(context.Background()); err != nil {
+		t.Errorf("Ping() after Connect() error = %v", err)
+	}
+}
+
 func TestValidateConfig_AllValid(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -334,6 +361,103 @@ func TestValidateConfig_AllInvalid(t *testing.T) {
 	}
 }
 
+func TestApplyStatementPrefix(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		query  string
+		want   string
+	}{
+		{
+			name:   "no prefix",
+			prefix: "",
+			query:  "SELECT 1",
+			want:   "SELECT 1",
+		},
+		{
+			name:   "simple prefix",
+			prefix: "app=mcp route=replica",
+			query:  "SELECT 1",
+			want:   "/* app=mcp route=replica */ SELECT 1",
+		},
+		{
+			name:   "comment terminator is stripped",
+			prefix: "x */ DROP TABLE users; /*",
+			query:  "SELECT 1",
+			want:   "/* x  DROP TABLE users; */ SELECT 1",
+		},
+		{
+			name:   "nested terminators are stripped",
+			prefix: "a **// b",
+			query:  "SELECT 1",
+			want:   "/* a  b */ SELECT 1",
+		},
+		{
+			name:   "newlines cannot start a line comment escape",
+			prefix: "hint\n--\nDELETE FROM users",
+			query:  "SELECT 1",
+			want:   "/* hint -- DELETE FROM users */ SELECT 1",
+		},
+		{
+			name:   "prefix of only delimiters is dropped",
+			prefix: " */ /* ",
+			query:  "SELECT 1",
+			want:   "SELECT 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := applyStatementPrefix(tt.prefix, tt.query)
+			if got != tt.want {
+				t.Errorf("applyStatementPrefix() = %q, want %q", got, tt.want)
+			}
+			if strings.Count(got, "*/") > 1 {
+				t.Errorf("applyStatementPrefix() produced an early comment terminator: %q", got)
+			}
+		})
+	}
+}
+
+func TestStatementPrefix_AppliedToExecutedQueries(t *testing.T) {
+	for _, dbType := range []string{"mysql", "postgres"} {
+		t.Run(dbType, func(t *testing.T) {
+			cfg := NewTestConfig(dbType)
+			cfg.StatementPrefix = "service=mcp"
+
+			sqlDB, recorder := NewRecordingDB()
+			defer sqlDB.Close()
+
+			var db Database
+			if dbType == "mysql" {
+				db = &MySQL{db: sqlDB, config: cfg}
+			} else {
+				db = &PostgreSQL{db: sqlDB, config: cfg}
+			}
+
+			ctx := context.Background()
+			if _, err := db.Exec(ctx, "UPDATE users SET active = true"); err != nil {
+				t.Fatalf("Exec() error = %v", err)
+			}
+			if rows, err := db.Query(ctx, "SELECT id FROM users"); err == nil {
+				rows.Close()
+			}
+			var id int
+			_ = db.QueryRow(ctx, "SELECT id FROM users LIMIT 1").Scan(&id)
+
+			queries := recorder.Queries()
+			if len(queries) != 3 {
+				t.Fatalf("Expected 3 recorded queries, got %d: %v", len(queries), queries)
+			}
+			for _, query := range queries {
+				if !strings.HasPrefix(query, "/* service=mcp */ ") {
+					t.Errorf("Expected query to start with statement prefix, got %q", query)
+				}
+			}
+		})
+	}
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	if len(s) < len(substr) {
@@ -346,3 +470,134 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestBeginTx_AppliesConfiguredIsolation(t *testing.T) {
+	tests := []struct {
+		name      string
+		dbType    string
+		isolation string
+		opts      *sql.TxOptions
+		want      sql.IsolationLevel
+	}{
+		{name: "postgres configured default", dbType: "postgres", isolation: "serializable", want: sql.LevelSerializable},
+		{name: "mysql configured default", dbType: "mysql", isolation: "repeatable read", want: sql.LevelRepeatableRead},
+		{name: "explicit level wins", dbType: "postgres", isolation: "serializable", opts: &sql.TxOptions{Isolation: sql.LevelReadCommitted}, want: sql.LevelReadCommitted},
+		{name: "no configured level", dbType: "postgres", want: sql.LevelDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewTestConfig(tt.dbType)
+			cfg.IsolationLevel = tt.isolation
+
+			sqlDB, recorder := NewRecordingDB()
+			defer sqlDB.Close()
+
+			var db Database
+			if tt.dbType == "mysql" {
+				db = &MySQL{db: sqlDB, config: cfg}
+			} else {
+				db = &PostgreSQL{db: sqlDB, config: cfg}
+			}
+
+			tx, err := db.BeginTx(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("BeginTx() error = %v", err)
+			}
+			defer tx.Rollback()
+
+			options := recorder.TxOptions()
+			if len(options) != 1 {
+				t.Fatalf("Expected 1 transaction, got %d", len(options))
+			}
+			if got := sql.IsolationLevel(options[0].Isolation); got != tt.want {
+				t.Errorf("Expected isolation %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBeginTx_NoConnection(t *testing.T) {
+	db := &PostgreSQL{config: NewTestConfig("postgres")}
+	if _, err := db.BeginTx(context.Background(), nil); err == nil {
+		t.Error("Expected error when beginning a transaction without a connection")
+	}
+}
+
+func TestExplainAnalyzeQuery_Transaction(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType string
+		want   string
+	}{
+		{name: "postgres", dbType: "postgres", want: "EXPLAIN (ANALYZE, FORMAT JSON) SELECT * FROM users"},
+		{name: "mysql", dbType: "mysql", want: "EXPLAIN ANALYZE SELECT * FROM users"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, recorder := NewRecordingDB()
+			defer sqlDB.Close()
+
+			var db Database
+			if tt.dbType == "mysql" {
+				db = &MySQL{db: sqlDB, config: NewTestConfig(tt.dbType)}
+			} else {
+				db = &PostgreSQL{db: sqlDB, config: NewTestConfig(tt.dbType)}
+			}
+
+			// The mock driver returns no rows, so only the issued statement is checked
+			_, _ = db.ExplainAnalyzeQuery(context.Background(), "SELECT * FROM users")
+
+			if options := recorder.TxOptions(); len(options) != 1 {
+				t.Fatalf("Expected the plan to be collected in one transaction, got %+v", options)
+			}
+
+			queries := recorder.Queries()
+			if len(queries) != 1 || queries[0] != tt.want {
+				t.Errorf("Expected query %q, got %v", tt.want, queries)
+			}
+		})
+	}
+}
+
+func TestExplainAnalyzeQuery_NoConnection(t *testing.T) {
+	db := &MySQL{config: NewTestConfig("mysql")}
+	if _, err := db.ExplainAnalyzeQuery(context.Background(), "SELECT 1"); err == nil {
+		t.Error("Expected error when explaining without a connection")
+	}
+}
+
+func TestRedactPassword(t *testing.T) {
+	err := fmt.Errorf("invalid DSN: app:s3cr3t@tcp(db:3306: %w", context.DeadlineExceeded)
+
+	redacted := redactPassword(err, "s3cr3t")
+	if strings.Contains(redacted.Error(), "s3cr3t") || !strings.Contains(redacted.Error(), "app:****@tcp") {
+		t.Errorf("redactPassword() = %q, expected the password to be masked", redacted)
+	}
+	if !errors.Is(redacted, context.DeadlineExceeded) {
+		t.Error("Expected the redacted error to unwrap to the original error")
+	}
+
+	if got := redactPassword(err, ""); got != err {
+		t.Errorf("Expected the error unchanged without a password, got %v", got)
+	}
+	if got := redactPassword(nil, "s3cr3t"); got != nil {
+		t.Errorf("Expected nil, got %v", got)
+	}
+}
+
+func TestManager_SafeDSN(t *testing.T) {
+	cfg := NewTestConfig("mysql")
+	cfg.Password = "s3cr3t"
+
+	manager, err := NewManager(cfg)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	dsn := manager.SafeDSN()
+	if dsn == "" || strings.Contains(dsn, "s3cr3t") || !strings.Contains(dsn, ":****@tcp(") {
+		t.Errorf("SafeDSN() = %q, expected a DSN with the password masked", dsn)
+	}
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"sort"
+	"strings"
+)
+
+// Constraint types reported in ConstraintInfo.Type.
+const (
+	ConstraintPrimaryKey = "primary_key"
+	ConstraintForeignKey = "foreign_key"
+	ConstraintUnique     = "unique"
+	ConstraintCheck      = "check"
+	ConstraintNotNull    = "not_null"
+)
+
+// constraintTypes maps information_schema constraint types to ConstraintInfo types.
+var constraintTypes = map[string]string{
+	"PRIMARY KEY": ConstraintPrimaryKey,
+	"FOREIGN KEY": ConstraintForeignKey,
+	"UNIQUE":      ConstraintUnique,
+	"CHECK":       ConstraintCheck,
+}
+
+// constraintOrder ranks constraint types for display, keys first and not-null constraints last.
+var constraintOrder = map[string]int{
+	ConstraintPrimaryKey: 0,
+	ConstraintForeignKey: 1,
+	ConstraintUnique:     2,
+	ConstraintCheck:      3,
+	ConstraintNotNull:    4,
+}
+
+// appendConstraintColumn adds one column of a constraint, as read from information_schema,
+// to the list. Rows are expected to be ordered by constraint name and column position, so
+// consecutive rows for the same constraint are merged into one entry; an empty column adds
+// the constraint without columns. PostgreSQL reports NOT NULL constraints as CHECK constraints
+// named "..._not_null" with a "column IS NOT NULL" clause; these are returned as not_null.
+func appendConstraintColumn(constraints []ConstraintInfo, name, constraintType, column, checkClause string) []ConstraintInfo {
+	kind, ok := constraintTypes[constraintType]
+	if !ok {
+		kind = strings.ToLower(strings.ReplaceAll(constraintType, " ", "_"))
+	}
+	if kind == ConstraintCheck && strings.HasSuffix(name, "_not_null") {
+		if notNull, found := strings.CutSuffix(checkClause, " IS NOT NULL"); found {
+			kind, checkClause = ConstraintNotNull, ""
+			if column == "" {
+				column = unquotePostgresIdentifier(notNull)
+			}
+		}
+	}
+
+	if n := len(constraints); n > 0 && constraints[n-1].Name == name && constraints[n-1].Type == kind {
+		if column != "" {
+			constraints[n-1].Columns = append(constraints[n-1].Columns, column)
+		}
+		return constraints
+	}
+
+	constraint := ConstraintInfo{Name: name, Type: kind, Columns: []string{}, CheckClause: checkClause}
+	if column != "" {
+		constraint.Columns = append(constraint.Columns, column)
+	}
+	return append(constraints, constraint)
+}
+
+// sortConstraints orders constraints by type (primary key, foreign keys, unique, check,
+// not-null), keeping the existing order within each type.
+func sortConstraints(constraints []ConstraintInfo) {
+	sort.SliceStable(constraints, func(i, j int) bool {
+		return constraintOrder[constraints[i].Type] < constraintOrder[constraints[j].Type]
+	})
+}
+
+// unquotePostgresIdentifier removes the double quotes around a quoted PostgreSQL identifier.
+func unquotePostgresIdentifier(name string) string {
+	name = strings.TrimSpace(name)
+	if len(name) >= 2 && name[0] == '"' && name[len(name)-1] == '"' {
+		return strings.ReplaceAll(name[1:len(name)-1], `""`, `"`)
+	}
+	return name
+}
+
+// setKeyConstraints fills in PrimaryKey and UniqueConstraints from the schema's indexes.
+// When no primary key index was found, the primary key falls back to the columns flagged
+// IsPrimaryKey. Unique column groups are ordered by index name so results are stable.
+func (s *TableSchema) setKeyConstraints() {
+	s.PrimaryKey = nil
+	s.UniqueConstraints = nil
+
+	unique := make([]IndexInfo, 0, len(s.Indexes))
+	for _, index := range s.Indexes {
+		switch {
+		case index.IsPrimary:
+			s.PrimaryKey = append([]string(nil), index.Columns...)
+		case index.IsUnique:
+			unique = append(unique, index)
+		}
+	}
+
+	if s.PrimaryKey == nil {
+		for _, column := range s.Columns {
+			if column.IsPrimaryKey {
+				s.PrimaryKey = append(s.PrimaryKey, column.Name)
+			}
+		}
+	}
+
+	sort.Slice(unique, func(i, j int) bool { return unique[i].Name < unique[j].Name })
+	for _, index := range unique {
+		s.UniqueConstraints = append(s.UniqueConstraints, append([]string(nil), index.Columns...))
+	}
+}
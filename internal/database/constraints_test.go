@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestAppendConstraintColumn(t *testing.T) {
+	var constraints []ConstraintInfo
+	constraints = appendConstraintColumn(constraints, "orders_amount_check", "CHECK", "amount", "(amount > 0)")
+	constraints = appendConstraintColumn(constraints, "orders_pkey", "PRIMARY KEY", "tenant_id", "")
+	constraints = appendConstraintColumn(constraints, "orders_pkey", "PRIMARY KEY", "id", "")
+	constraints = appendConstraintColumn(constraints, "2200_16385_1_not_null", "CHECK", "", `"Id" IS NOT NULL`)
+	constraints = appendConstraintColumn(constraints, "chk_status", "CHECK", "", "(`status` in (_utf8mb4'open',_utf8mb4'closed'))")
+	constraints = appendConstraintColumn(constraints, "orders_ref_key", "UNIQUE", "ref", "")
+	sortConstraints(constraints)
+
+	want := []ConstraintInfo{
+		{Name: "orders_pkey", Type: ConstraintPrimaryKey, Columns: []string{"tenant_id", "id"}},
+		{Name: "orders_ref_key", Type: ConstraintUnique, Columns: []string{"ref"}},
+		{Name: "orders_amount_check", Type: ConstraintCheck, Columns: []string{"amount"}, CheckClause: "(amount > 0)"},
+		{Name: "chk_status", Type: ConstraintCheck, Columns: []string{}, CheckClause: "(`status` in (_utf8mb4'open',_utf8mb4'closed'))"},
+		{Name: "2200_16385_1_not_null", Type: ConstraintNotNull, Columns: []string{"Id"}},
+	}
+	if !reflect.DeepEqual(constraints, want) {
+		t.Errorf("appendConstraintColumn() =\n  %+v\nwant\n  %+v", constraints, want)
+	}
+}
+
+func TestGetConstraints_Query(t *testing.T) {
+	tests := []struct {
+		name      string
+		dbType    string
+		wantTable string
+	}{
+		{name: "postgres", dbType: "postgres", wantTable: "information_schema.check_constraints"},
+		{name: "mysql", dbType: "mysql", wantTable: "INFORMATION_SCHEMA.CHECK_CONSTRAINTS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, recorder := NewRecordingDB()
+			defer sqlDB.Close()
+
+			var db Database
+			if tt.dbType == "mysql" {
+				db = &MySQL{db: sqlDB, config: NewTestConfig(tt.dbType)}
+			} else {
+				db = &PostgreSQL{db: sqlDB, config: NewTestConfig(tt.dbType)}
+			}
+
+			// The mock driver fails when reading rows, so only the first query is issued
+			_, _ = db.GetConstraints(context.Background(), "orders")
+
+			queries := recorder.Queries()
+			if len(queries) != 1 || !contains(queries[0], tt.wantTable) {
+				t.Errorf("Expected a query reading %s, got %v", tt.wantTable, queries)
+			}
+		})
+	}
+}
+
+func TestTableSchema_SetKeyConstraints(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     TableSchema
+		wantPK     []string
+		wantUnique [][]string
+	}{
+		{
+			name: "composite primary key and unique indexes",
+			schema: TableSchema{
+				Columns: []ColumnInfo{{Name: "tenant_id", IsPrimaryKey: true}, {Name: "id", IsPrimaryKey: true}, {Name: "email"}, {Name: "slug"}},
+				Indexes: []IndexInfo{
+					{Name: "users_slug_key", Columns: []string{"tenant_id", "slug"}, IsUnique: true},
+					{Name: "users_created_idx", Columns: []string{"created_at"}},
+					{Name: "users_pkey", Columns: []string{"tenant_id", "id"}, IsUnique: true, IsPrimary: true},
+					{Name: "users_email_key", Columns: []string{"email"}, IsUnique: true},
+				},
+			},
+			wantPK:     []string{"tenant_id", "id"},
+			wantUnique: [][]string{{"email"}, {"tenant_id", "slug"}},
+		},
+		{
+			name: "primary key from column flags",
+			schema: TableSchema{
+				Columns: []ColumnInfo{{Name: "id", IsPrimaryKey: true}, {Name: "name"}},
+			},
+			wantPK: []string{"id"},
+		},
+		{
+			name: "no keys",
+			schema: TableSchema{
+				Columns: []ColumnInfo{{Name: "message"}},
+				Indexes: []IndexInfo{{Name: "logs_message_idx", Columns: []string{"message"}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.schema.setKeyConstraints()
+			if !reflect.DeepEqual(tt.schema.PrimaryKey, tt.wantPK) {
+				t.Errorf("PrimaryKey = %v, want %v", tt.schema.PrimaryKey, tt.wantPK)
+			}
+			if !reflect.DeepEqual(tt.schema.UniqueConstraints, tt.wantUnique) {
+				t.Errorf("UniqueConstraints = %v, want %v", tt.schema.UniqueConstraints, tt.wantUnique)
+			}
+		})
+	}
+}
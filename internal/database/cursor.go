@@ -0,0 +1,102 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// EncodeCursor returns an opaque cursor identifying a row by its key values, in key column
+// order. Byte slices are encoded as text, as drivers return text columns that way.
+func EncodeCursor(values []any) (string, error) {
+	encoded := make([]any, len(values))
+	for i, value := range values {
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+		encoded[i] = value
+	}
+
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor returns the key values encoded in cursor by EncodeCursor, checking that there
+// is one for each of the keys key columns. Numbers are returned as json.Number, so large
+// integer keys keep their precision; they are bound as text and converted by the database.
+func DecodeCursor(cursor string, keys int) ([]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	var values []any
+	if err := decoder.Decode(&values); err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	if len(values) != keys {
+		return nil, fmt.Errorf("invalid cursor: expected %d key values, got %d", keys, len(values))
+	}
+	for _, value := range values {
+		if value == nil {
+			return nil, fmt.Errorf("invalid cursor: key values cannot be NULL")
+		}
+	}
+	return values, nil
+}
+
+// cursorClause returns the condition selecting the rows after the one identified by
+// o.Cursor in key column order, e.g. ("a", "b") > ($2, $3), along with its arguments,
+// numbered from placeholder(n). Without a cursor the condition is empty.
+func (o TableDataOptions) cursorClause(quote func(string) string, placeholder func(int) string, n int) (string, []any, error) {
+	if o.Cursor == "" {
+		return "", nil, nil
+	}
+	if len(o.KeyColumns) == 0 {
+		return "", nil, fmt.Errorf("cursor pagination requires key columns")
+	}
+
+	values, err := DecodeCursor(o.Cursor, len(o.KeyColumns))
+	if err != nil {
+		return "", nil, err
+	}
+
+	columns := make([]string, len(o.KeyColumns))
+	placeholders := make([]string, len(o.KeyColumns))
+	for i, column := range o.KeyColumns {
+		columns[i] = quote(column)
+		placeholders[i] = placeholder(n + i)
+	}
+	return fmt.Sprintf("(%s) > (%s)", strings.Join(columns, ", "), strings.Join(placeholders, ", ")), values, nil
+}
+
+// nextCursor returns the cursor of the last of rows when a full page of limit rows was
+// read in key column order, so more rows may follow. It returns nil on a partial page,
+// without key columns, or when the rows do not include every key column.
+func (o TableDataOptions) nextCursor(rows []map[string]any, limit int) (*string, error) {
+	if len(o.KeyColumns) == 0 || len(rows) == 0 || len(rows) < limit {
+		return nil, nil
+	}
+
+	last := rows[len(rows)-1]
+	values := make([]any, len(o.KeyColumns))
+	for i, column := range o.KeyColumns {
+		value, ok := last[column]
+		if !ok || value == nil {
+			return nil, nil
+		}
+		values[i] = value
+	}
+
+	cursor, err := EncodeCursor(values)
+	if err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
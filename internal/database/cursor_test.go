@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	cursor, err := EncodeCursor([]any{int64(9007199254740993), []byte("eu-west"), "a/b"})
+	if err != nil {
+		t.Fatalf("EncodeCursor() error = %v", err)
+	}
+
+	values, err := DecodeCursor(cursor, 3)
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	want := []any{json.Number("9007199254740993"), "eu-west", "a/b"}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("DecodeCursor() = %#v, want %#v", values, want)
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	valid, _ := EncodeCursor([]any{int64(1)})
+	withNull, _ := EncodeCursor([]any{nil})
+
+	tests := []struct {
+		name    string
+		cursor  string
+		keys    int
+		wantErr string
+	}{
+		{name: "not base64", cursor: "%%%", keys: 1, wantErr: "invalid cursor"},
+		{name: "not a JSON array", cursor: "bm90IGpzb24", keys: 1, wantErr: "invalid cursor"},
+		{name: "wrong number of keys", cursor: valid, keys: 2, wantErr: "expected 2 key values, got 1"},
+		{name: "null key", cursor: withNull, keys: 1, wantErr: "cannot be NULL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.cursor, tt.keys); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("DecodeCursor() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetTableData_Cursor(t *testing.T) {
+	cursor, _ := EncodeCursor([]any{int64(1), int64(20)})
+
+	tests := []struct {
+		name      string
+		dbType    string
+		opts      TableDataOptions
+		wantQuery string
+	}{
+		{
+			name:      "postgres first page",
+			dbType:    "postgres",
+			opts:      TableDataOptions{KeyColumns: []string{"tenant_id", "id"}},
+			wantQuery: `SELECT * FROM "public"."orders" ORDER BY "tenant_id", "id" LIMIT $1 OFFSET $2`,
+		},
+		{
+			name:      "postgres with cursor and filter",
+			dbType:    "postgres",
+			opts:      TableDataOptions{KeyColumns: []string{"tenant_id", "id"}, Cursor: cursor, Filter: &TableFilter{Column: "status", Operator: "=", Value: "open"}},
+			wantQuery: `SELECT * FROM "public"."orders" WHERE "status" = $1 AND ("tenant_id", "id") > ($2, $3) ORDER BY "tenant_id", "id" LIMIT $4 OFFSET $5`,
+		},
+		{
+			name:      "mysql with cursor",
+			dbType:    "mysql",
+			opts:      TableDataOptions{KeyColumns: []string{"tenant_id", "id"}, Cursor: cursor},
+			wantQuery: "SELECT * FROM `orders` WHERE (`tenant_id`, `id`) > (?, ?) ORDER BY `tenant_id`, `id` LIMIT ? OFFSET ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var queries []string
+			sqlDB := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+				mu.Lock()
+				queries = append(queries, query)
+				mu.Unlock()
+				if strings.HasPrefix(query, "SELECT COUNT(*)") {
+					return []string{"count"}, [][]driver.Value{{int64(10)}}
+				}
+				return []string{"tenant_id", "id"}, [][]driver.Value{{int64(1), int64(21)}, {int64(1), int64(22)}}
+			})
+			defer sqlDB.Close()
+
+			cfg := NewTestConfig(tt.dbType)
+			var db Database = &PostgreSQL{db: sqlDB, config: cfg}
+			if tt.dbType == "mysql" {
+				db = &MySQL{db: sqlDB, config: cfg}
+			}
+
+			data, err := db.GetTableData(context.Background(), "orders", 2, 0, tt.opts)
+			if err != nil {
+				t.Fatalf("GetTableData() error = %v", err)
+			}
+
+			if len(queries) != 2 || queries[1] != tt.wantQuery {
+				t.Fatalf("Expected data query %q, got %q", tt.wantQuery, queries)
+			}
+			// The total still counts every row, not just those after the cursor
+			if strings.Contains(queries[0], ">") || data.Total != 10 {
+				t.Errorf("Expected an unrestricted count of 10, got %d from %q", data.Total, queries[0])
+			}
+
+			if data.NextCursor == nil {
+				t.Fatal("Expected a next cursor for a full page")
+			}
+			values, err := DecodeCursor(*data.NextCursor, 2)
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if want := []any{json.Number("1"), json.Number("22")}; !reflect.DeepEqual(values, want) {
+				t.Errorf("Next cursor values = %v, want %v", values, want)
+			}
+		})
+	}
+}
+
+func TestTableDataOptions_NextCursor(t *testing.T) {
+	rows := []map[string]any{{"id": int64(1)}, {"id": int64(2)}}
+
+	tests := []struct {
+		name  string
+		opts  TableDataOptions
+		limit int
+		want  bool
+	}{
+		{name: "full page", opts: TableDataOptions{KeyColumns: []string{"id"}}, limit: 2, want: true},
+		{name: "partial page", opts: TableDataOptions{KeyColumns: []string{"id"}}, limit: 5},
+		{name: "no key columns", limit: 2},
+		{name: "key column not returned", opts: TableDataOptions{KeyColumns: []string{"uuid"}}, limit: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := tt.opts.nextCursor(rows, tt.limit)
+			if err != nil {
+				t.Fatalf("nextCursor() error = %v", err)
+			}
+			if (cursor != nil) != tt.want {
+				t.Errorf("nextCursor() = %v, want a cursor: %v", cursor, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,10 @@ package database
 
 import (
 	"context"
+	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/jhoffmann/go-database-mcp/internal/config"
 )
@@ -144,6 +147,33 @@ func TestConfigureConnectionPool(t *testing.T) {
 	}
 }
 
+func TestConfigureConnectionPool_EngineDefaults(t *testing.T) {
+	tests := []struct {
+		name         string
+		dbType       string
+		maxConns     int
+		wantMaxConns int
+	}{
+		{"postgres default", "postgres", 0, 20},
+		{"mysql default", "mysql", 0, 10},
+		{"configured value", "postgres", 7, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := NewRecordingDB()
+			defer db.Close()
+
+			cfg := config.DatabaseConfig{Type: tt.dbType, MaxConns: tt.maxConns}
+			configureConnectionPool(db, cfg)
+
+			if got := db.Stats().MaxOpenConnections; got != tt.wantMaxConns {
+				t.Errorf("MaxOpenConnections = %d, want %d", got, tt.wantMaxConns)
+			}
+		})
+	}
+}
+
 // Test database interface implementations
 func TestDatabaseInterface(t *testing.T) {
 	// Test that both MySQL and PostgreSQL implement the Database interface
@@ -290,6 +320,27 @@ func TestDataStructures(t *testing.T) {
 	}
 }
 
+func TestTableSchema_IsView(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]any
+		want     bool
+	}{
+		{"view", map[string]any{"table_type": "VIEW", "is_view": true}, true},
+		{"base table", map[string]any{"table_type": "BASE TABLE", "is_view": false}, false},
+		{"no metadata", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := &TableSchema{TableName: "test", Metadata: tt.metadata}
+			if got := schema.IsView(); got != tt.want {
+				t.Errorf("IsView() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // Helper functions for testing
 func stringPtr(s string) *string {
 	return &s
@@ -298,3 +349,336 @@ func stringPtr(s string) *string {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestManager_NamedConnections(t *testing.T) {
+	primary := NewTestConfig("postgres")
+	analytics := NewTestConfig("mysql")
+	analytics.Database = "analytics"
+
+	manager, err := NewManager(primary)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	if err := manager.AddConnection("analytics", analytics); err != nil {
+		t.Fatalf("AddConnection() error = %v", err)
+	}
+
+	errorTests := []struct {
+		name      string
+		connName  string
+		cfg       config.DatabaseConfig
+		wantError string
+	}{
+		{"duplicate name", "analytics", analytics, "already exists"},
+		{"reserved name", config.DefaultConnection, analytics, "invalid connection name"},
+		{"empty name", "", analytics, "invalid connection name"},
+		{"invalid config", "broken", config.DatabaseConfig{Type: "sqlite"}, "unsupported database type"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := manager.AddConnection(tt.connName, tt.cfg)
+			if err == nil || !contains(err.Error(), tt.wantError) {
+				t.Errorf("AddConnection() error = %v, want error containing %q", err, tt.wantError)
+			}
+		})
+	}
+
+	names := manager.ConnectionNames()
+	if len(names) != 2 || names[0] != config.DefaultConnection || names[1] != "analytics" {
+		t.Errorf("ConnectionNames() = %v, want [default analytics]", names)
+	}
+
+	for _, name := range []string{"", config.DefaultConnection} {
+		conn, err := manager.Connection(name)
+		if err != nil || conn != manager {
+			t.Errorf("Connection(%q) = %v, %v, want the manager itself", name, conn, err)
+		}
+	}
+
+	conn, err := manager.Connection("analytics")
+	if err != nil {
+		t.Fatalf("Connection(analytics) error = %v", err)
+	}
+	if conn.Config().Database != "analytics" {
+		t.Errorf("Connection(analytics) database = %s, want analytics", conn.Config().Database)
+	}
+
+	if _, err := manager.Connection("missing"); err == nil || !contains(err.Error(), "available: default, analytics") {
+		t.Errorf("Connection(missing) error = %v, want unknown connection listing available names", err)
+	}
+}
+
+func TestManager_CloseNamedConnections(t *testing.T) {
+	manager, err := NewManager(NewTestConfig("postgres"))
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	if err := manager.AddConnection("replica", NewTestConfig("postgres")); err != nil {
+		t.Fatalf("AddConnection() error = %v", err)
+	}
+
+	var closed []string
+	manager.database = &MockDatabase{CloseFunc: func() error {
+		closed = append(closed, "default")
+		return nil
+	}}
+	replica, _ := manager.Connection("replica")
+	replica.database = &MockDatabase{CloseFunc: func() error {
+		closed = append(closed, "replica")
+		return fmt.Errorf("close failed")
+	}}
+
+	err = manager.Close()
+	if err == nil || !contains(err.Error(), `connection "replica"`) {
+		t.Errorf("Close() error = %v, want error from the replica connection", err)
+	}
+	if len(closed) != 2 {
+		t.Errorf("Close() closed %v, want both connections closed", closed)
+	}
+
+	// Closed connections are released once, so closing again is a no-op
+	if err := manager.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+	if len(closed) != 2 {
+		t.Errorf("second Close() closed %v, want no connection closed again", closed)
+	}
+	if manager.GetDatabase() != nil || replica.Status().Connected {
+		t.Error("Expected closed connections to be reported as not connected")
+	}
+}
+
+func TestManager_ConnectRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		retries      int
+		wantErr      string
+		wantAttempts int
+	}{
+		{name: "succeeds first time", failures: 0, retries: 3, wantAttempts: 1},
+		{name: "succeeds after failures", failures: 2, retries: 3, wantAttempts: 3},
+		{name: "succeeds on last retry", failures: 3, retries: 3, wantAttempts: 4},
+		{name: "retries exhausted", failures: 5, retries: 2, wantErr: "after 3 attempts", wantAttempts: 3},
+		{name: "no retries fails immediately", failures: 1, retries: 0, wantErr: "failed to connect to database", wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewTestConfig("postgres")
+			cfg.ConnectRetries = tt.retries
+			cfg.ConnectRetryInterval = time.Millisecond
+
+			manager, err := NewManager(cfg)
+			if err != nil {
+				t.Fatalf("NewManager() error = %v", err)
+			}
+
+			attempts := 0
+			mock := &MockDatabase{ConnectFunc: func(ctx context.Context) error {
+				attempts++
+				if attempts <= tt.failures {
+					return fmt.Errorf("connection refused")
+				}
+				return nil
+			}}
+			manager.newDatabase = func(config.DatabaseConfig) (Database, error) { return mock, nil }
+
+			err = manager.Connect(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || !contains(err.Error(), tt.wantErr) {
+					t.Errorf("Connect() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				if manager.GetDatabase() != nil {
+					t.Error("Connect() failed but left a database set")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Connect() unexpected error = %v", err)
+				}
+				if manager.GetDatabase() != mock {
+					t.Error("Connect() did not set the connected database")
+				}
+			}
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("Connect() made %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestManager_Status(t *testing.T) {
+	cfg := NewTestConfig("postgres")
+	cfg.Password = "s3cret"
+	cfg.ConnectRetries = 1
+	cfg.ConnectRetryInterval = time.Millisecond
+
+	manager, err := NewManager(cfg)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	if status := manager.Status(); status.Connected || status.Attempts != 0 || status.LastError != "" {
+		t.Errorf("Expected an unattempted connection, got %+v", status)
+	}
+
+	reachable := false
+	manager.newDatabase = func(config.DatabaseConfig) (Database, error) {
+		return &MockDatabase{ConnectFunc: func(ctx context.Context) error {
+			if !reachable {
+				return fmt.Errorf("password authentication failed for password s3cret")
+			}
+			return nil
+		}}, nil
+	}
+
+	// Both attempts fail while the database is unreachable
+	if err := manager.Connect(context.Background()); err == nil {
+		t.Fatal("Expected Connect() to fail")
+	}
+	status := manager.Status()
+	if status.Connected || status.Attempts != 2 {
+		t.Errorf("Expected 2 failed attempts, got %+v", status)
+	}
+	if !contains(status.LastError, "password authentication failed") || contains(status.LastError, "s3cret") {
+		t.Errorf("Expected the last error with the password masked, got %q", status.LastError)
+	}
+
+	// The next attempt recovers
+	reachable = true
+	if err := manager.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	if status := manager.Status(); !status.Connected || status.Attempts != 3 || status.LastError != "" || status.Type != "postgres" {
+		t.Errorf("Expected a recovered connection after 3 attempts, got %+v", status)
+	}
+}
+
+func TestManager_Connect_ServerReadOnly(t *testing.T) {
+	tests := []struct {
+		name         string
+		autoReadOnly bool
+		readOnly     bool
+		readOnlyErr  error
+		want         bool
+	}{
+		{name: "read-only server with auto read-only", autoReadOnly: true, readOnly: true, want: true},
+		{name: "read-only server without auto read-only", readOnly: true, want: false},
+		{name: "writable server with auto read-only", autoReadOnly: true, want: false},
+		{name: "detection fails", autoReadOnly: true, readOnlyErr: fmt.Errorf("permission denied"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewTestConfig("postgres")
+			cfg.AutoReadOnly = tt.autoReadOnly
+
+			manager, err := NewManager(cfg)
+			if err != nil {
+				t.Fatalf("NewManager() error = %v", err)
+			}
+			manager.newDatabase = func(config.DatabaseConfig) (Database, error) {
+				return &MockDatabase{ReadOnlyFunc: func(ctx context.Context) (bool, error) {
+					return tt.readOnly, tt.readOnlyErr
+				}}, nil
+			}
+
+			if err := manager.Connect(context.Background()); err != nil {
+				t.Fatalf("Connect() error = %v", err)
+			}
+			if got := manager.ReadOnly(); got != tt.want {
+				t.Errorf("Expected read-only mode %v, got %v", tt.want, got)
+			}
+			if manager.Config().ReadOnly {
+				t.Error("Expected the configuration to be left unchanged")
+			}
+		})
+	}
+}
+
+func TestManager_ConnectRetry_ContextCancelled(t *testing.T) {
+	cfg := NewTestConfig("postgres")
+	cfg.ConnectRetries = 5
+	cfg.ConnectRetryInterval = time.Minute
+
+	manager, err := NewManager(cfg)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	manager.newDatabase = func(config.DatabaseConfig) (Database, error) {
+		return &MockDatabase{ConnectFunc: func(ctx context.Context) error {
+			attempts++
+			cancel() // Shutdown is requested while waiting for the database
+			return fmt.Errorf("connection refused")
+		}}, nil
+	}
+
+	err = manager.Connect(ctx)
+	if err == nil || !contains(err.Error(), "context canceled") {
+		t.Errorf("Connect() error = %v, want error mentioning the cancelled context", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Connect() made %d attempts, want 1", attempts)
+	}
+}
+
+func TestManager_Connect_NamedConnectionFailure(t *testing.T) {
+	manager, err := NewManager(NewTestConfig("postgres"))
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	for _, name := range []string{"analytics", "replica"} {
+		cfg := NewTestConfig("postgres")
+		cfg.Database = name
+		cfg.ConnectRetryInterval = time.Millisecond
+		if err := manager.AddConnection(name, cfg); err != nil {
+			t.Fatalf("AddConnection(%s) error = %v", name, err)
+		}
+	}
+
+	var reachable atomic.Bool
+	manager.SetDatabaseFactory(func(cfg config.DatabaseConfig) (Database, error) {
+		return &MockDatabase{ConnectFunc: func(ctx context.Context) error {
+			if cfg.Database == "analytics" && !reachable.Load() {
+				return fmt.Errorf("connection refused")
+			}
+			return nil
+		}}, nil
+	})
+
+	// The failing analytics connection does not keep the others from connecting
+	err = manager.Connect(context.Background())
+	if err == nil || !contains(err.Error(), `connection "analytics"`) {
+		t.Fatalf("Connect() error = %v, want error from the analytics connection", err)
+	}
+	for _, name := range []string{config.DefaultConnection, "analytics", "replica"} {
+		conn, _ := manager.Connection(name)
+		if got, want := conn.Status().Connected, name != "analytics"; got != want {
+			t.Errorf("Connection(%s) connected = %v, want %v", name, got, want)
+		}
+	}
+
+	// Reconnect retries the failed connection in the background until it recovers
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reachable.Store(true)
+	manager.Reconnect(ctx)
+
+	analytics, _ := manager.Connection("analytics")
+	deadline := time.Now().Add(5 * time.Second)
+	for !analytics.Status().Connected {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the analytics connection, last status %+v", analytics.Status())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := manager.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
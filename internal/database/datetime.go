@@ -0,0 +1,44 @@
+package database
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jhoffmann/go-database-mcp/internal/config"
+)
+
+// mysqlDateTimeLayouts are the text formats MySQL uses for DATE, DATETIME and TIMESTAMP values.
+// Fractional seconds are accepted by time.Parse without being part of the layout.
+var mysqlDateTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// IsMySQLDateTimeType reports whether typeName is a MySQL column type decoded by DecodeMySQLDateTime.
+func IsMySQLDateTimeType(typeName string) bool {
+	switch strings.ToUpper(typeName) {
+	case "DATE", "DATETIME", "TIMESTAMP":
+		return true
+	default:
+		return false
+	}
+}
+
+// DecodeMySQLDateTime converts the text form of a MySQL DATE, DATETIME or TIMESTAMP value into a
+// time.Time in UTC. Zero dates such as '0000-00-00' and values that are not valid times are
+// returned as nil for ZeroDateNull and as the original text otherwise.
+func DecodeMySQLDateTime(value []byte, behavior config.ZeroDateBehavior) any {
+	text := string(value)
+	if !strings.HasPrefix(text, "0000-00-00") {
+		for _, layout := range mysqlDateTimeLayouts {
+			if parsed, err := time.ParseInLocation(layout, text, time.UTC); err == nil {
+				return parsed
+			}
+		}
+	}
+
+	if behavior == config.ZeroDateNull {
+		return nil
+	}
+	return text
+}
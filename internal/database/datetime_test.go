@@ -0,0 +1,50 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jhoffmann/go-database-mcp/internal/config"
+)
+
+func TestDecodeMySQLDateTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		behavior config.ZeroDateBehavior
+		want     any
+	}{
+		{"date", "2024-03-15", config.ZeroDateNull, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"datetime", "2024-03-15 10:30:00", config.ZeroDateNull, time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)},
+		{"fractional seconds", "2024-03-15 10:30:00.250000", config.ZeroDateNull, time.Date(2024, 3, 15, 10, 30, 0, 250000000, time.UTC)},
+		{"zero date as null", "0000-00-00", config.ZeroDateNull, nil},
+		{"zero datetime as null", "0000-00-00 00:00:00", config.ZeroDateNull, nil},
+		{"invalid date as null", "2024-02-30", config.ZeroDateNull, nil},
+		{"partial zero date as null", "2024-00-00", config.ZeroDateNull, nil},
+		{"zero date as string", "0000-00-00 00:00:00", config.ZeroDateString, "0000-00-00 00:00:00"},
+		{"invalid date as string", "2024-02-30", config.ZeroDateString, "2024-02-30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DecodeMySQLDateTime([]byte(tt.value), tt.behavior)
+			if got != tt.want {
+				t.Errorf("DecodeMySQLDateTime(%q, %s) = %#v, want %#v", tt.value, tt.behavior, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsMySQLDateTimeType(t *testing.T) {
+	for typeName, want := range map[string]bool{
+		"DATE":      true,
+		"DATETIME":  true,
+		"timestamp": true,
+		"TIME":      false,
+		"VARCHAR":   false,
+	} {
+		if got := IsMySQLDateTimeType(typeName); got != want {
+			t.Errorf("IsMySQLDateTimeType(%q) = %v, want %v", typeName, got, want)
+		}
+	}
+}
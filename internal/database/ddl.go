@@ -0,0 +1,65 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// ddlColumn is a column definition used to reconstruct a CREATE TABLE statement.
+type ddlColumn struct {
+	name         string
+	dataType     string  // Full type, including length or precision (e.g. "character varying(255)")
+	nullable     bool    // Whether the column allows NULL values
+	defaultValue *string // Default expression, if any
+}
+
+// postgresColumnType renders an information_schema.columns type as it would be written in DDL.
+// Lengths and numeric precisions are appended where set (numeric_precision is also reported
+// for integer and float types, where it is implied by the type and left out); arrays and
+// user-defined types use the underlying udt_name.
+func postgresColumnType(dataType, udtName string, maxLength, precision, scale sql.NullInt64) string {
+	switch dataType {
+	case "ARRAY":
+		return strings.TrimPrefix(udtName, "_") + "[]"
+	case "USER-DEFINED":
+		return udtName
+	case "character varying", "character", "bit", "bit varying":
+		if maxLength.Valid {
+			return fmt.Sprintf("%s(%d)", dataType, maxLength.Int64)
+		}
+	case "numeric":
+		if precision.Valid && scale.Valid {
+			return fmt.Sprintf("numeric(%d,%d)", precision.Int64, scale.Int64)
+		}
+	}
+	return dataType
+}
+
+// buildCreateTableDDL formats a CREATE TABLE statement with one column or table constraint
+// per line, followed by one CREATE INDEX statement per line for each index.
+// Constraints and indexes are complete definitions, e.g. "PRIMARY KEY (id)" and
+// "CREATE INDEX users_email_idx ON public.users USING btree (email)".
+func buildCreateTableDDL(quote func(string) string, table string, columns []ddlColumn, constraints []string, indexes []string) string {
+	lines := make([]string, 0, len(columns)+len(constraints))
+	for _, column := range columns {
+		line := quote(column.name) + " " + column.dataType
+		if !column.nullable {
+			line += " NOT NULL"
+		}
+		if column.defaultValue != nil {
+			line += " DEFAULT " + *column.defaultValue
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, constraints...)
+
+	var ddl strings.Builder
+	fmt.Fprintf(&ddl, "CREATE TABLE %s (\n    %s\n);\n", quote(table), strings.Join(lines, ",\n    "))
+
+	for _, index := range indexes {
+		fmt.Fprintf(&ddl, "\n%s;\n", strings.TrimSuffix(index, ";"))
+	}
+
+	return ddl.String()
+}
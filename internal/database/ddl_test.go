@@ -0,0 +1,170 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var (
+	createTablePattern = regexp.MustCompile(`^CREATE TABLE ("(?:[^"]|"")+") \($`)
+	columnLinePattern  = regexp.MustCompile(`^    ("(?:[^"]|"")+") (\S.*?)( NOT NULL)?( DEFAULT .+)?,?$`)
+	constraintPattern  = regexp.MustCompile(`^    CONSTRAINT "(?:[^"]|"")+" (PRIMARY KEY|UNIQUE|CHECK|FOREIGN KEY|EXCLUDE) .+$`)
+	createIndexPattern = regexp.MustCompile(`^CREATE (UNIQUE )?INDEX \S+ ON \S+ .+;$`)
+)
+
+// parseCreateTableDDL checks that ddl has the structure produced by buildCreateTableDDL
+// and returns the column lines, constraint lines and index statements it contains.
+func parseCreateTableDDL(t *testing.T, ddl string) (columns, constraints, indexes []string) {
+	t.Helper()
+
+	lines := strings.Split(strings.TrimSuffix(ddl, "\n"), "\n")
+	if !createTablePattern.MatchString(lines[0]) {
+		t.Fatalf("Expected CREATE TABLE header, got %q", lines[0])
+	}
+
+	i := 1
+	for ; i < len(lines) && lines[i] != ");"; i++ {
+		line := lines[i]
+		last := i+1 < len(lines) && lines[i+1] == ");"
+		if last == strings.HasSuffix(line, ",") {
+			t.Errorf("Line %q: only the last definition may omit the trailing comma", line)
+		}
+
+		switch {
+		case constraintPattern.MatchString(line):
+			constraints = append(constraints, line)
+		case columnLinePattern.MatchString(line):
+			if len(constraints) > 0 {
+				t.Errorf("Column %q appears after a table constraint", line)
+			}
+			columns = append(columns, line)
+		default:
+			t.Errorf("Unrecognised definition line %q", line)
+		}
+	}
+	if i == len(lines) {
+		t.Fatalf("CREATE TABLE statement is not closed:\n%s", ddl)
+	}
+
+	for _, line := range lines[i+1:] {
+		if line == "" {
+			continue
+		}
+		if !createIndexPattern.MatchString(line) {
+			t.Errorf("Expected CREATE INDEX statement, got %q", line)
+		}
+		indexes = append(indexes, line)
+	}
+
+	return columns, constraints, indexes
+}
+
+func TestBuildCreateTableDDL(t *testing.T) {
+	serial := "nextval('users_id_seq'::regclass)"
+	active := "true"
+	columns := []ddlColumn{
+		{name: "id", dataType: "integer", defaultValue: &serial},
+		{name: "email", dataType: "character varying(255)"},
+		{name: "nickname", dataType: "text", nullable: true},
+		{name: "active", dataType: "boolean", defaultValue: &active},
+	}
+	constraints := []string{
+		`CONSTRAINT "users_pkey" PRIMARY KEY (id)`,
+		`CONSTRAINT "users_email_key" UNIQUE (email)`,
+	}
+	indexes := []string{
+		"CREATE INDEX users_nickname_idx ON public.users USING btree (nickname)",
+		"CREATE UNIQUE INDEX users_lower_email_idx ON public.users USING btree (lower((email)::text));",
+	}
+
+	ddl := buildCreateTableDDL(quotePostgresIdentifier, "users", columns, constraints, indexes)
+
+	want := `CREATE TABLE "users" (
+    "id" integer NOT NULL DEFAULT nextval('users_id_seq'::regclass),
+    "email" character varying(255) NOT NULL,
+    "nickname" text,
+    "active" boolean NOT NULL DEFAULT true,
+    CONSTRAINT "users_pkey" PRIMARY KEY (id),
+    CONSTRAINT "users_email_key" UNIQUE (email)
+);
+
+CREATE INDEX users_nickname_idx ON public.users USING btree (nickname);
+
+CREATE UNIQUE INDEX users_lower_email_idx ON public.users USING btree (lower((email)::text));
+`
+	if ddl != want {
+		t.Errorf("buildCreateTableDDL() =\n%s\nwant\n%s", ddl, want)
+	}
+
+	gotColumns, gotConstraints, gotIndexes := parseCreateTableDDL(t, ddl)
+	if len(gotColumns) != 4 || len(gotConstraints) != 2 || len(gotIndexes) != 2 {
+		t.Errorf("Parsed %d columns, %d constraints, %d indexes; want 4, 2, 2",
+			len(gotColumns), len(gotConstraints), len(gotIndexes))
+	}
+}
+
+func TestBuildCreateTableDDL_Minimal(t *testing.T) {
+	ddl := buildCreateTableDDL(quotePostgresIdentifier, `odd"name`, []ddlColumn{{name: "value", dataType: "text", nullable: true}}, nil, nil)
+
+	if ddl != "CREATE TABLE \"odd\"\"name\" (\n    \"value\" text\n);\n" {
+		t.Errorf("buildCreateTableDDL() = %q", ddl)
+	}
+
+	columns, constraints, indexes := parseCreateTableDDL(t, ddl)
+	if len(columns) != 1 || constraints != nil || indexes != nil {
+		t.Errorf("Parsed %d columns, %v constraints, %v indexes", len(columns), constraints, indexes)
+	}
+}
+
+func TestPostgresColumnType(t *testing.T) {
+	valid := func(n int64) sql.NullInt64 { return sql.NullInt64{Int64: n, Valid: true} }
+	none := sql.NullInt64{}
+
+	tests := []struct {
+		dataType  string
+		udtName   string
+		maxLength sql.NullInt64
+		precision sql.NullInt64
+		scale     sql.NullInt64
+		want      string
+	}{
+		{"character varying", "varchar", valid(255), none, none, "character varying(255)"},
+		{"character varying", "varchar", none, none, none, "character varying"},
+		{"character", "bpchar", valid(2), none, none, "character(2)"},
+		{"numeric", "numeric", none, valid(10), valid(2), "numeric(10,2)"},
+		{"numeric", "numeric", none, none, none, "numeric"},
+		{"integer", "int4", none, valid(32), valid(0), "integer"},
+		{"ARRAY", "_text", none, none, none, "text[]"},
+		{"USER-DEFINED", "mood", none, none, none, "mood"},
+		{"timestamp with time zone", "timestamptz", none, none, none, "timestamp with time zone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := postgresColumnType(tt.dataType, tt.udtName, tt.maxLength, tt.precision, tt.scale); got != tt.want {
+				t.Errorf("postgresColumnType(%q, %q) = %q, want %q", tt.dataType, tt.udtName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMySQL_GetCreateStatement(t *testing.T) {
+	sqlDB, recorder := NewRecordingDB()
+	defer sqlDB.Close()
+
+	ctx := context.Background()
+
+	// The recording driver returns no rows, so there is no statement to scan
+	mysql := &MySQL{db: sqlDB, config: NewTestConfig("mysql")}
+	if _, err := mysql.GetCreateStatement(ctx, "my`table"); err == nil || !contains(err.Error(), "failed to get create table statement") {
+		t.Errorf("GetCreateStatement() error = %v", err)
+	}
+
+	queries := recorder.Queries()
+	if len(queries) == 0 || queries[0] != "SHOW CREATE TABLE `my``table`" {
+		t.Errorf("Expected quoted SHOW CREATE TABLE, got %v", queries)
+	}
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TableFilter restricts the rows returned by GetTableData to those where Column
+// compares to Value using Operator. Value is ignored for the IS NULL operator.
+type TableFilter struct {
+	Column   string `json:"column"`          // Column to filter on; must exist in the table
+	Operator string `json:"operator"`        // One of FilterOperators
+	Value    string `json:"value,omitempty"` // Value bound as a query parameter
+}
+
+// FilterOperators lists the comparison operators a TableFilter supports.
+var FilterOperators = []string{"=", ">", "<", "LIKE", "IS NULL"}
+
+// Validate checks that the filter names a column and uses a supported operator.
+// The operator is normalized to upper case in place.
+func (f *TableFilter) Validate() error {
+	if strings.TrimSpace(f.Column) == "" {
+		return fmt.Errorf("filter column cannot be empty")
+	}
+
+	f.Operator = strings.ToUpper(strings.Join(strings.Fields(f.Operator), " "))
+	for _, operator := range FilterOperators {
+		if f.Operator == operator {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported filter operator %q. Supported operators: %s", f.Operator, strings.Join(FilterOperators, ", "))
+}
+
+// whereClause returns the WHERE clause for filter along with its arguments. The column
+// is quoted with quote and the value is always bound through placeholder(n), where n is
+// the 1-based position of the argument, so no user input is concatenated into the SQL.
+// A nil filter yields an empty clause.
+func (f *TableFilter) whereClause(quote func(string) string, placeholder func(int) string) (string, []any, error) {
+	if f == nil {
+		return "", nil, nil
+	}
+	if err := f.Validate(); err != nil {
+		return "", nil, err
+	}
+
+	if f.Operator == "IS NULL" {
+		return fmt.Sprintf(" WHERE %s IS NULL", quote(f.Column)), nil, nil
+	}
+	return fmt.Sprintf(" WHERE %s %s %s", quote(f.Column), f.Operator, placeholder(1)), []any{f.Value}, nil
+}
+
+// joinCondition adds condition to the WHERE clause where, which may be empty.
+func joinCondition(where, condition string) string {
+	if where == "" {
+		return " WHERE " + condition
+	}
+	return where + " AND " + condition
+}
+
+// mysqlPlaceholder returns MySQL's positional placeholder, which is always "?".
+func mysqlPlaceholder(int) string {
+	return "?"
+}
+
+// postgresPlaceholder returns PostgreSQL's numbered placeholder for argument n.
+func postgresPlaceholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
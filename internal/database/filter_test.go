@@ -0,0 +1,207 @@
+package database
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestTableFilter_WhereClause(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     *TableFilter
+		mysql      bool
+		wantClause string
+		wantArgs   []any
+		wantErr    bool
+	}{
+		{
+			name:       "nil filter",
+			filter:     nil,
+			wantClause: "",
+		},
+		{
+			name:       "postgres equality",
+			filter:     &TableFilter{Column: "status", Operator: "=", Value: "active"},
+			wantClause: ` WHERE "status" = $1`,
+			wantArgs:   []any{"active"},
+		},
+		{
+			name:       "mysql greater than",
+			filter:     &TableFilter{Column: "age", Operator: ">", Value: "30"},
+			mysql:      true,
+			wantClause: " WHERE `age` > ?",
+			wantArgs:   []any{"30"},
+		},
+		{
+			name:       "lower case like",
+			filter:     &TableFilter{Column: "email", Operator: "like", Value: "%@example.com"},
+			wantClause: ` WHERE "email" LIKE $1`,
+			wantArgs:   []any{"%@example.com"},
+		},
+		{
+			name:       "is null ignores value",
+			filter:     &TableFilter{Column: "deleted_at", Operator: "is  null", Value: "ignored"},
+			mysql:      true,
+			wantClause: " WHERE `deleted_at` IS NULL",
+		},
+		{
+			name:       "column is quoted, not concatenated",
+			filter:     &TableFilter{Column: `name" OR 1=1 --`, Operator: "=", Value: "x"},
+			wantClause: ` WHERE "name"" OR 1=1 --" = $1`,
+			wantArgs:   []any{"x"},
+		},
+		{
+			name:    "unsupported operator",
+			filter:  &TableFilter{Column: "id", Operator: "!= 1 OR 1=1", Value: "1"},
+			wantErr: true,
+		},
+		{
+			name:    "empty column",
+			filter:  &TableFilter{Operator: "="},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, placeholder := quotePostgresIdentifier, postgresPlaceholder
+			if tt.mysql {
+				quote, placeholder = quoteMySQLIdentifier, mysqlPlaceholder
+			}
+
+			clause, args, err := tt.filter.whereClause(quote, placeholder)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("whereClause() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if clause != tt.wantClause {
+				t.Errorf("Expected clause %q, got %q", tt.wantClause, clause)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("Expected args %v, got %v", tt.wantArgs, args)
+			}
+		})
+	}
+}
+
+func TestGetTableData_FilterCountQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType string
+		want   string
+	}{
+		{name: "postgres", dbType: "postgres", want: `SELECT COUNT(*) FROM "public"."users" WHERE "status" = $1`},
+		{name: "mysql", dbType: "mysql", want: "SELECT COUNT(*) FROM `users` WHERE `status` = ?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, recorder := NewRecordingDB()
+			defer sqlDB.Close()
+
+			var db Database
+			if tt.dbType == "mysql" {
+				db = &MySQL{db: sqlDB, config: NewTestConfig(tt.dbType)}
+			} else {
+				db = &PostgreSQL{db: sqlDB, config: NewTestConfig(tt.dbType)}
+			}
+
+			// The mock driver returns no rows, so only the count query is issued
+			filter := &TableFilter{Column: "status", Operator: "=", Value: "active"}
+			_, _ = db.GetTableData(context.Background(), "users", 10, 0, TableDataOptions{Filter: filter})
+
+			queries := recorder.Queries()
+			if len(queries) == 0 || queries[0] != tt.want {
+				t.Errorf("Expected count query %q, got %v", tt.want, queries)
+			}
+		})
+	}
+}
+
+func TestGetTableData_EstimateCountQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType string
+		filter *TableFilter
+		want   string
+	}{
+		{name: "postgres estimate", dbType: "postgres", want: "c.reltuples::bigint"},
+		{name: "mysql estimate", dbType: "mysql", want: "TABLE_ROWS"},
+		{name: "filter forces exact count", dbType: "postgres", filter: &TableFilter{Column: "id", Operator: "IS NULL"}, want: "SELECT COUNT(*)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, recorder := NewRecordingDB()
+			defer sqlDB.Close()
+
+			var db Database
+			if tt.dbType == "mysql" {
+				db = &MySQL{db: sqlDB, config: NewTestConfig(tt.dbType)}
+			} else {
+				db = &PostgreSQL{db: sqlDB, config: NewTestConfig(tt.dbType)}
+			}
+
+			// The mock driver returns no rows, so only the first count query is issued
+			_, _ = db.GetTableData(context.Background(), "users", 10, 0, TableDataOptions{Filter: tt.filter, EstimateCount: true})
+
+			queries := recorder.Queries()
+			if len(queries) == 0 || !contains(queries[0], tt.want) {
+				t.Errorf("Expected count query containing %q, got %v", tt.want, queries)
+			}
+		})
+	}
+}
+
+func TestTableDataOptions_SelectList(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []string
+		mysql   bool
+		want    string
+	}{
+		{name: "all columns", want: "*"},
+		{name: "postgres projection", columns: []string{"id", "email"}, want: `"id", "email"`},
+		{name: "mysql projection", columns: []string{"id", "email"}, mysql: true, want: "`id`, `email`"},
+		{name: "embedded quote", columns: []string{`a"b`}, want: `"a""b"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := quotePostgresIdentifier
+			if tt.mysql {
+				quote = quoteMySQLIdentifier
+			}
+			if got := (TableDataOptions{Columns: tt.columns}).selectList(quote); got != tt.want {
+				t.Errorf("selectList() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableDataOptions_OrderClause(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  TableDataOptions
+		mysql bool
+		want  string
+	}{
+		{name: "no ordering", want: ""},
+		{name: "default ascending", opts: TableDataOptions{OrderBy: "id"}, want: ` ORDER BY "id" ASC`},
+		{name: "descending", opts: TableDataOptions{OrderBy: "created_at", OrderDir: "desc"}, mysql: true, want: " ORDER BY `created_at` DESC"},
+		{name: "unknown direction sorts ascending", opts: TableDataOptions{OrderBy: "id", OrderDir: "DESC; DROP TABLE users"}, want: ` ORDER BY "id" ASC`},
+		{name: "column is quoted", opts: TableDataOptions{OrderBy: `id" DESC, "x`}, want: ` ORDER BY "id"" DESC, ""x" ASC`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := quotePostgresIdentifier
+			if tt.mysql {
+				quote = quoteMySQLIdentifier
+			}
+			if got := tt.opts.orderClause(quote); got != tt.want {
+				t.Errorf("orderClause() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package database
+
+// appendForeignKeyColumn adds one column pair of a foreign key constraint to the list.
+// Foreign key metadata is read one row per column pair, ordered by constraint name and
+// column position, so consecutive rows for the same constraint are merged into one entry.
+func appendForeignKeyColumn(foreignKeys []ForeignKeyInfo, constraintName, column, referencedTable, referencedColumn, onDelete, onUpdate string) []ForeignKeyInfo {
+	if n := len(foreignKeys); n > 0 && foreignKeys[n-1].ConstraintName == constraintName {
+		last := &foreignKeys[n-1]
+		last.Columns = append(last.Columns, column)
+		last.ReferencedColumns = append(last.ReferencedColumns, referencedColumn)
+		return foreignKeys
+	}
+
+	return append(foreignKeys, ForeignKeyInfo{
+		ConstraintName:    constraintName,
+		Columns:           []string{column},
+		ReferencedTable:   referencedTable,
+		ReferencedColumns: []string{referencedColumn},
+		OnDelete:          onDelete,
+		OnUpdate:          onUpdate,
+	})
+}
+
+// appendForeignKeyRelationship adds one column pair of a foreign key relationship to the list.
+// Rows are expected to be ordered by table, constraint name and column position, so consecutive
+// rows for the same table and constraint are merged into one relationship.
+func appendForeignKeyRelationship(relationships []ForeignKeyRelationship, constraintName, fromTable, fromColumn, toTable, toColumn, onDelete, onUpdate string) []ForeignKeyRelationship {
+	if n := len(relationships); n > 0 && relationships[n-1].ConstraintName == constraintName && relationships[n-1].FromTable == fromTable {
+		last := &relationships[n-1]
+		last.FromColumns = append(last.FromColumns, fromColumn)
+		last.ToColumns = append(last.ToColumns, toColumn)
+		return relationships
+	}
+
+	return append(relationships, ForeignKeyRelationship{
+		ConstraintName: constraintName,
+		FromTable:      fromTable,
+		FromColumns:    []string{fromColumn},
+		ToTable:        toTable,
+		ToColumns:      []string{toColumn},
+		OnDelete:       onDelete,
+		OnUpdate:       onUpdate,
+	})
+}
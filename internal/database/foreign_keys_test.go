@@ -0,0 +1,68 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAppendForeignKeyColumn(t *testing.T) {
+	var foreignKeys []ForeignKeyInfo
+	foreignKeys = appendForeignKeyColumn(foreignKeys, "fk_order_customer", "customer_id", "customers", "id", "CASCADE", "NO ACTION")
+	foreignKeys = appendForeignKeyColumn(foreignKeys, "fk_order_item", "product_id", "order_items", "product_id", "RESTRICT", "CASCADE")
+	foreignKeys = appendForeignKeyColumn(foreignKeys, "fk_order_item", "line_no", "order_items", "line_no", "RESTRICT", "CASCADE")
+
+	want := []ForeignKeyInfo{
+		{
+			ConstraintName:    "fk_order_customer",
+			Columns:           []string{"customer_id"},
+			ReferencedTable:   "customers",
+			ReferencedColumns: []string{"id"},
+			OnDelete:          "CASCADE",
+			OnUpdate:          "NO ACTION",
+		},
+		{
+			ConstraintName:    "fk_order_item",
+			Columns:           []string{"product_id", "line_no"},
+			ReferencedTable:   "order_items",
+			ReferencedColumns: []string{"product_id", "line_no"},
+			OnDelete:          "RESTRICT",
+			OnUpdate:          "CASCADE",
+		},
+	}
+
+	if !reflect.DeepEqual(foreignKeys, want) {
+		t.Errorf("appendForeignKeyColumn() = %+v, want %+v", foreignKeys, want)
+	}
+}
+
+func TestAppendForeignKeyRelationship(t *testing.T) {
+	var relationships []ForeignKeyRelationship
+	relationships = appendForeignKeyRelationship(relationships, "fk_parent", "orders", "customer_id", "customers", "id", "CASCADE", "NO ACTION")
+	relationships = appendForeignKeyRelationship(relationships, "fk_parent", "invoices", "order_id", "orders", "id", "RESTRICT", "NO ACTION")
+	relationships = appendForeignKeyRelationship(relationships, "fk_parent", "invoices", "order_line", "orders", "line_no", "RESTRICT", "NO ACTION")
+
+	want := []ForeignKeyRelationship{
+		{
+			ConstraintName: "fk_parent",
+			FromTable:      "orders",
+			FromColumns:    []string{"customer_id"},
+			ToTable:        "customers",
+			ToColumns:      []string{"id"},
+			OnDelete:       "CASCADE",
+			OnUpdate:       "NO ACTION",
+		},
+		{
+			ConstraintName: "fk_parent",
+			FromTable:      "invoices",
+			FromColumns:    []string{"order_id", "order_line"},
+			ToTable:        "orders",
+			ToColumns:      []string{"id", "line_no"},
+			OnDelete:       "RESTRICT",
+			OnUpdate:       "NO ACTION",
+		},
+	}
+
+	if !reflect.DeepEqual(relationships, want) {
+		t.Errorf("appendForeignKeyRelationship() = %+v, want %+v", relationships, want)
+	}
+}
@@ -4,6 +4,9 @@ package database
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 )
 
 // Database defines the interface for database operations that must be implemented by all database drivers.
@@ -33,23 +36,149 @@ type Database interface {
 	// It returns a Result containing information about the execution.
 	Exec(ctx context.Context, query string, args ...any) (sql.Result, error)
 
+	// BeginTx starts a transaction. If opts is nil or leaves the isolation level at its
+	// default, the configured default isolation level (DB_ISOLATION_LEVEL) is applied.
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+
+	// GetIsolationLevel returns the effective transaction isolation level of the connection,
+	// as reported by the database (e.g. "read committed" or "REPEATABLE-READ").
+	GetIsolationLevel(ctx context.Context) (string, error)
+
+	// GetServerSettings returns the server's configuration parameters whose name starts with
+	// prefix (case-insensitive, empty for all), sorted by name, with sensitive values redacted.
+	GetServerSettings(ctx context.Context, prefix string) ([]ServerSetting, error)
+
+	// GetServerVersion returns the version string reported by the database server,
+	// e.g. "PostgreSQL 16.2 on x86_64-pc-linux-gnu, ..." or "8.0.36".
+	GetServerVersion(ctx context.Context) (string, error)
+
+	// IsServerReadOnly reports whether the server only accepts reads, e.g. a PostgreSQL
+	// standby in recovery or a MySQL replica with read_only set.
+	IsServerReadOnly(ctx context.Context) (bool, error)
+
+	// GetServerInfo returns the server version, character set or encoding, current database
+	// and user, uptime and connection limit.
+	GetServerInfo(ctx context.Context) (*ServerInfo, error)
+
+	// GetActiveConnections returns the server sessions that are currently running a statement
+	// or inside a transaction, with the first MaxActiveQueryLength characters of their statement.
+	GetActiveConnections(ctx context.Context) ([]ActiveConnection, error)
+
+	// KillConnection terminates the server session with the given process ID, after checking
+	// that it exists and is not the session running the check.
+	KillConnection(ctx context.Context, pid int64) error
+
 	// ListTables returns a list of all table names in the current database.
 	ListTables(ctx context.Context) ([]string, error)
 
 	// ListDatabases returns a list of all available database names on the server.
 	ListDatabases(ctx context.Context) ([]string, error)
 
+	// ListViews returns a list of all view names in the current database.
+	ListViews(ctx context.Context) ([]string, error)
+
+	// DescribeView returns the definition and exposed columns of the specified view.
+	DescribeView(ctx context.Context, viewName string) (*ViewSchema, error)
+
 	// DescribeTable returns detailed schema information about the specified table,
 	// including column definitions, indexes, and metadata.
 	DescribeTable(ctx context.Context, tableName string) (*TableSchema, error)
 
+	// GetConstraints returns the primary key, foreign key, unique, check and not-null
+	// constraints of the specified table.
+	GetConstraints(ctx context.Context, tableName string) ([]ConstraintInfo, error)
+
+	// ListForeignKeys returns every foreign key relationship in the current database,
+	// so the complete relationship graph can be inspected without describing each table.
+	ListForeignKeys(ctx context.Context) ([]ForeignKeyRelationship, error)
+
+	// SearchTables returns the names of base tables whose name contains pattern (case-insensitive),
+	// searching the primary database and every additional allowed database. Tables outside the
+	// primary database are qualified as "database.table". Exact matches come first, then prefix
+	// matches, then other substring matches.
+	SearchTables(ctx context.Context, pattern string) ([]string, error)
+
+	// SearchColumns returns the columns whose name contains pattern (case-insensitive), across the
+	// same databases as SearchTables and ranked the same way by column name.
+	SearchColumns(ctx context.Context, pattern string) ([]ColumnSearchResult, error)
+
+	// ListFunctions returns the stored functions and procedures defined in the current database
+	// (the public schema for PostgreSQL), ordered by name.
+	ListFunctions(ctx context.Context) ([]FunctionInfo, error)
+
+	// DescribeFunction returns the signature and, where the database exposes it to the current
+	// user, the source body of the named stored function or procedure.
+	DescribeFunction(ctx context.Context, name string) (*FunctionSchema, error)
+
+	// EstimateColumnStats returns approximate per-column statistics for the specified table,
+	// keyed by column name. Values come from the planner's statistics and never require a full scan;
+	// columns without statistics are omitted.
+	EstimateColumnStats(ctx context.Context, tableName string) (map[string]ColumnStatsEstimate, error)
+
+	// GetColumnStatistics profiles every column of the specified table with exact aggregates
+	// (null and distinct counts, min/max, average string length). Unlike EstimateColumnStats
+	// this scans the table, so it refuses tables larger than the configured row threshold.
+	GetColumnStatistics(ctx context.Context, tableName string) ([]ColumnStatistics, error)
+
+	// ProfileTable returns the null percentage, distinct count, maxDistinctValues most common
+	// values and, for numeric and date/time columns, the minimum and maximum of every column
+	// of the specified table. It scans the table, so it refuses tables larger than
+	// DB_PROFILE_MAX_ROWS.
+	ProfileTable(ctx context.Context, tableName string, maxDistinctValues int) (*TableProfile, error)
+
+	// GetCreateStatement returns SQL that recreates the specified table: the output of SHOW
+	// CREATE TABLE on MySQL, and on PostgreSQL a CREATE TABLE statement rebuilt from the catalog
+	// with one column per line, followed by any secondary indexes.
+	GetCreateStatement(ctx context.Context, tableName string) (string, error)
+
+	// GetTableSize returns the storage used by the specified table and its indexes,
+	// together with the planner's row estimate.
+	GetTableSize(ctx context.Context, tableName string) (*TableSizeInfo, error)
+
+	// ListTableSizes returns the storage used by every base table in the current database,
+	// largest first.
+	ListTableSizes(ctx context.Context) ([]TableSizeInfo, error)
+
+	// GetTableBloat returns live and dead tuple counts and vacuum history for the specified
+	// table, or for every table when tableName is empty. Only PostgreSQL tracks dead tuples;
+	// other databases return an error.
+	GetTableBloat(ctx context.Context, tableName string) ([]TableBloatStats, error)
+
+	// GetIndexUsage returns how often each index has been scanned since statistics were last
+	// reset, least used first. PostgreSQL reports every index; MySQL only reports the unused
+	// indexes listed by the sys schema, and returns an error when it is not installed.
+	GetIndexUsage(ctx context.Context) ([]IndexUsageInfo, error)
+
+	// ListenForNotifications subscribes to a notification channel and calls handler with the
+	// payload of each notification until Unlisten is called or the connection is closed.
+	// Only PostgreSQL supports LISTEN/NOTIFY; other databases return an error.
+	ListenForNotifications(ctx context.Context, channel string, handler func(payload string)) error
+
+	// Unlisten stops the notifications of a channel subscribed with ListenForNotifications.
+	Unlisten(ctx context.Context, channel string) error
+
+	// GetColumnValues returns up to limit distinct values of the specified column, sorted,
+	// together with the number of distinct values and whether the list was truncated.
+	// The table and column names are quoted but not checked against the schema.
+	GetColumnValues(ctx context.Context, tableName, columnName string, limit int) (*ColumnValues, error)
+
 	// GetTableData retrieves data from the specified table with pagination support.
 	// The limit parameter controls how many rows to return, and offset specifies how many rows to skip.
-	GetTableData(ctx context.Context, tableName string, limit int, offset int) (*TableData, error)
+	// opts optionally restricts the returned columns and rows and selects how the total is counted.
+	GetTableData(ctx context.Context, tableName string, limit int, offset int, opts TableDataOptions) (*TableData, error)
 
 	// ExplainQuery returns the execution plan for the given SQL query in JSON format.
 	ExplainQuery(ctx context.Context, query string) (string, error)
 
+	// ExplainAnalyzeQuery executes the given SQL query and returns its execution plan with
+	// actual row counts and timings. The query runs in a transaction that is always rolled
+	// back, so data modified by DML statements is discarded.
+	ExplainAnalyzeQuery(ctx context.Context, query string) (string, error)
+
+	// QuoteTable returns tableName quoted for use in a statement, qualified with its schema on
+	// PostgreSQL so that it names the same table as DescribeTable and GetTableData.
+	QuoteTable(ctx context.Context, tableName string) string
+
 	// GetDB returns the underlying *sql.DB instance for direct database operations.
 	GetDB() *sql.DB
 
@@ -59,10 +188,51 @@ type Database interface {
 
 // TableSchema represents the complete schema definition of a database table.
 type TableSchema struct {
-	TableName string         `json:"table_name"`         // Name of the table
-	Columns   []ColumnInfo   `json:"columns"`            // List of column definitions
-	Indexes   []IndexInfo    `json:"indexes,omitempty"`  // List of indexes on the table
-	Metadata  map[string]any `json:"metadata,omitempty"` // Additional metadata about the table
+	TableName   string           `json:"table_name"`             // Name of the table
+	Columns     []ColumnInfo     `json:"columns"`                // List of column definitions
+	Indexes     []IndexInfo      `json:"indexes,omitempty"`      // List of indexes on the table
+	ForeignKeys []ForeignKeyInfo `json:"foreign_keys,omitempty"` // List of foreign keys defined on the table
+	Metadata    map[string]any   `json:"metadata,omitempty"`     // Additional metadata about the table
+
+	PrimaryKey        []string   `json:"primary_key,omitempty"`        // Primary key columns, derived from Indexes
+	UniqueConstraints [][]string `json:"unique_constraints,omitempty"` // Column groups of the other unique indexes, by index name
+}
+
+// IsView reports whether the described object is a view rather than a base table,
+// based on the "is_view" metadata flag populated by DescribeTable.
+func (s *TableSchema) IsView() bool {
+	isView, _ := s.Metadata["is_view"].(bool)
+	return isView
+}
+
+// ViewSchema represents the definition of a database view.
+type ViewSchema struct {
+	ViewName   string       `json:"view_name"`  // Name of the view
+	Definition string       `json:"definition"` // SQL definition of the view
+	Columns    []ColumnInfo `json:"columns"`    // Columns exposed by the view
+}
+
+// FunctionInfo summarizes a stored function or procedure.
+type FunctionInfo struct {
+	Name          string   `json:"name"`                  // Routine name
+	Type          string   `json:"type"`                  // Routine type: "function" or "procedure"
+	Language      string   `json:"language"`              // Implementation language (e.g. "plpgsql", "sql")
+	ReturnType    string   `json:"return_type,omitempty"` // Return type; empty for procedures
+	ArgumentTypes []string `json:"argument_types"`        // Argument types in order, prefixed with OUT or INOUT for output arguments
+}
+
+// FunctionSchema describes a stored function or procedure in full.
+type FunctionSchema struct {
+	FunctionInfo
+	Arguments  []FunctionArgument `json:"arguments"`            // Arguments in order
+	Definition string             `json:"definition,omitempty"` // Source body; empty when the database does not expose it to the current user
+}
+
+// FunctionArgument describes one argument of a stored function or procedure.
+type FunctionArgument struct {
+	Name string `json:"name,omitempty"` // Argument name; empty for unnamed PostgreSQL arguments
+	Type string `json:"type"`           // Data type
+	Mode string `json:"mode"`           // Argument mode: IN, OUT or INOUT
 }
 
 // ColumnInfo represents detailed information about a database table column.
@@ -74,6 +244,131 @@ type ColumnInfo struct {
 	IsPrimaryKey    bool    `json:"is_primary_key"`       // Whether this column is part of the primary key
 	IsAutoIncrement bool    `json:"is_auto_increment"`    // Whether this column auto-increments
 	MaxLength       *int    `json:"max_length,omitempty"` // Maximum length for string types
+
+	NullFraction  *float64 `json:"null_fraction,omitempty"`  // Approximate fraction of NULL values (statistics only)
+	DistinctCount *int64   `json:"distinct_count,omitempty"` // Approximate number of distinct values (statistics only)
+}
+
+// ColumnStatsEstimate holds approximate column statistics gathered from the database's
+// planner statistics. Fields are nil when the database does not track the value.
+type ColumnStatsEstimate struct {
+	NullFraction  *float64 `json:"null_fraction,omitempty"`  // Approximate fraction of NULL values
+	DistinctCount *int64   `json:"distinct_count,omitempty"` // Approximate number of distinct values
+}
+
+// ColumnStatistics holds exact data profiling results for a single table column.
+// Aggregates that do not apply to the column's type (e.g. MinValue for a JSON column
+// or AvgLength for a number) are nil.
+type ColumnStatistics struct {
+	Name          string   `json:"name"`                     // Column name
+	DataType      string   `json:"data_type"`                // Data type as reported by information_schema
+	NullCount     int64    `json:"null_count"`               // Number of NULL values
+	DistinctCount *int64   `json:"distinct_count,omitempty"` // Number of distinct non-NULL values
+	MinValue      *string  `json:"min_value,omitempty"`      // Smallest non-NULL value, rendered as text
+	MaxValue      *string  `json:"max_value,omitempty"`      // Largest non-NULL value, rendered as text
+	AvgLength     *float64 `json:"avg_length,omitempty"`     // Average length in characters (string columns only)
+}
+
+// TableProfile describes the value distribution of every column of a table.
+type TableProfile struct {
+	TableName string          `json:"table_name"` // Name of the table
+	RowCount  int64           `json:"row_count"`  // Number of rows in the table
+	Columns   []ColumnProfile `json:"columns"`    // Column profiles, in column order
+}
+
+// ColumnProfile describes the value distribution of a table column.
+type ColumnProfile struct {
+	Name           string           `json:"name"`                     // Column name
+	DataType       string           `json:"data_type"`                // Data type as reported by information_schema
+	NullPercentage float64          `json:"null_percentage"`          // Share of NULL values, from 0 to 100
+	DistinctCount  *int64           `json:"distinct_count,omitempty"` // Number of distinct non-NULL values
+	TopValues      []ValueFrequency `json:"top_values,omitempty"`     // Most common non-NULL values, most frequent first
+	MinValue       *string          `json:"min,omitempty"`            // Smallest value (numeric and date/time columns only)
+	MaxValue       *string          `json:"max,omitempty"`            // Largest value (numeric and date/time columns only)
+}
+
+// ValueFrequency is a column value with the number of rows holding it.
+type ValueFrequency struct {
+	Value      string  `json:"value"`      // The value, rendered as text
+	Count      int64   `json:"count"`      // Number of rows with the value
+	Percentage float64 `json:"percentage"` // Share of all rows, from 0 to 100
+}
+
+// ColumnSearchResult identifies a table column matched by SearchColumns.
+type ColumnSearchResult struct {
+	Database   string `json:"database"`    // Database containing the table
+	TableName  string `json:"table_name"`  // Table the column belongs to
+	ColumnName string `json:"column_name"` // Matching column name
+	DataType   string `json:"data_type"`   // Data type as reported by information_schema
+}
+
+// TableSizeInfo describes the storage used by a table. Sizes are in bytes and, like the
+// row estimate, come from the database's catalog rather than a scan of the table.
+type TableSizeInfo struct {
+	TableName      string `json:"table_name"`       // Name of the table
+	DataSizeBytes  int64  `json:"data_size_bytes"`  // Size of the table data
+	IndexSizeBytes int64  `json:"index_size_bytes"` // Combined size of the table's indexes
+	TotalSizeBytes int64  `json:"total_size_bytes"` // Total size, including indexes (and TOAST data for PostgreSQL)
+	RowEstimate    int64  `json:"row_estimate"`     // Approximate number of rows
+}
+
+// ServerInfo describes the database server behind a connection, so that clients can adapt
+// their SQL to its version and settings.
+type ServerInfo struct {
+	DatabaseType    string `json:"database_type"`             // Database type: "mysql" or "postgres"
+	ServerVersion   string `json:"server_version"`            // Version string reported by the server
+	ServerCharset   string `json:"server_charset,omitempty"`  // Default character set of the server (MySQL)
+	ServerEncoding  string `json:"server_encoding,omitempty"` // Character encoding of the server (PostgreSQL)
+	CurrentDatabase string `json:"current_database"`          // Database the connection is using
+	CurrentUser     string `json:"current_user"`              // User the connection is authenticated as
+	Hostname        string `json:"hostname,omitempty"`        // Host name of the server (MySQL)
+	Uptime          string `json:"uptime"`                    // Time since the server started, e.g. "72h5m3s"
+	MaxConnections  int    `json:"max_connections"`           // Maximum number of concurrent client connections
+}
+
+// ActiveConnection describes a non-idle session on the database server, for monitoring
+// long-running statements.
+type ActiveConnection struct {
+	PID             int64   `json:"pid"`              // Backend process ID (PostgreSQL) or connection ID (MySQL)
+	User            string  `json:"user"`             // User the session is authenticated as
+	Host            string  `json:"host"`             // Client address of the session
+	Database        string  `json:"database"`         // Database the session is using
+	State           string  `json:"state"`            // Session state (PostgreSQL) or command (MySQL), e.g. "active" or "Query"
+	QueryText       string  `json:"query_text"`       // Current or last statement, truncated to MaxActiveQueryLength characters
+	DurationSeconds float64 `json:"duration_seconds"` // Time since the statement started
+}
+
+// TableBloatStats holds the tuple and vacuum statistics PostgreSQL's statistics collector
+// keeps for a table. The counts are estimates, and every field is zero or nil until the
+// collector has seen activity on the table.
+type TableBloatStats struct {
+	TableName      string     `json:"table_name"`                // Name of the table
+	LiveTuples     int64      `json:"live_tuples"`               // Estimated number of live rows
+	DeadTuples     int64      `json:"dead_tuples"`               // Estimated number of dead rows awaiting vacuum
+	LastVacuum     *time.Time `json:"last_vacuum,omitempty"`     // Last manual VACUUM, if any
+	LastAutovacuum *time.Time `json:"last_autovacuum,omitempty"` // Last autovacuum run, if any
+}
+
+// IndexUsageInfo holds the scan statistics the database keeps for an index. The counts are
+// cumulative since the statistics were last reset (or, on MySQL, since the server started).
+type IndexUsageInfo struct {
+	TableName     string `json:"table_name"`     // Table the index belongs to
+	IndexName     string `json:"index_name"`     // Name of the index
+	IndexScans    int64  `json:"index_scans"`    // Number of scans that used the index
+	TuplesRead    int64  `json:"tuples_read"`    // Index entries returned by scans (PostgreSQL only)
+	TuplesFetched int64  `json:"tuples_fetched"` // Table rows fetched through the index (PostgreSQL only)
+	SizeBytes     int64  `json:"size_bytes"`     // On-disk size of the index (PostgreSQL only)
+	IsUnique      bool   `json:"is_unique"`      // Whether the index enforces uniqueness, so it cannot simply be dropped
+}
+
+// ColumnValues holds the distinct values of a table column. NULL is reported as a
+// value (nil) and counts towards DistinctCount.
+type ColumnValues struct {
+	TableName     string `json:"table_name"`     // Name of the table
+	ColumnName    string `json:"column_name"`    // Name of the column
+	Values        []any  `json:"values"`         // Distinct values in ascending order
+	DistinctCount int64  `json:"distinct_count"` // Total number of distinct values in the column
+	Truncated     bool   `json:"truncated"`      // Whether Values omits some distinct values
 }
 
 // IndexInfo represents information about a database table index.
@@ -84,12 +379,111 @@ type IndexInfo struct {
 	IsPrimary bool     `json:"is_primary"` // Whether this is the primary key index
 }
 
+// ForeignKeyInfo represents a foreign key constraint from a table to a referenced table.
+type ForeignKeyInfo struct {
+	ConstraintName    string   `json:"constraint_name"`    // Foreign key constraint name
+	Columns           []string `json:"columns"`            // Referencing columns, in constraint order
+	ReferencedTable   string   `json:"referenced_table"`   // Table the foreign key points to
+	ReferencedColumns []string `json:"referenced_columns"` // Referenced columns, matching Columns by position
+	OnDelete          string   `json:"on_delete"`          // Referential action on delete (e.g. "CASCADE")
+	OnUpdate          string   `json:"on_update"`          // Referential action on update (e.g. "NO ACTION")
+}
+
+// ForeignKeyRelationship represents a foreign key constraint as an edge between two tables.
+type ForeignKeyRelationship struct {
+	ConstraintName string   `json:"constraint_name"` // Foreign key constraint name
+	FromTable      string   `json:"from_table"`      // Table that defines the foreign key
+	FromColumns    []string `json:"from_columns"`    // Referencing columns, in constraint order
+	ToTable        string   `json:"to_table"`        // Table the foreign key points to
+	ToColumns      []string `json:"to_columns"`      // Referenced columns, matching FromColumns by position
+	OnDelete       string   `json:"on_delete"`       // Referential action on delete (e.g. "CASCADE")
+	OnUpdate       string   `json:"on_update"`       // Referential action on update (e.g. "NO ACTION")
+}
+
+// ConstraintInfo describes a constraint defined on a table.
+type ConstraintInfo struct {
+	Name        string   `json:"name"`                   // Constraint name
+	Type        string   `json:"type"`                   // Constraint type: primary_key, foreign_key, unique, check or not_null
+	Columns     []string `json:"columns"`                // Constrained columns, in constraint order where the database records one
+	CheckClause string   `json:"check_clause,omitempty"` // Boolean expression of a CHECK constraint
+}
+
+// TableDataOptions customizes a GetTableData call. The zero value selects every column
+// and row and counts the rows exactly.
+type TableDataOptions struct {
+	// Columns lists the columns to return, in order. Empty selects every column.
+	Columns []string
+	// Filter, when non-nil, restricts both the returned rows and the reported total.
+	Filter *TableFilter
+	// EstimateCount reads the total from the planner statistics instead of running
+	// COUNT(*), which is much faster on large tables. It is ignored when Filter is set.
+	EstimateCount bool
+	// OrderBy, when set, sorts the rows by this column so that pages are stable.
+	OrderBy string
+	// OrderDir is the sort direction for OrderBy: "ASC" (the default) or "DESC".
+	OrderDir string
+	// KeyColumns, when set and OrderBy is not, sorts the rows by these columns (the primary
+	// key) and enables cursor pagination: a full page reports the NextCursor of its last row.
+	KeyColumns []string
+	// Cursor, when set, returns the rows after the one it identifies in KeyColumns order,
+	// which unlike an offset does not scan the skipped rows. It requires KeyColumns.
+	Cursor string
+}
+
+// orderClause returns the ORDER BY clause for the requested sort column, quoted with quote,
+// falling back to the key columns, or an empty string when there is neither. Any direction other than DESC sorts
+// ascending, so the direction can never inject SQL.
+func (o TableDataOptions) orderClause(quote func(string) string) string {
+	if o.OrderBy == "" {
+		if len(o.KeyColumns) == 0 {
+			return ""
+		}
+		quoted := make([]string, len(o.KeyColumns))
+		for i, column := range o.KeyColumns {
+			quoted[i] = quote(column)
+		}
+		return " ORDER BY " + strings.Join(quoted, ", ")
+	}
+	direction := "ASC"
+	if strings.EqualFold(strings.TrimSpace(o.OrderDir), "DESC") {
+		direction = "DESC"
+	}
+	return fmt.Sprintf(" ORDER BY %s %s", quote(o.OrderBy), direction)
+}
+
+// selectList returns the SELECT list for the requested columns, quoting each one with quote,
+// or "*" when no columns were requested.
+func (o TableDataOptions) selectList(quote func(string) string) string {
+	if len(o.Columns) == 0 {
+		return "*"
+	}
+	quoted := make([]string, len(o.Columns))
+	for i, column := range o.Columns {
+		quoted[i] = quote(column)
+	}
+	return strings.Join(quoted, ", ")
+}
+
 // TableData represents paginated data from a database table.
 type TableData struct {
 	TableName string           `json:"table_name"` // Name of the table
 	Columns   []string         `json:"columns"`    // Column names in the result set
 	Rows      []map[string]any `json:"rows"`       // Actual row data as key-value pairs
-	Total     int              `json:"total"`      // Total number of rows in the table
+	Total     int              `json:"total"`      // Total number of rows in the table matching the filter
 	Limit     int              `json:"limit"`      // Number of rows returned in this batch
 	Offset    int              `json:"offset"`     // Number of rows skipped from the beginning
+
+	CountIsEstimate bool    `json:"count_is_estimate,omitempty"` // Whether Total is an approximate row count
+	NextCursor      *string `json:"next_cursor,omitempty"`       // Cursor for the next page, when paging by primary key
+}
+
+// ServerSetting describes a server configuration parameter, from pg_settings (PostgreSQL)
+// or SHOW VARIABLES (MySQL).
+type ServerSetting struct {
+	Name        string `json:"name"`                  // Parameter name
+	Value       string `json:"value"`                 // Current value, or RedactedSettingValue for sensitive parameters
+	Unit        string `json:"unit,omitempty"`        // Unit of the value, e.g. "kB" or "ms" (PostgreSQL)
+	Category    string `json:"category,omitempty"`    // Parameter group (PostgreSQL)
+	Description string `json:"description,omitempty"` // Short description (PostgreSQL)
+	Redacted    bool   `json:"redacted,omitempty"`    // Whether the value was hidden because it may hold credentials
 }
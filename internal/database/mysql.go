@@ -3,10 +3,13 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/jhoffmann/go-database-mcp/internal/config"
 )
 
@@ -16,13 +19,34 @@ import (
 type MySQL struct {
 	db     *sql.DB               // The underlying database connection
 	config config.DatabaseConfig // Configuration settings for the connection
+	stmts  *stmtCache            // Prepared statement cache (nil when disabled)
 }
 
+// mysqlTableSizeQuery selects the name, data size, index size, total size and row estimate
+// of the base tables in the database bound to its first parameter.
+const mysqlTableSizeQuery = `
+		SELECT
+			TABLE_NAME,
+			COALESCE(DATA_LENGTH, 0),
+			COALESCE(INDEX_LENGTH, 0),
+			COALESCE(DATA_LENGTH, 0) + COALESCE(INDEX_LENGTH, 0),
+			COALESCE(TABLE_ROWS, 0)
+		FROM INFORMATION_SCHEMA.TABLES
+		WHERE TABLE_SCHEMA = ? AND TABLE_TYPE = 'BASE TABLE'`
+
+// mysqlRowEstimateQuery reads a table's approximate row count. For InnoDB, TABLE_ROWS is an
+// estimate maintained by the storage engine and may differ from COUNT(*) by 40% or more.
+const mysqlRowEstimateQuery = `
+		SELECT COALESCE(TABLE_ROWS, 0)
+		FROM INFORMATION_SCHEMA.TABLES
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?`
+
 // NewMySQL creates a new MySQL database instance with the given configuration.
 // The connection is not established until Connect() is called.
 func NewMySQL(cfg config.DatabaseConfig) (*MySQL, error) {
 	return &MySQL{
 		config: cfg,
+		stmts:  newStmtCache(cfg.StatementCacheSize),
 	}, nil
 }
 
@@ -30,18 +54,32 @@ func NewMySQL(cfg config.DatabaseConfig) (*MySQL, error) {
 // It builds the DSN from configuration, opens the connection, configures the connection pool,
 // and verifies connectivity with a ping. Returns an error if any step fails.
 func (m *MySQL) Connect(ctx context.Context) error {
+	if m.usesClientTLS() {
+		tlsConfig, err := m.config.ClientTLSConfig()
+		if err != nil {
+			return err
+		}
+		if err := mysql.RegisterTLSConfig(m.tlsConfigName(), tlsConfig); err != nil {
+			return fmt.Errorf("failed to register TLS configuration: %w", err)
+		}
+	}
+
 	dsn := m.buildDSN()
 
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
-		return fmt.Errorf("failed to open MySQL connection: %w", err)
+		return fmt.Errorf("failed to open MySQL connection to %s: %w", m.SafeDSN(), redactPassword(err, m.config.Password))
 	}
 
 	configureConnectionPool(db, m.config)
 
 	if err := db.PingContext(ctx); err != nil {
 		db.Close()
-		return fmt.Errorf("failed to ping MySQL database: %w", err)
+		err = redactPassword(err, m.config.Password)
+		if hint := mysqlAuthHint(err); hint != "" {
+			return fmt.Errorf("failed to ping MySQL database at %s: %w (%s)", m.SafeDSN(), err, hint)
+		}
+		return fmt.Errorf("failed to ping MySQL database at %s: %w", m.SafeDSN(), err)
 	}
 
 	m.db = db
@@ -52,6 +90,7 @@ func (m *MySQL) Connect(ctx context.Context) error {
 // It's safe to call even if no connection has been established.
 func (m *MySQL) Close() error {
 	if m.db != nil {
+		m.stmts.close()
 		return m.db.Close()
 	}
 	return nil
@@ -68,34 +107,181 @@ func (m *MySQL) Ping(ctx context.Context) error {
 
 // Query executes a SQL query that returns rows, typically a SELECT statement.
 // It supports parameter binding to prevent SQL injection attacks.
+// The configured statement prefix, if any, is prepended before execution, and the
+// prepared statement cache is used when enabled.
 func (m *MySQL) Query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
 	if m.db == nil {
 		return nil, fmt.Errorf("no database connection")
 	}
-	return m.db.QueryContext(ctx, query, args...)
+	query, args, err := PrepareStatement(&m.config, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return queryContext(ctx, m.db, m.stmts, query, args...)
 }
 
 // QueryRow executes a SQL query that is expected to return at most one row.
 // It supports parameter binding to prevent SQL injection attacks.
+// The configured statement prefix, if any, is prepended before execution, and the
+// prepared statement cache is used when enabled.
 func (m *MySQL) QueryRow(ctx context.Context, query string, args ...any) *sql.Row {
-	return m.db.QueryRowContext(ctx, query, args...)
+	query, args, err := PrepareStatement(&m.config, query, args)
+	if err != nil {
+		return errorRow(err)
+	}
+	return queryRowContext(ctx, m.db, m.stmts, query, args...)
 }
 
 // Exec executes a SQL statement that doesn't return rows, such as INSERT, UPDATE, or DELETE.
 // It supports parameter binding to prevent SQL injection attacks.
+// The configured statement prefix, if any, is prepended before execution, and the
+// prepared statement cache is used when enabled.
 // Returns a Result containing information about the execution.
 func (m *MySQL) Exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
 	if m.db == nil {
 		return nil, fmt.Errorf("no database connection")
 	}
-	return m.db.ExecContext(ctx, query, args...)
+	query, args, err := PrepareStatement(&m.config, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return execContext(ctx, m.db, m.stmts, query, args...)
 }
 
-// ListTables returns a list of all table names in the current MySQL database.
-// Uses the SHOW TABLES command to retrieve table names.
-func (m *MySQL) ListTables(ctx context.Context) ([]string, error) {
-	query := "SHOW TABLES"
+// BeginTx starts a MySQL transaction, applying the configured default
+// isolation level unless opts requests a specific one.
+func (m *MySQL) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return beginTx(ctx, m.db, m.config.IsolationLevel, opts)
+}
+
+// GetIsolationLevel returns the effective transaction isolation level of the MySQL session. MySQL 8.0+ exposes the level as @@transaction_isolation.
+func (m *MySQL) GetIsolationLevel(ctx context.Context) (string, error) {
+	if m.db == nil {
+		return "", fmt.Errorf("no database connection")
+	}
+
+	var level string
+	if err := m.QueryRow(ctx, "SELECT @@transaction_isolation").Scan(&level); err != nil {
+		return "", fmt.Errorf("failed to get isolation level: %w", err)
+	}
+	return level, nil
+}
+
+// GetServerVersion returns the MySQL server version as reported by VERSION().
+func (m *MySQL) GetServerVersion(ctx context.Context) (string, error) {
+	if m.db == nil {
+		return "", fmt.Errorf("no database connection")
+	}
+
+	var version string
+	if err := m.QueryRow(ctx, "SELECT VERSION()").Scan(&version); err != nil {
+		return "", fmt.Errorf("failed to get server version: %w", err)
+	}
+	return version, nil
+}
+
+// IsServerReadOnly reports whether the MySQL server has read_only set, as replicas usually
+// do. Users with SUPER or CONNECTION_ADMIN can still write unless super_read_only is also set.
+func (m *MySQL) IsServerReadOnly(ctx context.Context) (bool, error) {
+	if m.db == nil {
+		return false, fmt.Errorf("no database connection")
+	}
+
+	var readOnly bool
+	if err := m.QueryRow(ctx, "SELECT @@read_only").Scan(&readOnly); err != nil {
+		return false, fmt.Errorf("failed to get read-only state: %w", err)
+	}
+	return readOnly, nil
+}
+
+// GetServerInfo returns the MySQL server version, default character set, connection limit,
+// host name, current database and user. The uptime is read from the Uptime status variable.
+func (m *MySQL) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	if m.db == nil {
+		return nil, fmt.Errorf("no database connection")
+	}
+
+	query := "SELECT @@version, @@character_set_server, @@max_connections, @@hostname, COALESCE(DATABASE(), ''), CURRENT_USER()"
+
+	info := &ServerInfo{DatabaseType: "mysql"}
+	err := m.QueryRow(ctx, query).Scan(
+		&info.ServerVersion, &info.ServerCharset, &info.MaxConnections, &info.Hostname, &info.CurrentDatabase, &info.CurrentUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read server variables: %w", err)
+	}
+
+	var name string
+	var uptime int64
+	if err := m.QueryRow(ctx, "SHOW GLOBAL STATUS LIKE 'Uptime'").Scan(&name, &uptime); err != nil {
+		return nil, fmt.Errorf("failed to get server uptime: %w", err)
+	}
+	info.Uptime = (time.Duration(uptime) * time.Second).String()
+
+	return info, nil
+}
+
+// GetActiveConnections returns the sessions from INFORMATION_SCHEMA.PROCESSLIST that are not
+// sleeping, longest running first, excluding the session running the query. Without the
+// PROCESS privilege, only the user's own sessions are listed.
+func (m *MySQL) GetActiveConnections(ctx context.Context) ([]ActiveConnection, error) {
+	query := `
+		SELECT
+			ID,
+			USER,
+			COALESCE(HOST, ''),
+			COALESCE(DB, ''),
+			COMMAND,
+			COALESCE(INFO, ''),
+			TIME
+		FROM INFORMATION_SCHEMA.PROCESSLIST
+		WHERE COMMAND <> 'Sleep' AND ID <> CONNECTION_ID()
+		ORDER BY TIME DESC`
+
 	rows, err := m.Query(ctx, query)
+	if err != nil {
+		if isPermissionDenied(err) {
+			return nil, fmt.Errorf("insufficient privileges to read the process list (requires the PROCESS privilege): %w", err)
+		}
+		return nil, fmt.Errorf("failed to query the process list: %w", err)
+	}
+	defer rows.Close()
+
+	return readActiveConnections(rows)
+}
+
+// KillConnection terminates the MySQL connection with the given process list ID using
+// KILL CONNECTION. Connections of other users require CONNECTION_ADMIN or SUPER.
+func (m *MySQL) KillConnection(ctx context.Context, pid int64) error {
+	lookup := "SELECT ID = CONNECTION_ID() FROM INFORMATION_SCHEMA.PROCESSLIST WHERE ID = ?"
+	return killConnection(ctx, m.db, pid, lookup, func(conn *sql.Conn) error {
+		// KILL does not accept a placeholder; pid is an integer, so formatting it is safe
+		_, err := conn.ExecContext(ctx, fmt.Sprintf("KILL CONNECTION %d", pid))
+		return err
+	}, "requires the CONNECTION_ADMIN or SUPER privilege")
+}
+
+// GetServerSettings returns the MySQL system variables from SHOW VARIABLES whose name starts
+// with prefix. Session values are reported where they differ from the global ones.
+func (m *MySQL) GetServerSettings(ctx context.Context, prefix string) ([]ServerSetting, error) {
+	rows, err := m.Query(ctx, "SHOW VARIABLES")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query system variables: %w", err)
+	}
+	defer rows.Close()
+
+	return readServerSettings(rows, prefix)
+}
+
+// ListTables returns a list of all base table names in the current MySQL database.
+// Queries INFORMATION_SCHEMA.TABLES so that views are excluded (see ListViews).
+func (m *MySQL) ListTables(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT TABLE_NAME 
+		FROM INFORMATION_SCHEMA.TABLES 
+		WHERE TABLE_SCHEMA = ? AND TABLE_TYPE = 'BASE TABLE'
+		ORDER BY TABLE_NAME`
+
+	rows, err := m.Query(ctx, query, m.config.Database)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tables: %w", err)
 	}
@@ -113,6 +299,82 @@ func (m *MySQL) ListTables(ctx context.Context) ([]string, error) {
 	return tables, rows.Err()
 }
 
+// SearchTables returns the base tables whose name contains pattern in the configured database and
+// every additional allowed database, ranked by relevance. All databases are on the same server,
+// so a single INFORMATION_SCHEMA query covers them.
+func (m *MySQL) SearchTables(ctx context.Context, pattern string) ([]string, error) {
+	databases := searchDatabases(m.config)
+	query := fmt.Sprintf(`
+		SELECT TABLE_SCHEMA, TABLE_NAME
+		FROM INFORMATION_SCHEMA.TABLES
+		WHERE TABLE_SCHEMA IN (%s) AND TABLE_TYPE = 'BASE TABLE' AND TABLE_NAME LIKE ?`,
+		strings.TrimSuffix(strings.Repeat("?, ", len(databases)), ", "))
+
+	args := make([]any, 0, len(databases)+1)
+	for _, name := range databases {
+		args = append(args, name)
+	}
+	args = append(args, likeContains(pattern))
+
+	rows, err := m.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tables: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []tableMatch
+	for rows.Next() {
+		var match tableMatch
+		if err := rows.Scan(&match.database, &match.table); err != nil {
+			return nil, fmt.Errorf("failed to scan table name: %w", err)
+		}
+		matches = append(matches, match)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading table names: %w", err)
+	}
+
+	return rankTableMatches(m.config, matches, pattern), nil
+}
+
+// SearchColumns returns the columns whose name contains pattern in the configured database and
+// every additional allowed database, ranked by relevance.
+func (m *MySQL) SearchColumns(ctx context.Context, pattern string) ([]ColumnSearchResult, error) {
+	databases := searchDatabases(m.config)
+	query := fmt.Sprintf(`
+		SELECT TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME, DATA_TYPE
+		FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE TABLE_SCHEMA IN (%s) AND COLUMN_NAME LIKE ?`,
+		strings.TrimSuffix(strings.Repeat("?, ", len(databases)), ", "))
+
+	args := make([]any, 0, len(databases)+1)
+	for _, name := range databases {
+		args = append(args, name)
+	}
+	args = append(args, likeContains(pattern))
+
+	rows, err := m.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search columns: %w", err)
+	}
+	defer rows.Close()
+
+	var results []ColumnSearchResult
+	for rows.Next() {
+		var result ColumnSearchResult
+		if err := rows.Scan(&result.Database, &result.TableName, &result.ColumnName, &result.DataType); err != nil {
+			return nil, fmt.Errorf("failed to scan column: %w", err)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading columns: %w", err)
+	}
+
+	rankColumnMatches(results, pattern)
+	return results, nil
+}
+
 // ListDatabases returns a list of all available database names on the MySQL server.
 // Uses the SHOW DATABASES command to retrieve database names.
 func (m *MySQL) ListDatabases(ctx context.Context) ([]string, error) {
@@ -135,6 +397,164 @@ func (m *MySQL) ListDatabases(ctx context.Context) ([]string, error) {
 	return databases, rows.Err()
 }
 
+// ListViews returns a list of all view names in the current MySQL database.
+// Queries the INFORMATION_SCHEMA.VIEWS table for the configured database.
+func (m *MySQL) ListViews(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT TABLE_NAME 
+		FROM INFORMATION_SCHEMA.VIEWS 
+		WHERE TABLE_SCHEMA = ?
+		ORDER BY TABLE_NAME`
+
+	rows, err := m.Query(ctx, query, m.config.Database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list views: %w", err)
+	}
+	defer rows.Close()
+
+	var views []string
+	for rows.Next() {
+		var viewName string
+		if err := rows.Scan(&viewName); err != nil {
+			return nil, fmt.Errorf("failed to scan view name: %w", err)
+		}
+		views = append(views, viewName)
+	}
+
+	return views, rows.Err()
+}
+
+// DescribeView returns the definition and exposed columns of the specified MySQL view.
+// The definition is read from INFORMATION_SCHEMA.VIEWS and the columns from INFORMATION_SCHEMA.COLUMNS.
+func (m *MySQL) DescribeView(ctx context.Context, viewName string) (*ViewSchema, error) {
+	view := &ViewSchema{
+		ViewName: viewName,
+		Columns:  []ColumnInfo{},
+	}
+
+	definitionQuery := `
+		SELECT VIEW_DEFINITION 
+		FROM INFORMATION_SCHEMA.VIEWS 
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?`
+
+	var definition sql.NullString
+	if err := m.QueryRow(ctx, definitionQuery, m.config.Database, viewName).Scan(&definition); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("view %s not found", viewName)
+		}
+		return nil, fmt.Errorf("failed to get view definition: %w", err)
+	}
+	view.Definition = strings.TrimSpace(definition.String)
+
+	query := `
+		SELECT 
+			COLUMN_NAME,
+			DATA_TYPE,
+			IS_NULLABLE,
+			COLUMN_DEFAULT,
+			CHARACTER_MAXIMUM_LENGTH
+		FROM INFORMATION_SCHEMA.COLUMNS 
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
+		ORDER BY ORDINAL_POSITION`
+
+	rows, err := m.Query(ctx, query, m.config.Database, viewName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe view: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var column ColumnInfo
+		var nullable string
+		var defaultValue, maxLength sql.NullString
+
+		if err := rows.Scan(&column.Name, &column.Type, &nullable, &defaultValue, &maxLength); err != nil {
+			return nil, fmt.Errorf("failed to scan column info: %w", err)
+		}
+
+		column.IsNullable = nullable == "YES"
+
+		if defaultValue.Valid {
+			column.DefaultValue = &defaultValue.String
+		}
+
+		if maxLength.Valid {
+			if length, err := strconv.Atoi(maxLength.String); err == nil {
+				column.MaxLength = &length
+			}
+		}
+
+		view.Columns = append(view.Columns, column)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading column data: %w", err)
+	}
+
+	return view, nil
+}
+
+// mysqlRoutinesQuery selects the stored routines of a database, one row per argument, in
+// the column order expected by readRoutines. The first %s is replaced by the routine definition
+// expression and the second by extra filter conditions. Argument position 0 is a function's
+// return value, so it is excluded from the arguments.
+const mysqlRoutinesQuery = `
+		SELECT 
+			r.SPECIFIC_NAME,
+			r.ROUTINE_NAME,
+			r.ROUTINE_TYPE,
+			r.ROUTINE_BODY,
+			COALESCE(r.DTD_IDENTIFIER, ''),
+			p.PARAMETER_NAME,
+			p.PARAMETER_MODE,
+			p.DTD_IDENTIFIER,
+			%s
+		FROM INFORMATION_SCHEMA.ROUTINES r
+		LEFT JOIN INFORMATION_SCHEMA.PARAMETERS p
+			ON p.SPECIFIC_SCHEMA = r.ROUTINE_SCHEMA
+			AND p.SPECIFIC_NAME = r.SPECIFIC_NAME
+			AND p.ROUTINE_TYPE = r.ROUTINE_TYPE
+			AND p.ORDINAL_POSITION > 0
+		WHERE r.ROUTINE_SCHEMA = ?%s
+		ORDER BY r.ROUTINE_NAME, r.ROUTINE_TYPE, p.ORDINAL_POSITION`
+
+// ListFunctions returns the stored functions and procedures of the current MySQL database,
+// read from INFORMATION_SCHEMA.ROUTINES and INFORMATION_SCHEMA.PARAMETERS.
+func (m *MySQL) ListFunctions(ctx context.Context) ([]FunctionInfo, error) {
+	rows, err := m.Query(ctx, fmt.Sprintf(mysqlRoutinesQuery, "NULL", ""), m.config.Database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list functions: %w", err)
+	}
+	defer rows.Close()
+
+	routines, err := readRoutines(rows)
+	if err != nil {
+		return nil, err
+	}
+	return functionInfos(routines), nil
+}
+
+// DescribeFunction returns the signature and body of the named MySQL stored function or procedure.
+// ROUTINE_DEFINITION is NULL unless the user created the routine or has the SHOW_ROUTINE privilege.
+// If a function and a procedure share the name, the function is described.
+func (m *MySQL) DescribeFunction(ctx context.Context, name string) (*FunctionSchema, error) {
+	query := fmt.Sprintf(mysqlRoutinesQuery, "r.ROUTINE_DEFINITION", " AND r.ROUTINE_NAME = ?")
+	rows, err := m.Query(ctx, query, m.config.Database, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe function: %w", err)
+	}
+	defer rows.Close()
+
+	routines, err := readRoutines(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(routines) == 0 {
+		return nil, fmt.Errorf("function %s not found", name)
+	}
+	return &routines[0], nil
+}
+
 // DescribeTable returns detailed schema information about the specified MySQL table.
 // It retrieves column definitions, data types, constraints, and index information
 // using the INFORMATION_SCHEMA tables.
@@ -146,6 +566,30 @@ func (m *MySQL) DescribeTable(ctx context.Context, tableName string) (*TableSche
 		Metadata:  make(map[string]any),
 	}
 
+	// CREATE_TIME and UPDATE_TIME are NULL for views, and UPDATE_TIME is also NULL for
+	// InnoDB tables not modified since the server started; missing times are omitted
+	var tableType string
+	var createTime, updateTime sql.NullString
+	typeQuery := `
+		SELECT TABLE_TYPE,
+			DATE_FORMAT(CREATE_TIME, '%Y-%m-%dT%H:%i:%s'),
+			DATE_FORMAT(UPDATE_TIME, '%Y-%m-%dT%H:%i:%s')
+		FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?`
+	err := m.QueryRow(ctx, typeQuery, m.config.Database, tableName).Scan(&tableType, &createTime, &updateTime)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get table type: %w", err)
+	}
+	if err == nil {
+		schema.Metadata["table_type"] = tableType
+		schema.Metadata["is_view"] = tableType == "VIEW"
+		if createTime.Valid {
+			schema.Metadata["created_at"] = createTime.String
+		}
+		if updateTime.Valid {
+			schema.Metadata["updated_at"] = updateTime.String
+		}
+	}
+
 	query := `
 		SELECT 
 			COLUMN_NAME,
@@ -245,26 +689,391 @@ func (m *MySQL) DescribeTable(ctx context.Context, tableName string) (*TableSche
 		schema.Indexes = append(schema.Indexes, *index)
 	}
 
+	foreignKeyQuery := `
+		SELECT 
+			k.CONSTRAINT_NAME,
+			k.COLUMN_NAME,
+			k.REFERENCED_TABLE_NAME,
+			k.REFERENCED_COLUMN_NAME,
+			r.DELETE_RULE,
+			r.UPDATE_RULE
+		FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE k
+		JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS r
+			ON r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA AND r.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+		WHERE k.TABLE_SCHEMA = ? AND k.TABLE_NAME = ? AND k.REFERENCED_TABLE_NAME IS NOT NULL
+		ORDER BY k.CONSTRAINT_NAME, k.ORDINAL_POSITION`
+
+	foreignKeyRows, err := m.Query(ctx, foreignKeyQuery, m.config.Database, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get foreign key info: %w", err)
+	}
+	defer foreignKeyRows.Close()
+
+	for foreignKeyRows.Next() {
+		var constraintName, columnName, referencedTable, referencedColumn, onDelete, onUpdate string
+
+		err := foreignKeyRows.Scan(&constraintName, &columnName, &referencedTable, &referencedColumn, &onDelete, &onUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan foreign key info: %w", err)
+		}
+
+		schema.ForeignKeys = appendForeignKeyColumn(schema.ForeignKeys, constraintName, columnName,
+			referencedTable, referencedColumn, onDelete, onUpdate)
+	}
+
+	if err := foreignKeyRows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading foreign key data: %w", err)
+	}
+
+	schema.setKeyConstraints()
 	return schema, nil
 }
 
+// EstimateColumnStats returns approximate distinct counts for the indexed columns of the
+// specified MySQL table, using the index cardinality in INFORMATION_SCHEMA.STATISTICS.
+// MySQL does not track null fractions, so NullFraction is always nil.
+func (m *MySQL) EstimateColumnStats(ctx context.Context, tableName string) (map[string]ColumnStatsEstimate, error) {
+	query := `
+		SELECT 
+			COLUMN_NAME,
+			MAX(CARDINALITY)
+		FROM INFORMATION_SCHEMA.STATISTICS 
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND SEQ_IN_INDEX = 1
+		GROUP BY COLUMN_NAME`
+
+	rows, err := m.Query(ctx, query, m.config.Database, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column statistics: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make(map[string]ColumnStatsEstimate)
+	for rows.Next() {
+		var columnName string
+		var cardinality sql.NullInt64
+
+		if err := rows.Scan(&columnName, &cardinality); err != nil {
+			return nil, fmt.Errorf("failed to scan column statistics: %w", err)
+		}
+
+		if cardinality.Valid {
+			distinct := cardinality.Int64
+			stats[columnName] = ColumnStatsEstimate{DistinctCount: &distinct}
+		}
+	}
+
+	return stats, rows.Err()
+}
+
+// GetColumnStatistics profiles every column of the specified MySQL table. Columns are read
+// from information_schema and all aggregates are computed in a single scan of the table,
+// which is refused when the table exceeds the configured DB_STATISTICS_MAX_ROWS threshold.
+func (m *MySQL) GetColumnStatistics(ctx context.Context, tableName string) ([]ColumnStatistics, error) {
+	columns, err := m.statisticsColumns(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	dialect := statisticsSQL{quote: quoteMySQLIdentifier, length: "CHAR_LENGTH", text: "CHAR"}
+	return collectColumnStatistics(ctx, m, dialect, quoteMySQLIdentifier(tableName), tableName, columns, m.config.StatisticsMaxRows)
+}
+
+// ProfileTable profiles every column of the specified MySQL table, with the aggregates and
+// the most common values of all columns computed in two queries. Tables with more rows than
+// the configured DB_PROFILE_MAX_ROWS threshold are refused.
+func (m *MySQL) ProfileTable(ctx context.Context, tableName string, maxDistinctValues int) (*TableProfile, error) {
+	columns, err := m.statisticsColumns(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	dialect := statisticsSQL{quote: quoteMySQLIdentifier, length: "CHAR_LENGTH", text: "CHAR"}
+	return profileTable(ctx, m, dialect, quoteMySQLIdentifier(tableName), tableName, columns, m.config.ProfileMaxRows, maxDistinctValues)
+}
+
+// statisticsColumns reads the columns of the specified table from information_schema and
+// classifies them for profiling.
+func (m *MySQL) statisticsColumns(ctx context.Context, tableName string) ([]statisticsColumn, error) {
+	query := `
+		SELECT COLUMN_NAME, DATA_TYPE
+		FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
+		ORDER BY ORDINAL_POSITION`
+
+	rows, err := m.Query(ctx, query, m.config.Database, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []statisticsColumn
+	for rows.Next() {
+		var columnName, dataType string
+		if err := rows.Scan(&columnName, &dataType); err != nil {
+			return nil, fmt.Errorf("failed to scan column info: %w", err)
+		}
+		columns = append(columns, newStatisticsColumn(columnName, dataType))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading column data: %w", err)
+	}
+	return columns, nil
+}
+
+// GetCreateStatement returns the CREATE TABLE statement for the specified MySQL table as
+// reported by SHOW CREATE TABLE, which already lists one column, key or constraint per line.
+func (m *MySQL) GetCreateStatement(ctx context.Context, tableName string) (string, error) {
+	var name, ddl string
+	err := m.QueryRow(ctx, "SHOW CREATE TABLE "+quoteMySQLIdentifier(tableName)).Scan(&name, &ddl)
+	if err != nil {
+		return "", fmt.Errorf("failed to get create table statement: %w", err)
+	}
+	return ddl + ";\n", nil
+}
+
+// GetConstraints returns the constraints of the specified MySQL table, read from
+// INFORMATION_SCHEMA.TABLE_CONSTRAINTS, with CHECK clauses from CHECK_CONSTRAINTS (MySQL 8.0.16+).
+// MySQL does not record the columns of a CHECK constraint, so their Columns are empty.
+// NOT NULL is a column attribute rather than a named constraint in MySQL; every NOT NULL
+// column is reported as a not_null constraint named "<table>_<column>_not_null".
+func (m *MySQL) GetConstraints(ctx context.Context, tableName string) ([]ConstraintInfo, error) {
+	query := `
+		SELECT
+			tc.CONSTRAINT_NAME,
+			tc.CONSTRAINT_TYPE,
+			COALESCE(k.COLUMN_NAME, ''),
+			COALESCE(cc.CHECK_CLAUSE, '')
+		FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+		LEFT JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE k
+			ON k.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA
+			AND k.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+			AND k.TABLE_NAME = tc.TABLE_NAME
+		LEFT JOIN INFORMATION_SCHEMA.CHECK_CONSTRAINTS cc
+			ON cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		WHERE tc.TABLE_SCHEMA = ? AND tc.TABLE_NAME = ?
+		ORDER BY tc.CONSTRAINT_NAME, k.ORDINAL_POSITION`
+
+	rows, err := m.Query(ctx, query, m.config.Database, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get constraints: %w", err)
+	}
+	defer rows.Close()
+
+	var constraints []ConstraintInfo
+	for rows.Next() {
+		var name, constraintType, column, checkClause string
+		if err := rows.Scan(&name, &constraintType, &column, &checkClause); err != nil {
+			return nil, fmt.Errorf("failed to scan constraint info: %w", err)
+		}
+		constraints = appendConstraintColumn(constraints, name, constraintType, column, checkClause)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading constraint data: %w", err)
+	}
+
+	notNullQuery := `
+		SELECT COLUMN_NAME
+		FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND IS_NULLABLE = 'NO'
+		ORDER BY ORDINAL_POSITION`
+
+	columnRows, err := m.Query(ctx, notNullQuery, m.config.Database, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get not-null columns: %w", err)
+	}
+	defer columnRows.Close()
+
+	for columnRows.Next() {
+		var column string
+		if err := columnRows.Scan(&column); err != nil {
+			return nil, fmt.Errorf("failed to scan not-null column: %w", err)
+		}
+		constraints = append(constraints, ConstraintInfo{
+			Name:    tableName + "_" + column + "_not_null",
+			Type:    ConstraintNotNull,
+			Columns: []string{column},
+		})
+	}
+	if err := columnRows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading not-null columns: %w", err)
+	}
+
+	sortConstraints(constraints)
+	return constraints, nil
+}
+
+// ListForeignKeys returns all foreign key relationships in the current MySQL database.
+func (m *MySQL) ListForeignKeys(ctx context.Context) ([]ForeignKeyRelationship, error) {
+	query := `
+		SELECT 
+			k.CONSTRAINT_NAME,
+			k.TABLE_NAME,
+			k.COLUMN_NAME,
+			k.REFERENCED_TABLE_NAME,
+			k.REFERENCED_COLUMN_NAME,
+			r.DELETE_RULE,
+			r.UPDATE_RULE
+		FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE k
+		JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS r
+			ON r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA
+			AND r.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+			AND r.TABLE_NAME = k.TABLE_NAME
+		WHERE k.TABLE_SCHEMA = ? AND k.REFERENCED_TABLE_NAME IS NOT NULL
+		ORDER BY k.TABLE_NAME, k.CONSTRAINT_NAME, k.ORDINAL_POSITION`
+
+	rows, err := m.Query(ctx, query, m.config.Database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	var relationships []ForeignKeyRelationship
+	for rows.Next() {
+		var constraintName, fromTable, fromColumn, toTable, toColumn, onDelete, onUpdate string
+
+		err := rows.Scan(&constraintName, &fromTable, &fromColumn, &toTable, &toColumn, &onDelete, &onUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan foreign key info: %w", err)
+		}
+
+		relationships = appendForeignKeyRelationship(relationships, constraintName, fromTable, fromColumn,
+			toTable, toColumn, onDelete, onUpdate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading foreign key data: %w", err)
+	}
+
+	return relationships, nil
+}
+
+// GetTableSize returns the storage used by the specified MySQL table, read from the DATA_LENGTH,
+// INDEX_LENGTH and TABLE_ROWS columns of INFORMATION_SCHEMA.TABLES. For InnoDB these are
+// estimates maintained by the storage engine.
+func (m *MySQL) GetTableSize(ctx context.Context, tableName string) (*TableSizeInfo, error) {
+	query := mysqlTableSizeQuery + " AND TABLE_NAME = ?"
+
+	var size TableSizeInfo
+	err := m.QueryRow(ctx, query, m.config.Database, tableName).Scan(
+		&size.TableName, &size.DataSizeBytes, &size.IndexSizeBytes, &size.TotalSizeBytes, &size.RowEstimate)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("table %s not found", tableName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table size: %w", err)
+	}
+
+	return &size, nil
+}
+
+// ListTableSizes returns the storage used by every base table in the configured MySQL database,
+// largest first.
+func (m *MySQL) ListTableSizes(ctx context.Context) ([]TableSizeInfo, error) {
+	rows, err := m.Query(ctx, mysqlTableSizeQuery+" ORDER BY 4 DESC, 1", m.config.Database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list table sizes: %w", err)
+	}
+	defer rows.Close()
+
+	var sizes []TableSizeInfo
+	for rows.Next() {
+		var size TableSizeInfo
+		if err := rows.Scan(&size.TableName, &size.DataSizeBytes, &size.IndexSizeBytes, &size.TotalSizeBytes, &size.RowEstimate); err != nil {
+			return nil, fmt.Errorf("failed to scan table size: %w", err)
+		}
+		sizes = append(sizes, size)
+	}
+
+	return sizes, rows.Err()
+}
+
+// GetTableBloat is not supported for MySQL, which does not track dead tuples.
+func (m *MySQL) GetTableBloat(ctx context.Context, tableName string) ([]TableBloatStats, error) {
+	return nil, fmt.Errorf("table bloat statistics are only available for PostgreSQL")
+}
+
+// GetIndexUsage returns the indexes of the current database that have not been used since
+// the server started, from the sys schema's schema_unused_indexes view, which excludes
+// primary keys. MySQL does not expose per-index tuple counts or sizes there, so only the
+// names are filled in; an error is returned when the sys schema is not installed.
+func (m *MySQL) GetIndexUsage(ctx context.Context) ([]IndexUsageInfo, error) {
+	query := `
+		SELECT u.object_name, u.index_name,
+			NOT EXISTS (
+				SELECT 1 FROM information_schema.statistics st
+				WHERE st.table_schema = u.object_schema AND st.table_name = u.object_name
+					AND st.index_name = u.index_name AND st.non_unique = 1
+			)
+		FROM sys.schema_unused_indexes u
+		WHERE u.object_schema = ?
+		ORDER BY u.object_name, u.index_name`
+
+	rows, err := m.Query(ctx, query, m.config.Database)
+	if err != nil {
+		return nil, fmt.Errorf("index usage statistics are only available for PostgreSQL and for MySQL "+
+			"with the sys schema (sys.schema_unused_indexes): %w", err)
+	}
+	defer rows.Close()
+
+	var usage []IndexUsageInfo
+	for rows.Next() {
+		var index IndexUsageInfo
+		if err := rows.Scan(&index.TableName, &index.IndexName, &index.IsUnique); err != nil {
+			return nil, fmt.Errorf("failed to scan unused index: %w", err)
+		}
+		usage = append(usage, index)
+	}
+	return usage, rows.Err()
+}
+
+// GetColumnValues returns up to limit distinct values of a column in the specified MySQL
+// table, sorted, with the number of distinct values and whether the list was truncated.
+func (m *MySQL) GetColumnValues(ctx context.Context, tableName, columnName string, limit int) (*ColumnValues, error) {
+	return collectColumnValues(ctx, m, quoteMySQLIdentifier, mysqlPlaceholder, quoteMySQLIdentifier(tableName), tableName, columnName, limit)
+}
+
 // GetTableData retrieves data from the specified MySQL table with pagination support.
 // If limit is 0 or negative, it defaults to 100 rows. The method also returns
-// the total row count for pagination purposes.
-func (m *MySQL) GetTableData(ctx context.Context, tableName string, limit int, offset int) (*TableData, error) {
+// the total row count, restricted by opts.Filter when one is given, for pagination purposes.
+// With opts.EstimateCount and no filter, the total is taken from the table statistics instead.
+func (m *MySQL) GetTableData(ctx context.Context, tableName string, limit int, offset int, opts TableDataOptions) (*TableData, error) {
 	if limit <= 0 {
 		limit = 100
 	}
 
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM `%s`", tableName)
+	where, args, err := opts.Filter.whereClause(quoteMySQLIdentifier, mysqlPlaceholder)
+	if err != nil {
+		return nil, err
+	}
+
 	var total int
-	err := m.QueryRow(ctx, countQuery).Scan(&total)
+	estimated := opts.EstimateCount && opts.Filter == nil
+	if estimated {
+		err = m.QueryRow(ctx, mysqlRowEstimateQuery, m.config.Database, tableName).Scan(&total)
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("table %s not found", tableName)
+		}
+	} else {
+		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM `%s`%s", tableName, where)
+		err = m.QueryRow(ctx, countQuery, args...).Scan(&total)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to count rows: %w", err)
 	}
 
-	query := fmt.Sprintf("SELECT * FROM `%s` LIMIT ? OFFSET ?", tableName)
-	rows, err := m.Query(ctx, query, limit, offset)
+	// The total counts every matching row, not just those after the cursor
+	after, afterArgs, err := opts.cursorClause(quoteMySQLIdentifier, mysqlPlaceholder, len(args)+1)
+	if err != nil {
+		return nil, err
+	}
+	if after != "" {
+		where = joinCondition(where, after)
+		args = append(args, afterArgs...)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM `%s`%s%s LIMIT ? OFFSET ?", opts.selectList(quoteMySQLIdentifier), tableName, where,
+		opts.orderClause(quoteMySQLIdentifier))
+	rows, err := m.Query(ctx, query, append(args, limit, offset)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query table data: %w", err)
 	}
@@ -275,6 +1084,14 @@ func (m *MySQL) GetTableData(ctx context.Context, tableName string, limit int, o
 		return nil, fmt.Errorf("failed to get columns: %w", err)
 	}
 
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column types: %w", err)
+	}
+	zeroDates := m.zeroDateBehavior()
+	// The output is validated when configuration is loaded
+	booleans, _ := config.ParseBooleanOutput(m.config.BooleanOutput)
+
 	data := &TableData{
 		TableName: tableName,
 		Columns:   columns,
@@ -282,8 +1099,11 @@ func (m *MySQL) GetTableData(ctx context.Context, tableName string, limit int, o
 		Total:     total,
 		Limit:     limit,
 		Offset:    offset,
+
+		CountIsEstimate: estimated,
 	}
 
+	size := 0
 	for rows.Next() {
 		values := make([]any, len(columns))
 		valuePtrs := make([]any, len(columns))
@@ -295,10 +1115,22 @@ func (m *MySQL) GetTableData(ctx context.Context, tableName string, limit int, o
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
+		if limit := m.config.MaxResultBytes; limit > 0 {
+			if size += RowSize(values); size > limit {
+				return nil, ResultSizeError(limit)
+			}
+		}
 
 		row := make(map[string]any)
 		for i, col := range columns {
-			if values[i] != nil {
+			if m.config.TrimStrings {
+				values[i] = TrimString(values[i], columnTypes[i].DatabaseTypeName())
+			}
+			if b, ok := values[i].([]byte); ok && IsMySQLDateTimeType(columnTypes[i].DatabaseTypeName()) {
+				row[col] = DecodeMySQLDateTime(b, zeroDates)
+			} else if booleans != config.BooleanNative && IsBooleanType(columnTypes[i].DatabaseTypeName()) {
+				row[col] = NormalizeBoolean(values[i], booleans)
+			} else if values[i] != nil {
 				row[col] = values[i]
 			} else {
 				row[col] = nil
@@ -306,8 +1138,14 @@ func (m *MySQL) GetTableData(ctx context.Context, tableName string, limit int, o
 		}
 		data.Rows = append(data.Rows, row)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return data, rows.Err()
+	if data.NextCursor, err = opts.nextCursor(data.Rows, limit); err != nil {
+		return nil, err
+	}
+	return data, nil
 }
 
 // ExplainQuery returns the execution plan for the given SQL query in JSON format.
@@ -322,22 +1160,127 @@ func (m *MySQL) ExplainQuery(ctx context.Context, query string) (string, error)
 	return result, nil
 }
 
+// ExplainAnalyzeQuery executes the given SQL query and returns its EXPLAIN ANALYZE plan
+// (MySQL 8.0.18+) in tree format, including actual row counts and timings.
+func (m *MySQL) ExplainAnalyzeQuery(ctx context.Context, query string) (string, error) {
+	explainQuery, args, err := applyRowFilters(&m.config, fmt.Sprintf("EXPLAIN ANALYZE %s", query), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to explain analyze query: %w", err)
+	}
+	explainQuery = applyStatementPrefix(m.config.StatementPrefix, explainQuery)
+	result, err := explainAnalyze(ctx, m.db, m.config.IsolationLevel, explainQuery, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to explain analyze query: %w", err)
+	}
+	return result, nil
+}
+
 // GetDB returns the underlying *sql.DB instance for direct database operations.
 // Returns nil if no connection has been established.
 func (m *MySQL) GetDB() *sql.DB {
 	return m.db
 }
 
+// QuoteTable returns tableName quoted as a MySQL identifier; a "database.table" name is
+// quoted part by part.
+func (m *MySQL) QuoteTable(ctx context.Context, tableName string) string {
+	return QuoteIdentifier("mysql", tableName)
+}
+
 // GetDriverName returns the name of the database driver.
 // Always returns "mysql" for MySQL connections.
 func (m *MySQL) GetDriverName() string {
 	return "mysql"
 }
 
+// zeroDateBehavior returns the configured handling of zero and invalid dates.
+// Unrecognised values, which configuration validation rejects, fall back to ZeroDateNull.
+func (m *MySQL) zeroDateBehavior() config.ZeroDateBehavior {
+	behavior, _ := config.ParseZeroDateBehavior(m.config.ZeroDateBehavior)
+	return behavior
+}
+
+// mysqlAuthParams returns the DSN flags for the password methods listed in DB_MYSQL_AUTH.
+// Without a list the driver defaults apply; with one, mysql_native_password is only
+// allowed when it is listed.
+func mysqlAuthParams(methods []string) []string {
+	// The methods are validated when configuration is loaded
+	parsed, _ := config.ParseMySQLAuth(methods)
+	if len(parsed) == 0 {
+		return nil
+	}
+
+	allowed := make(map[config.MySQLAuthMethod]bool, len(parsed))
+	for _, method := range parsed {
+		allowed[method] = true
+	}
+
+	params := []string{fmt.Sprintf("allowNativePasswords=%t", allowed[config.MySQLAuthNative])}
+	if allowed[config.MySQLAuthCleartext] {
+		params = append(params, "allowCleartextPasswords=true")
+	}
+	if allowed[config.MySQLAuthOld] {
+		params = append(params, "allowOldPasswords=true")
+	}
+	return params
+}
+
+// mysqlAuthHint returns advice for a connection error caused by the authentication plugin
+// of the configured user, or "" for any other error. The driver's own messages name the
+// DSN flag rather than the setting that controls it.
+func mysqlAuthHint(err error) string {
+	var serverErr *mysql.MySQLError
+	switch {
+	case errors.Is(err, mysql.ErrNativePassword):
+		return "the user authenticates with mysql_native_password; add native to DB_MYSQL_AUTH"
+	case errors.Is(err, mysql.ErrCleartextPassword):
+		return "the user authenticates with mysql_clear_password (e.g. PAM or LDAP); add cleartext to DB_MYSQL_AUTH and set DB_SSL_MODE=require so the password is encrypted"
+	case errors.Is(err, mysql.ErrOldPassword):
+		return "the user has a pre-4.1 password hash; reset the password, or add old to DB_MYSQL_AUTH"
+	case errors.Is(err, mysql.ErrUnknownPlugin):
+		return "the user's authentication plugin is not supported; switch the user to caching_sha2_password or mysql_native_password"
+	case errors.Is(err, mysql.ErrNoTLS):
+		return "the server does not support TLS; set DB_SSL_MODE=prefer or none"
+	case errors.As(err, &serverErr) && serverErr.Number == 1251: // ER_NOT_SUPPORTED_AUTH_MODE
+		return "the server requires an authentication plugin the client did not offer; check DB_MYSQL_AUTH or switch the user to caching_sha2_password"
+	case strings.Contains(err.Error(), "caching_sha2_password") || strings.Contains(strings.ToLower(err.Error()), "pem data"):
+		return "caching_sha2_password needs TLS or the server's RSA public key to send the password; set DB_SSL_MODE=require"
+	}
+	return ""
+}
+
+// usesClientTLS reports whether the connection uses a custom TLS configuration built from
+// the configured certificate files, rather than one of the driver's built-in modes. The
+// driver has no built-in mode for verify-ca, so it always uses one. Without certificate
+// files, DB_SSL_VERIFY uses the built-in "true" mode, which verifies the certificate
+// chain and host name.
+func (m *MySQL) usesClientTLS() bool {
+	if mode, _ := m.config.ValidateSSLMode(); mode == config.SSLModeVerifyCA {
+		return true
+	}
+	return m.config.HasTLSFiles() && m.config.SSLMode != string(config.SSLModeNone)
+}
+
+// tlsConfigName returns the name under which the connection's TLS configuration is
+// registered with the driver. The registry is global, so the name identifies the server.
+func (m *MySQL) tlsConfigName() string {
+	return fmt.Sprintf("mcp-%s-%d", m.config.Host, m.config.Port)
+}
+
 // buildDSN constructs a MySQL Data Source Name (DSN) from the configuration.
 // It includes SSL configuration, timeout settings, and other connection parameters
 // required for establishing a secure and reliable MySQL connection.
 func (m *MySQL) buildDSN() string {
+	return m.dsn(m.config.Password)
+}
+
+// SafeDSN returns the DSN with the password masked, for use in logs and error messages.
+func (m *MySQL) SafeDSN() string {
+	return m.dsn(maskPassword(m.config.Password))
+}
+
+// dsn constructs the DSN using the given password.
+func (m *MySQL) dsn(password string) string {
 	var params []string
 
 	// Handle SSL mode using common SSL configuration
@@ -348,16 +1291,30 @@ func (m *MySQL) buildDSN() string {
 	}
 
 	mysqlSSLMode, _ := sslMode.ToMySQLSSLMode()
+	if m.usesClientTLS() {
+		// Certificate files are set in the TLS configuration registered by Connect
+		mysqlSSLMode = m.tlsConfigName()
+	}
 	params = append(params, fmt.Sprintf("tls=%s", mysqlSSLMode))
 
-	params = append(params, "parseTime=true")
+	// Without parseTime, dates arrive as text so zero and invalid dates can be decoded
+	// leniently by DecodeMySQLDateTime instead of failing the whole query
+	if m.zeroDateBehavior() == config.ZeroDateError {
+		params = append(params, "parseTime=true")
+	}
+	params = append(params, mysqlAuthParams(m.config.MySQLAuth)...)
+	// Read-only mode is also enforced by the server: the driver sets this session variable
+	// on every new connection, so writes that pass the validator are still rejected
+	if m.config.ReadOnly {
+		params = append(params, "transaction_read_only=1")
+	}
 	params = append(params, "timeout=30s")
 	params = append(params, "readTimeout=30s")
 	params = append(params, "writeTimeout=30s")
 
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s",
 		m.config.Username,
-		m.config.Password,
+		password,
 		m.config.Host,
 		m.config.Port,
 		m.config.Database,
@@ -2,8 +2,14 @@ package database
 
 import (
 	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/jhoffmann/go-database-mcp/internal/config"
 )
 
@@ -141,10 +147,24 @@ func TestMySQL_buildDSN(t *testing.T) {
 			config: NewTestConfig("mysql"),
 			contains: []string{
 				"testuser:testpass@tcp(localhost:3306)/testdb",
-				"parseTime=true",
 				"timeout=30s",
 			},
 		},
+		{
+			name: "strict zero date handling parses times in the driver",
+			config: config.DatabaseConfig{
+				Type:             "mysql",
+				Host:             "localhost",
+				Port:             3306,
+				Database:         "testdb",
+				Username:         "user",
+				Password:         "pass",
+				ZeroDateBehavior: "error",
+			},
+			contains: []string{
+				"parseTime=true",
+			},
+		},
 		{
 			name: "with SSL none",
 			config: config.DatabaseConfig{
@@ -209,6 +229,60 @@ func TestMySQL_buildDSN(t *testing.T) {
 				"tls=false",
 			},
 		},
+		{
+			name: "with SSL verify-full",
+			config: config.DatabaseConfig{
+				Type:     "mysql",
+				Host:     "localhost",
+				Port:     3306,
+				Database: "testdb",
+				Username: "user",
+				Password: "pass",
+				SSLMode:  "verify-full",
+			},
+			contains: []string{"tls=true"},
+		},
+		{
+			name: "read-only session",
+			config: config.DatabaseConfig{
+				Type:     "mysql",
+				Host:     "localhost",
+				Port:     3306,
+				Database: "testdb",
+				Username: "user",
+				Password: "pass",
+				ReadOnly: true,
+			},
+			contains: []string{"transaction_read_only=1"},
+		},
+		{
+			name: "with DB_SSL_VERIFY",
+			config: config.DatabaseConfig{
+				Type:      "mysql",
+				Host:      "localhost",
+				Port:      3306,
+				Database:  "testdb",
+				Username:  "user",
+				Password:  "pass",
+				SSLMode:   "verify-ca",
+				SSLVerify: true,
+			},
+			contains: []string{"tls=true"},
+		},
+		{
+			// The driver has no verify-ca mode, so a custom TLS configuration is registered
+			name: "with SSL verify-ca",
+			config: config.DatabaseConfig{
+				Type:     "mysql",
+				Host:     "localhost",
+				Port:     3306,
+				Database: "testdb",
+				Username: "user",
+				Password: "pass",
+				SSLMode:  "verify-ca",
+			},
+			contains: []string{"tls=mcp-localhost-3306"},
+		},
 		{
 			name: "custom host and port",
 			config: config.DatabaseConfig{
@@ -306,3 +380,293 @@ func TestMySQL_StructFields(t *testing.T) {
 		t.Errorf("Expected config.Database = %s, got %s", cfg.Database, mysql.config.Database)
 	}
 }
+
+func TestMySQL_buildDSN_LenientZeroDates(t *testing.T) {
+	for _, behavior := range []string{"", "null", "string"} {
+		cfg := NewTestConfig("mysql")
+		cfg.ZeroDateBehavior = behavior
+
+		mysql := &MySQL{config: cfg}
+		if dsn := mysql.buildDSN(); contains(dsn, "parseTime") {
+			t.Errorf("Zero date behavior %q: expected dates to be decoded after scanning, got DSN %s", behavior, dsn)
+		}
+	}
+}
+
+func TestMySQL_GetTableBloat_Unsupported(t *testing.T) {
+	db := &MySQL{config: NewTestConfig("mysql")}
+	if _, err := db.GetTableBloat(context.Background(), ""); err == nil {
+		t.Error("Expected table bloat to be unsupported for MySQL")
+	}
+}
+
+func TestMySQL_GetIndexUsage(t *testing.T) {
+	var issued string
+	sqlDB := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+		issued = query
+		return []string{"object_name", "index_name", "is_unique"}, [][]driver.Value{{"orders", "idx_legacy", int64(0)}}
+	})
+	defer sqlDB.Close()
+
+	db := &MySQL{db: sqlDB, config: NewTestConfig("mysql")}
+	usage, err := db.GetIndexUsage(context.Background())
+	if err != nil {
+		t.Fatalf("GetIndexUsage() error = %v", err)
+	}
+
+	if !strings.Contains(issued, "FROM sys.schema_unused_indexes") {
+		t.Errorf("Expected a sys.schema_unused_indexes query, got %s", issued)
+	}
+	want := IndexUsageInfo{TableName: "orders", IndexName: "idx_legacy"}
+	if len(usage) != 1 || usage[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, usage)
+	}
+}
+
+func TestMySQL_SafeDSN(t *testing.T) {
+	cfg := config.DatabaseConfig{
+		Type:     "mysql",
+		Host:     "localhost",
+		Port:     3306,
+		Database: "testdb",
+		Username: "user",
+		Password: "s3cr3t",
+		SSLMode:  "none",
+	}
+
+	mysql, err := NewMySQL(cfg)
+	if err != nil {
+		t.Fatalf("NewMySQL() error = %v", err)
+	}
+
+	safe := mysql.SafeDSN()
+	if contains(safe, "s3cr3t") {
+		t.Errorf("SafeDSN() = %q, should not contain the password", safe)
+	}
+	if want := strings.Replace(mysql.buildDSN(), "s3cr3t", "****", 1); safe != want {
+		t.Errorf("SafeDSN() = %q, want %q", safe, want)
+	}
+
+	cfg.Password = ""
+	mysql, _ = NewMySQL(cfg)
+	if safe := mysql.SafeDSN(); safe != mysql.buildDSN() {
+		t.Errorf("SafeDSN() = %q, want the DSN unchanged without a password", safe)
+	}
+}
+
+func TestMySQL_IsServerReadOnly(t *testing.T) {
+	var issued string
+	sqlDB := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+		issued = query
+		return []string{"read_only"}, [][]driver.Value{{int64(1)}}
+	})
+	defer sqlDB.Close()
+
+	db := &MySQL{db: sqlDB, config: NewTestConfig("mysql")}
+	readOnly, err := db.IsServerReadOnly(context.Background())
+	if err != nil {
+		t.Fatalf("IsServerReadOnly() error = %v", err)
+	}
+	if issued != "SELECT @@read_only" {
+		t.Errorf("Expected query %q, got %q", "SELECT @@read_only", issued)
+	}
+	if !readOnly {
+		t.Error("Expected the server to be reported as read-only")
+	}
+}
+
+func TestMySQL_GetServerVersion(t *testing.T) {
+	var issued string
+	sqlDB := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+		issued = query
+		return []string{"version"}, [][]driver.Value{{"8.0.36"}}
+	})
+	defer sqlDB.Close()
+
+	db := &MySQL{db: sqlDB, config: NewTestConfig("mysql")}
+	version, err := db.GetServerVersion(context.Background())
+	if err != nil {
+		t.Fatalf("GetServerVersion() error = %v", err)
+	}
+	if issued != "SELECT VERSION()" {
+		t.Errorf("Expected query %q, got %q", "SELECT VERSION()", issued)
+	}
+	if version != "8.0.36" {
+		t.Errorf("Expected version %q, got %q", "8.0.36", version)
+	}
+}
+
+func TestMySQL_GetServerInfo_Query(t *testing.T) {
+	sqlDB, recorder := NewRecordingDB()
+	defer sqlDB.Close()
+
+	db := &MySQL{db: sqlDB, config: NewTestConfig("mysql")}
+
+	// The mock driver returns no rows, so only the first statement is issued
+	if _, err := db.GetServerInfo(context.Background()); err == nil {
+		t.Error("Expected an error without a result row")
+	}
+
+	queries := recorder.Queries()
+	if len(queries) != 1 {
+		t.Fatalf("Expected one query, got %v", queries)
+	}
+	for _, part := range []string{"@@version", "@@character_set_server", "@@max_connections", "@@hostname", "DATABASE()", "CURRENT_USER()"} {
+		if !strings.Contains(queries[0], part) {
+			t.Errorf("Expected %q in query, got %s", part, queries[0])
+		}
+	}
+}
+
+func TestMySQL_ClientCertificates(t *testing.T) {
+	cfg := NewTestConfig("mysql")
+	cfg.Host = "db.internal"
+	cfg.Port = 3307
+	cfg.SSLMode = "require"
+	cfg.SSLRootCert = filepath.Join(t.TempDir(), "missing-ca.crt")
+
+	m := &MySQL{config: cfg}
+	if dsn := m.buildDSN(); !strings.Contains(dsn, "tls=mcp-db.internal-3307") {
+		t.Errorf("Expected the DSN to reference the registered TLS configuration, got %s", dsn)
+	}
+
+	// Unreadable certificate files fail before the server is contacted
+	err := m.Connect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "DB_SSL_ROOT_CERT") {
+		t.Errorf("Connect() error = %v, want a root certificate error", err)
+	}
+}
+
+func TestMySQL_buildDSN_AuthFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    []string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "driver defaults",
+			notWant: []string{"allowNativePasswords", "allowCleartextPasswords", "allowOldPasswords"},
+		},
+		{
+			name:    "cleartext only",
+			auth:    []string{"cleartext"},
+			want:    []string{"allowNativePasswords=false", "allowCleartextPasswords=true"},
+			notWant: []string{"allowOldPasswords"},
+		},
+		{
+			name: "native and old",
+			auth: []string{"native", "old"},
+			want: []string{"allowNativePasswords=true", "allowOldPasswords=true"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewTestConfig("mysql")
+			cfg.MySQLAuth = tt.auth
+
+			dsn := (&MySQL{config: cfg}).buildDSN()
+			for _, part := range tt.want {
+				if !strings.Contains(dsn, part) {
+					t.Errorf("Expected DSN to contain %q, got %s", part, dsn)
+				}
+			}
+			for _, part := range tt.notWant {
+				if strings.Contains(dsn, part) {
+					t.Errorf("Expected DSN not to contain %q, got %s", part, dsn)
+				}
+			}
+		})
+	}
+}
+
+func TestMySQLAuthHint(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "native password", err: mysql.ErrNativePassword, want: "add native to DB_MYSQL_AUTH"},
+		{name: "cleartext password", err: mysql.ErrCleartextPassword, want: "add cleartext to DB_MYSQL_AUTH"},
+		{name: "old password", err: mysql.ErrOldPassword, want: "add old to DB_MYSQL_AUTH"},
+		{name: "unknown plugin", err: mysql.ErrUnknownPlugin, want: "switch the user to caching_sha2_password"},
+		{name: "no TLS", err: mysql.ErrNoTLS, want: "DB_SSL_MODE=prefer"},
+		{name: "server rejects auth mode", err: &mysql.MySQLError{Number: 1251, Message: "Client does not support authentication protocol requested by server"}, want: "DB_MYSQL_AUTH"},
+		{name: "caching_sha2 full authentication", err: errors.New("unexpected resp from server for caching_sha2_password, perform full authentication"), want: "DB_SSL_MODE=require"},
+		{name: "wrapped and redacted", err: redactPassword(fmt.Errorf("connect: %w", mysql.ErrNativePassword), "secret"), want: "add native to DB_MYSQL_AUTH"},
+		{name: "unrelated error", err: errors.New("connection refused"), want: ""},
+		{name: "access denied", err: &mysql.MySQLError{Number: 1045, Message: "Access denied for user 'app'@'%'"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hint := mysqlAuthHint(tt.err)
+			if tt.want == "" && hint != "" {
+				t.Errorf("mysqlAuthHint() = %q, want no hint", hint)
+			}
+			if !strings.Contains(hint, tt.want) {
+				t.Errorf("mysqlAuthHint() = %q, want it to contain %q", hint, tt.want)
+			}
+		})
+	}
+}
+
+func TestMySQL_DescribeTable_Timestamps(t *testing.T) {
+	tests := []struct {
+		name        string
+		createTime  driver.Value
+		updateTime  driver.Value
+		wantCreated any
+		wantUpdated any
+	}{
+		{
+			name:        "both times",
+			createTime:  []byte("2024-01-02T03:04:05"),
+			updateTime:  []byte("2024-06-07T08:09:10"),
+			wantCreated: "2024-01-02T03:04:05",
+			wantUpdated: "2024-06-07T08:09:10",
+		},
+		{
+			name:        "update time not tracked",
+			createTime:  []byte("2024-01-02T03:04:05"),
+			wantCreated: "2024-01-02T03:04:05",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+				if strings.Contains(query, "INFORMATION_SCHEMA.TABLES") {
+					return []string{"TABLE_TYPE", "CREATE_TIME", "UPDATE_TIME"},
+						[][]driver.Value{{"BASE TABLE", tt.createTime, tt.updateTime}}
+				}
+				return nil, nil
+			})
+			defer sqlDB.Close()
+
+			db := &MySQL{db: sqlDB, config: NewTestConfig("mysql")}
+			schema, err := db.DescribeTable(context.Background(), "users")
+			if err != nil {
+				t.Fatalf("DescribeTable() error = %v", err)
+			}
+
+			if got := schema.Metadata["created_at"]; got != tt.wantCreated {
+				t.Errorf("Expected created_at %v, got %v", tt.wantCreated, got)
+			}
+			if got := schema.Metadata["updated_at"]; got != tt.wantUpdated {
+				t.Errorf("Expected updated_at %v, got %v", tt.wantUpdated, got)
+			}
+		})
+	}
+}
+
+func TestMySQL_ListenForNotifications_Unsupported(t *testing.T) {
+	m := &MySQL{config: NewTestConfig("mysql")}
+	if err := m.ListenForNotifications(context.Background(), "orders", func(string) {}); err == nil || !strings.Contains(err.Error(), "only supported by PostgreSQL") {
+		t.Errorf("ListenForNotifications() error = %v, want PostgreSQL-only error", err)
+	}
+	if err := m.Unlisten(context.Background(), "orders"); err == nil || !strings.Contains(err.Error(), "only supported by PostgreSQL") {
+		t.Errorf("Unlisten() error = %v, want PostgreSQL-only error", err)
+	}
+}
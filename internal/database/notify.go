@@ -0,0 +1,148 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Reconnect intervals of the dedicated LISTEN connection. After a lost connection the
+// interval doubles after each failed attempt, up to the maximum.
+const (
+	listenerMinReconnectInterval = time.Second
+	listenerMaxReconnectInterval = time.Minute
+)
+
+// ListenForNotifications subscribes to a PostgreSQL NOTIFY channel and calls handler with the
+// payload of every notification sent on it, until Unlisten or Close. LISTEN runs on a dedicated
+// connection outside the pool, opened on first use and re-established when it is lost;
+// notifications sent while it is down are not delivered. handler is called from a single
+// goroutine, so a slow handler delays the notifications of every channel. Listening again on a
+// channel replaces its handler. The channel name is quoted, so it is case-sensitive.
+func (p *PostgreSQL) ListenForNotifications(ctx context.Context, channel string, handler func(payload string)) error {
+	if p.db == nil {
+		return fmt.Errorf("no database connection")
+	}
+	if channel == "" {
+		return fmt.Errorf("channel name cannot be empty")
+	}
+	if handler == nil {
+		return fmt.Errorf("notification handler cannot be nil")
+	}
+
+	p.listenMu.Lock()
+	if p.listener == nil {
+		p.listener = pq.NewListener(p.buildDSN(), listenerMinReconnectInterval, listenerMaxReconnectInterval, p.logListenerEvent)
+		p.channels = make(map[string]func(payload string))
+		go p.dispatchNotifications(p.listener.Notify)
+	}
+	listener := p.listener
+	_, listening := p.channels[channel]
+	p.channels[channel] = handler
+	p.listenMu.Unlock()
+
+	if listening {
+		return nil
+	}
+
+	// Listen blocks until the dedicated connection is established, so it is bounded by ctx
+	done := make(chan error, 1)
+	go func() { done <- listener.Listen(channel) }()
+
+	select {
+	case err := <-done:
+		if err != nil && !errors.Is(err, pq.ErrChannelAlreadyOpen) {
+			p.removeChannel(channel)
+			return fmt.Errorf("failed to listen on channel %s: %w", channel, redactPassword(err, p.config.Password))
+		}
+		return nil
+	case <-ctx.Done():
+		p.removeChannel(channel)
+		return fmt.Errorf("failed to listen on channel %s: %w", channel, ctx.Err())
+	}
+}
+
+// Unlisten stops delivering the notifications of a channel subscribed with
+// ListenForNotifications.
+func (p *PostgreSQL) Unlisten(ctx context.Context, channel string) error {
+	p.listenMu.Lock()
+	listener := p.listener
+	_, listening := p.channels[channel]
+	p.listenMu.Unlock()
+
+	if listener == nil || !listening {
+		return fmt.Errorf("not listening on channel %s", channel)
+	}
+
+	p.removeChannel(channel)
+	if err := listener.Unlisten(channel); err != nil && !errors.Is(err, pq.ErrChannelNotOpen) {
+		return fmt.Errorf("failed to unlisten channel %s: %w", channel, err)
+	}
+	return nil
+}
+
+// removeChannel forgets the handler of channel.
+func (p *PostgreSQL) removeChannel(channel string) {
+	p.listenMu.Lock()
+	defer p.listenMu.Unlock()
+	delete(p.channels, channel)
+}
+
+// dispatchNotifications calls the registered handler for each notification received on
+// notify, until the listener is closed. The listener sends nil after reconnecting.
+func (p *PostgreSQL) dispatchNotifications(notify <-chan *pq.Notification) {
+	for notification := range notify {
+		if notification == nil {
+			continue
+		}
+
+		p.listenMu.Lock()
+		handler := p.channels[notification.Channel]
+		p.listenMu.Unlock()
+
+		if handler != nil {
+			handler(notification.Extra)
+		}
+	}
+}
+
+// logListenerEvent logs state changes of the dedicated LISTEN connection.
+func (p *PostgreSQL) logListenerEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventDisconnected:
+		slog.Warn("Notification listener disconnected; notifications are lost until it reconnects",
+			"error", redactPassword(err, p.config.Password))
+	case pq.ListenerEventReconnected:
+		slog.Info("Notification listener reconnected")
+	case pq.ListenerEventConnectionAttemptFailed:
+		slog.Warn("Notification listener connection attempt failed", "error", redactPassword(err, p.config.Password))
+	}
+}
+
+// closeListener closes the dedicated LISTEN connection, if one was opened.
+func (p *PostgreSQL) closeListener() error {
+	p.listenMu.Lock()
+	defer p.listenMu.Unlock()
+
+	if p.listener == nil {
+		return nil
+	}
+	err := p.listener.Close()
+	p.listener = nil
+	p.channels = nil
+	return err
+}
+
+// ListenForNotifications is not supported: MySQL has no LISTEN/NOTIFY equivalent.
+func (m *MySQL) ListenForNotifications(ctx context.Context, channel string, handler func(payload string)) error {
+	return fmt.Errorf("LISTEN/NOTIFY is only supported by PostgreSQL")
+}
+
+// Unlisten is not supported: MySQL has no LISTEN/NOTIFY equivalent.
+func (m *MySQL) Unlisten(ctx context.Context, channel string) error {
+	return fmt.Errorf("LISTEN/NOTIFY is only supported by PostgreSQL")
+}
@@ -4,10 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/jhoffmann/go-database-mcp/internal/config"
+
+	"github.com/lib/pq"
 )
 
 // PostgreSQL implements the Database interface for PostgreSQL database connections.
@@ -16,13 +22,42 @@ import (
 type PostgreSQL struct {
 	db     *sql.DB               // The underlying database connection
 	config config.DatabaseConfig // Configuration settings for the connection
+	stmts  *stmtCache            // Prepared statement cache (nil when disabled)
+
+	listenMu sync.Mutex                      // Guards listener and channels
+	listener *pq.Listener                    // Dedicated LISTEN connection (nil until first used)
+	channels map[string]func(payload string) // Notification handler by channel
 }
 
+// postgresTableSizeQuery selects the name, data size, index size, total size and row estimate
+// of ordinary and partitioned tables. The first %s is replaced by the name expression and the
+// second by the schema condition. reltuples is -1 for tables that have never been analyzed,
+// so the estimate is clamped at zero.
+const postgresTableSizeQuery = `
+		SELECT
+			%s,
+			pg_relation_size(c.oid),
+			pg_indexes_size(c.oid),
+			pg_total_relation_size(c.oid),
+			GREATEST(c.reltuples, 0)::bigint
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE %s AND c.relkind IN ('r', 'p')`
+
+// postgresRowEstimateQuery reads the planner's row estimate for a table, maintained by VACUUM,
+// ANALYZE and autovacuum.
+const postgresRowEstimateQuery = `
+		SELECT c.reltuples::bigint
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $2 AND c.relname = $1 AND c.relkind IN ('r', 'p')`
+
 // NewPostgreSQL creates a new PostgreSQL database instance with the given configuration.
 // The connection is not established until Connect() is called.
 func NewPostgreSQL(cfg config.DatabaseConfig) (*PostgreSQL, error) {
 	return &PostgreSQL{
 		config: cfg,
+		stmts:  newStmtCache(cfg.StatementCacheSize),
 	}, nil
 }
 
@@ -34,14 +69,14 @@ func (p *PostgreSQL) Connect(ctx context.Context) error {
 
 	db, err := sql.Open("postgres", dsn)
 	if err != nil {
-		return fmt.Errorf("failed to open PostgreSQL connection: %w", err)
+		return fmt.Errorf("failed to open PostgreSQL connection to %s: %w", p.SafeDSN(), redactPassword(err, p.config.Password))
 	}
 
 	configureConnectionPool(db, p.config)
 
 	if err := db.PingContext(ctx); err != nil {
 		db.Close()
-		return fmt.Errorf("failed to ping PostgreSQL database: %w", err)
+		return fmt.Errorf("failed to ping PostgreSQL database at %s: %w", p.SafeDSN(), redactPassword(err, p.config.Password))
 	}
 
 	p.db = db
@@ -51,10 +86,14 @@ func (p *PostgreSQL) Connect(ctx context.Context) error {
 // Close closes the PostgreSQL database connection and releases associated resources.
 // It's safe to call even if no connection has been established.
 func (p *PostgreSQL) Close() error {
+	listenerErr := p.closeListener()
 	if p.db != nil {
-		return p.db.Close()
+		p.stmts.close()
+		if err := p.db.Close(); err != nil {
+			return err
+		}
 	}
-	return nil
+	return listenerErr
 }
 
 // Ping verifies that the PostgreSQL database connection is still alive and accessible.
@@ -68,39 +107,192 @@ func (p *PostgreSQL) Ping(ctx context.Context) error {
 
 // Query executes a SQL query that returns rows, typically a SELECT statement.
 // It supports parameter binding to prevent SQL injection attacks.
+// The configured statement prefix, if any, is prepended before execution, and the
+// prepared statement cache is used when enabled.
 func (p *PostgreSQL) Query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
 	if p.db == nil {
 		return nil, fmt.Errorf("no database connection")
 	}
-	return p.db.QueryContext(ctx, query, args...)
+	query, args, err := PrepareStatement(&p.config, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return queryContext(ctx, p.db, p.stmts, query, args...)
 }
 
 // QueryRow executes a SQL query that is expected to return at most one row.
 // It supports parameter binding to prevent SQL injection attacks.
+// The configured statement prefix, if any, is prepended before execution, and the
+// prepared statement cache is used when enabled.
 func (p *PostgreSQL) QueryRow(ctx context.Context, query string, args ...any) *sql.Row {
-	return p.db.QueryRowContext(ctx, query, args...)
+	query, args, err := PrepareStatement(&p.config, query, args)
+	if err != nil {
+		return errorRow(err)
+	}
+	return queryRowContext(ctx, p.db, p.stmts, query, args...)
 }
 
 // Exec executes a SQL statement that doesn't return rows, such as INSERT, UPDATE, or DELETE.
 // It supports parameter binding to prevent SQL injection attacks.
+// The configured statement prefix, if any, is prepended before execution, and the
+// prepared statement cache is used when enabled.
 // Returns a Result containing information about the execution.
 func (p *PostgreSQL) Exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
 	if p.db == nil {
 		return nil, fmt.Errorf("no database connection")
 	}
-	return p.db.ExecContext(ctx, query, args...)
+	query, args, err := PrepareStatement(&p.config, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return execContext(ctx, p.db, p.stmts, query, args...)
+}
+
+// BeginTx starts a PostgreSQL transaction, applying the configured default
+// isolation level unless opts requests a specific one.
+func (p *PostgreSQL) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return beginTx(ctx, p.db, p.config.IsolationLevel, opts)
+}
+
+// GetIsolationLevel returns the effective transaction isolation level of the PostgreSQL session.
+func (p *PostgreSQL) GetIsolationLevel(ctx context.Context) (string, error) {
+	if p.db == nil {
+		return "", fmt.Errorf("no database connection")
+	}
+
+	var level string
+	if err := p.QueryRow(ctx, "SHOW transaction_isolation").Scan(&level); err != nil {
+		return "", fmt.Errorf("failed to get isolation level: %w", err)
+	}
+	return level, nil
+}
+
+// GetServerVersion returns the PostgreSQL server version as reported by version().
+func (p *PostgreSQL) GetServerVersion(ctx context.Context) (string, error) {
+	if p.db == nil {
+		return "", fmt.Errorf("no database connection")
+	}
+
+	var version string
+	if err := p.QueryRow(ctx, "SELECT version()").Scan(&version); err != nil {
+		return "", fmt.Errorf("failed to get server version: %w", err)
+	}
+	return version, nil
+}
+
+// IsServerReadOnly reports whether the PostgreSQL server is a standby in recovery, which
+// rejects every write.
+func (p *PostgreSQL) IsServerReadOnly(ctx context.Context) (bool, error) {
+	if p.db == nil {
+		return false, fmt.Errorf("no database connection")
+	}
+
+	var readOnly bool
+	if err := p.QueryRow(ctx, "SELECT pg_is_in_recovery()").Scan(&readOnly); err != nil {
+		return false, fmt.Errorf("failed to get read-only state: %w", err)
+	}
+	return readOnly, nil
+}
+
+// GetServerInfo returns the PostgreSQL server version, encoding, current database and user,
+// the uptime since pg_postmaster_start_time() and the max_connections setting.
+func (p *PostgreSQL) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	if p.db == nil {
+		return nil, fmt.Errorf("no database connection")
+	}
+
+	query := `
+		SELECT
+			version(),
+			current_setting('server_encoding'),
+			current_database(),
+			current_user,
+			EXTRACT(EPOCH FROM now() - pg_postmaster_start_time())::bigint,
+			current_setting('max_connections')::int`
+
+	info := &ServerInfo{DatabaseType: "postgres"}
+	var uptime int64
+	err := p.QueryRow(ctx, query).Scan(
+		&info.ServerVersion, &info.ServerEncoding, &info.CurrentDatabase, &info.CurrentUser, &uptime, &info.MaxConnections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server settings: %w", err)
+	}
+	info.Uptime = (time.Duration(uptime) * time.Second).String()
+
+	return info, nil
+}
+
+// GetActiveConnections returns the non-idle sessions from pg_stat_activity, longest running
+// first, excluding the session running the query. Without superuser rights or the
+// pg_read_all_stats role, other users' statements are reported as "<insufficient privilege>".
+func (p *PostgreSQL) GetActiveConnections(ctx context.Context) ([]ActiveConnection, error) {
+	query := `
+		SELECT
+			pid,
+			COALESCE(usename, ''),
+			COALESCE(client_addr::text, client_hostname, ''),
+			COALESCE(datname, ''),
+			state,
+			COALESCE(query, ''),
+			COALESCE(EXTRACT(EPOCH FROM now() - query_start), 0)::float8
+		FROM pg_stat_activity
+		WHERE state <> 'idle' AND pid <> pg_backend_pid()
+		ORDER BY query_start`
+
+	rows, err := p.Query(ctx, query)
+	if err != nil {
+		if isPermissionDenied(err) {
+			return nil, fmt.Errorf("insufficient privileges to read pg_stat_activity (requires the pg_read_all_stats role): %w", err)
+		}
+		return nil, fmt.Errorf("failed to query pg_stat_activity: %w", err)
+	}
+	defer rows.Close()
+
+	return readActiveConnections(rows)
+}
+
+// KillConnection terminates the PostgreSQL backend with the given process ID using
+// pg_terminate_backend. Backends of other users require superuser or pg_signal_backend.
+func (p *PostgreSQL) KillConnection(ctx context.Context, pid int64) error {
+	lookup := "SELECT pid = pg_backend_pid() FROM pg_stat_activity WHERE pid = $1"
+	return killConnection(ctx, p.db, pid, lookup, func(conn *sql.Conn) error {
+		var terminated bool
+		if err := conn.QueryRowContext(ctx, "SELECT pg_terminate_backend($1)", pid).Scan(&terminated); err != nil {
+			return err
+		}
+		if !terminated {
+			return fmt.Errorf("the backend exited before it could be signalled")
+		}
+		return nil
+	}, "requires superuser or the pg_signal_backend role")
+}
+
+// GetServerSettings returns the PostgreSQL configuration parameters from pg_settings whose
+// name starts with prefix, with their unit, category and short description.
+func (p *PostgreSQL) GetServerSettings(ctx context.Context, prefix string) ([]ServerSetting, error) {
+	query := "SELECT name, COALESCE(setting, ''), unit, category, short_desc FROM pg_settings ORDER BY name"
+
+	rows, err := p.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pg_settings: %w", err)
+	}
+	defer rows.Close()
+
+	return readServerSettings(rows, prefix)
 }
 
 // ListTables returns a list of all table names in the current PostgreSQL database.
-// Queries the information_schema.tables view for tables in the 'public' schema.
+// Queries the information_schema.tables view for tables in the configured schema
+// (see schemaName); with every schema listed, names are qualified as "schema.table".
 func (p *PostgreSQL) ListTables(ctx context.Context) ([]string, error) {
-	query := `
-		SELECT table_name 
+	filter, args := p.schemaFilter(ctx, "table_schema", 1)
+	query := fmt.Sprintf(`
+		SELECT %s 
 		FROM information_schema.tables 
-		WHERE table_schema = 'public' AND table_type = 'BASE TABLE'
-		ORDER BY table_name`
+		WHERE %s AND table_type = 'BASE TABLE'
+		ORDER BY 1`, p.nameColumn(ctx, "table_schema", "table_name"), filter)
 
-	rows, err := p.Query(ctx, query)
+	rows, err := p.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tables: %w", err)
 	}
@@ -118,6 +310,104 @@ func (p *PostgreSQL) ListTables(ctx context.Context) ([]string, error) {
 	return tables, rows.Err()
 }
 
+// SearchTables returns the base tables in the configured schema whose name contains pattern, in
+// the configured database and every additional allowed database, ranked by relevance.
+func (p *PostgreSQL) SearchTables(ctx context.Context, pattern string) ([]string, error) {
+	filter, schemaArgs := p.schemaFilter(ctx, "table_schema", 2)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM information_schema.tables
+		WHERE %s AND table_type = 'BASE TABLE' AND table_name ILIKE $1`, p.nameColumn(ctx, "table_schema", "table_name"), filter)
+	args := append([]any{likeContains(pattern)}, schemaArgs...)
+
+	var matches []tableMatch
+	err := p.forEachSearchDatabase(ctx, func(database string, conn *PostgreSQL) error {
+		rows, err := conn.Query(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to search tables: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			match := tableMatch{database: database}
+			if err := rows.Scan(&match.table); err != nil {
+				return fmt.Errorf("failed to scan table name: %w", err)
+			}
+			matches = append(matches, match)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rankTableMatches(p.config, matches, pattern), nil
+}
+
+// SearchColumns returns the columns of the configured schema whose name contains pattern, in the
+// configured database and every additional allowed database, ranked by relevance.
+func (p *PostgreSQL) SearchColumns(ctx context.Context, pattern string) ([]ColumnSearchResult, error) {
+	filter, schemaArgs := p.schemaFilter(ctx, "table_schema", 2)
+	query := fmt.Sprintf(`
+		SELECT %s, column_name, data_type
+		FROM information_schema.columns
+		WHERE %s AND column_name ILIKE $1`, p.nameColumn(ctx, "table_schema", "table_name"), filter)
+	args := append([]any{likeContains(pattern)}, schemaArgs...)
+
+	var results []ColumnSearchResult
+	err := p.forEachSearchDatabase(ctx, func(database string, conn *PostgreSQL) error {
+		rows, err := conn.Query(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to search columns: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			result := ColumnSearchResult{Database: database}
+			if err := rows.Scan(&result.TableName, &result.ColumnName, &result.DataType); err != nil {
+				return fmt.Errorf("failed to scan column: %w", err)
+			}
+			results = append(results, result)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rankColumnMatches(results, pattern)
+	return results, nil
+}
+
+// forEachSearchDatabase calls fn for the configured database and each additional allowed database.
+// PostgreSQL catalogs only describe the connected database, so every other database is searched
+// through a short-lived connection with the same settings.
+func (p *PostgreSQL) forEachSearchDatabase(ctx context.Context, fn func(database string, conn *PostgreSQL) error) error {
+	for _, database := range searchDatabases(p.config) {
+		if database == p.config.Database {
+			if err := fn(database, p); err != nil {
+				return err
+			}
+			continue
+		}
+
+		cfg := p.config
+		cfg.Database = database
+		cfg.MaxConns, cfg.MaxIdleConns = 1, 1
+		conn := &PostgreSQL{config: cfg}
+		if err := conn.Connect(ctx); err != nil {
+			return fmt.Errorf("database %s: %w", database, err)
+		}
+
+		err := fn(database, conn)
+		conn.Close()
+		if err != nil {
+			return fmt.Errorf("database %s: %w", database, err)
+		}
+	}
+	return nil
+}
+
 // ListDatabases returns a list of all available database names on the PostgreSQL server.
 // Queries the pg_database system catalog, excluding template databases.
 func (p *PostgreSQL) ListDatabases(ctx context.Context) ([]string, error) {
@@ -145,6 +435,203 @@ func (p *PostgreSQL) ListDatabases(ctx context.Context) ([]string, error) {
 	return databases, rows.Err()
 }
 
+// ListViews returns a list of all view names in the current PostgreSQL database.
+// Queries the information_schema.views view for views in the configured schema.
+func (p *PostgreSQL) ListViews(ctx context.Context) ([]string, error) {
+	filter, args := p.schemaFilter(ctx, "table_schema", 1)
+	query := fmt.Sprintf(`
+		SELECT %s 
+		FROM information_schema.views 
+		WHERE %s
+		ORDER BY 1`, p.nameColumn(ctx, "table_schema", "table_name"), filter)
+
+	rows, err := p.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list views: %w", err)
+	}
+	defer rows.Close()
+
+	var views []string
+	for rows.Next() {
+		var viewName string
+		if err := rows.Scan(&viewName); err != nil {
+			return nil, fmt.Errorf("failed to scan view name: %w", err)
+		}
+		views = append(views, viewName)
+	}
+
+	return views, rows.Err()
+}
+
+// DescribeView returns the definition and exposed columns of the specified PostgreSQL view.
+// The definition is read from information_schema.views and the columns from information_schema.columns.
+func (p *PostgreSQL) DescribeView(ctx context.Context, viewName string) (*ViewSchema, error) {
+	view := &ViewSchema{
+		ViewName: viewName,
+		Columns:  []ColumnInfo{},
+	}
+
+	schemaName, name := p.splitTable(ctx, viewName)
+	definitionQuery := `
+		SELECT view_definition 
+		FROM information_schema.views 
+		WHERE table_schema = $2 AND table_name = $1`
+
+	var definition sql.NullString
+	if err := p.QueryRow(ctx, definitionQuery, name, schemaName).Scan(&definition); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("view %s not found", viewName)
+		}
+		return nil, fmt.Errorf("failed to get view definition: %w", err)
+	}
+	view.Definition = strings.TrimSpace(definition.String)
+
+	query := `
+		SELECT 
+			column_name,
+			data_type,
+			is_nullable,
+			column_default,
+			character_maximum_length
+		FROM information_schema.columns 
+		WHERE table_schema = $2 AND table_name = $1
+		ORDER BY ordinal_position`
+
+	rows, err := p.Query(ctx, query, name, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe view: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var column ColumnInfo
+		var nullable string
+		var defaultValue, maxLength sql.NullString
+
+		if err := rows.Scan(&column.Name, &column.Type, &nullable, &defaultValue, &maxLength); err != nil {
+			return nil, fmt.Errorf("failed to scan column info: %w", err)
+		}
+
+		column.IsNullable = nullable == "YES"
+
+		if defaultValue.Valid {
+			column.DefaultValue = &defaultValue.String
+		}
+
+		if maxLength.Valid {
+			if length, err := strconv.Atoi(maxLength.String); err == nil {
+				column.MaxLength = &length
+			}
+		}
+
+		view.Columns = append(view.Columns, column)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading column data: %w", err)
+	}
+
+	return view, nil
+}
+
+// postgresRoutinesQuery selects routines, one row per argument, in the column order expected
+// by readRoutines. The first %s is replaced by the routine definition expression and the
+// second by the filter conditions. Array and user-defined types are reported by
+// their underlying type name (e.g. _int4) instead of "ARRAY" or "USER-DEFINED".
+const postgresRoutinesQuery = `
+		SELECT 
+			r.specific_name,
+			r.routine_name,
+			COALESCE(r.routine_type, 'FUNCTION'),
+			COALESCE(r.external_language, ''),
+			COALESCE(CASE WHEN r.data_type IN ('ARRAY', 'USER-DEFINED') THEN r.type_udt_name ELSE r.data_type END, ''),
+			p.parameter_name,
+			p.parameter_mode,
+			CASE WHEN p.data_type IN ('ARRAY', 'USER-DEFINED') THEN p.udt_name ELSE p.data_type END,
+			%s
+		FROM information_schema.routines r
+		LEFT JOIN information_schema.parameters p
+			ON p.specific_schema = r.specific_schema
+			AND p.specific_name = r.specific_name
+		WHERE %s
+		ORDER BY r.routine_name, r.specific_name, p.ordinal_position`
+
+// ListFunctions returns the functions and procedures of the configured schema, read from
+// information_schema.routines and information_schema.parameters. Each overload of a
+// function is listed separately.
+func (p *PostgreSQL) ListFunctions(ctx context.Context) ([]FunctionInfo, error) {
+	filter, args := p.schemaFilter(ctx, "r.routine_schema", 1)
+	rows, err := p.Query(ctx, fmt.Sprintf(postgresRoutinesQuery, "NULL", filter), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list functions: %w", err)
+	}
+	defer rows.Close()
+
+	routines, err := readRoutines(rows)
+	if err != nil {
+		return nil, err
+	}
+	return functionInfos(routines), nil
+}
+
+// DescribeFunction returns the signature and body of the named PostgreSQL function or procedure.
+// routine_definition is only populated for routines owned by one of the current user's roles.
+// For an overloaded function, the first overload by specific name is described.
+func (p *PostgreSQL) DescribeFunction(ctx context.Context, name string) (*FunctionSchema, error) {
+	filter, schemaArgs := p.schemaFilter(ctx, "r.routine_schema", 2)
+	query := fmt.Sprintf(postgresRoutinesQuery, "r.routine_definition", filter+" AND r.routine_name = $1")
+	rows, err := p.Query(ctx, query, append([]any{name}, schemaArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe function: %w", err)
+	}
+	defer rows.Close()
+
+	routines, err := readRoutines(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(routines) == 0 {
+		return nil, fmt.Errorf("function %s not found", name)
+	}
+	return &routines[0], nil
+}
+
+// setTableActivity adds the times a table was last vacuumed and analyzed and the number of
+// rows modified since, from pg_stat_user_tables, to metadata. PostgreSQL does not record
+// when a table was created or last modified, so these serve as approximations; values the
+// statistics collector has not recorded, and tables it does not track, such as views, are
+// omitted.
+func (p *PostgreSQL) setTableActivity(ctx context.Context, schemaName, tableName string, metadata map[string]any) error {
+	query := `
+		SELECT
+			GREATEST(last_vacuum, last_autovacuum),
+			GREATEST(last_analyze, last_autoanalyze),
+			n_mod_since_analyze
+		FROM pg_stat_user_tables
+		WHERE schemaname = $1 AND relname = $2`
+
+	var lastVacuum, lastAnalyze sql.NullTime
+	var modified sql.NullInt64
+	err := p.QueryRow(ctx, query, schemaName, tableName).Scan(&lastVacuum, &lastAnalyze, &modified)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get table activity: %w", err)
+	}
+
+	if lastVacuum.Valid {
+		metadata["last_vacuumed"] = lastVacuum.Time.UTC().Format(time.RFC3339)
+	}
+	if lastAnalyze.Valid {
+		metadata["last_analyzed"] = lastAnalyze.Time.UTC().Format(time.RFC3339)
+	}
+	if modified.Valid {
+		metadata["rows_modified_since_analyze"] = modified.Int64
+	}
+	return nil
+}
+
 // DescribeTable returns detailed schema information about the specified PostgreSQL table.
 // It retrieves column definitions, data types, constraints, and index information
 // using the information_schema views and system catalogs.
@@ -156,6 +643,22 @@ func (p *PostgreSQL) DescribeTable(ctx context.Context, tableName string) (*Tabl
 		Metadata:  make(map[string]any),
 	}
 
+	schemaName, name := p.splitTable(ctx, tableName)
+
+	var tableType string
+	typeQuery := "SELECT table_type FROM information_schema.tables WHERE table_schema = $2 AND table_name = $1"
+	err := p.QueryRow(ctx, typeQuery, name, schemaName).Scan(&tableType)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get table type: %w", err)
+	}
+	if err == nil {
+		schema.Metadata["table_type"] = tableType
+		schema.Metadata["is_view"] = tableType == "VIEW"
+	}
+	if err := p.setTableActivity(ctx, schemaName, name, schema.Metadata); err != nil {
+		return nil, err
+	}
+
 	query := `
 		SELECT 
 			c.column_name,
@@ -169,14 +672,15 @@ func (p *PostgreSQL) DescribeTable(ctx context.Context, tableName string) (*Tabl
 		LEFT JOIN (
 			SELECT k.column_name
 			FROM information_schema.table_constraints t
-			JOIN information_schema.key_column_usage k ON t.constraint_name = k.constraint_name
+			JOIN information_schema.key_column_usage k
+				ON t.constraint_schema = k.constraint_schema AND t.constraint_name = k.constraint_name
 			WHERE t.constraint_type = 'PRIMARY KEY' 
-				AND t.table_name = $1 AND k.table_name = $1
+				AND t.table_schema = $2 AND t.table_name = $1 AND k.table_name = $1
 		) pk ON c.column_name = pk.column_name
-		WHERE c.table_name = $1 AND c.table_schema = 'public'
+		WHERE c.table_name = $1 AND c.table_schema = $2
 		ORDER BY c.ordinal_position`
 
-	rows, err := p.Query(ctx, query, tableName)
+	rows, err := p.Query(ctx, query, name, schemaName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe table: %w", err)
 	}
@@ -232,10 +736,11 @@ func (p *PostgreSQL) DescribeTable(ctx context.Context, tableName string) (*Tabl
 		JOIN pg_index ix ON t.oid = ix.indrelid
 		JOIN pg_class i ON i.oid = ix.indexrelid
 		JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ANY(ix.indkey)
-		WHERE t.relname = $1 AND t.relkind = 'r'
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		WHERE n.nspname = $2 AND t.relname = $1 AND t.relkind = 'r'
 		GROUP BY i.relname, ix.indisunique, ix.indisprimary`
 
-	indexRows, err := p.Query(ctx, indexQuery, tableName)
+	indexRows, err := p.Query(ctx, indexQuery, name, schemaName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get index info: %w", err)
 	}
@@ -256,26 +761,538 @@ func (p *PostgreSQL) DescribeTable(ctx context.Context, tableName string) (*Tabl
 		schema.Indexes = append(schema.Indexes, index)
 	}
 
+	foreignKeyQuery := `
+		SELECT 
+			rc.constraint_name,
+			kcu.column_name,
+			ccu.table_name AS referenced_table,
+			ccu.column_name AS referenced_column,
+			rc.delete_rule,
+			rc.update_rule
+		FROM information_schema.referential_constraints rc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_schema = rc.constraint_schema AND kcu.constraint_name = rc.constraint_name
+		JOIN information_schema.key_column_usage ccu
+			ON ccu.constraint_schema = rc.unique_constraint_schema
+			AND ccu.constraint_name = rc.unique_constraint_name
+			AND ccu.ordinal_position = kcu.position_in_unique_constraint
+		WHERE kcu.table_schema = $2 AND kcu.table_name = $1
+		ORDER BY rc.constraint_name, kcu.ordinal_position`
+
+	foreignKeyRows, err := p.Query(ctx, foreignKeyQuery, name, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get foreign key info: %w", err)
+	}
+	defer foreignKeyRows.Close()
+
+	for foreignKeyRows.Next() {
+		var constraintName, columnName, referencedTable, referencedColumn, onDelete, onUpdate string
+
+		err := foreignKeyRows.Scan(&constraintName, &columnName, &referencedTable, &referencedColumn, &onDelete, &onUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan foreign key info: %w", err)
+		}
+
+		schema.ForeignKeys = appendForeignKeyColumn(schema.ForeignKeys, constraintName, columnName,
+			referencedTable, referencedColumn, onDelete, onUpdate)
+	}
+
+	if err := foreignKeyRows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading foreign key data: %w", err)
+	}
+
+	schema.setKeyConstraints()
 	return schema, nil
 }
 
+// EstimateColumnStats returns approximate null fractions and distinct counts for the columns
+// of the specified PostgreSQL table, read from pg_stats. Negative n_distinct values are a
+// fraction of the row count and are scaled by pg_class.reltuples.
+func (p *PostgreSQL) EstimateColumnStats(ctx context.Context, tableName string) (map[string]ColumnStatsEstimate, error) {
+	query := `
+		SELECT 
+			s.attname,
+			s.null_frac,
+			s.n_distinct,
+			c.reltuples
+		FROM pg_stats s
+		JOIN pg_namespace n ON n.nspname = s.schemaname
+		JOIN pg_class c ON c.relname = s.tablename AND c.relnamespace = n.oid
+		WHERE s.schemaname = $2 AND s.tablename = $1`
+
+	schemaName, name := p.splitTable(ctx, tableName)
+	rows, err := p.Query(ctx, query, name, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column statistics: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make(map[string]ColumnStatsEstimate)
+	for rows.Next() {
+		var columnName string
+		var nullFraction, nDistinct, relTuples float64
+
+		if err := rows.Scan(&columnName, &nullFraction, &nDistinct, &relTuples); err != nil {
+			return nil, fmt.Errorf("failed to scan column statistics: %w", err)
+		}
+
+		estimate := ColumnStatsEstimate{NullFraction: &nullFraction}
+		if nDistinct >= 0 {
+			distinct := int64(nDistinct)
+			estimate.DistinctCount = &distinct
+		} else if relTuples > 0 {
+			distinct := int64(-nDistinct * relTuples)
+			estimate.DistinctCount = &distinct
+		}
+
+		stats[columnName] = estimate
+	}
+
+	return stats, rows.Err()
+}
+
+// GetColumnStatistics profiles every column of the specified PostgreSQL table. Columns are read
+// from information_schema and all aggregates are computed in a single scan of the table,
+// which is refused when the table exceeds the configured DB_STATISTICS_MAX_ROWS threshold.
+func (p *PostgreSQL) GetColumnStatistics(ctx context.Context, tableName string) ([]ColumnStatistics, error) {
+	columns, err := p.statisticsColumns(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	dialect := statisticsSQL{quote: quotePostgresIdentifier, length: "LENGTH", text: "TEXT"}
+	return collectColumnStatistics(ctx, p, dialect, p.quoteTable(ctx, tableName), tableName, columns, p.config.StatisticsMaxRows)
+}
+
+// ProfileTable profiles every column of the specified PostgreSQL table, with the aggregates and
+// the most common values of all columns computed in two queries. Tables with more rows than
+// the configured DB_PROFILE_MAX_ROWS threshold are refused.
+func (p *PostgreSQL) ProfileTable(ctx context.Context, tableName string, maxDistinctValues int) (*TableProfile, error) {
+	columns, err := p.statisticsColumns(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	dialect := statisticsSQL{quote: quotePostgresIdentifier, length: "LENGTH", text: "TEXT"}
+	return profileTable(ctx, p, dialect, p.quoteTable(ctx, tableName), tableName, columns, p.config.ProfileMaxRows, maxDistinctValues)
+}
+
+// statisticsColumns reads the columns of the specified table from information_schema and
+// classifies them for profiling.
+func (p *PostgreSQL) statisticsColumns(ctx context.Context, tableName string) ([]statisticsColumn, error) {
+	query := `
+		SELECT column_name, data_type
+		FROM information_schema.columns
+		WHERE table_schema = $2 AND table_name = $1
+		ORDER BY ordinal_position`
+
+	schemaName, name := p.splitTable(ctx, tableName)
+	rows, err := p.Query(ctx, query, name, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []statisticsColumn
+	for rows.Next() {
+		var columnName, dataType string
+		if err := rows.Scan(&columnName, &dataType); err != nil {
+			return nil, fmt.Errorf("failed to scan column info: %w", err)
+		}
+		columns = append(columns, newStatisticsColumn(columnName, dataType))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading column data: %w", err)
+	}
+	return columns, nil
+}
+
+// GetCreateStatement reconstructs the CREATE TABLE statement for the specified PostgreSQL table,
+// which has no SHOW CREATE TABLE. Columns come from information_schema.columns, table constraints from pg_constraint and
+// secondary indexes from pg_indexes; indexes that back a constraint are not repeated.
+func (p *PostgreSQL) GetCreateStatement(ctx context.Context, tableName string) (string, error) {
+	columnQuery := `
+		SELECT
+			column_name,
+			data_type,
+			udt_name,
+			is_nullable,
+			column_default,
+			character_maximum_length,
+			numeric_precision,
+			numeric_scale
+		FROM information_schema.columns
+		WHERE table_schema = $2 AND table_name = $1
+		ORDER BY ordinal_position`
+
+	schemaName, name := p.splitTable(ctx, tableName)
+	rows, err := p.Query(ctx, columnQuery, name, schemaName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get columns: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []ddlColumn
+	for rows.Next() {
+		var column ddlColumn
+		var dataType, udtName, nullable string
+		var defaultValue sql.NullString
+		var maxLength, precision, scale sql.NullInt64
+
+		if err := rows.Scan(&column.name, &dataType, &udtName, &nullable, &defaultValue, &maxLength, &precision, &scale); err != nil {
+			return "", fmt.Errorf("failed to scan column info: %w", err)
+		}
+
+		column.dataType = postgresColumnType(dataType, udtName, maxLength, precision, scale)
+		column.nullable = nullable == "YES"
+		if defaultValue.Valid {
+			column.defaultValue = &defaultValue.String
+		}
+		columns = append(columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("error reading column data: %w", err)
+	}
+	if len(columns) == 0 {
+		return "", fmt.Errorf("table %s not found", tableName)
+	}
+
+	constraintQuery := `
+		SELECT c.conname, pg_get_constraintdef(c.oid)
+		FROM pg_constraint c
+		JOIN pg_class t ON t.oid = c.conrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		WHERE n.nspname = $2 AND t.relname = $1 AND c.contype IN ('p', 'u', 'c', 'f', 'x')
+		ORDER BY CASE c.contype WHEN 'p' THEN 0 WHEN 'u' THEN 1 WHEN 'c' THEN 2 WHEN 'x' THEN 3 ELSE 4 END, c.conname`
+
+	constraintRows, err := p.Query(ctx, constraintQuery, name, schemaName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get constraints: %w", err)
+	}
+	defer constraintRows.Close()
+
+	var constraints []string
+	for constraintRows.Next() {
+		var name, definition string
+		if err := constraintRows.Scan(&name, &definition); err != nil {
+			return "", fmt.Errorf("failed to scan constraint: %w", err)
+		}
+		constraints = append(constraints, fmt.Sprintf("CONSTRAINT %s %s", quotePostgresIdentifier(name), definition))
+	}
+	if err := constraintRows.Err(); err != nil {
+		return "", fmt.Errorf("error reading constraint data: %w", err)
+	}
+
+	indexQuery := `
+		SELECT i.indexdef
+		FROM pg_indexes i
+		WHERE i.schemaname = $2 AND i.tablename = $1
+			AND NOT EXISTS (
+				SELECT 1 FROM pg_constraint c
+				JOIN pg_class t ON t.oid = c.conrelid
+				JOIN pg_namespace n ON n.oid = t.relnamespace
+				WHERE c.conname = i.indexname AND t.relname = i.tablename AND n.nspname = i.schemaname
+			)
+		ORDER BY i.indexname`
+
+	indexRows, err := p.Query(ctx, indexQuery, name, schemaName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get indexes: %w", err)
+	}
+	defer indexRows.Close()
+
+	var indexes []string
+	for indexRows.Next() {
+		var definition string
+		if err := indexRows.Scan(&definition); err != nil {
+			return "", fmt.Errorf("failed to scan index: %w", err)
+		}
+		indexes = append(indexes, definition)
+	}
+	if err := indexRows.Err(); err != nil {
+		return "", fmt.Errorf("error reading index data: %w", err)
+	}
+
+	return buildCreateTableDDL(quotePostgresIdentifier, tableName, columns, constraints, indexes), nil
+}
+
+// GetConstraints returns the constraints of the specified table, read
+// from information_schema.table_constraints. CHECK clauses come from check_constraints and
+// their columns from constraint_column_usage.
+func (p *PostgreSQL) GetConstraints(ctx context.Context, tableName string) ([]ConstraintInfo, error) {
+	query := `
+		SELECT
+			tc.constraint_name,
+			tc.constraint_type,
+			COALESCE(kcu.column_name, ccu.column_name, ''),
+			COALESCE(cc.check_clause, '')
+		FROM information_schema.table_constraints tc
+		LEFT JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_schema = tc.constraint_schema
+			AND kcu.constraint_name = tc.constraint_name
+			AND kcu.table_schema = tc.table_schema
+			AND kcu.table_name = tc.table_name
+		LEFT JOIN information_schema.constraint_column_usage ccu
+			ON tc.constraint_type = 'CHECK'
+			AND ccu.constraint_schema = tc.constraint_schema
+			AND ccu.constraint_name = tc.constraint_name
+		LEFT JOIN information_schema.check_constraints cc
+			ON cc.constraint_schema = tc.constraint_schema AND cc.constraint_name = tc.constraint_name
+		WHERE tc.table_schema = $2 AND tc.table_name = $1
+		ORDER BY tc.constraint_name, kcu.ordinal_position, ccu.column_name`
+
+	schemaName, name := p.splitTable(ctx, tableName)
+	rows, err := p.Query(ctx, query, name, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get constraints: %w", err)
+	}
+	defer rows.Close()
+
+	var constraints []ConstraintInfo
+	for rows.Next() {
+		var name, constraintType, column, checkClause string
+		if err := rows.Scan(&name, &constraintType, &column, &checkClause); err != nil {
+			return nil, fmt.Errorf("failed to scan constraint info: %w", err)
+		}
+		constraints = appendConstraintColumn(constraints, name, constraintType, column, checkClause)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading constraint data: %w", err)
+	}
+
+	sortConstraints(constraints)
+	return constraints, nil
+}
+
+// ListForeignKeys returns all foreign key relationships between tables in the configured schema.
+func (p *PostgreSQL) ListForeignKeys(ctx context.Context) ([]ForeignKeyRelationship, error) {
+	filter, args := p.schemaFilter(ctx, "kcu.table_schema", 1)
+	query := fmt.Sprintf(`
+		SELECT 
+			rc.constraint_name,
+			%s,
+			kcu.column_name,
+			%s AS referenced_table,
+			ccu.column_name AS referenced_column,
+			rc.delete_rule,
+			rc.update_rule
+		FROM information_schema.referential_constraints rc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_schema = rc.constraint_schema AND kcu.constraint_name = rc.constraint_name
+		JOIN information_schema.key_column_usage ccu
+			ON ccu.constraint_schema = rc.unique_constraint_schema
+			AND ccu.constraint_name = rc.unique_constraint_name
+			AND ccu.ordinal_position = kcu.position_in_unique_constraint
+		WHERE %s
+		ORDER BY 2, rc.constraint_name, kcu.ordinal_position`,
+		p.nameColumn(ctx, "kcu.table_schema", "kcu.table_name"), p.nameColumn(ctx, "ccu.table_schema", "ccu.table_name"), filter)
+
+	rows, err := p.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	var relationships []ForeignKeyRelationship
+	for rows.Next() {
+		var constraintName, fromTable, fromColumn, toTable, toColumn, onDelete, onUpdate string
+
+		err := rows.Scan(&constraintName, &fromTable, &fromColumn, &toTable, &toColumn, &onDelete, &onUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan foreign key info: %w", err)
+		}
+
+		relationships = appendForeignKeyRelationship(relationships, constraintName, fromTable, fromColumn,
+			toTable, toColumn, onDelete, onUpdate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading foreign key data: %w", err)
+	}
+
+	return relationships, nil
+}
+
+// GetTableSize returns the storage used by the specified PostgreSQL table. The data size is
+// pg_relation_size of the table itself, while the total from pg_total_relation_size also
+// counts indexes and TOAST data. The row estimate is pg_class.reltuples.
+func (p *PostgreSQL) GetTableSize(ctx context.Context, tableName string) (*TableSizeInfo, error) {
+	query := fmt.Sprintf(postgresTableSizeQuery, "c.relname", "n.nspname = $2") + " AND c.relname = $1"
+
+	var size TableSizeInfo
+	schemaName, name := p.splitTable(ctx, tableName)
+	err := p.QueryRow(ctx, query, name, schemaName).Scan(
+		&size.TableName, &size.DataSizeBytes, &size.IndexSizeBytes, &size.TotalSizeBytes, &size.RowEstimate)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("table %s not found", tableName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table size: %w", err)
+	}
+
+	size.TableName = tableName
+	return &size, nil
+}
+
+// ListTableSizes returns the storage used by every table in the configured schema, largest first.
+func (p *PostgreSQL) ListTableSizes(ctx context.Context) ([]TableSizeInfo, error) {
+	filter, args := p.schemaFilter(ctx, "n.nspname", 1)
+	query := fmt.Sprintf(postgresTableSizeQuery, p.nameColumn(ctx, "n.nspname", "c.relname"), filter)
+	rows, err := p.Query(ctx, query+" ORDER BY 4 DESC, 1", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list table sizes: %w", err)
+	}
+	defer rows.Close()
+
+	var sizes []TableSizeInfo
+	for rows.Next() {
+		var size TableSizeInfo
+		if err := rows.Scan(&size.TableName, &size.DataSizeBytes, &size.IndexSizeBytes, &size.TotalSizeBytes, &size.RowEstimate); err != nil {
+			return nil, fmt.Errorf("failed to scan table size: %w", err)
+		}
+		sizes = append(sizes, size)
+	}
+
+	return sizes, rows.Err()
+}
+
+// GetTableBloat returns dead tuple counts and vacuum history from pg_stat_user_tables for the
+// specified table, or for every table in the configured schema when tableName is empty, with
+// the most dead tuples first.
+func (p *PostgreSQL) GetTableBloat(ctx context.Context, tableName string) ([]TableBloatStats, error) {
+	name := p.nameColumn(ctx, "schemaname", "relname")
+	filter, args := p.schemaFilter(ctx, "schemaname", 1)
+	if tableName != "" {
+		schemaName, table := p.splitTable(ctx, tableName)
+		name, filter, args = "relname", "schemaname = $1 AND relname = $2", []any{schemaName, table}
+	}
+	query := fmt.Sprintf(`
+		SELECT %s, n_live_tup, n_dead_tup, last_vacuum, last_autovacuum
+		FROM pg_stat_user_tables
+		WHERE %s
+		ORDER BY n_dead_tup DESC, 1`, name, filter)
+
+	rows, err := p.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table bloat statistics: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []TableBloatStats
+	for rows.Next() {
+		var table TableBloatStats
+		var lastVacuum, lastAutovacuum sql.NullTime
+		if err := rows.Scan(&table.TableName, &table.LiveTuples, &table.DeadTuples, &lastVacuum, &lastAutovacuum); err != nil {
+			return nil, fmt.Errorf("failed to scan table bloat statistics: %w", err)
+		}
+		if lastVacuum.Valid {
+			table.LastVacuum = &lastVacuum.Time
+		}
+		if lastAutovacuum.Valid {
+			table.LastAutovacuum = &lastAutovacuum.Time
+		}
+		stats = append(stats, table)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if tableName != "" && len(stats) == 0 {
+		return nil, fmt.Errorf("table %s not found", tableName)
+	}
+	return stats, nil
+}
+
+// GetIndexUsage returns the scan counts and sizes of the indexes in the configured schema from
+// pg_stat_user_indexes, joined with pg_indexes for the index definition, least scanned and
+// then largest first.
+func (p *PostgreSQL) GetIndexUsage(ctx context.Context) ([]IndexUsageInfo, error) {
+	name := p.nameColumn(ctx, "s.schemaname", "s.relname")
+	filter, args := p.schemaFilter(ctx, "s.schemaname", 1)
+	query := fmt.Sprintf(`
+		SELECT %s, s.indexrelname, s.idx_scan, s.idx_tup_read, s.idx_tup_fetch,
+			pg_relation_size(s.indexrelid), i.indexdef LIKE 'CREATE UNIQUE%%'
+		FROM pg_stat_user_indexes s
+		JOIN pg_indexes i ON i.schemaname = s.schemaname AND i.tablename = s.relname AND i.indexname = s.indexrelname
+		WHERE %s
+		ORDER BY s.idx_scan, 6 DESC, 1, 2`, name, filter)
+
+	rows, err := p.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index usage statistics: %w", err)
+	}
+	defer rows.Close()
+
+	var usage []IndexUsageInfo
+	for rows.Next() {
+		var index IndexUsageInfo
+		if err := rows.Scan(&index.TableName, &index.IndexName, &index.IndexScans, &index.TuplesRead,
+			&index.TuplesFetched, &index.SizeBytes, &index.IsUnique); err != nil {
+			return nil, fmt.Errorf("failed to scan index usage statistics: %w", err)
+		}
+		usage = append(usage, index)
+	}
+	return usage, rows.Err()
+}
+
+// GetColumnValues returns up to limit distinct values of a column in the specified PostgreSQL
+// table, sorted, with the number of distinct values and whether the list was truncated.
+func (p *PostgreSQL) GetColumnValues(ctx context.Context, tableName, columnName string, limit int) (*ColumnValues, error) {
+	return collectColumnValues(ctx, p, quotePostgresIdentifier, postgresPlaceholder, p.quoteTable(ctx, tableName), tableName, columnName, limit)
+}
+
 // GetTableData retrieves data from the specified PostgreSQL table with pagination support.
 // If limit is 0 or negative, it defaults to 100 rows. The method also returns
-// the total row count for pagination purposes.
-func (p *PostgreSQL) GetTableData(ctx context.Context, tableName string, limit int, offset int) (*TableData, error) {
+// the total row count, restricted by opts.Filter when one is given, for pagination purposes.
+// With opts.EstimateCount and no filter, the total is taken from the table statistics instead.
+func (p *PostgreSQL) GetTableData(ctx context.Context, tableName string, limit int, offset int, opts TableDataOptions) (*TableData, error) {
 	if limit <= 0 {
 		limit = 100
 	}
 
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM \"%s\"", tableName)
-	var total int
-	err := p.QueryRow(ctx, countQuery).Scan(&total)
+	where, args, err := opts.Filter.whereClause(quotePostgresIdentifier, postgresPlaceholder)
 	if err != nil {
-		return nil, fmt.Errorf("failed to count rows: %w", err)
+		return nil, err
 	}
 
-	query := fmt.Sprintf("SELECT * FROM \"%s\" LIMIT $1 OFFSET $2", tableName)
-	rows, err := p.Query(ctx, query, limit, offset)
+	// reltuples is -1 for tables that have never been vacuumed or analyzed, in which
+	// case there is no estimate and the rows are counted exactly
+	total := -1
+	if opts.EstimateCount && opts.Filter == nil {
+		schemaName, name := p.splitTable(ctx, tableName)
+		err = p.QueryRow(ctx, postgresRowEstimateQuery, name, schemaName).Scan(&total)
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("table %s not found", tableName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate row count: %w", err)
+		}
+	}
+	estimated := total >= 0
+
+	if !estimated {
+		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s%s", p.quoteTable(ctx, tableName), where)
+		err = p.QueryRow(ctx, countQuery, args...).Scan(&total)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count rows: %w", err)
+		}
+	}
+
+	// The total counts every matching row, not just those after the cursor
+	after, afterArgs, err := opts.cursorClause(quotePostgresIdentifier, postgresPlaceholder, len(args)+1)
+	if err != nil {
+		return nil, err
+	}
+	if after != "" {
+		where = joinCondition(where, after)
+		args = append(args, afterArgs...)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s%s%s LIMIT %s OFFSET %s", opts.selectList(quotePostgresIdentifier), p.quoteTable(ctx, tableName), where,
+		opts.orderClause(quotePostgresIdentifier),
+		postgresPlaceholder(len(args)+1), postgresPlaceholder(len(args)+2))
+	rows, err := p.Query(ctx, query, append(args, limit, offset)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query table data: %w", err)
 	}
@@ -286,6 +1303,13 @@ func (p *PostgreSQL) GetTableData(ctx context.Context, tableName string, limit i
 		return nil, fmt.Errorf("failed to get columns: %w", err)
 	}
 
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column types: %w", err)
+	}
+	// The output is validated when configuration is loaded
+	booleans, _ := config.ParseBooleanOutput(p.config.BooleanOutput)
+
 	data := &TableData{
 		TableName: tableName,
 		Columns:   columns,
@@ -293,8 +1317,11 @@ func (p *PostgreSQL) GetTableData(ctx context.Context, tableName string, limit i
 		Total:     total,
 		Limit:     limit,
 		Offset:    offset,
+
+		CountIsEstimate: estimated,
 	}
 
+	size := 0
 	for rows.Next() {
 		values := make([]any, len(columns))
 		valuePtrs := make([]any, len(columns))
@@ -306,10 +1333,20 @@ func (p *PostgreSQL) GetTableData(ctx context.Context, tableName string, limit i
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
+		if limit := p.config.MaxResultBytes; limit > 0 {
+			if size += RowSize(values); size > limit {
+				return nil, ResultSizeError(limit)
+			}
+		}
 
 		row := make(map[string]any)
 		for i, col := range columns {
-			if values[i] != nil {
+			if p.config.TrimStrings {
+				values[i] = TrimString(values[i], columnTypes[i].DatabaseTypeName())
+			}
+			if booleans != config.BooleanNative && IsBooleanType(columnTypes[i].DatabaseTypeName()) {
+				row[col] = NormalizeBoolean(values[i], booleans)
+			} else if values[i] != nil {
 				row[col] = values[i]
 			} else {
 				row[col] = nil
@@ -317,8 +1354,14 @@ func (p *PostgreSQL) GetTableData(ctx context.Context, tableName string, limit i
 		}
 		data.Rows = append(data.Rows, row)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return data, rows.Err()
+	if data.NextCursor, err = opts.nextCursor(data.Rows, limit); err != nil {
+		return nil, err
+	}
+	return data, nil
 }
 
 // ExplainQuery returns the execution plan for the given SQL query in JSON format.
@@ -333,6 +1376,21 @@ func (p *PostgreSQL) ExplainQuery(ctx context.Context, query string) (string, er
 	return result, nil
 }
 
+// ExplainAnalyzeQuery executes the given SQL query and returns its execution plan in
+// JSON format, including actual row counts and timings.
+func (p *PostgreSQL) ExplainAnalyzeQuery(ctx context.Context, query string) (string, error) {
+	explainQuery, args, err := applyRowFilters(&p.config, fmt.Sprintf("EXPLAIN (ANALYZE, FORMAT JSON) %s", query), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to explain analyze query: %w", err)
+	}
+	explainQuery = applyStatementPrefix(p.config.StatementPrefix, explainQuery)
+	result, err := explainAnalyze(ctx, p.db, p.config.IsolationLevel, explainQuery, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to explain analyze query: %w", err)
+	}
+	return result, nil
+}
+
 // GetDB returns the underlying *sql.DB instance for direct database operations.
 // Returns nil if no connection has been established.
 func (p *PostgreSQL) GetDB() *sql.DB {
@@ -349,12 +1407,33 @@ func (p *PostgreSQL) GetDriverName() string {
 // It includes SSL configuration, timeout settings, and other connection parameters
 // required for establishing a secure and reliable PostgreSQL connection.
 func (p *PostgreSQL) buildDSN() string {
+	return p.dsn(p.config.Password)
+}
+
+// SafeDSN returns the connection string with the password masked, for use in logs and
+// error messages.
+func (p *PostgreSQL) SafeDSN() string {
+	return p.dsn(maskPassword(p.config.Password))
+}
+
+// quoteDSNValue quotes a key=value connection string value that contains spaces, quotes
+// or backslashes, as lib/pq expects; other values are returned unchanged.
+func quoteDSNValue(value string) string {
+	if !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return "'" + strings.ReplaceAll(value, "'", `\'`) + "'"
+}
+
+// dsn constructs the connection string using the given password.
+func (p *PostgreSQL) dsn(password string) string {
 	var params []string
 
 	params = append(params, fmt.Sprintf("host=%s", p.config.Host))
 	params = append(params, fmt.Sprintf("port=%d", p.config.Port))
 	params = append(params, fmt.Sprintf("user=%s", p.config.Username))
-	params = append(params, fmt.Sprintf("password=%s", p.config.Password))
+	params = append(params, fmt.Sprintf("password=%s", password))
 	params = append(params, fmt.Sprintf("dbname=%s", p.config.Database))
 
 	// Handle SSL mode using common SSL configuration
@@ -367,6 +1446,35 @@ func (p *PostgreSQL) buildDSN() string {
 	postgresSSLMode, _ := sslMode.ToPostgreSQLSSLMode()
 	params = append(params, fmt.Sprintf("sslmode=%s", postgresSSLMode))
 
+	// With a root certificate, lib/pq verifies the server certificate even in require mode
+	for _, file := range []struct{ key, path string }{
+		{"sslcert", p.config.SSLCert},
+		{"sslkey", p.config.SSLKey},
+		{"sslrootcert", p.config.SSLRootCert},
+	} {
+		if file.path != "" {
+			params = append(params, fmt.Sprintf("%s=%s", file.key, quoteDSNValue(file.path)))
+		}
+	}
+
+	// Read-only mode is also enforced by the server, which rejects writes that pass the
+	// validator, such as SELECT INTO or nextval() in a SELECT
+	if p.config.ReadOnly {
+		params = append(params, "default_transaction_read_only=on")
+	}
+
+	// Row filter parameters are also set as row_filter.<name> session settings, so that
+	// row-level security policies can enforce the filters where the rewriting cannot
+	// reach, such as inside views and functions
+	filterParams, _ := config.ParseRowFilterParams(p.config.RowFilterParams)
+	for _, name := range slices.Sorted(maps.Keys(filterParams)) {
+		value := quoteDSNValue(fmt.Sprint(filterParams[name]))
+		if value == "" {
+			value = "''"
+		}
+		params = append(params, fmt.Sprintf("row_filter.%s=%s", name, value))
+	}
+
 	params = append(params, "connect_timeout=30")
 
 	return strings.Join(params, " ")
@@ -2,9 +2,14 @@ package database
 
 import (
 	"context"
+	"database/sql/driver"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/jhoffmann/go-database-mcp/internal/config"
+
+	"github.com/lib/pq"
 )
 
 func TestNewPostgreSQL(t *testing.T) {
@@ -189,6 +194,73 @@ func TestPostgreSQL_buildDSN(t *testing.T) {
 				"sslmode=require",
 			},
 		},
+		{
+			name: "with SSL verify-ca",
+			config: config.DatabaseConfig{
+				Type:     "postgres",
+				Host:     "localhost",
+				Port:     5432,
+				Database: "testdb",
+				Username: "user",
+				Password: "pass",
+				SSLMode:  "verify-ca",
+			},
+			contains: []string{"sslmode=verify-ca"},
+		},
+		{
+			name: "with SSL verify-full",
+			config: config.DatabaseConfig{
+				Type:     "postgres",
+				Host:     "localhost",
+				Port:     5432,
+				Database: "testdb",
+				Username: "user",
+				Password: "pass",
+				SSLMode:  "verify-full",
+			},
+			contains: []string{"sslmode=verify-full"},
+		},
+		{
+			name: "read-only session",
+			config: config.DatabaseConfig{
+				Type:     "postgres",
+				Host:     "localhost",
+				Port:     5432,
+				Database: "testdb",
+				Username: "user",
+				Password: "pass",
+				ReadOnly: true,
+			},
+			contains: []string{"default_transaction_read_only=on"},
+		},
+		{
+			name: "row filter parameters as session settings",
+			config: config.DatabaseConfig{
+				Type:            "postgres",
+				Host:            "localhost",
+				Port:            5432,
+				Database:        "testdb",
+				Username:        "user",
+				Password:        "pass",
+				RowFilters:      "orders: tenant_id = :tenant",
+				RowFilterParams: "tenant=42; region=eu west",
+			},
+			contains: []string{"row_filter.region='eu west'", "row_filter.tenant=42"},
+		},
+		{
+			name: "with DB_SSL_VERIFY",
+			config: config.DatabaseConfig{
+				Type:      "postgres",
+				Host:      "localhost",
+				Port:      5432,
+				Database:  "testdb",
+				Username:  "user",
+				Password:  "pass",
+				SSLMode:   "prefer",
+				SSLVerify: true,
+			},
+			contains: []string{"sslmode=verify-full"},
+		},
 		{
 			name: "custom host and port",
 			config: config.DatabaseConfig{
@@ -326,3 +398,267 @@ func TestPostgreSQL_buildDSN_AllParameters(t *testing.T) {
 		}
 	}
 }
+
+func TestPostgreSQL_GetTableBloat_Query(t *testing.T) {
+	sqlDB, recorder := NewRecordingDB()
+	defer sqlDB.Close()
+
+	db := &PostgreSQL{db: sqlDB, config: NewTestConfig("postgres")}
+
+	// The mock driver returns no rows, so only the issued statement is checked
+	_, _ = db.GetTableBloat(context.Background(), "users")
+
+	queries := recorder.Queries()
+	if len(queries) != 1 || !contains(queries[0], "FROM pg_stat_user_tables") || !contains(queries[0], "n_dead_tup") {
+		t.Errorf("Expected a pg_stat_user_tables query, got %v", queries)
+	}
+}
+
+func TestPostgreSQL_GetIndexUsage(t *testing.T) {
+	var issued string
+	sqlDB := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+		issued = query
+		return []string{"relname", "indexrelname", "idx_scan", "idx_tup_read", "idx_tup_fetch", "size", "is_unique"},
+			[][]driver.Value{{"orders", "orders_legacy_idx", int64(0), int64(0), int64(0), int64(8192), false}}
+	})
+	defer sqlDB.Close()
+
+	db := &PostgreSQL{db: sqlDB, config: NewTestConfig("postgres")}
+	usage, err := db.GetIndexUsage(context.Background())
+	if err != nil {
+		t.Fatalf("GetIndexUsage() error = %v", err)
+	}
+
+	for _, part := range []string{"FROM pg_stat_user_indexes s", "JOIN pg_indexes i", "pg_relation_size(s.indexrelid)", "s.schemaname = $1"} {
+		if !contains(issued, part) {
+			t.Errorf("Expected %q in query, got %s", part, issued)
+		}
+	}
+	want := IndexUsageInfo{TableName: "orders", IndexName: "orders_legacy_idx", SizeBytes: 8192}
+	if len(usage) != 1 || usage[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, usage)
+	}
+}
+
+func TestPostgreSQL_buildDSN_ClientCertificates(t *testing.T) {
+	cfg := NewTestConfig("postgres")
+	cfg.SSLMode = "require"
+	cfg.SSLCert = "/etc/mcp/client.crt"
+	cfg.SSLKey = "/etc/mcp/client.key"
+	cfg.SSLRootCert = "/etc/mcp/My CA's.crt"
+
+	dsn := (&PostgreSQL{config: cfg}).buildDSN()
+	for _, part := range []string{
+		"sslmode=require",
+		"sslcert=/etc/mcp/client.crt",
+		"sslkey=/etc/mcp/client.key",
+		`sslrootcert='/etc/mcp/My CA\'s.crt'`,
+	} {
+		if !contains(dsn, part) {
+			t.Errorf("DSN = %q, expected to contain %q", dsn, part)
+		}
+	}
+
+	// Without certificate files, no parameters are added
+	if dsn := (&PostgreSQL{config: NewTestConfig("postgres")}).buildDSN(); contains(dsn, "sslcert") || contains(dsn, "sslrootcert") {
+		t.Errorf("DSN = %q, expected no certificate parameters", dsn)
+	}
+}
+
+func TestPostgreSQL_SafeDSN(t *testing.T) {
+	cfg := config.DatabaseConfig{
+		Type:     "postgres",
+		Host:     "localhost",
+		Port:     5432,
+		Database: "testdb",
+		Username: "user",
+		Password: "s3cr3t",
+	}
+
+	pg, err := NewPostgreSQL(cfg)
+	if err != nil {
+		t.Fatalf("NewPostgreSQL() error = %v", err)
+	}
+
+	safe := pg.SafeDSN()
+	if contains(safe, "s3cr3t") {
+		t.Errorf("SafeDSN() = %q, should not contain the password", safe)
+	}
+	if want := strings.Replace(pg.buildDSN(), "s3cr3t", "****", 1); safe != want {
+		t.Errorf("SafeDSN() = %q, want %q", safe, want)
+	}
+
+	cfg.Password = ""
+	pg, _ = NewPostgreSQL(cfg)
+	if safe := pg.SafeDSN(); safe != pg.buildDSN() {
+		t.Errorf("SafeDSN() = %q, want the DSN unchanged without a password", safe)
+	}
+}
+
+func TestPostgreSQL_GetServerInfo_Query(t *testing.T) {
+	sqlDB, recorder := NewRecordingDB()
+	defer sqlDB.Close()
+
+	db := &PostgreSQL{db: sqlDB, config: NewTestConfig("postgres")}
+
+	// The mock driver returns no rows, so only the issued statement is checked
+	if _, err := db.GetServerInfo(context.Background()); err == nil {
+		t.Error("Expected an error without a result row")
+	}
+
+	queries := recorder.Queries()
+	if len(queries) != 1 {
+		t.Fatalf("Expected one query, got %v", queries)
+	}
+	for _, part := range []string{"version()", "current_database()", "current_user", "pg_postmaster_start_time()", "'server_encoding'", "'max_connections'"} {
+		if !contains(queries[0], part) {
+			t.Errorf("Expected %q in query, got %s", part, queries[0])
+		}
+	}
+}
+
+func TestPostgreSQL_IsServerReadOnly(t *testing.T) {
+	var issued string
+	sqlDB := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+		issued = query
+		return []string{"read_only"}, [][]driver.Value{{true}}
+	})
+	defer sqlDB.Close()
+
+	db := &PostgreSQL{db: sqlDB, config: NewTestConfig("postgres")}
+	readOnly, err := db.IsServerReadOnly(context.Background())
+	if err != nil {
+		t.Fatalf("IsServerReadOnly() error = %v", err)
+	}
+	if issued != "SELECT pg_is_in_recovery()" {
+		t.Errorf("Expected query %q, got %q", "SELECT pg_is_in_recovery()", issued)
+	}
+	if !readOnly {
+		t.Error("Expected the server to be reported as read-only")
+	}
+}
+
+func TestPostgreSQL_GetServerVersion(t *testing.T) {
+	var issued string
+	sqlDB := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+		issued = query
+		return []string{"version"}, [][]driver.Value{{"PostgreSQL 16.2 on x86_64-pc-linux-gnu"}}
+	})
+	defer sqlDB.Close()
+
+	db := &PostgreSQL{db: sqlDB, config: NewTestConfig("postgres")}
+	version, err := db.GetServerVersion(context.Background())
+	if err != nil {
+		t.Fatalf("GetServerVersion() error = %v", err)
+	}
+	if issued != "SELECT version()" {
+		t.Errorf("Expected query %q, got %q", "SELECT version()", issued)
+	}
+	if version != "PostgreSQL 16.2 on x86_64-pc-linux-gnu" {
+		t.Errorf("Expected version %q, got %q", "PostgreSQL 16.2 on x86_64-pc-linux-gnu", version)
+	}
+}
+
+func TestPostgreSQL_GetServerInfo_BeforeConnect(t *testing.T) {
+	db, _ := NewPostgreSQL(NewTestConfig("postgres"))
+	if _, err := db.GetServerInfo(context.Background()); err == nil {
+		t.Error("Expected an error before connecting")
+	}
+}
+
+func TestPostgreSQL_DescribeTable_Activity(t *testing.T) {
+	vacuumed := time.Date(2024, 3, 4, 5, 6, 7, 0, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name  string
+		stats [][]driver.Value
+		want  map[string]any
+	}{
+		{
+			name:  "vacuumed but never analyzed",
+			stats: [][]driver.Value{{vacuumed, nil, int64(12)}},
+			want: map[string]any{
+				"last_vacuumed":               "2024-03-04T04:06:07Z",
+				"rows_modified_since_analyze": int64(12),
+			},
+		},
+		{
+			name: "no statistics",
+			want: map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+				if strings.Contains(query, "pg_stat_user_tables") {
+					return []string{"last_vacuum", "last_analyze", "n_mod_since_analyze"}, tt.stats
+				}
+				return nil, nil
+			})
+			defer sqlDB.Close()
+
+			db := &PostgreSQL{db: sqlDB, config: NewTestConfig("postgres")}
+			schema, err := db.DescribeTable(context.Background(), "users")
+			if err != nil {
+				t.Fatalf("DescribeTable() error = %v", err)
+			}
+
+			for _, key := range []string{"last_vacuumed", "last_analyzed", "rows_modified_since_analyze"} {
+				if got, want := schema.Metadata[key], tt.want[key]; got != want {
+					t.Errorf("Expected %s %v, got %v", key, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestPostgreSQL_ListenForNotifications_Validation(t *testing.T) {
+	sqlDB, _ := NewRecordingDB()
+	defer sqlDB.Close()
+
+	tests := []struct {
+		name    string
+		db      *PostgreSQL
+		channel string
+		handler func(string)
+		wantErr string
+	}{
+		{name: "not connected", db: &PostgreSQL{config: NewTestConfig("postgres")}, channel: "orders", handler: func(string) {}, wantErr: "no database connection"},
+		{name: "empty channel", db: &PostgreSQL{db: sqlDB, config: NewTestConfig("postgres")}, handler: func(string) {}, wantErr: "channel name cannot be empty"},
+		{name: "nil handler", db: &PostgreSQL{db: sqlDB, config: NewTestConfig("postgres")}, channel: "orders", wantErr: "notification handler cannot be nil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.db.ListenForNotifications(context.Background(), tt.channel, tt.handler)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ListenForNotifications() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := (&PostgreSQL{config: NewTestConfig("postgres")}).Unlisten(context.Background(), "orders"); err == nil || err.Error() != "not listening on channel orders" {
+		t.Errorf("Unlisten() error = %v, want not listening", err)
+	}
+}
+
+func TestPostgreSQL_DispatchNotifications(t *testing.T) {
+	var got []string
+	p := &PostgreSQL{channels: map[string]func(string){
+		"orders": func(payload string) { got = append(got, payload) },
+	}}
+
+	notify := make(chan *pq.Notification, 4)
+	notify <- &pq.Notification{Channel: "orders", Extra: "first"}
+	notify <- nil // Sent after reconnecting
+	notify <- &pq.Notification{Channel: "invoices", Extra: "ignored"}
+	notify <- &pq.Notification{Channel: "orders", Extra: "second"}
+	close(notify)
+
+	p.dispatchNotifications(notify)
+
+	if len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Errorf("Expected payloads [first second], got %v", got)
+	}
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// routineRow is one row of a routine query: a routine joined with one of its arguments,
+// or with none (NULL argument columns) when it takes no arguments.
+type routineRow struct {
+	specificName string // Identifies one routine, distinguishing PostgreSQL overloads
+	name         string
+	routineType  string
+	language     string
+	returnType   string
+	argName      sql.NullString
+	argMode      sql.NullString
+	argType      sql.NullString
+	definition   sql.NullString
+}
+
+// appendRoutineRow adds one row of a routine query to the list. Rows are expected to be
+// ordered by routine and argument position; unless newRoutine is set, the row's argument
+// is added to the last routine in the list.
+func appendRoutineRow(routines []FunctionSchema, row routineRow, newRoutine bool) []FunctionSchema {
+	if newRoutine || len(routines) == 0 {
+		routines = append(routines, FunctionSchema{
+			FunctionInfo: FunctionInfo{
+				Name:          row.name,
+				Type:          strings.ToLower(row.routineType),
+				Language:      strings.ToLower(row.language),
+				ReturnType:    row.returnType,
+				ArgumentTypes: []string{},
+			},
+			Arguments:  []FunctionArgument{},
+			Definition: strings.TrimSpace(row.definition.String),
+		})
+	}
+
+	if row.argType.Valid {
+		routine := &routines[len(routines)-1]
+		mode := strings.ToUpper(row.argMode.String)
+		if mode == "" {
+			mode = "IN"
+		}
+		routine.Arguments = append(routine.Arguments, FunctionArgument{Name: row.argName.String, Type: row.argType.String, Mode: mode})
+
+		argType := row.argType.String
+		if mode != "IN" {
+			argType = mode + " " + argType
+		}
+		routine.ArgumentTypes = append(routine.ArgumentTypes, argType)
+	}
+
+	return routines
+}
+
+// readRoutines reads the result of a routine query whose columns match routineRow.
+// A MySQL function and procedure may share a specific name, so routines are told
+// apart by specific name and type.
+func readRoutines(rows *sql.Rows) ([]FunctionSchema, error) {
+	var routines []FunctionSchema
+	previous := ""
+	for rows.Next() {
+		var row routineRow
+		err := rows.Scan(&row.specificName, &row.name, &row.routineType, &row.language, &row.returnType,
+			&row.argName, &row.argMode, &row.argType, &row.definition)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan routine info: %w", err)
+		}
+		key := row.routineType + " " + row.specificName
+		routines = appendRoutineRow(routines, row, key != previous)
+		previous = key
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading routine data: %w", err)
+	}
+	return routines, nil
+}
+
+// functionInfos returns the summaries of routines.
+func functionInfos(routines []FunctionSchema) []FunctionInfo {
+	infos := make([]FunctionInfo, len(routines))
+	for i, routine := range routines {
+		infos[i] = routine.FunctionInfo
+	}
+	return infos
+}
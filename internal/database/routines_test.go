@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+func TestAppendRoutineRow(t *testing.T) {
+	null := sql.NullString{}
+	text := func(s string) sql.NullString { return sql.NullString{String: s, Valid: true} }
+
+	var routines []FunctionSchema
+	routines = appendRoutineRow(routines, routineRow{
+		specificName: "add_tax_16384", name: "add_tax", routineType: "FUNCTION", language: "PLPGSQL", returnType: "numeric",
+		argName: text("amount"), argMode: text("IN"), argType: text("numeric"), definition: text("\n BEGIN RETURN amount * 1.2; END; "),
+	}, true)
+	routines = appendRoutineRow(routines, routineRow{
+		specificName: "add_tax_16384", name: "add_tax", routineType: "FUNCTION", language: "PLPGSQL", returnType: "numeric",
+		argName: text("total"), argMode: text("OUT"), argType: text("numeric"), definition: text("ignored"),
+	}, false)
+	routines = appendRoutineRow(routines, routineRow{
+		specificName: "refresh_stats", name: "refresh_stats", routineType: "PROCEDURE", language: "SQL",
+		argName: null, argMode: null, argType: null,
+	}, true)
+
+	want := []FunctionSchema{
+		{
+			FunctionInfo: FunctionInfo{
+				Name:          "add_tax",
+				Type:          "function",
+				Language:      "plpgsql",
+				ReturnType:    "numeric",
+				ArgumentTypes: []string{"numeric", "OUT numeric"},
+			},
+			Arguments: []FunctionArgument{
+				{Name: "amount", Type: "numeric", Mode: "IN"},
+				{Name: "total", Type: "numeric", Mode: "OUT"},
+			},
+			Definition: "BEGIN RETURN amount * 1.2; END;",
+		},
+		{
+			FunctionInfo: FunctionInfo{
+				Name:          "refresh_stats",
+				Type:          "procedure",
+				Language:      "sql",
+				ArgumentTypes: []string{},
+			},
+			Arguments: []FunctionArgument{},
+		},
+	}
+
+	if !reflect.DeepEqual(routines, want) {
+		t.Errorf("appendRoutineRow() = %+v, want %+v", routines, want)
+	}
+}
+
+func TestAppendRoutineRow_DefaultMode(t *testing.T) {
+	// MySQL reports no mode for function arguments
+	routines := appendRoutineRow(nil, routineRow{
+		specificName: "full_name", name: "full_name", routineType: "FUNCTION", language: "SQL", returnType: "varchar(255)",
+		argName: sql.NullString{String: "first", Valid: true}, argType: sql.NullString{String: "varchar(100)", Valid: true},
+	}, true)
+
+	if got := routines[0].Arguments[0].Mode; got != "IN" {
+		t.Errorf("Argument mode = %q, want IN", got)
+	}
+	if got := routines[0].ArgumentTypes; !reflect.DeepEqual(got, []string{"varchar(100)"}) {
+		t.Errorf("ArgumentTypes = %v, want [varchar(100)]", got)
+	}
+}
+
+func TestDescribeFunction_Query(t *testing.T) {
+	tests := []struct {
+		name     string
+		open     func(sqlDB *sql.DB) Database
+		contains []string
+	}{
+		{
+			name:     "postgres",
+			open:     func(sqlDB *sql.DB) Database { return &PostgreSQL{db: sqlDB, config: NewTestConfig("postgres")} },
+			contains: []string{"FROM information_schema.routines r", "r.routine_definition", "AND r.routine_name = $1"},
+		},
+		{
+			name:     "mysql",
+			open:     func(sqlDB *sql.DB) Database { return &MySQL{db: sqlDB, config: NewTestConfig("mysql")} },
+			contains: []string{"FROM INFORMATION_SCHEMA.ROUTINES r", "r.ROUTINE_DEFINITION", "AND r.ROUTINE_NAME = ?"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, recorder := NewRecordingDB()
+			defer sqlDB.Close()
+
+			// The mock driver returns no rows, so only the issued statement is checked
+			_, _ = tt.open(sqlDB).DescribeFunction(context.Background(), "add_tax")
+
+			queries := recorder.Queries()
+			if len(queries) != 1 {
+				t.Fatalf("Expected one query, got %v", queries)
+			}
+			for _, part := range tt.contains {
+				if !contains(queries[0], part) {
+					t.Errorf("Query %q does not contain %q", queries[0], part)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRowFilters_GeneratedQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		dbType   string
+		want     string
+		wantArgs []driver.Value
+	}{
+		{
+			name:     "postgres",
+			dbType:   "postgres",
+			want:     `SELECT COUNT(*) FROM (SELECT * FROM "public"."users" WHERE tenant_id = $2) AS "users" WHERE "status" = $1`,
+			wantArgs: []driver.Value{"active", "42"},
+		},
+		{
+			name:     "mysql",
+			dbType:   "mysql",
+			want:     "SELECT COUNT(*) FROM (SELECT * FROM `users` WHERE tenant_id = ?) AS `users` WHERE `status` = ?",
+			wantArgs: []driver.Value{"42", "active"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewTestConfig(tt.dbType)
+			cfg.RowFilters = "users: tenant_id = :tenant"
+			cfg.RowFilterParams = "tenant=42"
+
+			sqlDB, recorder := NewRecordingDB()
+			defer sqlDB.Close()
+
+			var db Database
+			if tt.dbType == "mysql" {
+				db = &MySQL{db: sqlDB, config: cfg}
+			} else {
+				db = &PostgreSQL{db: sqlDB, config: cfg}
+			}
+
+			filter := &TableFilter{Column: "status", Operator: "=", Value: "active"}
+			_, _ = db.GetTableData(context.Background(), "users", 10, 0, TableDataOptions{Filter: filter})
+
+			queries := recorder.Queries()
+			if len(queries) == 0 || queries[0] != tt.want {
+				t.Fatalf("Expected count query %q, got %v", tt.want, queries)
+			}
+			if args := recorder.Args(); len(args) == 0 || !reflect.DeepEqual(args[0], tt.wantArgs) {
+				t.Errorf("Expected arguments %v, got %v", tt.wantArgs, args)
+			}
+		})
+	}
+}
+
+func TestRowFilters_FreehandQueries(t *testing.T) {
+	cfg := NewTestConfig("postgres")
+	cfg.RowFilters = "orders: tenant_id = :tenant"
+	cfg.RowFilterParams = "tenant=acme"
+
+	sqlDB, recorder := NewRecordingDB()
+	defer sqlDB.Close()
+	db := &PostgreSQL{db: sqlDB, config: cfg}
+
+	ctx := context.Background()
+	if rows, err := db.Query(ctx, "SELECT * FROM orders o WHERE o.total > $1", 100); err != nil {
+		t.Fatalf("Query() error = %v", err)
+	} else {
+		rows.Close()
+	}
+	if _, err := db.Exec(ctx, "DELETE FROM orders WHERE id = $1", 7); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+
+	wantQueries := []string{
+		"SELECT * FROM (SELECT * FROM orders WHERE tenant_id = $2) o WHERE o.total > $1",
+		"DELETE FROM orders WHERE (id = $1) AND (tenant_id = $2)",
+	}
+	if queries := recorder.Queries(); !reflect.DeepEqual(queries, wantQueries) {
+		t.Errorf("Expected queries %q, got %q", wantQueries, queries)
+	}
+	wantArgs := [][]driver.Value{{int64(100), "acme"}, {int64(7), "acme"}}
+	if args := recorder.Args(); !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("Expected arguments %v, got %v", wantArgs, args)
+	}
+}
+
+func TestRowFilters_MissingParameter(t *testing.T) {
+	cfg := NewTestConfig("mysql")
+	cfg.RowFilters = "orders: tenant_id = :tenant"
+
+	sqlDB, recorder := NewRecordingDB()
+	defer sqlDB.Close()
+	db := &MySQL{db: sqlDB, config: cfg}
+
+	_, err := db.Query(context.Background(), "SELECT * FROM orders")
+	if err == nil || !strings.Contains(err.Error(), "tenant") {
+		t.Errorf("Expected a missing parameter error, got %v", err)
+	}
+	if queries := recorder.Queries(); len(queries) != 0 {
+		t.Errorf("Expected no query to run, got %v", queries)
+	}
+}
+
+func TestRowFilters_QueryRowRejected(t *testing.T) {
+	for _, dbType := range []string{"postgres", "mysql"} {
+		t.Run(dbType, func(t *testing.T) {
+			cfg := NewTestConfig(dbType)
+			cfg.RowFilters = "orders: tenant_id = :tenant"
+			cfg.RowFilterParams = "tenant=42"
+
+			sqlDB, recorder := NewRecordingDB()
+			defer sqlDB.Close()
+
+			var db Database
+			if dbType == "mysql" {
+				db = &MySQL{db: sqlDB, config: cfg}
+			} else {
+				db = &PostgreSQL{db: sqlDB, config: cfg}
+			}
+
+			var count int
+			err := db.QueryRow(context.Background(), "SELECT COUNT(*) FROM orders; TRUNCATE orders").Scan(&count)
+			if err == nil || !strings.Contains(err.Error(), "row filters cannot restrict") {
+				t.Errorf("Expected the rejection reason, got %v", err)
+			}
+			if queries := recorder.Queries(); len(queries) != 0 {
+				t.Errorf("Expected no query to run, got %v", queries)
+			}
+		})
+	}
+}
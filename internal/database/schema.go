@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jhoffmann/go-database-mcp/internal/config"
+)
+
+// schemaContextKey is the context key under which WithSchema stores a schema name.
+type schemaContextKey struct{}
+
+// WithSchema returns a context that scopes PostgreSQL introspection and table access to
+// schema instead of the configured DB_SCHEMA. MySQL ignores it.
+func WithSchema(ctx context.Context, schema string) context.Context {
+	return context.WithValue(ctx, schemaContextKey{}, schema)
+}
+
+// schemaName returns the schema PostgreSQL queries are scoped to: the one set with WithSchema,
+// otherwise DB_SCHEMA, otherwise public. It is config.AllSchemas when every non-system schema
+// is listed.
+func (p *PostgreSQL) schemaName(ctx context.Context) string {
+	if schema, _ := ctx.Value(schemaContextKey{}).(string); schema != "" {
+		return schema
+	}
+	if p.config.Schema != "" {
+		return p.config.Schema
+	}
+	return config.DefaultSchema
+}
+
+// schemaFilter returns a condition restricting column to the schema of ctx, bound to
+// placeholder $n, and the argument to pass for it. When every schema is listed, the
+// condition excludes the system schemas instead and takes no argument.
+func (p *PostgreSQL) schemaFilter(ctx context.Context, column string, n int) (string, []any) {
+	schema := p.schemaName(ctx)
+	if schema == config.AllSchemas {
+		return fmt.Sprintf("%[1]s <> 'information_schema' AND %[1]s !~ '^pg_'", column), nil
+	}
+	return fmt.Sprintf("%s = %s", column, postgresPlaceholder(n)), []any{schema}
+}
+
+// nameColumn returns the expression naming objects in listings: nameColumn itself, or
+// "schema.name" built from schemaColumn when every schema is listed, so that names stay
+// unambiguous and can be passed back to the other tools.
+func (p *PostgreSQL) nameColumn(ctx context.Context, schemaColumn, nameColumn string) string {
+	if p.schemaName(ctx) == config.AllSchemas {
+		return schemaColumn + " || '.' || " + nameColumn
+	}
+	return nameColumn
+}
+
+// splitTable returns the schema and unqualified name of a table. A "schema.table" name
+// selects its schema explicitly; other names are looked up in the schema of ctx, or in
+// public when every schema is listed.
+func (p *PostgreSQL) splitTable(ctx context.Context, tableName string) (string, string) {
+	if schema, table, ok := strings.Cut(tableName, "."); ok {
+		return schema, table
+	}
+	schema := p.schemaName(ctx)
+	if schema == config.AllSchemas {
+		schema = config.DefaultSchema
+	}
+	return schema, tableName
+}
+
+// quoteTable returns tableName quoted and qualified with its schema, e.g. "app"."users".
+func (p *PostgreSQL) quoteTable(ctx context.Context, tableName string) string {
+	schema, table := p.splitTable(ctx, tableName)
+	return quotePostgresIdentifier(schema) + "." + quotePostgresIdentifier(table)
+}
+
+// QuoteTable returns tableName quoted and qualified with its schema (see splitTable).
+func (p *PostgreSQL) QuoteTable(ctx context.Context, tableName string) string {
+	return p.quoteTable(ctx, tableName)
+}
@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jhoffmann/go-database-mcp/internal/config"
+)
+
+func TestPostgreSQL_SchemaName(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		ctx    context.Context
+		want   string
+	}{
+		{name: "default", ctx: context.Background(), want: "public"},
+		{name: "configured", schema: "app", ctx: context.Background(), want: "app"},
+		{name: "context overrides configuration", schema: "app", ctx: WithSchema(context.Background(), "audit"), want: "audit"},
+		{name: "all schemas", schema: config.AllSchemas, ctx: context.Background(), want: config.AllSchemas},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewTestConfig("postgres")
+			cfg.Schema = tt.schema
+			p := &PostgreSQL{config: cfg}
+			if got := p.schemaName(tt.ctx); got != tt.want {
+				t.Errorf("schemaName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteTable(t *testing.T) {
+	tests := []struct {
+		name      string
+		dbType    string
+		schema    string
+		ctx       context.Context
+		tableName string
+		want      string
+	}{
+		{name: "configured schema", dbType: "postgres", schema: "app", ctx: context.Background(), tableName: "orders", want: `"app"."orders"`},
+		{name: "schema from context", dbType: "postgres", schema: "app", ctx: WithSchema(context.Background(), "audit"), tableName: "events", want: `"audit"."events"`},
+		{name: "qualified table name", dbType: "postgres", schema: "app", ctx: context.Background(), tableName: "sales.orders", want: `"sales"."orders"`},
+		{name: "quotes in name", dbType: "postgres", ctx: context.Background(), tableName: `odd"name`, want: `"public"."odd""name"`},
+		{name: "mysql", dbType: "mysql", ctx: context.Background(), tableName: "shop.orders", want: "`shop`.`orders`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewTestConfig(tt.dbType)
+			cfg.Schema = tt.schema
+
+			var db Database = &PostgreSQL{config: cfg}
+			if tt.dbType == "mysql" {
+				db = &MySQL{config: cfg}
+			}
+			if got := db.QuoteTable(tt.ctx, tt.tableName); got != tt.want {
+				t.Errorf("QuoteTable() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostgreSQL_ListTablesSchema(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     string
+		wantSelect string
+		wantFilter string
+		wantArgs   []driver.Value
+	}{
+		{
+			name:       "single schema",
+			schema:     "reporting",
+			wantSelect: "SELECT table_name",
+			wantFilter: "table_schema = $1",
+			wantArgs:   []driver.Value{"reporting"},
+		},
+		{
+			name:       "all schemas",
+			schema:     config.AllSchemas,
+			wantSelect: "SELECT table_schema || '.' || table_name",
+			wantFilter: "table_schema <> 'information_schema' AND table_schema !~ '^pg_'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewTestConfig("postgres")
+			cfg.Schema = tt.schema
+
+			sqlDB, recorder := NewRecordingDB()
+			defer sqlDB.Close()
+
+			// The mock driver returns no rows, so only the issued statement is checked
+			_, _ = (&PostgreSQL{db: sqlDB, config: cfg}).ListTables(context.Background())
+
+			queries := recorder.Queries()
+			if len(queries) == 0 || !strings.Contains(queries[0], tt.wantSelect) || !strings.Contains(queries[0], tt.wantFilter) {
+				t.Fatalf("Expected %q and %q in query, got %v", tt.wantSelect, tt.wantFilter, queries)
+			}
+			if args := recorder.Args(); len(args) == 0 || fmt.Sprint(args[0]) != fmt.Sprint(tt.wantArgs) {
+				t.Errorf("Expected arguments %v, got %v", tt.wantArgs, args)
+			}
+		})
+	}
+}
+
+func TestPostgreSQL_GetTableDataSchema(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    string
+		ctx       context.Context
+		tableName string
+		want      string
+	}{
+		{
+			name:      "configured schema",
+			schema:    "app",
+			ctx:       context.Background(),
+			tableName: "users",
+			want:      `SELECT COUNT(*) FROM "app"."users"`,
+		},
+		{
+			name:      "schema from context",
+			schema:    "app",
+			ctx:       WithSchema(context.Background(), "audit"),
+			tableName: "events",
+			want:      `SELECT COUNT(*) FROM "audit"."events"`,
+		},
+		{
+			name:      "qualified table name",
+			schema:    config.AllSchemas,
+			ctx:       context.Background(),
+			tableName: "reporting.daily_totals",
+			want:      `SELECT COUNT(*) FROM "reporting"."daily_totals"`,
+		},
+		{
+			name:      "unqualified table name with all schemas",
+			schema:    config.AllSchemas,
+			ctx:       context.Background(),
+			tableName: "users",
+			want:      `SELECT COUNT(*) FROM "public"."users"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewTestConfig("postgres")
+			cfg.Schema = tt.schema
+
+			sqlDB, recorder := NewRecordingDB()
+			defer sqlDB.Close()
+
+			_, _ = (&PostgreSQL{db: sqlDB, config: cfg}).GetTableData(tt.ctx, tt.tableName, 10, 0, TableDataOptions{})
+
+			if queries := recorder.Queries(); len(queries) == 0 || queries[0] != tt.want {
+				t.Errorf("Expected count query %q, got %v", tt.want, queries)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/jhoffmann/go-database-mcp/internal/config"
+)
+
+// searchDatabases returns the databases searched by SearchTables and SearchColumns:
+// the primary database followed by each additional allowed database.
+func searchDatabases(cfg config.DatabaseConfig) []string {
+	databases := []string{cfg.Database}
+	for _, name := range cfg.AllowedDatabases {
+		if name != "" && !slices.Contains(databases, name) {
+			databases = append(databases, name)
+		}
+	}
+	return databases
+}
+
+// likeContains returns a LIKE pattern matching values that contain pattern literally.
+// The LIKE wildcards % and _ and the escape character are escaped with a backslash,
+// the default escape character for both MySQL and PostgreSQL.
+func likeContains(pattern string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(pattern)
+	return "%" + escaped + "%"
+}
+
+// matchRank orders search matches by relevance: 0 for an exact match, 1 for a prefix
+// match and 2 for any other substring match. Comparisons are case-insensitive.
+func matchRank(name, pattern string) int {
+	name, pattern = strings.ToLower(name), strings.ToLower(pattern)
+	switch {
+	case name == pattern:
+		return 0
+	case strings.HasPrefix(name, pattern):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// qualifiedTableName prefixes table with its database unless it is in the primary database.
+func qualifiedTableName(cfg config.DatabaseConfig, database, table string) string {
+	if database == cfg.Database {
+		return table
+	}
+	return database + "." + table
+}
+
+// tableMatch is a table found by SearchTables, before ranking.
+type tableMatch struct {
+	database string
+	table    string
+}
+
+// rankTableMatches sorts matches by relevance to pattern, then by database and table name,
+// and returns their qualified names.
+func rankTableMatches(cfg config.DatabaseConfig, matches []tableMatch, pattern string) []string {
+	slices.SortStableFunc(matches, func(a, b tableMatch) int {
+		if rank := matchRank(a.table, pattern) - matchRank(b.table, pattern); rank != 0 {
+			return rank
+		}
+		if a.database != b.database {
+			return strings.Compare(a.database, b.database)
+		}
+		return strings.Compare(a.table, b.table)
+	})
+
+	tables := make([]string, len(matches))
+	for i, match := range matches {
+		tables[i] = qualifiedTableName(cfg, match.database, match.table)
+	}
+	return tables
+}
+
+// rankColumnMatches sorts column search results by relevance of the column name to pattern,
+// then by database, table and column name.
+func rankColumnMatches(results []ColumnSearchResult, pattern string) {
+	slices.SortStableFunc(results, func(a, b ColumnSearchResult) int {
+		if rank := matchRank(a.ColumnName, pattern) - matchRank(b.ColumnName, pattern); rank != 0 {
+			return rank
+		}
+		if a.Database != b.Database {
+			return strings.Compare(a.Database, b.Database)
+		}
+		if a.TableName != b.TableName {
+			return strings.Compare(a.TableName, b.TableName)
+		}
+		return strings.Compare(a.ColumnName, b.ColumnName)
+	})
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jhoffmann/go-database-mcp/internal/config"
+)
+
+func TestSearchDatabases(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		want    []string
+	}{
+		{name: "primary only", allowed: nil, want: []string{"app"}},
+		{name: "allowed databases", allowed: []string{"reporting", "archive"}, want: []string{"app", "reporting", "archive"}},
+		{name: "duplicates and blanks skipped", allowed: []string{"app", "", "reporting", "reporting"}, want: []string{"app", "reporting"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DatabaseConfig{Database: "app", AllowedDatabases: tt.allowed}
+			if got := searchDatabases(cfg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("searchDatabases() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLikeContains(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"user", "%user%"},
+		{"user_id", `%user\_id%`},
+		{"100%", `%100\%%`},
+		{`a\b`, `%a\\b%`},
+	}
+
+	for _, tt := range tests {
+		if got := likeContains(tt.pattern); got != tt.want {
+			t.Errorf("likeContains(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestRankTableMatches(t *testing.T) {
+	cfg := config.DatabaseConfig{Database: "app", AllowedDatabases: []string{"reporting"}}
+	matches := []tableMatch{
+		{database: "app", table: "order_users"},
+		{database: "app", table: "users_archive"},
+		{database: "reporting", table: "users"},
+		{database: "app", table: "Users"},
+		{database: "app", table: "active_users"},
+	}
+
+	got := rankTableMatches(cfg, matches, "users")
+	want := []string{
+		"Users",
+		"reporting.users",
+		"users_archive",
+		"active_users",
+		"order_users",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rankTableMatches() = %v, want %v", got, want)
+	}
+}
+
+func TestRankColumnMatches(t *testing.T) {
+	results := []ColumnSearchResult{
+		{Database: "app", TableName: "orders", ColumnName: "customer_email"},
+		{Database: "app", TableName: "users", ColumnName: "email_verified"},
+		{Database: "app", TableName: "users", ColumnName: "email"},
+		{Database: "app", TableName: "accounts", ColumnName: "email"},
+	}
+
+	rankColumnMatches(results, "EMAIL")
+
+	var got []string
+	for _, result := range results {
+		got = append(got, result.TableName+"."+result.ColumnName)
+	}
+	want := []string{"accounts.email", "users.email", "users.email_verified", "orders.customer_email"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rankColumnMatches() order = %v, want %v", got, want)
+	}
+}
+
+func TestMySQL_SearchTables_Query(t *testing.T) {
+	sqlDB, recorder := NewRecordingDB()
+	defer sqlDB.Close()
+
+	cfg := NewTestConfig("mysql")
+	cfg.AllowedDatabases = []string{"reporting"}
+	db := &MySQL{db: sqlDB, config: cfg}
+
+	// The recording driver accepts no arguments, so only the generated SQL is checked
+	_, _ = db.SearchTables(context.Background(), "user")
+
+	queries := recorder.Queries()
+	if len(queries) != 1 || !contains(queries[0], "TABLE_SCHEMA IN (?, ?)") || !contains(queries[0], "TABLE_NAME LIKE ?") {
+		t.Errorf("Expected one search query over both databases, got %v", queries)
+	}
+}
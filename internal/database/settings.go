@@ -0,0 +1,72 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RedactedSettingValue replaces the value of server settings that may hold credentials.
+const RedactedSettingValue = "[REDACTED]"
+
+// sensitiveSettingParts are name parts of server settings whose values may contain
+// passwords or connection strings with credentials, such as PostgreSQL's primary_conninfo
+// or ssl_passphrase_command.
+var sensitiveSettingParts = []string{"password", "passwd", "passphrase", "secret", "token", "credential", "credentials", "conninfo"}
+
+// isSensitiveSetting reports whether the value of the named setting must be redacted. Names
+// are compared by their underscore-separated parts, so ssl_key and ssl_key_file are redacted
+// but key_buffer_size and foreign_key_checks are not.
+func isSensitiveSetting(name string) bool {
+	parts := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool { return r == '_' || r == '.' || r == '-' })
+	for i, part := range parts {
+		if slices.Contains(sensitiveSettingParts, part) {
+			return true
+		}
+		// A key setting is the key itself (ssl_key) or the file or path holding it (ssl_key_file)
+		if part == "key" && i > 0 && (i == len(parts)-1 || parts[i+1] == "file" || parts[i+1] == "path") {
+			return true
+		}
+	}
+	return false
+}
+
+// readServerSettings scans rows of name and value, optionally followed by unit, category
+// and description, keeping the settings whose name starts with prefix (case-insensitive).
+// Sensitive values are redacted, and the settings are returned sorted by name.
+func readServerSettings(rows *sql.Rows, prefix string) ([]ServerSetting, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get setting columns: %w", err)
+	}
+
+	prefix = strings.ToLower(prefix)
+	settings := []ServerSetting{}
+	for rows.Next() {
+		var setting ServerSetting
+		var unit, category, description sql.NullString
+		dest := []any{&setting.Name, &setting.Value, &unit, &category, &description}
+		if err := rows.Scan(dest[:len(columns)]...); err != nil {
+			return nil, fmt.Errorf("failed to scan setting: %w", err)
+		}
+		if !strings.HasPrefix(strings.ToLower(setting.Name), prefix) {
+			continue
+		}
+
+		setting.Unit = unit.String
+		setting.Category = category.String
+		setting.Description = description.String
+		if isSensitiveSetting(setting.Name) && setting.Value != "" {
+			setting.Value = RedactedSettingValue
+			setting.Redacted = true
+		}
+		settings = append(settings, setting)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading settings: %w", err)
+	}
+
+	slices.SortFunc(settings, func(a, b ServerSetting) int { return strings.Compare(a.Name, b.Name) })
+	return settings, nil
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetServerSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		dbType    string
+		prefix    string
+		wantQuery string
+		columns   []string
+		rows      [][]driver.Value
+		want      []ServerSetting
+	}{
+		{
+			name:      "postgres",
+			dbType:    "postgres",
+			prefix:    "Autovacuum",
+			wantQuery: "FROM pg_settings",
+			columns:   []string{"name", "setting", "unit", "category", "short_desc"},
+			rows: [][]driver.Value{
+				{"autovacuum_naptime", "60", "s", "Autovacuum", "Time to sleep between autovacuum runs."},
+				{"autovacuum", "on", nil, "Autovacuum", "Starts the autovacuum subprocess."},
+				{"work_mem", "4096", "kB", "Resource Usage / Memory", "Sets the maximum memory to be used for query workspaces."},
+			},
+			want: []ServerSetting{
+				{Name: "autovacuum", Value: "on", Category: "Autovacuum", Description: "Starts the autovacuum subprocess."},
+				{Name: "autovacuum_naptime", Value: "60", Unit: "s", Category: "Autovacuum", Description: "Time to sleep between autovacuum runs."},
+			},
+		},
+		{
+			name:      "postgres redacts credentials",
+			dbType:    "postgres",
+			prefix:    "primary",
+			wantQuery: "FROM pg_settings",
+			columns:   []string{"name", "setting", "unit", "category", "short_desc"},
+			rows: [][]driver.Value{
+				{"primary_conninfo", "host=primary user=replicator password=s3cret", nil, "Replication / Standby Servers", "Sets the connection string to be used to connect to the sending server."},
+				{"primary_slot_name", "", nil, "Replication / Standby Servers", "Sets the name of the replication slot to use on the sending server."},
+			},
+			want: []ServerSetting{
+				{Name: "primary_conninfo", Value: RedactedSettingValue, Category: "Replication / Standby Servers",
+					Description: "Sets the connection string to be used to connect to the sending server.", Redacted: true},
+				{Name: "primary_slot_name", Category: "Replication / Standby Servers",
+					Description: "Sets the name of the replication slot to use on the sending server."},
+			},
+		},
+		{
+			name:      "mysql",
+			dbType:    "mysql",
+			prefix:    "innodb_",
+			wantQuery: "SHOW VARIABLES",
+			columns:   []string{"Variable_name", "Value"},
+			rows: [][]driver.Value{
+				{"max_connections", "151"},
+				{"innodb_buffer_pool_size", "134217728"},
+				{"innodb_log_file_size", "50331648"},
+			},
+			want: []ServerSetting{
+				{Name: "innodb_buffer_pool_size", Value: "134217728"},
+				{Name: "innodb_log_file_size", Value: "50331648"},
+			},
+		},
+		{
+			name:      "mysql without prefix",
+			dbType:    "mysql",
+			wantQuery: "SHOW VARIABLES",
+			columns:   []string{"Variable_name", "Value"},
+			rows: [][]driver.Value{
+				{"ssl_key", "/etc/mysql/server-key.pem"},
+				{"max_connections", "151"},
+			},
+			want: []ServerSetting{
+				{Name: "max_connections", Value: "151"},
+				{Name: "ssl_key", Value: RedactedSettingValue, Redacted: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []string
+			sqlDB := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+				queries = append(queries, query)
+				return tt.columns, tt.rows
+			})
+			defer sqlDB.Close()
+
+			var db Database
+			if tt.dbType == "mysql" {
+				db = &MySQL{db: sqlDB, config: NewTestConfig("mysql")}
+			} else {
+				db = &PostgreSQL{db: sqlDB, config: NewTestConfig("postgres")}
+			}
+
+			settings, err := db.GetServerSettings(context.Background(), tt.prefix)
+			if err != nil {
+				t.Fatalf("GetServerSettings() error = %v", err)
+			}
+			if len(queries) != 1 || !strings.Contains(queries[0], tt.wantQuery) {
+				t.Errorf("Expected a query %s, got %v", tt.wantQuery, queries)
+			}
+			if !reflect.DeepEqual(settings, tt.want) {
+				t.Errorf("GetServerSettings() = %+v, want %+v", settings, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSensitiveSetting(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "primary_conninfo", want: true},
+		{name: "ssl_passphrase_command", want: true},
+		{name: "ssl_key_file", want: true},
+		{name: "caching_sha2_password_private_key_path", want: true},
+		{name: "ssl_key", want: true},
+		{name: "key_buffer_size", want: false},
+		{name: "foreign_key_checks", want: false},
+		{name: "keyring_file_data", want: false},
+		{name: "work_mem", want: false},
+		{name: "max_connections", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSensitiveSetting(tt.name); got != tt.want {
+				t.Errorf("isSensitiveSetting(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// RowSize approximates the number of bytes a row of scanned values occupies once
+// serialized: the length of strings and byte slices, and of the text form of any
+// other value. It is used to enforce DB_MAX_RESULT_BYTES while rows are read.
+func RowSize(values []any) int {
+	size := 0
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+			size += len("null")
+		case string:
+			size += len(v)
+		case []byte:
+			size += len(v)
+		case time.Time:
+			size += len(time.RFC3339Nano)
+		default:
+			size += len(fmt.Sprint(v))
+		}
+	}
+	return size
+}
+
+// ResultSizeError is returned when the rows read for a result exceed limit bytes
+// (DB_MAX_RESULT_BYTES).
+func ResultSizeError(limit int) error {
+	return fmt.Errorf("result exceeded %d bytes (DB_MAX_RESULT_BYTES); select fewer rows or columns", limit)
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRowSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []any
+		want   int
+	}{
+		{name: "text and bytes", values: []any{"hello", []byte("abc")}, want: 8},
+		{name: "numbers", values: []any{int64(12345), 1.5, true}, want: 12},
+		{name: "null", values: []any{nil}, want: 4},
+		{name: "time", values: []any{time.Now()}, want: len(time.RFC3339Nano)},
+		{name: "empty row", values: nil, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RowSize(tt.values); got != tt.want {
+				t.Errorf("RowSize(%v) = %d, want %d", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTableData_MaxResultBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		dbType   string
+		maxBytes int
+		wantErr  bool
+	}{
+		{name: "postgres under the limit", dbType: "postgres", maxBytes: 1000},
+		{name: "postgres over the limit", dbType: "postgres", maxBytes: 150, wantErr: true},
+		{name: "mysql over the limit", dbType: "mysql", maxBytes: 150, wantErr: true},
+		{name: "postgres without a limit", dbType: "postgres"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewTestConfig(tt.dbType)
+			cfg.MaxResultBytes = tt.maxBytes
+
+			// Three rows of about 100 bytes each
+			body := strings.Repeat("x", 99)
+			sqlDB := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+				if strings.HasPrefix(query, "SELECT COUNT(*)") {
+					return []string{"count"}, [][]driver.Value{{int64(3)}}
+				}
+				return []string{"id", "body"}, [][]driver.Value{
+					{int64(1), body}, {int64(2), body}, {int64(3), body},
+				}
+			})
+			defer sqlDB.Close()
+
+			var db Database = &PostgreSQL{db: sqlDB, config: cfg}
+			if tt.dbType == "mysql" {
+				db = &MySQL{db: sqlDB, config: cfg}
+			}
+
+			data, err := db.GetTableData(context.Background(), "documents", 10, 0, TableDataOptions{})
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "result exceeded 150 bytes") {
+					t.Fatalf("GetTableData() error = %v, want a result size error", err)
+				}
+				if data != nil {
+					t.Error("Expected no partial data with the error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetTableData() error = %v", err)
+			}
+			if len(data.Rows) != 3 {
+				t.Errorf("Expected 3 rows, got %d", len(data.Rows))
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package database
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// stmtCache is a fixed-size LRU cache of prepared statements keyed by query text.
+// It is safe for concurrent use. A nil *stmtCache is valid and caches nothing.
+//
+// Statements are reference counted: an evicted statement stays open until the
+// last caller that acquired it has released it, so eviction never closes a
+// statement out from under a query that is about to run.
+type stmtCache struct {
+	mu      sync.Mutex
+	size    int                      // Maximum number of cached statements
+	entries map[string]*list.Element // Query text -> element in order
+	order   *list.List               // Most recently used at the front
+}
+
+// cachedStmt is a prepared statement tracked by stmtCache.
+type cachedStmt struct {
+	query   string
+	stmt    *sql.Stmt
+	refs    int  // Number of callers currently using stmt
+	evicted bool // Whether the statement has left the cache
+}
+
+// newStmtCache creates a statement cache holding up to size statements.
+// It returns nil, which disables caching, when size is zero or negative.
+func newStmtCache(size int) *stmtCache {
+	if size <= 0 {
+		return nil
+	}
+	return &stmtCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// acquire returns a prepared statement for query, preparing it on db if it is
+// not cached yet. The returned release function must be called once the caller
+// no longer needs the statement. ok is false when caching is disabled or the
+// statement could not be prepared; callers should then execute the query directly.
+func (c *stmtCache) acquire(ctx context.Context, db *sql.DB, query string) (stmt *sql.Stmt, release func(), ok bool) {
+	if c == nil || db == nil {
+		return nil, nil, false
+	}
+
+	c.mu.Lock()
+	if element, found := c.entries[query]; found {
+		c.order.MoveToFront(element)
+		entry := element.Value.(*cachedStmt)
+		entry.refs++
+		c.mu.Unlock()
+		return entry.stmt, func() { c.release(entry) }, true
+	}
+	c.mu.Unlock()
+
+	// Prepare outside the lock so a slow round trip does not block other queries
+	prepared, err := db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Another goroutine may have cached the same query while we were preparing
+	if element, found := c.entries[query]; found {
+		prepared.Close()
+		c.order.MoveToFront(element)
+		entry := element.Value.(*cachedStmt)
+		entry.refs++
+		return entry.stmt, func() { c.release(entry) }, true
+	}
+
+	entry := &cachedStmt{query: query, stmt: prepared, refs: 1}
+	c.entries[query] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.size {
+		c.evict(c.order.Back())
+	}
+
+	return entry.stmt, func() { c.release(entry) }, true
+}
+
+// release drops one reference to entry, closing it if it was evicted and is no longer in use.
+func (c *stmtCache) release(entry *cachedStmt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.refs--
+	if entry.evicted && entry.refs == 0 {
+		entry.stmt.Close()
+	}
+}
+
+// evict removes element from the cache. The caller must hold c.mu.
+func (c *stmtCache) evict(element *list.Element) {
+	entry := element.Value.(*cachedStmt)
+	c.order.Remove(element)
+	delete(c.entries, entry.query)
+
+	entry.evicted = true
+	if entry.refs == 0 {
+		entry.stmt.Close()
+	}
+}
+
+// Len returns the number of cached statements.
+func (c *stmtCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// close evicts every cached statement.
+func (c *stmtCache) close() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.order.Len() > 0 {
+		c.evict(c.order.Back())
+	}
+}
+
+// queryContext runs a row-returning query, reusing a cached prepared statement when available.
+func queryContext(ctx context.Context, db *sql.DB, cache *stmtCache, query string, args ...any) (*sql.Rows, error) {
+	if stmt, release, ok := cache.acquire(ctx, db, query); ok {
+		defer release()
+		return stmt.QueryContext(ctx, args...)
+	}
+	return db.QueryContext(ctx, query, args...)
+}
+
+// queryRowContext runs a single-row query, reusing a cached prepared statement when available.
+func queryRowContext(ctx context.Context, db *sql.DB, cache *stmtCache, query string, args ...any) *sql.Row {
+	if stmt, release, ok := cache.acquire(ctx, db, query); ok {
+		defer release()
+		return stmt.QueryRowContext(ctx, args...)
+	}
+	return db.QueryRowContext(ctx, query, args...)
+}
+
+// execContext runs a statement that returns no rows, reusing a cached prepared statement when available.
+func execContext(ctx context.Context, db *sql.DB, cache *stmtCache, query string, args ...any) (sql.Result, error) {
+	if stmt, release, ok := cache.acquire(ctx, db, query); ok {
+		defer release()
+		return stmt.ExecContext(ctx, args...)
+	}
+	return db.ExecContext(ctx, query, args...)
+}
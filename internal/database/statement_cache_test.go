@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestNewStmtCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		disabled bool
+	}{
+		{name: "zero disables caching", size: 0, disabled: true},
+		{name: "negative disables caching", size: -1, disabled: true},
+		{name: "positive enables caching", size: 5, disabled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newStmtCache(tt.size)
+			if (cache == nil) != tt.disabled {
+				t.Errorf("newStmtCache(%d) disabled = %v, want %v", tt.size, cache == nil, tt.disabled)
+			}
+		})
+	}
+}
+
+func TestStmtCacheReusesStatements(t *testing.T) {
+	db, recorder := NewRecordingDB()
+	defer db.Close()
+
+	ctx := context.Background()
+	cache := newStmtCache(10)
+	defer cache.close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := execContext(ctx, db, cache, "UPDATE users SET active = 1"); err != nil {
+			t.Fatalf("execContext() error = %v", err)
+		}
+	}
+
+	if got := len(recorder.Queries()); got != 1 {
+		t.Errorf("prepared %d statements, want 1", got)
+	}
+	if got := cache.Len(); got != 1 {
+		t.Errorf("cache.Len() = %d, want 1", got)
+	}
+}
+
+func TestStmtCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	db, recorder := NewRecordingDB()
+	defer db.Close()
+
+	ctx := context.Background()
+	cache := newStmtCache(2)
+	defer cache.close()
+
+	for _, query := range []string{"SELECT 1", "SELECT 2", "SELECT 1", "SELECT 3", "SELECT 1", "SELECT 2"} {
+		if _, err := execContext(ctx, db, cache, query); err != nil {
+			t.Fatalf("execContext(%q) error = %v", query, err)
+		}
+	}
+
+	// "SELECT 2" is evicted by "SELECT 3" and has to be prepared again
+	want := []string{"SELECT 1", "SELECT 2", "SELECT 3", "SELECT 2"}
+	got := recorder.Queries()
+	if len(got) != len(want) {
+		t.Fatalf("prepared %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("prepared[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if got := cache.Len(); got != 2 {
+		t.Errorf("cache.Len() = %d, want 2", got)
+	}
+}
+
+func TestStmtCacheDisabled(t *testing.T) {
+	db, recorder := NewRecordingDB()
+	defer db.Close()
+
+	ctx := context.Background()
+	var cache *stmtCache
+
+	for i := 0; i < 3; i++ {
+		if _, err := execContext(ctx, db, cache, "DELETE FROM sessions"); err != nil {
+			t.Fatalf("execContext() error = %v", err)
+		}
+	}
+
+	// Without a cache every execution prepares the statement itself
+	if got := len(recorder.Queries()); got != 3 {
+		t.Errorf("prepared %d statements, want 3", got)
+	}
+	if got := cache.Len(); got != 0 {
+		t.Errorf("cache.Len() = %d, want 0", got)
+	}
+	cache.close()
+}
+
+func TestStmtCacheConcurrentAccess(t *testing.T) {
+	db, _ := NewRecordingDB()
+	defer db.Close()
+
+	ctx := context.Background()
+	cache := newStmtCache(4)
+	defer cache.close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 160)
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				query := fmt.Sprintf("SELECT %d", (worker+i)%6)
+				if _, err := execContext(ctx, db, cache, query); err != nil {
+					errs <- err
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("execContext() error = %v", err)
+	}
+	if got := cache.Len(); got > 4 {
+		t.Errorf("cache.Len() = %d, want at most 4", got)
+	}
+}
+
+func TestStatementCacheWiring(t *testing.T) {
+	for _, dbType := range []string{"mysql", "postgres"} {
+		t.Run(dbType, func(t *testing.T) {
+			sqlDB, recorder := NewRecordingDB()
+			cfg := NewTestConfig(dbType)
+			cfg.StatementCacheSize = 8
+
+			var db Database
+			if dbType == "mysql" {
+				db = &MySQL{db: sqlDB, config: cfg, stmts: newStmtCache(cfg.StatementCacheSize)}
+			} else {
+				db = &PostgreSQL{db: sqlDB, config: cfg, stmts: newStmtCache(cfg.StatementCacheSize)}
+			}
+			defer db.Close()
+
+			ctx := context.Background()
+			for i := 0; i < 3; i++ {
+				if _, err := db.Exec(ctx, "UPDATE users SET active = 1"); err != nil {
+					t.Fatalf("Exec() error = %v", err)
+				}
+			}
+
+			if got := len(recorder.Queries()); got != 1 {
+				t.Errorf("prepared %d statements, want 1", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/jhoffmann/go-database-mcp/internal/config"
+)
+
+// statisticsColumn is a column to profile, along with the aggregates its type supports.
+type statisticsColumn struct {
+	name      string
+	dataType  string
+	distinct  bool // COUNT(DISTINCT) is supported
+	orderable bool // MIN and MAX are supported
+	textual   bool // Average character length is meaningful
+}
+
+// Type names are matched on their first word, so "character varying",
+// "timestamp with time zone" and "double precision" are all covered.
+var (
+	textualTypes = map[string]bool{
+		"char": true, "character": true, "varchar": true, "text": true, "citext": true,
+		"tinytext": true, "mediumtext": true, "longtext": true, "enum": true,
+	}
+	orderableTypes = map[string]bool{
+		"smallint": true, "integer": true, "int": true, "bigint": true, "tinyint": true, "mediumint": true,
+		"numeric": true, "decimal": true, "real": true, "double": true, "float": true, "money": true,
+		"date": true, "datetime": true, "timestamp": true, "time": true, "interval": true, "year": true,
+	}
+	distinctOnlyTypes = map[string]bool{
+		"boolean": true, "bool": true, "uuid": true, "bit": true,
+	}
+)
+
+// newStatisticsColumn classifies a column by its information_schema data type.
+func newStatisticsColumn(name, dataType string) statisticsColumn {
+	baseType := strings.ToLower(strings.TrimSpace(dataType))
+	if i := strings.IndexAny(baseType, " ("); i >= 0 {
+		baseType = baseType[:i]
+	}
+
+	column := statisticsColumn{name: name, dataType: dataType}
+	column.textual = textualTypes[baseType]
+	column.orderable = column.textual || orderableTypes[baseType]
+	column.distinct = column.orderable || distinctOnlyTypes[baseType]
+	return column
+}
+
+// statisticsSQL holds the dialect-specific pieces of the profiling queries.
+type statisticsSQL struct {
+	quote  func(identifier string) string // Quotes a table or column name
+	length string                         // Function returning a string's length in characters
+	text   string                         // Type values are cast to for listing them as text
+}
+
+// collectColumnStatistics profiles columns of table, read from the quoted table reference
+// from, in a single aggregate query. It first checks, with a scan bounded to maxRows+1 rows, that the table is not larger
+// than maxRows (config.DefaultStatisticsMaxRows when zero or negative).
+func collectColumnStatistics(ctx context.Context, db Database, dialect statisticsSQL, from, table string, columns []statisticsColumn, maxRows int64) ([]ColumnStatistics, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s not found or has no columns", table)
+	}
+	if maxRows <= 0 {
+		maxRows = config.DefaultStatisticsMaxRows
+	}
+
+	rowCount, err := countRowsUpTo(ctx, db, from, maxRows+1)
+	if err != nil {
+		return nil, err
+	}
+	if rowCount > maxRows {
+		return nil, fmt.Errorf("table %s has more than %d rows; column statistics are limited to smaller tables (DB_STATISTICS_MAX_ROWS)", table, maxRows)
+	}
+
+	dests, build := newStatisticsScan(columns)
+	if err := db.QueryRow(ctx, buildColumnStatisticsQuery(dialect, from, columns)).Scan(dests...); err != nil {
+		return nil, fmt.Errorf("failed to get column statistics: %w", err)
+	}
+
+	return build(), nil
+}
+
+// countRowsUpTo counts the rows of the quoted table from, stopping at limit so that large
+// tables are not scanned in full.
+func countRowsUpTo(ctx context.Context, db Database, from string, limit int64) (int64, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM (SELECT 1 FROM %s LIMIT %d) limited", from, limit)
+	var rowCount int64
+	if err := db.QueryRow(ctx, query).Scan(&rowCount); err != nil {
+		return 0, fmt.Errorf("failed to count rows: %w", err)
+	}
+	return rowCount, nil
+}
+
+// profileTable profiles columns of table, read from the quoted table reference from: the
+// aggregates of collectColumnStatistics in one query, then the topN most common values of
+// every column in a second query. Tables with more than maxRows rows
+// (config.DefaultProfileMaxRows when zero or negative) are refused.
+func profileTable(ctx context.Context, db Database, dialect statisticsSQL, from, table string, columns []statisticsColumn, maxRows int64, topN int) (*TableProfile, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s not found or has no columns", table)
+	}
+	if maxRows <= 0 {
+		maxRows = config.DefaultProfileMaxRows
+	}
+
+	// Within the limit the bounded count is the exact row count
+	rowCount, err := countRowsUpTo(ctx, db, from, maxRows+1)
+	if err != nil {
+		return nil, err
+	}
+	if rowCount > maxRows {
+		return nil, fmt.Errorf("table %s has more than %d rows; profiling is limited to smaller tables (DB_PROFILE_MAX_ROWS)", table, maxRows)
+	}
+
+	dests, build := newStatisticsScan(columns)
+	if err := db.QueryRow(ctx, buildColumnStatisticsQuery(dialect, from, columns)).Scan(dests...); err != nil {
+		return nil, fmt.Errorf("failed to get column statistics: %w", err)
+	}
+	stats := build()
+
+	profile := &TableProfile{TableName: table, RowCount: rowCount, Columns: make([]ColumnProfile, len(columns))}
+	for i, column := range columns {
+		columnProfile := ColumnProfile{
+			Name:           column.name,
+			DataType:       column.dataType,
+			NullPercentage: percentage(stats[i].NullCount, rowCount),
+			DistinctCount:  stats[i].DistinctCount,
+		}
+		if column.distinct {
+			columnProfile.TopValues = []ValueFrequency{}
+		}
+		// Minimum and maximum of strings are alphabetical, which says little about the data
+		if column.orderable && !column.textual {
+			columnProfile.MinValue = stats[i].MinValue
+			columnProfile.MaxValue = stats[i].MaxValue
+		}
+		profile.Columns[i] = columnProfile
+	}
+
+	query := buildTopValuesQuery(dialect, from, columns, topN)
+	if query == "" || rowCount == 0 {
+		return profile, nil
+	}
+
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top values: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var index int
+		var frequency ValueFrequency
+		if err := rows.Scan(&index, &frequency.Value, &frequency.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan top value: %w", err)
+		}
+		if index < 0 || index >= len(profile.Columns) {
+			return nil, fmt.Errorf("top value refers to unknown column %d", index)
+		}
+		frequency.Percentage = percentage(frequency.Count, rowCount)
+		profile.Columns[index].TopValues = append(profile.Columns[index].TopValues, frequency)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading top values: %w", err)
+	}
+
+	// UNION ALL does not guarantee the order of its branches' rows
+	for i := range profile.Columns {
+		slices.SortStableFunc(profile.Columns[i].TopValues, func(a, b ValueFrequency) int {
+			if c := cmp.Compare(b.Count, a.Count); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Value, b.Value)
+		})
+	}
+
+	return profile, nil
+}
+
+// buildTopValuesQuery returns a query listing, for every column that supports COUNT(DISTINCT),
+// its limit most common non-NULL values as rows of column index, value cast to text and
+// number of occurrences, in a single UNION ALL statement. Returns "" when no column qualifies.
+func buildTopValuesQuery(dialect statisticsSQL, from string, columns []statisticsColumn, limit int) string {
+	var branches []string
+	for i, column := range columns {
+		if !column.distinct {
+			continue
+		}
+		quoted := dialect.quote(column.name)
+		branches = append(branches, fmt.Sprintf(
+			"(SELECT %d AS column_index, CAST(%s AS %s) AS value, COUNT(*) AS frequency FROM %s WHERE %s IS NOT NULL GROUP BY %s ORDER BY frequency DESC LIMIT %d)",
+			i, quoted, dialect.text, from, quoted, quoted, limit))
+	}
+	return strings.Join(branches, " UNION ALL ")
+}
+
+// percentage returns part as a percentage of total, rounded to two decimals, or 0 when
+// total is 0.
+func percentage(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)*10000/float64(total)) / 100
+}
+
+// buildColumnStatisticsQuery returns a query computing COUNT(*) followed, for each column,
+// by the aggregates newStatisticsScan expects, in the same order, over the quoted table from.
+func buildColumnStatisticsQuery(dialect statisticsSQL, from string, columns []statisticsColumn) string {
+	exprs := []string{"COUNT(*)"}
+	for _, column := range columns {
+		quoted := dialect.quote(column.name)
+		exprs = append(exprs, fmt.Sprintf("COUNT(%s)", quoted))
+		if column.distinct {
+			exprs = append(exprs, fmt.Sprintf("COUNT(DISTINCT %s)", quoted))
+		}
+		if column.orderable {
+			exprs = append(exprs, fmt.Sprintf("MIN(%s)", quoted), fmt.Sprintf("MAX(%s)", quoted))
+		}
+		if column.textual {
+			exprs = append(exprs, fmt.Sprintf("AVG(%s(%s))", dialect.length, quoted))
+		}
+	}
+
+	return fmt.Sprintf("SELECT %s FROM %s", strings.Join(exprs, ", "), from)
+}
+
+// newStatisticsScan returns scan destinations matching buildColumnStatisticsQuery and a
+// function that converts the scanned values into ColumnStatistics once Scan has run.
+func newStatisticsScan(columns []statisticsColumn) ([]any, func() []ColumnStatistics) {
+	type columnValues struct {
+		nonNull  int64
+		distinct int64
+		min, max sql.NullString
+		avgLen   sql.NullFloat64
+	}
+
+	var total int64
+	values := make([]columnValues, len(columns))
+	dests := []any{&total}
+	for i, column := range columns {
+		dests = append(dests, &values[i].nonNull)
+		if column.distinct {
+			dests = append(dests, &values[i].distinct)
+		}
+		if column.orderable {
+			dests = append(dests, &values[i].min, &values[i].max)
+		}
+		if column.textual {
+			dests = append(dests, &values[i].avgLen)
+		}
+	}
+
+	build := func() []ColumnStatistics {
+		stats := make([]ColumnStatistics, len(columns))
+		for i, column := range columns {
+			v := values[i]
+			stats[i] = ColumnStatistics{
+				Name:      column.name,
+				DataType:  column.dataType,
+				NullCount: total - v.nonNull,
+			}
+			if column.distinct {
+				distinct := v.distinct
+				stats[i].DistinctCount = &distinct
+			}
+			if v.min.Valid {
+				stats[i].MinValue = &v.min.String
+			}
+			if v.max.Valid {
+				stats[i].MaxValue = &v.max.String
+			}
+			if v.avgLen.Valid {
+				stats[i].AvgLength = &v.avgLen.Float64
+			}
+		}
+		return stats
+	}
+
+	return dests, build
+}
+
+// quotePostgresIdentifier quotes a PostgreSQL identifier, doubling embedded quotes.
+func quotePostgresIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// quoteMySQLIdentifier quotes a MySQL identifier, doubling embedded backticks.
+func quoteMySQLIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// QuoteIdentifier quotes a table or column name for the given database type ("mysql" or
+// "postgres"). A qualified "schema.table" name is quoted part by part.
+func QuoteIdentifier(dbType, name string) string {
+	quote := quotePostgresIdentifier
+	if dbType == "mysql" {
+		quote = quoteMySQLIdentifier
+	}
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = quote(part)
+	}
+	return strings.Join(parts, ".")
+}
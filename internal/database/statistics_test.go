@@ -0,0 +1,261 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+func TestNewStatisticsColumn(t *testing.T) {
+	tests := []struct {
+		dataType      string
+		wantDistinct  bool
+		wantOrderable bool
+		wantTextual   bool
+	}{
+		{"character varying", true, true, true},
+		{"varchar", true, true, true},
+		{"text", true, true, true},
+		{"enum", true, true, true},
+		{"integer", true, true, false},
+		{"bigint", true, true, false},
+		{"double precision", true, true, false},
+		{"decimal(10,2)", true, true, false},
+		{"timestamp with time zone", true, true, false},
+		{"datetime", true, true, false},
+		{"boolean", true, false, false},
+		{"uuid", true, false, false},
+		{"json", false, false, false},
+		{"bytea", false, false, false},
+		{"point", false, false, false},
+		{"USER-DEFINED", false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dataType, func(t *testing.T) {
+			column := newStatisticsColumn("col", tt.dataType)
+			if column.distinct != tt.wantDistinct || column.orderable != tt.wantOrderable || column.textual != tt.wantTextual {
+				t.Errorf("newStatisticsColumn(%q) = distinct %v, orderable %v, textual %v; want %v, %v, %v",
+					tt.dataType, column.distinct, column.orderable, column.textual,
+					tt.wantDistinct, tt.wantOrderable, tt.wantTextual)
+			}
+		})
+	}
+}
+
+func TestBuildColumnStatisticsQuery(t *testing.T) {
+	columns := []statisticsColumn{
+		newStatisticsColumn("id", "integer"),
+		newStatisticsColumn("name", "text"),
+		newStatisticsColumn("payload", "json"),
+	}
+
+	tests := []struct {
+		name    string
+		dialect statisticsSQL
+		table   string
+		want    string
+	}{
+		{
+			name:    "postgres",
+			dialect: statisticsSQL{quote: quotePostgresIdentifier, length: "LENGTH"},
+			table:   `"app"."users"`,
+			want: `SELECT COUNT(*), COUNT("id"), COUNT(DISTINCT "id"), MIN("id"), MAX("id"), ` +
+				`COUNT("name"), COUNT(DISTINCT "name"), MIN("name"), MAX("name"), AVG(LENGTH("name")), ` +
+				`COUNT("payload") FROM "app"."users"`,
+		},
+		{
+			name:    "mysql with quote in table name",
+			dialect: statisticsSQL{quote: quoteMySQLIdentifier, length: "CHAR_LENGTH"},
+			table:   quoteMySQLIdentifier("odd`name"),
+			want: "SELECT COUNT(*), COUNT(`id`), COUNT(DISTINCT `id`), MIN(`id`), MAX(`id`), " +
+				"COUNT(`name`), COUNT(DISTINCT `name`), MIN(`name`), MAX(`name`), AVG(CHAR_LENGTH(`name`)), " +
+				"COUNT(`payload`) FROM `odd``name`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildColumnStatisticsQuery(tt.dialect, tt.table, columns); got != tt.want {
+				t.Errorf("buildColumnStatisticsQuery() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStatisticsScan(t *testing.T) {
+	columns := []statisticsColumn{
+		newStatisticsColumn("id", "integer"),
+		newStatisticsColumn("name", "varchar"),
+		newStatisticsColumn("payload", "json"),
+	}
+
+	dests, build := newStatisticsScan(columns)
+
+	// COUNT(*), then id: 4 values, name: 5 values, payload: 1 value
+	if len(dests) != 11 {
+		t.Fatalf("newStatisticsScan() returned %d destinations, want 11", len(dests))
+	}
+
+	// Simulate Scan for a 10 row table
+	*dests[0].(*int64) = 10
+	*dests[1].(*int64) = 10
+	*dests[2].(*int64) = 10
+	*dests[3].(*sql.NullString) = sql.NullString{String: "1", Valid: true}
+	*dests[4].(*sql.NullString) = sql.NullString{String: "10", Valid: true}
+	*dests[5].(*int64) = 7
+	*dests[6].(*int64) = 5
+	*dests[7].(*sql.NullString) = sql.NullString{String: "alice", Valid: true}
+	*dests[8].(*sql.NullString) = sql.NullString{String: "zoe", Valid: true}
+	*dests[9].(*sql.NullFloat64) = sql.NullFloat64{Float64: 4.5, Valid: true}
+	*dests[10].(*int64) = 0
+
+	stats := build()
+	if len(stats) != 3 {
+		t.Fatalf("build() returned %d columns, want 3", len(stats))
+	}
+
+	id, name, payload := stats[0], stats[1], stats[2]
+	if id.NullCount != 0 || *id.DistinctCount != 10 || *id.MinValue != "1" || *id.MaxValue != "10" || id.AvgLength != nil {
+		t.Errorf("id statistics = %+v", id)
+	}
+	if name.NullCount != 3 || *name.DistinctCount != 5 || *name.MinValue != "alice" || *name.MaxValue != "zoe" || *name.AvgLength != 4.5 {
+		t.Errorf("name statistics = %+v", name)
+	}
+	if payload.NullCount != 10 || payload.DistinctCount != nil || payload.MinValue != nil || payload.AvgLength != nil {
+		t.Errorf("payload statistics = %+v", payload)
+	}
+	if payload.DataType != "json" {
+		t.Errorf("payload DataType = %s, want json", payload.DataType)
+	}
+}
+
+func TestCollectColumnStatistics_Errors(t *testing.T) {
+	db, _ := NewRecordingDB()
+	defer db.Close()
+
+	pg := &PostgreSQL{db: db, config: NewTestConfig("postgres")}
+	dialect := statisticsSQL{quote: quotePostgresIdentifier, length: "LENGTH"}
+
+	_, err := collectColumnStatistics(context.Background(), pg, dialect, `"missing"`, "missing", nil, 0)
+	if err == nil || !contains(err.Error(), "not found") {
+		t.Errorf("collectColumnStatistics() error = %v, want table not found", err)
+	}
+
+	// The recording driver returns no rows, so the bounded row count fails
+	columns := []statisticsColumn{newStatisticsColumn("id", "integer")}
+	_, err = collectColumnStatistics(context.Background(), pg, dialect, `"users"`, "users", columns, 0)
+	if err == nil || !contains(err.Error(), "failed to count rows") {
+		t.Errorf("collectColumnStatistics() error = %v, want row count failure", err)
+	}
+}
+
+func TestQuoteIdentifiers(t *testing.T) {
+	if got := quotePostgresIdentifier(`my"table`); got != `"my""table"` {
+		t.Errorf("quotePostgresIdentifier() = %s", got)
+	}
+	if got := quoteMySQLIdentifier("my`table"); got != "`my``table`" {
+		t.Errorf("quoteMySQLIdentifier() = %s", got)
+	}
+}
+
+func TestBuildTopValuesQuery(t *testing.T) {
+	columns := []statisticsColumn{
+		newStatisticsColumn("id", "integer"),
+		newStatisticsColumn("payload", "json"),
+		newStatisticsColumn("status", "varchar"),
+	}
+
+	got := buildTopValuesQuery(statisticsSQL{quote: quoteMySQLIdentifier, text: "CHAR"}, "`orders`", columns, 5)
+	want := "(SELECT 0 AS column_index, CAST(`id` AS CHAR) AS value, COUNT(*) AS frequency FROM `orders` WHERE `id` IS NOT NULL GROUP BY `id` ORDER BY frequency DESC LIMIT 5)" +
+		" UNION ALL " +
+		"(SELECT 2 AS column_index, CAST(`status` AS CHAR) AS value, COUNT(*) AS frequency FROM `orders` WHERE `status` IS NOT NULL GROUP BY `status` ORDER BY frequency DESC LIMIT 5)"
+	if got != want {
+		t.Errorf("buildTopValuesQuery() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := buildTopValuesQuery(statisticsSQL{quote: quoteMySQLIdentifier, text: "CHAR"}, "`t`", columns[1:2], 5); got != "" {
+		t.Errorf("Expected no query without profilable columns, got %s", got)
+	}
+}
+
+func TestProfileTable(t *testing.T) {
+	var queries []string
+	db := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+		queries = append(queries, query)
+		switch {
+		case strings.Contains(query, "LIMIT 501"):
+			return []string{"count"}, [][]driver.Value{{int64(4)}}
+		case strings.HasPrefix(query, "SELECT COUNT(*), "):
+			// id: 4 non-NULL, 4 distinct, 1..4; status: 3 non-NULL, 2 distinct; payload: 4 non-NULL
+			return []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}, [][]driver.Value{{
+				int64(4), int64(4), int64(4), "1", "4", int64(3), int64(2), "active", "closed", 6.0, int64(4),
+			}}
+		default:
+			// Deliberately unordered, as UNION ALL does not guarantee branch order
+			return []string{"column_index", "value", "frequency"}, [][]driver.Value{
+				{int64(1), "closed", int64(1)},
+				{int64(0), "2", int64(1)},
+				{int64(1), "active", int64(2)},
+				{int64(0), "1", int64(1)},
+			}
+		}
+	})
+	defer db.Close()
+
+	pg := &PostgreSQL{db: db, config: NewTestConfig("postgres")}
+	dialect := statisticsSQL{quote: quotePostgresIdentifier, length: "LENGTH", text: "TEXT"}
+	columns := []statisticsColumn{
+		newStatisticsColumn("id", "integer"),
+		newStatisticsColumn("status", "text"),
+		newStatisticsColumn("payload", "json"),
+	}
+
+	profile, err := profileTable(context.Background(), pg, dialect, `"orders"`, "orders", columns, 500, 2)
+	if err != nil {
+		t.Fatalf("profileTable() error = %v", err)
+	}
+	if len(queries) != 3 || !strings.Contains(queries[2], "UNION ALL") || !strings.Contains(queries[2], "LIMIT 2)") {
+		t.Errorf("Expected the row count, aggregate and top values queries, got %v", queries)
+	}
+
+	if profile.RowCount != 4 || len(profile.Columns) != 3 {
+		t.Fatalf("Unexpected profile %+v", profile)
+	}
+
+	id, status, payload := profile.Columns[0], profile.Columns[1], profile.Columns[2]
+	if id.NullPercentage != 0 || *id.DistinctCount != 4 || *id.MinValue != "1" || *id.MaxValue != "4" {
+		t.Errorf("id profile = %+v", id)
+	}
+	if len(id.TopValues) != 2 || id.TopValues[0].Value != "1" || id.TopValues[0].Percentage != 25 {
+		t.Errorf("Expected id top values sorted by count then value, got %+v", id.TopValues)
+	}
+
+	if status.NullPercentage != 25 || status.MinValue != nil || status.MaxValue != nil {
+		t.Errorf("Expected 25%% NULLs and no min/max for a text column, got %+v", status)
+	}
+	if len(status.TopValues) != 2 || status.TopValues[0] != (ValueFrequency{Value: "active", Count: 2, Percentage: 50}) {
+		t.Errorf("Expected active to be the most common status, got %+v", status.TopValues)
+	}
+
+	if payload.DistinctCount != nil || payload.TopValues != nil {
+		t.Errorf("Expected no distinct values for a json column, got %+v", payload)
+	}
+}
+
+func TestProfileTable_RowLimit(t *testing.T) {
+	db := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+		return []string{"count"}, [][]driver.Value{{int64(11)}}
+	})
+	defer db.Close()
+
+	pg := &PostgreSQL{db: db, config: NewTestConfig("postgres")}
+	columns := []statisticsColumn{newStatisticsColumn("id", "integer")}
+
+	_, err := profileTable(context.Background(), pg, statisticsSQL{quote: quotePostgresIdentifier}, `"events"`, "events", columns, 10, 20)
+	if err == nil || !contains(err.Error(), "more than 10 rows") || !contains(err.Error(), "DB_PROFILE_MAX_ROWS") {
+		t.Errorf("profileTable() error = %v, want row limit error", err)
+	}
+}
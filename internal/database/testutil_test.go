@@ -5,25 +5,56 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
 
 	"github.com/jhoffmann/go-database-mcp/internal/config"
 )
 
 // MockDatabase implements the Database interface for testing
 type MockDatabase struct {
-	ConnectFunc       func(ctx context.Context) error
-	CloseFunc         func() error
-	PingFunc          func(ctx context.Context) error
-	QueryFunc         func(ctx context.Context, query string, args ...any) (*sql.Rows, error)
-	QueryRowFunc      func(ctx context.Context, query string, args ...any) *sql.Row
-	ExecFunc          func(ctx context.Context, query string, args ...any) (sql.Result, error)
-	ListTablesFunc    func(ctx context.Context) ([]string, error)
-	ListDatabasesFunc func(ctx context.Context) ([]string, error)
-	DescribeTableFunc func(ctx context.Context, tableName string) (*TableSchema, error)
-	GetTableDataFunc  func(ctx context.Context, tableName string, limit int, offset int) (*TableData, error)
-	ExplainQueryFunc  func(ctx context.Context, query string) (string, error)
-	GetDBFunc         func() *sql.DB
-	GetDriverNameFunc func() string
+	ConnectFunc        func(ctx context.Context) error
+	CloseFunc          func() error
+	PingFunc           func(ctx context.Context) error
+	QueryFunc          func(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowFunc       func(ctx context.Context, query string, args ...any) *sql.Row
+	ExecFunc           func(ctx context.Context, query string, args ...any) (sql.Result, error)
+	BeginTxFunc        func(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	IsolationFunc      func(ctx context.Context) (string, error)
+	SettingsFunc       func(ctx context.Context, prefix string) ([]ServerSetting, error)
+	ServerInfoFunc     func(ctx context.Context) (*ServerInfo, error)
+	ServerVersionFunc  func(ctx context.Context) (string, error)
+	ReadOnlyFunc       func(ctx context.Context) (bool, error)
+	ActiveConnsFunc    func(ctx context.Context) ([]ActiveConnection, error)
+	KillConnFunc       func(ctx context.Context, pid int64) error
+	ListTablesFunc     func(ctx context.Context) ([]string, error)
+	ListDatabasesFunc  func(ctx context.Context) ([]string, error)
+	ListViewsFunc      func(ctx context.Context) ([]string, error)
+	DescribeViewFunc   func(ctx context.Context, viewName string) (*ViewSchema, error)
+	DescribeTableFunc  func(ctx context.Context, tableName string) (*TableSchema, error)
+	ColumnStatsFunc    func(ctx context.Context, tableName string) (map[string]ColumnStatsEstimate, error)
+	StatisticsFunc     func(ctx context.Context, tableName string) ([]ColumnStatistics, error)
+	ForeignKeysFunc    func(ctx context.Context) ([]ForeignKeyRelationship, error)
+	GetConstraintsFunc func(ctx context.Context, tableName string) ([]ConstraintInfo, error)
+	CreateStmtFunc     func(ctx context.Context, tableName string) (string, error)
+	SearchTablesFunc   func(ctx context.Context, pattern string) ([]string, error)
+	SearchColumnsFunc  func(ctx context.Context, pattern string) ([]ColumnSearchResult, error)
+	TableSizeFunc      func(ctx context.Context, tableName string) (*TableSizeInfo, error)
+	TableSizesFunc     func(ctx context.Context) ([]TableSizeInfo, error)
+	TableBloatFunc     func(ctx context.Context, tableName string) ([]TableBloatStats, error)
+	IndexUsageFunc     func(ctx context.Context) ([]IndexUsageInfo, error)
+	ProfileTableFunc   func(ctx context.Context, tableName string, maxDistinctValues int) (*TableProfile, error)
+	ListenFunc         func(ctx context.Context, channel string, handler func(payload string)) error
+	ColumnValuesFunc   func(ctx context.Context, tableName, columnName string, limit int) (*ColumnValues, error)
+	ListFunctionsFunc  func(ctx context.Context) ([]FunctionInfo, error)
+	DescribeFuncFunc   func(ctx context.Context, name string) (*FunctionSchema, error)
+	GetTableDataFunc   func(ctx context.Context, tableName string, limit int, offset int, opts TableDataOptions) (*TableData, error)
+	ExplainQueryFunc   func(ctx context.Context, query string) (string, error)
+	ExplainAnalyzeFunc func(ctx context.Context, query string) (string, error)
+	QuoteTableFunc     func(ctx context.Context, tableName string) string
+	GetDBFunc          func() *sql.DB
+	GetDriverNameFunc  func() string
 
 	// State tracking
 	Connected  bool
@@ -92,6 +123,62 @@ func (m *MockDatabase) Exec(ctx context.Context, query string, args ...any) (sql
 	return &MockResult{RowsAffectedValue: 1}, nil
 }
 
+func (m *MockDatabase) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	if m.BeginTxFunc != nil {
+		return m.BeginTxFunc(ctx, opts)
+	}
+	return nil, fmt.Errorf("mock begin transaction not implemented")
+}
+
+func (m *MockDatabase) GetIsolationLevel(ctx context.Context) (string, error) {
+	if m.IsolationFunc != nil {
+		return m.IsolationFunc(ctx)
+	}
+	return "read committed", nil
+}
+
+func (m *MockDatabase) GetServerSettings(ctx context.Context, prefix string) ([]ServerSetting, error) {
+	if m.SettingsFunc != nil {
+		return m.SettingsFunc(ctx, prefix)
+	}
+	return []ServerSetting{}, nil
+}
+
+func (m *MockDatabase) GetServerVersion(ctx context.Context) (string, error) {
+	if m.ServerVersionFunc != nil {
+		return m.ServerVersionFunc(ctx)
+	}
+	return "PostgreSQL 16.0", nil
+}
+
+func (m *MockDatabase) IsServerReadOnly(ctx context.Context) (bool, error) {
+	if m.ReadOnlyFunc != nil {
+		return m.ReadOnlyFunc(ctx)
+	}
+	return false, nil
+}
+
+func (m *MockDatabase) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	if m.ServerInfoFunc != nil {
+		return m.ServerInfoFunc(ctx)
+	}
+	return &ServerInfo{DatabaseType: "postgres", ServerVersion: "PostgreSQL 16.0"}, nil
+}
+
+func (m *MockDatabase) GetActiveConnections(ctx context.Context) ([]ActiveConnection, error) {
+	if m.ActiveConnsFunc != nil {
+		return m.ActiveConnsFunc(ctx)
+	}
+	return []ActiveConnection{}, nil
+}
+
+func (m *MockDatabase) KillConnection(ctx context.Context, pid int64) error {
+	if m.KillConnFunc != nil {
+		return m.KillConnFunc(ctx, pid)
+	}
+	return nil
+}
+
 func (m *MockDatabase) ListTables(ctx context.Context) ([]string, error) {
 	if m.ListTablesFunc != nil {
 		return m.ListTablesFunc(ctx)
@@ -106,6 +193,27 @@ func (m *MockDatabase) ListDatabases(ctx context.Context) ([]string, error) {
 	return []string{"db1", "db2"}, nil
 }
 
+func (m *MockDatabase) ListViews(ctx context.Context) ([]string, error) {
+	if m.ListViewsFunc != nil {
+		return m.ListViewsFunc(ctx)
+	}
+	return []string{"view1"}, nil
+}
+
+func (m *MockDatabase) DescribeView(ctx context.Context, viewName string) (*ViewSchema, error) {
+	if m.DescribeViewFunc != nil {
+		return m.DescribeViewFunc(ctx, viewName)
+	}
+	return &ViewSchema{
+		ViewName:   viewName,
+		Definition: "SELECT id, name FROM table1",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "INTEGER"},
+			{Name: "name", Type: "VARCHAR", IsNullable: true},
+		},
+	}, nil
+}
+
 func (m *MockDatabase) DescribeTable(ctx context.Context, tableName string) (*TableSchema, error) {
 	if m.DescribeTableFunc != nil {
 		return m.DescribeTableFunc(ctx, tableName)
@@ -119,9 +227,125 @@ func (m *MockDatabase) DescribeTable(ctx context.Context, tableName string) (*Ta
 	}, nil
 }
 
-func (m *MockDatabase) GetTableData(ctx context.Context, tableName string, limit int, offset int) (*TableData, error) {
+func (m *MockDatabase) EstimateColumnStats(ctx context.Context, tableName string) (map[string]ColumnStatsEstimate, error) {
+	if m.ColumnStatsFunc != nil {
+		return m.ColumnStatsFunc(ctx, tableName)
+	}
+	return map[string]ColumnStatsEstimate{}, nil
+}
+
+func (m *MockDatabase) GetColumnStatistics(ctx context.Context, tableName string) ([]ColumnStatistics, error) {
+	if m.StatisticsFunc != nil {
+		return m.StatisticsFunc(ctx, tableName)
+	}
+	return []ColumnStatistics{}, nil
+}
+
+func (m *MockDatabase) GetCreateStatement(ctx context.Context, tableName string) (string, error) {
+	if m.CreateStmtFunc != nil {
+		return m.CreateStmtFunc(ctx, tableName)
+	}
+	return "", nil
+}
+
+func (m *MockDatabase) SearchTables(ctx context.Context, pattern string) ([]string, error) {
+	if m.SearchTablesFunc != nil {
+		return m.SearchTablesFunc(ctx, pattern)
+	}
+	return []string{}, nil
+}
+
+func (m *MockDatabase) SearchColumns(ctx context.Context, pattern string) ([]ColumnSearchResult, error) {
+	if m.SearchColumnsFunc != nil {
+		return m.SearchColumnsFunc(ctx, pattern)
+	}
+	return []ColumnSearchResult{}, nil
+}
+
+func (m *MockDatabase) GetTableSize(ctx context.Context, tableName string) (*TableSizeInfo, error) {
+	if m.TableSizeFunc != nil {
+		return m.TableSizeFunc(ctx, tableName)
+	}
+	return &TableSizeInfo{TableName: tableName}, nil
+}
+
+func (m *MockDatabase) ListTableSizes(ctx context.Context) ([]TableSizeInfo, error) {
+	if m.TableSizesFunc != nil {
+		return m.TableSizesFunc(ctx)
+	}
+	return []TableSizeInfo{}, nil
+}
+
+func (m *MockDatabase) GetTableBloat(ctx context.Context, tableName string) ([]TableBloatStats, error) {
+	if m.TableBloatFunc != nil {
+		return m.TableBloatFunc(ctx, tableName)
+	}
+	return []TableBloatStats{}, nil
+}
+
+func (m *MockDatabase) GetIndexUsage(ctx context.Context) ([]IndexUsageInfo, error) {
+	if m.IndexUsageFunc != nil {
+		return m.IndexUsageFunc(ctx)
+	}
+	return []IndexUsageInfo{}, nil
+}
+
+func (m *MockDatabase) ProfileTable(ctx context.Context, tableName string, maxDistinctValues int) (*TableProfile, error) {
+	if m.ProfileTableFunc != nil {
+		return m.ProfileTableFunc(ctx, tableName, maxDistinctValues)
+	}
+	return nil, nil
+}
+
+func (m *MockDatabase) ListenForNotifications(ctx context.Context, channel string, handler func(payload string)) error {
+	if m.ListenFunc != nil {
+		return m.ListenFunc(ctx, channel, handler)
+	}
+	return nil
+}
+
+func (m *MockDatabase) Unlisten(ctx context.Context, channel string) error {
+	return nil
+}
+
+func (m *MockDatabase) GetColumnValues(ctx context.Context, tableName, columnName string, limit int) (*ColumnValues, error) {
+	if m.ColumnValuesFunc != nil {
+		return m.ColumnValuesFunc(ctx, tableName, columnName, limit)
+	}
+	return &ColumnValues{TableName: tableName, ColumnName: columnName, Values: []any{}}, nil
+}
+
+func (m *MockDatabase) ListFunctions(ctx context.Context) ([]FunctionInfo, error) {
+	if m.ListFunctionsFunc != nil {
+		return m.ListFunctionsFunc(ctx)
+	}
+	return []FunctionInfo{}, nil
+}
+
+func (m *MockDatabase) DescribeFunction(ctx context.Context, name string) (*FunctionSchema, error) {
+	if m.DescribeFuncFunc != nil {
+		return m.DescribeFuncFunc(ctx, name)
+	}
+	return &FunctionSchema{FunctionInfo: FunctionInfo{Name: name, Type: "function"}}, nil
+}
+
+func (m *MockDatabase) GetConstraints(ctx context.Context, tableName string) ([]ConstraintInfo, error) {
+	if m.GetConstraintsFunc != nil {
+		return m.GetConstraintsFunc(ctx, tableName)
+	}
+	return []ConstraintInfo{}, nil
+}
+
+func (m *MockDatabase) ListForeignKeys(ctx context.Context) ([]ForeignKeyRelationship, error) {
+	if m.ForeignKeysFunc != nil {
+		return m.ForeignKeysFunc(ctx)
+	}
+	return []ForeignKeyRelationship{}, nil
+}
+
+func (m *MockDatabase) GetTableData(ctx context.Context, tableName string, limit int, offset int, opts TableDataOptions) (*TableData, error) {
 	if m.GetTableDataFunc != nil {
-		return m.GetTableDataFunc(ctx, tableName, limit, offset)
+		return m.GetTableDataFunc(ctx, tableName, limit, offset, opts)
 	}
 	return &TableData{
 		TableName: tableName,
@@ -143,6 +367,20 @@ func (m *MockDatabase) ExplainQuery(ctx context.Context, query string) (string,
 	return `{"query_plan": "mock"}`, nil
 }
 
+func (m *MockDatabase) ExplainAnalyzeQuery(ctx context.Context, query string) (string, error) {
+	if m.ExplainAnalyzeFunc != nil {
+		return m.ExplainAnalyzeFunc(ctx, query)
+	}
+	return `{"query_plan": "mock", "analyzed": true}`, nil
+}
+
+func (m *MockDatabase) QuoteTable(ctx context.Context, tableName string) string {
+	if m.QuoteTableFunc != nil {
+		return m.QuoteTableFunc(ctx, tableName)
+	}
+	return QuoteIdentifier("postgres", tableName)
+}
+
 func (m *MockDatabase) GetDB() *sql.DB {
 	if m.GetDBFunc != nil {
 		return m.GetDBFunc()
@@ -189,6 +427,7 @@ type MockConn struct {
 	PrepareFunc func(query string) (driver.Stmt, error)
 	CloseFunc   func() error
 	BeginFunc   func() (driver.Tx, error)
+	BeginTxFunc func(ctx context.Context, opts driver.TxOptions) (driver.Tx, error)
 }
 
 func (m *MockConn) Prepare(query string) (driver.Stmt, error) {
@@ -212,6 +451,13 @@ func (m *MockConn) Begin() (driver.Tx, error) {
 	return &MockTx{}, nil
 }
 
+func (m *MockConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if m.BeginTxFunc != nil {
+		return m.BeginTxFunc(ctx, opts)
+	}
+	return m.Begin()
+}
+
 // MockStmt implements driver.Stmt for testing
 type MockStmt struct{}
 
@@ -241,6 +487,145 @@ func (m *MockRows) Columns() []string              { return []string{"id", "name
 func (m *MockRows) Close() error                   { m.closed = true; return nil }
 func (m *MockRows) Next(dest []driver.Value) error { return fmt.Errorf("no more rows") }
 
+// QueryRecorder collects the SQL text prepared against a recording driver,
+// along with the arguments of every execution and the options of every transaction it started.
+type QueryRecorder struct {
+	mu        sync.Mutex
+	queries   []string
+	args      [][]driver.Value
+	txOptions []driver.TxOptions
+}
+
+// Args returns a copy of the arguments of every recorded statement execution.
+func (r *QueryRecorder) Args() [][]driver.Value {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]driver.Value(nil), r.args...)
+}
+
+// recordingStmt is a MockStmt accepting any number of arguments and recording them.
+type recordingStmt struct {
+	MockStmt
+	recorder *QueryRecorder
+}
+
+func (s *recordingStmt) NumInput() int { return -1 }
+
+func (s *recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.record(args)
+	return s.MockStmt.Exec(args)
+}
+
+func (s *recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.record(args)
+	return s.MockStmt.Query(args)
+}
+
+func (s *recordingStmt) record(args []driver.Value) {
+	s.recorder.mu.Lock()
+	s.recorder.args = append(s.recorder.args, args)
+	s.recorder.mu.Unlock()
+}
+
+// Queries returns a copy of the recorded SQL statements.
+func (r *QueryRecorder) Queries() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.queries...)
+}
+
+var recordingDriverCount atomic.Int64
+
+// TxOptions returns a copy of the recorded transaction options.
+func (r *QueryRecorder) TxOptions() []driver.TxOptions {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]driver.TxOptions(nil), r.txOptions...)
+}
+
+// NewRecordingDB opens a *sql.DB backed by a mock driver that records every prepared statement.
+func NewRecordingDB() (*sql.DB, *QueryRecorder) {
+	recorder := &QueryRecorder{}
+	name := fmt.Sprintf("recording-%d", recordingDriverCount.Add(1))
+
+	sql.Register(name, &MockDriver{
+		OpenFunc: func(string) (driver.Conn, error) {
+			return &MockConn{
+				PrepareFunc: func(query string) (driver.Stmt, error) {
+					recorder.mu.Lock()
+					recorder.queries = append(recorder.queries, query)
+					recorder.mu.Unlock()
+					return &recordingStmt{recorder: recorder}, nil
+				},
+				BeginTxFunc: func(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+					recorder.mu.Lock()
+					recorder.txOptions = append(recorder.txOptions, opts)
+					recorder.mu.Unlock()
+					return &MockTx{}, nil
+				},
+			}, nil
+		},
+	})
+
+	db, _ := sql.Open(name, "")
+	return db, recorder
+}
+
+// rowsStmt is a MockStmt whose queries return fixed columns and rows.
+type rowsStmt struct {
+	MockStmt
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (s *rowsStmt) NumInput() int { return -1 }
+
+func (s *rowsStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &valueRows{columns: s.columns, rows: s.rows}, nil
+}
+
+// valueRows implements driver.Rows over a fixed set of rows.
+type valueRows struct {
+	columns []string
+	rows    [][]driver.Value
+	next    int
+}
+
+func (r *valueRows) Columns() []string { return r.columns }
+func (r *valueRows) Close() error      { return nil }
+
+func (r *valueRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+var rowsDriverCount atomic.Int64
+
+// NewRowsDB opens a *sql.DB backed by a mock driver whose queries return the columns and
+// rows results chooses for their SQL text. Queries it returns no rows for yield an empty
+// result.
+func NewRowsDB(results func(query string) ([]string, [][]driver.Value)) *sql.DB {
+	name := fmt.Sprintf("rows-%d", rowsDriverCount.Add(1))
+
+	sql.Register(name, &MockDriver{
+		OpenFunc: func(string) (driver.Conn, error) {
+			return &MockConn{
+				PrepareFunc: func(query string) (driver.Stmt, error) {
+					columns, rows := results(query)
+					return &rowsStmt{columns: columns, rows: rows}, nil
+				},
+			}, nil
+		},
+	})
+
+	db, _ := sql.Open(name, "")
+	return db
+}
+
 // NewTestConfig returns a valid test configuration
 func NewTestConfig(dbType string) config.DatabaseConfig {
 	port := 5432
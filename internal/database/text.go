@@ -0,0 +1,37 @@
+package database
+
+import "strings"
+
+// textTypes are database type names whose values are character strings.
+var textTypes = map[string]bool{
+	"CHAR":       true,
+	"VARCHAR":    true,
+	"TEXT":       true,
+	"TINYTEXT":   true,
+	"MEDIUMTEXT": true,
+	"LONGTEXT":   true,
+	"BPCHAR":     true,
+	"NAME":       true,
+	"CITEXT":     true,
+}
+
+// IsTextType reports whether columns of the given database type hold character strings.
+func IsTextType(typeName string) bool {
+	return textTypes[strings.ToUpper(typeName)]
+}
+
+// TrimString removes leading and trailing whitespace from a string value, as done for
+// DB_TRIM_STRINGS. A []byte value is trimmed and returned as a string only when typeName
+// is a character type (see IsTextType), so binary data is never altered. Other values,
+// including NULL, are returned unchanged.
+func TrimString(value any, typeName string) any {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case []byte:
+		if IsTextType(typeName) {
+			return strings.TrimSpace(string(v))
+		}
+	}
+	return value
+}
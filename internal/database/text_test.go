@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTrimString(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		typeName string
+		want     any
+	}{
+		{"string", "  padded value \t\n", "TEXT", "padded value"},
+		{"internal whitespace kept", " two  words ", "VARCHAR", "two  words"},
+		{"fixed width char", []byte("abc       "), "BPCHAR", "abc"},
+		{"mysql text bytes", []byte(" name "), "varchar", "name"},
+		{"binary bytes unchanged", []byte(" \x00 "), "BLOB", []byte(" \x00 ")},
+		{"number unchanged", int64(7), "INT8", int64(7)},
+		{"null", nil, "TEXT", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TrimString(tt.value, tt.typeName); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TrimString(%#v, %q) = %#v, want %#v", tt.value, tt.typeName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostgreSQL_GetTableData_TrimStrings(t *testing.T) {
+	tests := []struct {
+		name string
+		trim bool
+		want any
+	}{
+		{name: "disabled by default", want: "  Jane  Doe  "},
+		{name: "enabled", trim: true, want: "Jane  Doe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewTestConfig("postgres")
+			cfg.TrimStrings = tt.trim
+
+			sqlDB := NewRowsDB(func(query string) ([]string, [][]driver.Value) {
+				if strings.HasPrefix(query, "SELECT COUNT(*)") {
+					return []string{"count"}, [][]driver.Value{{int64(1)}}
+				}
+				return []string{"id", "name"}, [][]driver.Value{{int64(1), "  Jane  Doe  "}}
+			})
+			defer sqlDB.Close()
+
+			data, err := (&PostgreSQL{db: sqlDB, config: cfg}).GetTableData(context.Background(), "users", 10, 0, TableDataOptions{})
+			if err != nil {
+				t.Fatalf("GetTableData() error = %v", err)
+			}
+			if len(data.Rows) != 1 {
+				t.Fatalf("Expected 1 row, got %d", len(data.Rows))
+			}
+			if got := data.Rows[0]["name"]; got != tt.want {
+				t.Errorf("name = %#v, want %#v", got, tt.want)
+			}
+			if got := data.Rows[0]["id"]; got != int64(1) {
+				t.Errorf("id = %#v, want 1", got)
+			}
+		})
+	}
+}
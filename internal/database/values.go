@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// collectColumnValues returns up to limit distinct values of column in table, sorted, with
+// NULL counted as a value. One extra row is fetched to detect truncation, in which case the
+// distinct values are counted separately. Rows are read from the quoted table reference from,
+// the column is quoted with quote and the limit is bound with placeholder; callers must still
+// check both names against the schema.
+func collectColumnValues(ctx context.Context, db Database, quote func(string) string, placeholder func(int) string, from, table, column string, limit int) (*ColumnValues, error) {
+	query := fmt.Sprintf("SELECT DISTINCT %s FROM %s ORDER BY %s LIMIT %s",
+		quote(column), from, quote(column), placeholder(1))
+	rows, err := db.Query(ctx, query, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query distinct values: %w", err)
+	}
+	defer rows.Close()
+
+	values := []any{}
+	for rows.Next() {
+		var value any
+		if err := rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("failed to scan value: %w", err)
+		}
+		// Drivers return text and many other types as raw bytes
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+		values = append(values, value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading values: %w", err)
+	}
+
+	result := &ColumnValues{
+		TableName:     table,
+		ColumnName:    column,
+		Values:        values,
+		DistinctCount: int64(len(values)),
+	}
+
+	if len(values) > limit {
+		result.Values = values[:limit]
+		result.Truncated = true
+
+		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM (SELECT DISTINCT %s FROM %s) distinct_values", quote(column), from)
+		if err := db.QueryRow(ctx, countQuery).Scan(&result.DistinctCount); err != nil {
+			return nil, fmt.Errorf("failed to count distinct values: %w", err)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func TestGetColumnValues_Query(t *testing.T) {
+	tests := []struct {
+		name string
+		open func(sqlDB *sql.DB) Database
+		want string
+	}{
+		{
+			name: "postgres",
+			open: func(sqlDB *sql.DB) Database { return &PostgreSQL{db: sqlDB, config: NewTestConfig("postgres")} },
+			want: `SELECT DISTINCT "status" FROM "public"."orders" ORDER BY "status" LIMIT $1`,
+		},
+		{
+			name: "mysql",
+			open: func(sqlDB *sql.DB) Database { return &MySQL{db: sqlDB, config: NewTestConfig("mysql")} },
+			want: "SELECT DISTINCT `status` FROM `orders` ORDER BY `status` LIMIT ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, recorder := NewRecordingDB()
+			defer sqlDB.Close()
+
+			// The mock driver returns no rows, so only the issued statement is checked
+			_, _ = tt.open(sqlDB).GetColumnValues(context.Background(), "orders", "status", 10)
+
+			queries := recorder.Queries()
+			if len(queries) == 0 || queries[0] != tt.want {
+				t.Errorf("Expected query %q, got %v", tt.want, queries)
+			}
+		})
+	}
+}
+
+func TestGetColumnValues_QuotesIdentifiers(t *testing.T) {
+	sqlDB, recorder := NewRecordingDB()
+	defer sqlDB.Close()
+
+	db := &PostgreSQL{db: sqlDB, config: NewTestConfig("postgres")}
+	_, _ = db.GetColumnValues(context.Background(), `orders"; DROP TABLE users; --`, "status", 10)
+
+	queries := recorder.Queries()
+	if len(queries) == 0 || !contains(queries[0], `FROM "public"."orders""; DROP TABLE users; --"`) {
+		t.Errorf("Expected the table name to be quoted, got %v", queries)
+	}
+}
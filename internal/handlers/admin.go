@@ -3,7 +3,9 @@ package handlers
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jhoffmann/go-database-mcp/internal/database"
@@ -19,6 +21,51 @@ type ConnectionInfo struct {
 	Driver    string `json:"driver"`    // Database driver name
 	Connected bool   `json:"connected"` // Whether currently connected
 	PingTime  string `json:"ping_time"` // Time taken to ping database
+
+	IsolationLevel string `json:"isolation_level,omitempty"` // Effective transaction isolation level
+	ServerVersion  string `json:"server_version,omitempty"`  // Version string reported by the server
+	ServerReadOnly bool   `json:"server_read_only"`          // Whether the server only accepts reads (replica or in recovery)
+
+	Pool *PoolStats `json:"pool,omitempty"` // Connection pool statistics, when a pool is open
+}
+
+// PoolStats reports the state of the connection pool, taken from sql.DBStats.
+type PoolStats struct {
+	MaxOpenConnections int    `json:"max_open_connections"` // Configured maximum number of open connections (0 is unlimited)
+	OpenConnections    int    `json:"open_connections"`     // Connections currently open, in use or idle
+	InUse              int    `json:"in_use"`               // Connections currently in use
+	Idle               int    `json:"idle"`                 // Idle connections
+	WaitCount          int64  `json:"wait_count"`           // Total number of times a caller waited for a connection
+	WaitDuration       string `json:"wait_duration"`        // Total time spent waiting for a connection
+	MaxIdleClosed      int64  `json:"max_idle_closed"`      // Connections closed because of the idle connection limit
+	MaxLifetimeClosed  int64  `json:"max_lifetime_closed"`  // Connections closed because they reached their maximum lifetime
+}
+
+// newPoolStats converts sql.DBStats into PoolStats.
+func newPoolStats(stats sql.DBStats) *PoolStats {
+	return &PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDuration:       stats.WaitDuration.String(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+}
+
+// Summary renders the pool state as a single human-readable line, for example
+// "Pool: 5/25 connections in use (20%), 0 waits (0s)".
+func (p *PoolStats) Summary() string {
+	waits := fmt.Sprintf("%d waits (%s)", p.WaitCount, p.WaitDuration)
+	if p.MaxOpenConnections <= 0 {
+		return fmt.Sprintf("Pool: %d connections in use (no limit), %d idle, %s", p.InUse, p.Idle, waits)
+	}
+
+	usage := float64(p.InUse) / float64(p.MaxOpenConnections) * 100
+	return fmt.Sprintf("Pool: %d/%d connections in use (%.0f%%), %d idle, %s",
+		p.InUse, p.MaxOpenConnections, usage, p.Idle, waits)
 }
 
 // NewAdminHandler creates a new AdminHandler instance.
@@ -28,15 +75,132 @@ func NewAdminHandler(db database.Database) *AdminHandler {
 	}
 }
 
-// GetConnectionInfo retrieves information about the current database connection.
+// GetConnectionInfo retrieves information about the current database connection,
+// including whether the server is read-only and connection pool statistics for
+// diagnosing pool exhaustion.
 func (h *AdminHandler) GetConnectionInfo(ctx context.Context) (*ConnectionInfo, error) {
 	start := time.Now()
 	err := h.db.Ping(ctx)
 	pingDuration := time.Since(start)
 
-	return &ConnectionInfo{
+	info := &ConnectionInfo{
 		Driver:    h.db.GetDriverName(),
 		Connected: err == nil,
 		PingTime:  fmt.Sprintf("%.2fms", float64(pingDuration.Nanoseconds())/1e6),
-	}, nil
+	}
+
+	// The isolation level and version are informational; leave them empty if they cannot be read
+	if info.Connected {
+		if level, err := h.db.GetIsolationLevel(ctx); err == nil {
+			info.IsolationLevel = level
+		}
+		if version, err := h.db.GetServerVersion(ctx); err == nil {
+			info.ServerVersion = version
+		}
+		if readOnly, err := h.db.IsServerReadOnly(ctx); err == nil {
+			info.ServerReadOnly = readOnly
+		}
+	}
+
+	if sqlDB := h.db.GetDB(); sqlDB != nil {
+		info.Pool = newPoolStats(sqlDB.Stats())
+	}
+
+	return info, nil
+}
+
+// GetServerInfo returns the server version, character set or encoding, current database and
+// user, uptime and connection limit, so that generated SQL can match the server's capabilities.
+func (h *AdminHandler) GetServerInfo(ctx context.Context) (*database.ServerInfo, error) {
+	info, err := h.db.GetServerInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server info: %w", err)
+	}
+	return info, nil
+}
+
+// ActiveConnectionsResult represents the result of listing active server sessions.
+type ActiveConnectionsResult struct {
+	Connections []database.ActiveConnection `json:"connections"` // Non-idle sessions, longest running first
+	Count       int                         `json:"count"`       // Number of sessions
+}
+
+// GetActiveConnections returns the sessions currently running a statement or inside a
+// transaction on the database server, with their statement text truncated.
+func (h *AdminHandler) GetActiveConnections(ctx context.Context) (*ActiveConnectionsResult, error) {
+	connections, err := h.db.GetActiveConnections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active connections: %w", err)
+	}
+	return &ActiveConnectionsResult{Connections: connections, Count: len(connections)}, nil
+}
+
+// KillConnectionResult represents a terminated server session.
+type KillConnectionResult struct {
+	PID        int64 `json:"pid"`        // Process ID of the session
+	Terminated bool  `json:"terminated"` // Whether the session was terminated
+}
+
+// KillConnection terminates the server session with the given process ID, e.g. to stop a
+// stuck query or release its locks. It is refused when readOnly is set (DB_READ_ONLY),
+// and by the database for the session running the check or a PID that does not exist.
+func (h *AdminHandler) KillConnection(ctx context.Context, pid int64, readOnly bool) (*KillConnectionResult, error) {
+	if readOnly {
+		return nil, fmt.Errorf("read-only mode: terminating connections is not permitted")
+	}
+	if pid <= 0 {
+		return nil, fmt.Errorf("pid must be positive")
+	}
+
+	if err := h.db.KillConnection(ctx, pid); err != nil {
+		return nil, fmt.Errorf("failed to kill connection: %w", err)
+	}
+	return &KillConnectionResult{PID: pid, Terminated: true}, nil
+}
+
+// GetPoolStats returns the connection pool statistics without pinging the database,
+// so it stays responsive even when every connection is busy.
+func (h *AdminHandler) GetPoolStats(ctx context.Context) (*PoolStats, error) {
+	sqlDB := h.db.GetDB()
+	if sqlDB == nil {
+		return nil, fmt.Errorf("no connection pool is open")
+	}
+	return newPoolStats(sqlDB.Stats()), nil
+}
+
+// MaxServerSettings is the largest number of settings GetServerSettings returns.
+const MaxServerSettings = 200
+
+// ServerSettingsResult represents the result of listing server configuration parameters.
+type ServerSettingsResult struct {
+	Settings  []database.ServerSetting `json:"settings"`            // Matching settings, sorted by name
+	Count     int                      `json:"count"`               // Number of settings returned
+	Total     int                      `json:"total"`               // Number of settings matching the filter
+	Truncated bool                     `json:"truncated,omitempty"` // Whether more settings matched than were returned
+}
+
+// GetServerSettings returns the server configuration parameters whose name starts with
+// prefix (all of them when empty), at most limit of them. A limit of zero returns up to
+// MaxServerSettings, which also caps larger limits. Values of parameters that may hold
+// credentials are redacted.
+func (h *AdminHandler) GetServerSettings(ctx context.Context, prefix string, limit int) (*ServerSettingsResult, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("limit cannot be negative")
+	}
+	if limit == 0 || limit > MaxServerSettings {
+		limit = MaxServerSettings
+	}
+
+	settings, err := h.db.GetServerSettings(ctx, strings.TrimSpace(prefix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server settings: %w", err)
+	}
+
+	result := &ServerSettingsResult{Settings: settings, Total: len(settings)}
+	if len(settings) > limit {
+		result.Settings = settings[:limit]
+		result.Truncated = true
+	}
+	result.Count = len(result.Settings)
+	return result, nil
 }
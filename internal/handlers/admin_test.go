@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+func TestAdminHandler_GetConnectionInfo(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockDB        *MockDatabase
+		wantIsolation string
+		wantVersion   string
+		wantReadOnly  bool
+	}{
+		{
+			name:          "postgres isolation level",
+			mockDB:        &MockDatabase{driver: "postgres", isolationLevel: "read committed", serverVersion: "PostgreSQL 16.2"},
+			wantIsolation: "read committed",
+			wantVersion:   "PostgreSQL 16.2",
+		},
+		{
+			name:          "mysql isolation level",
+			mockDB:        &MockDatabase{driver: "mysql", isolationLevel: "REPEATABLE-READ", serverVersion: "8.0.36"},
+			wantIsolation: "REPEATABLE-READ",
+			wantVersion:   "8.0.36",
+		},
+		{
+			name:          "read-only replica",
+			mockDB:        &MockDatabase{driver: "postgres", isolationLevel: "read committed", serverReadOnly: true},
+			wantIsolation: "read committed",
+			wantReadOnly:  true,
+		},
+		{
+			name:          "isolation level and version unavailable",
+			mockDB:        &MockDatabase{driver: "postgres", shouldReturnError: true, errorMessage: "permission denied"},
+			wantIsolation: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAdminHandler(tt.mockDB)
+
+			info, err := handler.GetConnectionInfo(context.Background())
+			if err != nil {
+				t.Fatalf("GetConnectionInfo() error = %v", err)
+			}
+
+			if info.Driver != tt.mockDB.driver {
+				t.Errorf("Expected driver %s, got %s", tt.mockDB.driver, info.Driver)
+			}
+			if !info.Connected {
+				t.Error("Expected connection to be reported as connected")
+			}
+			if info.IsolationLevel != tt.wantIsolation {
+				t.Errorf("Expected isolation level %q, got %q", tt.wantIsolation, info.IsolationLevel)
+			}
+			if info.ServerVersion != tt.wantVersion {
+				t.Errorf("Expected server version %q, got %q", tt.wantVersion, info.ServerVersion)
+			}
+			if info.ServerReadOnly != tt.wantReadOnly {
+				t.Errorf("Expected server read-only %v, got %v", tt.wantReadOnly, info.ServerReadOnly)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_GetConnectionInfo_PoolStats(t *testing.T) {
+	sqlDB := newMockSQLDB(t, &mockResultSet{columns: []string{"id"}})
+	sqlDB.SetMaxOpenConns(7)
+
+	// Hold one connection so the pool reports it as in use
+	conn, err := sqlDB.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("failed to open connection: %v", err)
+	}
+	defer conn.Close()
+
+	handler := NewAdminHandler(&MockDatabase{driver: "postgres", sqlDB: sqlDB})
+	info, err := handler.GetConnectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetConnectionInfo() error = %v", err)
+	}
+
+	if info.Pool == nil {
+		t.Fatal("Expected pool statistics")
+	}
+	if info.Pool.MaxOpenConnections != 7 {
+		t.Errorf("Expected max open connections 7, got %d", info.Pool.MaxOpenConnections)
+	}
+	if info.Pool.OpenConnections != 1 || info.Pool.InUse != 1 || info.Pool.Idle != 0 {
+		t.Errorf("Expected 1 open connection in use, got %+v", info.Pool)
+	}
+	if info.Pool.WaitDuration != "0s" {
+		t.Errorf("Expected no wait time, got %s", info.Pool.WaitDuration)
+	}
+}
+
+func TestAdminHandler_GetConnectionInfo_NoPool(t *testing.T) {
+	info, err := NewAdminHandler(&MockDatabase{driver: "mysql"}).GetConnectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetConnectionInfo() error = %v", err)
+	}
+	if info.Pool != nil {
+		t.Errorf("Expected no pool statistics without an open pool, got %+v", info.Pool)
+	}
+}
+
+func TestAdminHandler_GetPoolStats(t *testing.T) {
+	sqlDB := newMockSQLDB(t, &mockResultSet{columns: []string{"id"}})
+	sqlDB.SetMaxOpenConns(4)
+
+	conn, err := sqlDB.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("failed to open connection: %v", err)
+	}
+	defer conn.Close()
+
+	handler := NewAdminHandler(&MockDatabase{driver: "mysql", sqlDB: sqlDB})
+	stats, err := handler.GetPoolStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetPoolStats() error = %v", err)
+	}
+
+	if stats.MaxOpenConnections != 4 || stats.InUse != 1 {
+		t.Errorf("Expected 1 of 4 connections in use, got %+v", stats)
+	}
+	if want := "Pool: 1/4 connections in use (25%), 0 idle, 0 waits (0s)"; stats.Summary() != want {
+		t.Errorf("Summary() = %q, want %q", stats.Summary(), want)
+	}
+}
+
+func TestAdminHandler_GetPoolStats_NoPool(t *testing.T) {
+	handler := NewAdminHandler(&MockDatabase{driver: "postgres"})
+	if _, err := handler.GetPoolStats(context.Background()); err == nil {
+		t.Error("Expected an error without an open pool")
+	}
+}
+
+func TestPoolStats_Summary(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats PoolStats
+		want  string
+	}{
+		{
+			name:  "limited pool",
+			stats: PoolStats{MaxOpenConnections: 25, OpenConnections: 7, InUse: 5, Idle: 2, WaitDuration: "0s"},
+			want:  "Pool: 5/25 connections in use (20%), 2 idle, 0 waits (0s)",
+		},
+		{
+			name:  "exhausted pool with waiters",
+			stats: PoolStats{MaxOpenConnections: 10, OpenConnections: 10, InUse: 10, WaitCount: 42, WaitDuration: "3.5s"},
+			want:  "Pool: 10/10 connections in use (100%), 0 idle, 42 waits (3.5s)",
+		},
+		{
+			name:  "unlimited pool",
+			stats: PoolStats{OpenConnections: 3, InUse: 3, WaitDuration: "0s"},
+			want:  "Pool: 3 connections in use (no limit), 0 idle, 0 waits (0s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stats.Summary(); got != tt.want {
+				t.Errorf("Summary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_GetServerInfo(t *testing.T) {
+	want := &database.ServerInfo{
+		DatabaseType:    "postgres",
+		ServerVersion:   "PostgreSQL 16.2 on x86_64-pc-linux-gnu",
+		ServerEncoding:  "UTF8",
+		CurrentDatabase: "app",
+		CurrentUser:     "reader",
+		Uptime:          "72h0m5s",
+		MaxConnections:  100,
+	}
+
+	info, err := NewAdminHandler(&MockDatabase{driver: "postgres", serverInfo: want}).GetServerInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetServerInfo() error = %v", err)
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("GetServerInfo() = %+v, want %+v", info, want)
+	}
+
+	failing := NewAdminHandler(&MockDatabase{driver: "mysql", shouldReturnError: true, errorMessage: "access denied"})
+	if _, err := failing.GetServerInfo(context.Background()); err == nil || !strings.Contains(err.Error(), "failed to get server info: access denied") {
+		t.Errorf("Expected wrapped error, got %v", err)
+	}
+}
+
+func TestAdminHandler_GetActiveConnections(t *testing.T) {
+	want := []database.ActiveConnection{
+		{PID: 4242, User: "app", Host: "10.0.0.7", Database: "shop", State: "active", QueryText: "SELECT pg_sleep(60)", DurationSeconds: 12.5},
+	}
+
+	result, err := NewAdminHandler(&MockDatabase{driver: "postgres", activeConns: want}).GetActiveConnections(context.Background())
+	if err != nil {
+		t.Fatalf("GetActiveConnections() error = %v", err)
+	}
+	if result.Count != 1 || !reflect.DeepEqual(result.Connections, want) {
+		t.Errorf("GetActiveConnections() = %+v, want %+v", result, want)
+	}
+
+	failing := NewAdminHandler(&MockDatabase{driver: "mysql", shouldReturnError: true, errorMessage: "insufficient privileges"})
+	if _, err := failing.GetActiveConnections(context.Background()); err == nil || !strings.Contains(err.Error(), "failed to get active connections: insufficient privileges") {
+		t.Errorf("Expected wrapped error, got %v", err)
+	}
+}
+
+func TestAdminHandler_KillConnection(t *testing.T) {
+	tests := []struct {
+		name     string
+		mockDB   *MockDatabase
+		pid      int64
+		readOnly bool
+		wantErr  string
+	}{
+		{name: "terminates session", mockDB: &MockDatabase{driver: "postgres"}, pid: 4242},
+		{name: "read-only mode", mockDB: &MockDatabase{driver: "postgres"}, pid: 4242, readOnly: true, wantErr: "read-only mode"},
+		{name: "invalid pid", mockDB: &MockDatabase{driver: "mysql"}, pid: 0, wantErr: "pid must be positive"},
+		{
+			name:    "database refuses",
+			mockDB:  &MockDatabase{driver: "mysql", shouldReturnError: true, errorMessage: "connection 4242 does not exist"},
+			pid:     4242,
+			wantErr: "failed to kill connection: connection 4242 does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewAdminHandler(tt.mockDB).KillConnection(context.Background(), tt.pid, tt.readOnly)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("KillConnection() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				if tt.mockDB.killedPID != 0 {
+					t.Errorf("Expected no session to be terminated, got %d", tt.mockDB.killedPID)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("KillConnection() error = %v", err)
+			}
+			if !result.Terminated || result.PID != tt.pid || tt.mockDB.killedPID != tt.pid {
+				t.Errorf("Expected session %d to be terminated, got %+v (killed %d)", tt.pid, result, tt.mockDB.killedPID)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_GetServerSettings(t *testing.T) {
+	settings := make([]database.ServerSetting, MaxServerSettings+50)
+	for i := range settings {
+		settings[i] = database.ServerSetting{Name: fmt.Sprintf("setting_%03d", i), Value: "on"}
+	}
+
+	tests := []struct {
+		name          string
+		limit         int
+		wantCount     int
+		wantTruncated bool
+		wantErr       string
+	}{
+		{name: "default cap", wantCount: MaxServerSettings, wantTruncated: true},
+		{name: "explicit limit", limit: 10, wantCount: 10, wantTruncated: true},
+		{name: "limit above cap", limit: 1000, wantCount: MaxServerSettings, wantTruncated: true},
+		{name: "limit below total", limit: 100, wantCount: 100, wantTruncated: true},
+		{name: "negative limit", limit: -1, wantErr: "limit cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAdminHandler(&MockDatabase{driver: "postgres", serverSettings: settings})
+			result, err := handler.GetServerSettings(context.Background(), "setting_", tt.limit)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("GetServerSettings() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetServerSettings() error = %v", err)
+			}
+			if result.Count != tt.wantCount || len(result.Settings) != tt.wantCount {
+				t.Errorf("Expected %d settings, got %d (%d listed)", tt.wantCount, result.Count, len(result.Settings))
+			}
+			if result.Total != len(settings) || result.Truncated != tt.wantTruncated {
+				t.Errorf("Expected total %d and truncated %v, got %+v", len(settings), tt.wantTruncated, result)
+			}
+		})
+	}
+
+	small := NewAdminHandler(&MockDatabase{driver: "mysql", serverSettings: settings[:3]})
+	if result, err := small.GetServerSettings(context.Background(), "", 0); err != nil || result.Count != 3 || result.Truncated {
+		t.Errorf("Expected all 3 settings without truncation, got %+v, %v", result, err)
+	}
+
+	failing := NewAdminHandler(&MockDatabase{driver: "postgres", shouldReturnError: true, errorMessage: "permission denied"})
+	if _, err := failing.GetServerSettings(context.Background(), "", 0); err == nil || !strings.Contains(err.Error(), "failed to get server settings: permission denied") {
+		t.Errorf("Expected wrapped error, got %v", err)
+	}
+}
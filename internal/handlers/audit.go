@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditEntry is a single audit log record describing one query execution.
+type AuditEntry struct {
+	Timestamp    time.Time `json:"ts"`               // Time the query finished
+	Client       string    `json:"client,omitempty"` // MCP client identity, if reported
+	Query        string    `json:"query"`            // Query text with credentials redacted
+	ArgsCount    int       `json:"args_count"`       // Number of bound parameters
+	Type         string    `json:"type"`             // Query type: select, insert, update, delete, ddl
+	RowsAffected int64     `json:"rows_affected"`    // Rows returned (SELECT) or affected (other statements)
+	Error        string    `json:"error,omitempty"`  // Error message if the query failed
+	DurationMS   int64     `json:"duration_ms"`      // Execution time in milliseconds
+}
+
+// AuditLogger writes query audit entries as JSON lines.
+// It is safe for concurrent use by multiple goroutines.
+type AuditLogger struct {
+	mu     sync.Mutex
+	writer io.Writer
+	closer io.Closer
+}
+
+// NewAuditLogger creates an AuditLogger that writes to the given writer.
+func NewAuditLogger(writer io.Writer) *AuditLogger {
+	return &AuditLogger{writer: writer}
+}
+
+// OpenAuditLogger creates an AuditLogger that appends to the file at path.
+// If path is empty, entries are written to stderr.
+func OpenAuditLogger(path string) (*AuditLogger, error) {
+	if path == "" {
+		return NewAuditLogger(os.Stderr), nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", path, err)
+	}
+
+	return &AuditLogger{writer: file, closer: file}, nil
+}
+
+// Log writes a single audit entry as one line of JSON.
+func (l *AuditLogger) Log(entry AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.writer.Write(data); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// Close releases the underlying audit log file, if any.
+func (l *AuditLogger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
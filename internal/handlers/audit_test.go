@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func decodeAuditEntries(t *testing.T, buf *bytes.Buffer) []AuditEntry {
+	t.Helper()
+	var entries []AuditEntry
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry AuditEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Audit line is not valid JSON: %v (%q)", err, line)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestQueryHandler_AuditLog(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		args      []any
+		mockDB    *MockDatabase
+		wantType  string
+		wantRows  int64
+		wantError string
+	}{
+		{
+			name:     "successful update",
+			query:    "UPDATE users SET active = ? WHERE id = ?",
+			args:     []any{true, 1},
+			mockDB:   &MockDatabase{driver: "postgres"},
+			wantType: "update",
+			wantRows: 1,
+		},
+		{
+			name:  "failed insert",
+			query: "INSERT INTO users (id) VALUES (?)",
+			args:  []any{1},
+			mockDB: &MockDatabase{
+				driver:            "postgres",
+				shouldReturnError: true,
+				errorMessage:      "duplicate key violation",
+			},
+			wantType:  "insert",
+			wantError: "duplicate key violation",
+		},
+		{
+			name:      "rejected by validator",
+			query:     "SELECT * FROM users -- comment",
+			mockDB:    &MockDatabase{driver: "postgres"},
+			wantType:  "select",
+			wantError: "potentially dangerous pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			handler := NewQueryHandler(tt.mockDB, createTestConfig())
+			handler.SetAuditLogger(NewAuditLogger(&buf), "test-client/1.0")
+
+			_, execErr := handler.ExecuteQuery(context.Background(), tt.query, tt.args...)
+			if (execErr != nil) != (tt.wantError != "") {
+				t.Fatalf("ExecuteQuery() error = %v, want error %q", execErr, tt.wantError)
+			}
+
+			entries := decodeAuditEntries(t, &buf)
+			if len(entries) != 1 {
+				t.Fatalf("Expected 1 audit entry, got %d", len(entries))
+			}
+
+			entry := entries[0]
+			if entry.Query != tt.query {
+				t.Errorf("Expected query %q, got %q", tt.query, entry.Query)
+			}
+			if entry.Client != "test-client/1.0" {
+				t.Errorf("Expected client to be recorded, got %q", entry.Client)
+			}
+			if entry.ArgsCount != len(tt.args) {
+				t.Errorf("Expected args_count %d, got %d", len(tt.args), entry.ArgsCount)
+			}
+			if entry.Type != tt.wantType {
+				t.Errorf("Expected type %s, got %s", tt.wantType, entry.Type)
+			}
+			if entry.RowsAffected != tt.wantRows {
+				t.Errorf("Expected rows_affected %d, got %d", tt.wantRows, entry.RowsAffected)
+			}
+			if !strings.Contains(entry.Error, tt.wantError) || (tt.wantError == "" && entry.Error != "") {
+				t.Errorf("Expected error containing %q, got %q", tt.wantError, entry.Error)
+			}
+			if entry.Timestamp.IsZero() {
+				t.Error("Expected timestamp to be set")
+			}
+		})
+	}
+}
+
+func TestQueryHandler_AuditLog_RedactsCredentials(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewQueryHandler(&MockDatabase{driver: "postgres"}, createTestConfig())
+	handler.SetAuditLogger(NewAuditLogger(&buf), "")
+
+	_, err := handler.ExecuteQuery(context.Background(), "ALTER USER app WITH PASSWORD 'hunter2'")
+	if err != nil {
+		t.Fatalf("ExecuteQuery() error = %v", err)
+	}
+
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("Audit log should not contain the password, got %s", buf.String())
+	}
+}
+
+func TestAuditLogger_ConcurrentWrites(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewAuditLogger(&buf)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := logger.Log(AuditEntry{Query: "SELECT 1", Type: "select"}); err != nil {
+				t.Errorf("Log() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if entries := decodeAuditEntries(t, &buf); len(entries) != 50 {
+		t.Errorf("Expected 50 intact audit entries, got %d", len(entries))
+	}
+}
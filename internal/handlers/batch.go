@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jhoffmann/go-database-mcp/internal/config"
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+// BatchResult represents the outcome of statements executed by ExecuteBatch.
+type BatchResult struct {
+	Results         []QueryResult `json:"results"`               // One result per executed statement, in order; failed statements carry only their type and a message
+	Errors          []string      `json:"errors,omitempty"`      // Errors of the failed statements, prefixed with their 1-based position
+	TotalStatements int           `json:"total_statements"`      // Number of statements in the batch
+	SuccessCount    int           `json:"success_count"`         // Number of statements that succeeded and were not rolled back
+	RolledBack      int           `json:"rolled_back,omitempty"` // Number of statements that succeeded but were undone when a stop_on_error batch rolled back
+	Committed       bool          `json:"committed"`             // Whether the transaction was committed
+}
+
+// ExecuteBatch executes queries in order with ExecuteQuery inside a single transaction.
+// When stopOnError is set, the first failure stops the batch and rolls the transaction
+// back, undoing the statements executed before it: their results are kept but marked as
+// rolled back, and they count in RolledBack instead of SuccessCount. Otherwise each statement runs under a savepoint, so a failure undoes only that
+// statement; the batch continues and the successful statements are committed. Statement
+// failures are reported in the result, along with the results of every statement executed
+// before them. Statements that commit implicitly, such as DDL on MySQL, cannot be rolled
+// back.
+func (h *QueryHandler) ExecuteBatch(ctx context.Context, queries []string, stopOnError bool) (*BatchResult, error) {
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("batch contains no statements")
+	}
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Run the statements through a copy of the handler whose database is the transaction
+	batch := *h
+	batch.db = &txDatabase{Database: h.db, tx: tx, config: h.dbConfig}
+
+	result := &BatchResult{
+		Results:         make([]QueryResult, 0, len(queries)),
+		TotalStatements: len(queries),
+	}
+	for i, query := range queries {
+		savepoint := fmt.Sprintf("batch_statement_%d", i+1)
+		if !stopOnError {
+			if _, err := tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+				return nil, fmt.Errorf("failed to create savepoint: %w", err)
+			}
+		}
+
+		queryResult, err := batch.ExecuteQuery(ctx, query)
+		if err == nil {
+			result.Results = append(result.Results, *queryResult)
+			result.SuccessCount++
+			continue
+		}
+
+		result.Results = append(result.Results, QueryResult{
+			Type:    h.determineQueryType(query),
+			Message: fmt.Sprintf("Statement %d failed.", i+1),
+		})
+		result.Errors = append(result.Errors, fmt.Sprintf("statement %d: %v", i+1, err))
+		if stopOnError {
+			for j := range result.Results[:i] {
+				result.Results[j].Message = fmt.Sprintf("Statement %d executed but was rolled back because statement %d failed.", j+1, i+1)
+			}
+			result.RolledBack = result.SuccessCount
+			result.SuccessCount = 0
+			return result, nil
+		}
+		if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); err != nil {
+			return nil, fmt.Errorf("failed to roll back to savepoint: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit batch: %w", err)
+	}
+	result.Committed = true
+	return result, nil
+}
+
+// txDatabase runs Query and Exec inside a transaction, applying the configured row filters
+// and statement prefix as the connection itself would. Every other operation uses the
+// underlying connection.
+type txDatabase struct {
+	database.Database
+	tx     *sql.Tx
+	config *config.DatabaseConfig
+}
+
+// Query runs a row-returning statement inside the transaction.
+func (d *txDatabase) Query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	query, args, err := database.PrepareStatement(d.config, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return d.tx.QueryContext(ctx, query, args...)
+}
+
+// Exec runs a statement inside the transaction.
+func (d *txDatabase) Exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	query, args, err := database.PrepareStatement(d.config, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return d.tx.ExecContext(ctx, query, args...)
+}
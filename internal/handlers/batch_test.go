@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestQueryHandler_ExecuteBatch(t *testing.T) {
+	queries := []string{
+		"INSERT INTO a VALUES (1)",
+		"UPDATE broken SET x = 1",
+		"INSERT INTO a VALUES (2)",
+	}
+
+	tests := []struct {
+		name          string
+		stopOnError   bool
+		wantResults   int
+		wantSuccess   int
+		wantRollback  int
+		wantCommitted bool
+		wantExecuted  []string
+	}{
+		{
+			name:          "stop on error rolls back",
+			stopOnError:   true,
+			wantResults:   2,
+			wantSuccess:   0,
+			wantRollback:  1,
+			wantCommitted: false,
+			wantExecuted:  []string{"INSERT INTO a VALUES (1)", "UPDATE broken SET x = 1"},
+		},
+		{
+			name:          "continue after error",
+			wantResults:   3,
+			wantSuccess:   2,
+			wantCommitted: true,
+			wantExecuted: []string{
+				"SAVEPOINT batch_statement_1", "INSERT INTO a VALUES (1)",
+				"SAVEPOINT batch_statement_2", "UPDATE broken SET x = 1", "ROLLBACK TO SAVEPOINT batch_statement_2",
+				"SAVEPOINT batch_statement_3", "INSERT INTO a VALUES (2)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &mockResultSet{failExec: "broken"}
+			mockDB := &MockDatabase{driver: "postgres", sqlDB: newMockSQLDB(t, set)}
+			handler := NewQueryHandler(mockDB, createTestConfig())
+
+			result, err := handler.ExecuteBatch(context.Background(), queries, tt.stopOnError)
+			if err != nil {
+				t.Fatalf("ExecuteBatch() error = %v", err)
+			}
+
+			if result.TotalStatements != 3 || result.SuccessCount != tt.wantSuccess || len(result.Results) != tt.wantResults {
+				t.Errorf("Expected 3 statements, %d successes and %d results, got %+v", tt.wantSuccess, tt.wantResults, result)
+			}
+			if result.RolledBack != tt.wantRollback {
+				t.Errorf("Expected %d rolled back statements, got %d", tt.wantRollback, result.RolledBack)
+			}
+			wantFirst := "INSERT executed successfully. 0 rows affected."
+			if tt.stopOnError {
+				wantFirst = "Statement 1 executed but was rolled back because statement 2 failed."
+			}
+			if result.Results[0].Message != wantFirst {
+				t.Errorf("Expected the first statement's message %q, got %q", wantFirst, result.Results[0].Message)
+			}
+			if len(result.Errors) != 1 || !strings.HasPrefix(result.Errors[0], "statement 2: ") {
+				t.Errorf("Expected the second statement to be reported as failed, got %q", result.Errors)
+			}
+			if result.Results[1].Type != "update" || result.Results[1].Message != "Statement 2 failed." {
+				t.Errorf("Unexpected result for the failed statement: %+v", result.Results[1])
+			}
+			if result.Committed != tt.wantCommitted {
+				t.Errorf("Expected Committed=%v, got %v", tt.wantCommitted, result.Committed)
+			}
+			if queries := set.Queries(); !reflect.DeepEqual(queries, tt.wantExecuted) {
+				t.Errorf("Expected statements %q, got %q", tt.wantExecuted, queries)
+			}
+
+			commits, rollbacks := set.Transactions()
+			if tt.wantCommitted && (commits != 1 || rollbacks != 0) || !tt.wantCommitted && (commits != 0 || rollbacks != 1) {
+				t.Errorf("Unexpected %d commits and %d rollbacks", commits, rollbacks)
+			}
+		})
+	}
+}
+
+func TestQueryHandler_ExecuteBatch_StopsAtFailingStatement(t *testing.T) {
+	queries := []string{"DELETE FROM a", "DELETE FROM b", "DELETE FROM c"}
+
+	for failing := 1; failing <= len(queries); failing++ {
+		for _, stopOnError := range []bool{true, false} {
+			t.Run(fmt.Sprintf("statement %d fails, stop on error %v", failing, stopOnError), func(t *testing.T) {
+				set := &mockResultSet{failExec: queries[failing-1]}
+				mockDB := &MockDatabase{driver: "mysql", sqlDB: newMockSQLDB(t, set)}
+				handler := NewQueryHandler(mockDB, createTestConfig())
+
+				result, err := handler.ExecuteBatch(context.Background(), queries, stopOnError)
+				if err != nil {
+					t.Fatalf("ExecuteBatch() error = %v", err)
+				}
+
+				// Stopping leaves the statements after the failing one unexecuted
+				wantExecuted := queries
+				if stopOnError {
+					wantExecuted = queries[:failing]
+				}
+				var executed []string
+				for _, query := range set.Queries() {
+					if !strings.Contains(query, "SAVEPOINT") {
+						executed = append(executed, query)
+					}
+				}
+				if !reflect.DeepEqual(executed, wantExecuted) {
+					t.Errorf("Expected statements %q to run, got %q", wantExecuted, executed)
+				}
+				if len(result.Results) != len(wantExecuted) {
+					t.Errorf("Expected %d results, got %d", len(wantExecuted), len(result.Results))
+				}
+				wantErr := fmt.Sprintf("statement %d: ", failing)
+				if len(result.Errors) != 1 || !strings.HasPrefix(result.Errors[0], wantErr) {
+					t.Errorf("Expected only statement %d to fail, got %q", failing, result.Errors)
+				}
+
+				wantSuccess, wantRolledBack := len(queries)-1, 0
+				if stopOnError {
+					wantSuccess, wantRolledBack = 0, failing-1
+				}
+				if result.SuccessCount != wantSuccess || result.RolledBack != wantRolledBack || result.Committed == stopOnError {
+					t.Errorf("Expected %d successes, %d rolled back and committed %v, got %+v", wantSuccess, wantRolledBack, !stopOnError, result)
+				}
+			})
+		}
+	}
+}
+
+func TestQueryHandler_ExecuteBatch_Empty(t *testing.T) {
+	handler := NewQueryHandler(&MockDatabase{driver: "postgres"}, createTestConfig())
+	if _, err := handler.ExecuteBatch(context.Background(), nil, true); err == nil {
+		t.Error("Expected an error for an empty batch")
+	}
+}
+
+func TestQueryHandler_ExecuteBatch_StatementPrefix(t *testing.T) {
+	set := &mockResultSet{columns: []string{"id"}}
+	mockDB := &MockDatabase{driver: "postgres", sqlDB: newMockSQLDB(t, set)}
+	cfg := createTestConfig()
+	cfg.StatementPrefix = "service=mcp"
+	handler := NewQueryHandler(mockDB, cfg)
+
+	if _, err := handler.ExecuteBatch(context.Background(), []string{"SELECT id FROM a", "DELETE FROM a"}, true); err != nil {
+		t.Fatalf("ExecuteBatch() error = %v", err)
+	}
+
+	want := []string{"/* service=mcp */ SELECT id FROM a", "/* service=mcp */ DELETE FROM a"}
+	if queries := set.Queries(); !reflect.DeepEqual(queries, want) {
+		t.Errorf("Expected queries %q, got %q", want, queries)
+	}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+// Thresholds for recommending maintenance. The vacuum threshold mirrors PostgreSQL's
+// autovacuum defaults (autovacuum_vacuum_threshold = 50, autovacuum_vacuum_scale_factor
+// = 0.2): a table needs vacuuming once its dead tuples exceed 50 plus 20% of its live tuples.
+const (
+	bloatVacuumBaseThreshold = 50
+	bloatVacuumScaleFactor   = 0.2
+	bloatVacuumFullRatio     = 0.5 // Dead tuple ratio above which VACUUM FULL is worth considering
+)
+
+// TableBloatReport is a table's tuple statistics together with a bloat estimate and
+// a maintenance recommendation.
+type TableBloatReport struct {
+	database.TableBloatStats
+	StatsAvailable bool    `json:"stats_available"` // Whether the statistics collector has data for the table
+	DeadRatio      float64 `json:"dead_ratio"`      // Dead tuples as a fraction of all tuples (0 to 1)
+	NeedsVacuum    bool    `json:"needs_vacuum"`    // Whether dead tuples exceed the vacuum threshold
+	Recommendation string  `json:"recommendation"`  // Suggested maintenance action
+}
+
+// TableBloatResult represents the result of a table bloat check.
+type TableBloatResult struct {
+	Tables      []TableBloatReport `json:"tables"`       // One report per table, most dead tuples first
+	NeedsVacuum int                `json:"needs_vacuum"` // Number of tables that need vacuuming
+}
+
+// GetTableBloat estimates dead tuple bloat for the specified table, or for every table when
+// tableName is empty, and recommends VACUUM where the autovacuum thresholds are exceeded.
+// It is only supported for PostgreSQL.
+func (h *AdminHandler) GetTableBloat(ctx context.Context, tableName string) (*TableBloatResult, error) {
+	if driver := h.db.GetDriverName(); driver != "postgres" {
+		return nil, fmt.Errorf("table bloat statistics are only available for PostgreSQL, not %s", driver)
+	}
+
+	// The table name is bound as a query parameter, so it only needs trimming
+	stats, err := h.db.GetTableBloat(ctx, strings.TrimSpace(tableName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table bloat: %w", err)
+	}
+
+	result := &TableBloatResult{Tables: make([]TableBloatReport, 0, len(stats))}
+	for _, table := range stats {
+		report := newTableBloatReport(table)
+		if report.NeedsVacuum {
+			result.NeedsVacuum++
+		}
+		result.Tables = append(result.Tables, report)
+	}
+
+	return result, nil
+}
+
+// newTableBloatReport estimates bloat from stats and chooses a maintenance recommendation.
+func newTableBloatReport(stats database.TableBloatStats) TableBloatReport {
+	report := TableBloatReport{TableBloatStats: stats}
+
+	// A table the collector has never seen has no tuples counted and has never been vacuumed
+	report.StatsAvailable = stats.LiveTuples > 0 || stats.DeadTuples > 0 ||
+		stats.LastVacuum != nil || stats.LastAutovacuum != nil
+	if !report.StatsAvailable {
+		report.Recommendation = "No statistics collected yet; run ANALYZE to gather them"
+		return report
+	}
+
+	if total := stats.LiveTuples + stats.DeadTuples; total > 0 {
+		report.DeadRatio = float64(stats.DeadTuples) / float64(total)
+	}
+
+	threshold := bloatVacuumBaseThreshold + bloatVacuumScaleFactor*float64(stats.LiveTuples)
+	report.NeedsVacuum = float64(stats.DeadTuples) > threshold
+
+	switch {
+	case report.NeedsVacuum && report.DeadRatio >= bloatVacuumFullRatio:
+		report.Recommendation = fmt.Sprintf("Run VACUUM ANALYZE; %.0f%% of tuples are dead, so consider VACUUM FULL "+
+			"during a maintenance window to return space to the operating system (it locks the table)", report.DeadRatio*100)
+	case report.NeedsVacuum && stats.LastAutovacuum == nil:
+		report.Recommendation = "Run VACUUM ANALYZE; autovacuum has never processed this table, so check that autovacuum is enabled"
+	case report.NeedsVacuum:
+		report.Recommendation = "Run VACUUM ANALYZE; dead tuples exceed the autovacuum threshold"
+	default:
+		report.Recommendation = "No maintenance needed"
+	}
+
+	return report
+}
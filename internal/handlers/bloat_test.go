@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+func TestNewTableBloatReport(t *testing.T) {
+	vacuumed := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		stats              database.TableBloatStats
+		wantAvailable      bool
+		wantRatio          float64
+		wantVacuum         bool
+		wantRecommendation string
+	}{
+		{
+			name:               "no statistics collected",
+			stats:              database.TableBloatStats{TableName: "new_table"},
+			wantRecommendation: "No statistics collected yet",
+		},
+		{
+			name:               "healthy table",
+			stats:              database.TableBloatStats{TableName: "users", LiveTuples: 10000, DeadTuples: 100, LastAutovacuum: &vacuumed},
+			wantAvailable:      true,
+			wantRatio:          100.0 / 10100,
+			wantRecommendation: "No maintenance needed",
+		},
+		{
+			name:               "exactly at threshold",
+			stats:              database.TableBloatStats{TableName: "users", LiveTuples: 1000, DeadTuples: 250, LastAutovacuum: &vacuumed},
+			wantAvailable:      true,
+			wantRatio:          0.2,
+			wantRecommendation: "No maintenance needed",
+		},
+		{
+			name:               "just above threshold",
+			stats:              database.TableBloatStats{TableName: "users", LiveTuples: 1000, DeadTuples: 251, LastAutovacuum: &vacuumed},
+			wantAvailable:      true,
+			wantRatio:          251.0 / 1251,
+			wantVacuum:         true,
+			wantRecommendation: "exceed the autovacuum threshold",
+		},
+		{
+			name:               "never autovacuumed",
+			stats:              database.TableBloatStats{TableName: "events", LiveTuples: 1000, DeadTuples: 400},
+			wantAvailable:      true,
+			wantRatio:          400.0 / 1400,
+			wantVacuum:         true,
+			wantRecommendation: "autovacuum has never processed this table",
+		},
+		{
+			name:               "heavily bloated",
+			stats:              database.TableBloatStats{TableName: "queue", LiveTuples: 100, DeadTuples: 900, LastVacuum: &vacuumed},
+			wantAvailable:      true,
+			wantRatio:          0.9,
+			wantVacuum:         true,
+			wantRecommendation: "consider VACUUM FULL",
+		},
+		{
+			name:               "small table below base threshold",
+			stats:              database.TableBloatStats{TableName: "settings", LiveTuples: 2, DeadTuples: 40, LastAutovacuum: &vacuumed},
+			wantAvailable:      true,
+			wantRatio:          40.0 / 42,
+			wantRecommendation: "No maintenance needed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := newTableBloatReport(tt.stats)
+
+			if report.StatsAvailable != tt.wantAvailable {
+				t.Errorf("StatsAvailable = %v, want %v", report.StatsAvailable, tt.wantAvailable)
+			}
+			if math.Abs(report.DeadRatio-tt.wantRatio) > 1e-9 {
+				t.Errorf("DeadRatio = %v, want %v", report.DeadRatio, tt.wantRatio)
+			}
+			if report.NeedsVacuum != tt.wantVacuum {
+				t.Errorf("NeedsVacuum = %v, want %v", report.NeedsVacuum, tt.wantVacuum)
+			}
+			if !strings.Contains(report.Recommendation, tt.wantRecommendation) {
+				t.Errorf("Recommendation = %q, want it to contain %q", report.Recommendation, tt.wantRecommendation)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_GetTableBloat(t *testing.T) {
+	mockDB := &MockDatabase{
+		driver: "postgres",
+		tableBloat: []database.TableBloatStats{
+			{TableName: "queue", LiveTuples: 100, DeadTuples: 900},
+			{TableName: "users", LiveTuples: 10000, DeadTuples: 10},
+		},
+	}
+	handler := NewAdminHandler(mockDB)
+
+	result, err := handler.GetTableBloat(context.Background(), "")
+	if err != nil {
+		t.Fatalf("GetTableBloat() error = %v", err)
+	}
+	if len(result.Tables) != 2 {
+		t.Fatalf("Expected 2 table reports, got %d", len(result.Tables))
+	}
+	if result.NeedsVacuum != 1 {
+		t.Errorf("Expected 1 table to need vacuuming, got %d", result.NeedsVacuum)
+	}
+	if !result.Tables[0].NeedsVacuum || result.Tables[1].NeedsVacuum {
+		t.Errorf("Unexpected vacuum recommendations: %+v", result.Tables)
+	}
+}
+
+func TestAdminHandler_GetTableBloat_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		driver    string
+		tableName string
+		dbErr     bool
+		wantErr   string
+	}{
+		{name: "mysql is not supported", driver: "mysql", wantErr: "only available for PostgreSQL"},
+		{name: "database error", driver: "postgres", dbErr: true, wantErr: "failed to get table bloat"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockDatabase{driver: tt.driver, shouldReturnError: tt.dbErr, errorMessage: "stats unavailable"}
+			handler := NewAdminHandler(mockDB)
+
+			_, err := handler.GetTableBloat(context.Background(), tt.tableName)
+			if err == nil || !strings.Contains(strings.ToLower(err.Error()), strings.ToLower(tt.wantErr)) {
+				t.Errorf("GetTableBloat() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// columnPermutation returns the positions of columns in the order requested by order: the
+// listed columns first, in the listed order, followed by the unlisted columns in their
+// original order. It returns an error if order names a column that is not in columns, or
+// names one twice.
+func columnPermutation(columns, order []string) ([]int, error) {
+	positions := make([]int, 0, len(columns))
+	listed := make([]bool, len(columns))
+	for i, name := range order {
+		if slices.Contains(order[:i], name) {
+			return nil, fmt.Errorf("column_order lists column %q more than once", name)
+		}
+
+		found := false
+		for j, column := range columns {
+			if column == name {
+				positions = append(positions, j)
+				listed[j] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("column_order lists unknown column %q (result columns: %s)",
+				name, strings.Join(columns, ", "))
+		}
+	}
+
+	for j := range columns {
+		if !listed[j] {
+			positions = append(positions, j)
+		}
+	}
+	return positions, nil
+}
+
+// permute returns the elements of values at positions, in that order.
+func permute[T any](values []T, positions []int) []T {
+	permuted := make([]T, len(positions))
+	for i, position := range positions {
+		permuted[i] = values[position]
+	}
+	return permuted
+}
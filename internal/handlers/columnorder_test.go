@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestColumnPermutation(t *testing.T) {
+	columns := []string{"name", "email", "id", "created_at"}
+
+	tests := []struct {
+		name    string
+		order   []string
+		want    []int
+		wantErr string
+	}{
+		{name: "id first", order: []string{"id"}, want: []int{2, 0, 1, 3}},
+		{name: "several columns", order: []string{"id", "created_at", "name"}, want: []int{2, 3, 0, 1}},
+		{name: "every column", order: []string{"created_at", "id", "email", "name"}, want: []int{3, 2, 1, 0}},
+		{name: "unknown column", order: []string{"id", "uuid"}, wantErr: `unknown column "uuid"`},
+		{name: "duplicate column", order: []string{"id", "id"}, wantErr: `column "id" more than once`},
+		{name: "case sensitive", order: []string{"ID"}, wantErr: `unknown column "ID"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := columnPermutation(columns, tt.order)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("columnPermutation() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("columnPermutation() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("columnPermutation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryHandler_ExecuteQuery_ColumnOrder(t *testing.T) {
+	set := &mockResultSet{
+		columns: []string{"name", "email", "id"},
+		types:   []string{"VARCHAR", "VARCHAR", "INT8"},
+		rows:    [][]driver.Value{{"Ada", "ada@example.com", int64(1)}},
+	}
+
+	handler := NewQueryHandler(newSelectMock(t, "postgres", set), createTestConfig())
+	handler.SetColumnTypes(true)
+	handler.SetColumnOrder([]string{"id", "email"})
+
+	result, err := handler.ExecuteQuery(context.Background(), "SELECT name, email, id FROM users")
+	if err != nil {
+		t.Fatalf("ExecuteQuery() error = %v", err)
+	}
+
+	wantColumns := []string{"id", "email", "name"}
+	if !reflect.DeepEqual(result.Columns, wantColumns) {
+		t.Errorf("Columns = %v, want %v", result.Columns, wantColumns)
+	}
+	for i, columnType := range result.ColumnTypes {
+		if columnType.Name != wantColumns[i] {
+			t.Errorf("ColumnTypes[%d] = %s, want %s", i, columnType.Name, wantColumns[i])
+		}
+	}
+
+	// Ordered output formats follow the requested order
+	var buf bytes.Buffer
+	if err := handler.FormatResultStream(*result, "ndjson", &buf); err != nil {
+		t.Fatalf("FormatResultStream() error = %v", err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if want := `{"id":1,"email":"ada@example.com","name":"Ada"}`; len(lines) < 2 || lines[1] != want {
+		t.Errorf("Expected row line %s, got %q", want, buf.String())
+	}
+}
+
+func TestQueryHandler_ExecuteQuery_ColumnOrderUnknown(t *testing.T) {
+	set := &mockResultSet{
+		columns: []string{"id", "name"},
+		types:   []string{"INT8", "VARCHAR"},
+		rows:    [][]driver.Value{{int64(1), "Ada"}},
+	}
+
+	handler := NewQueryHandler(newSelectMock(t, "postgres", set), createTestConfig())
+	handler.SetColumnOrder([]string{"uuid"})
+
+	_, err := handler.ExecuteQuery(context.Background(), "SELECT id, name FROM users")
+	if err == nil || !strings.Contains(err.Error(), `column_order lists unknown column "uuid" (result columns: id, name)`) {
+		t.Errorf("ExecuteQuery() error = %v, want an unknown column error", err)
+	}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jhoffmann/go-database-mcp/internal/security"
+)
+
+// QueryColumnsResult describes the columns a query would return.
+type QueryColumnsResult struct {
+	Columns []ColumnTypeInfo `json:"columns"` // Result columns, in select-list order
+	Count   int              `json:"count"`   // Number of result columns
+}
+
+// QueryColumns returns the names and types of the columns a SELECT query would return,
+// without returning any rows. database/sql exposes no result metadata on prepared
+// statements, so the query's own row limit is replaced by LIMIT 0 and the query is
+// executed; both supported databases then report the column types without reading any
+// data. A row limit that binds parameters is kept, and only the column types of its result
+// are read. Parameters are bound as for ExecuteQuery, and the configured query timeout
+// applies.
+func (h *QueryHandler) QueryColumns(ctx context.Context, query string, args ...any) (*QueryColumnsResult, error) {
+	if err := h.validator.ValidateQuery(query); err != nil {
+		return nil, h.validator.SanitizeErrorMessage(err)
+	}
+
+	// A trailing semicolon would end the statement before the LIMIT 0
+	trimmedQuery := strings.TrimRight(strings.TrimSpace(query), "; \t\r\n")
+	if trimmedQuery == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+	if queryType := h.determineQueryType(trimmedQuery); queryType != "select" {
+		return nil, fmt.Errorf("only SELECT queries return result columns, got %s", queryType)
+	}
+
+	queryCtx, cancel := withQueryTimeout(ctx, h.timeout)
+	defer cancel()
+
+	columns, err := h.queryColumns(queryCtx, h.bindPlaceholders(trimmedQuery, args), args...)
+	if err != nil && queryCtx.Err() != nil {
+		return nil, describeContextError(ctx, queryCtx, h.timeout, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &QueryColumnsResult{Columns: columns, Count: len(columns)}, nil
+}
+
+// queryColumns runs query with LIMIT 0 and reads the result column types.
+func (h *QueryHandler) queryColumns(ctx context.Context, query string, args ...any) ([]ColumnTypeInfo, error) {
+	if limited, ok := security.ZeroRowLimit(query, h.db.GetDriverName()); ok {
+		query = limited
+	}
+	rows, err := h.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query execution failed: %w", err)
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column types: %w", err)
+	}
+
+	return newColumnTypeInfos(columnTypes), nil
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+func TestQueryHandler_QueryColumns(t *testing.T) {
+	set := &mockResultSet{
+		columns:  []string{"id", "email", "created_at"},
+		types:    []string{"INT8", "VARCHAR", "TIMESTAMP"},
+		nullable: []bool{false, true, false},
+		rows:     [][]driver.Value{{int64(1), "a@example.com", nil}},
+	}
+
+	handler := NewQueryHandler(newSelectMock(t, "postgres", set), createTestConfig())
+
+	result, err := handler.QueryColumns(context.Background(), "SELECT id, email, created_at FROM users WHERE id = $1;", 1)
+	if err != nil {
+		t.Fatalf("QueryColumns() error = %v", err)
+	}
+
+	notNull, nullable := false, true
+	expected := []ColumnTypeInfo{
+		{Name: "id", DatabaseType: "INT8", Nullable: &notNull},
+		{Name: "email", DatabaseType: "VARCHAR", Nullable: &nullable},
+		{Name: "created_at", DatabaseType: "TIMESTAMP", Nullable: &notNull},
+	}
+	if result.Count != len(expected) || len(result.Columns) != len(expected) {
+		t.Fatalf("Expected %d columns, got %+v", len(expected), result)
+	}
+	for i, want := range expected {
+		got := result.Columns[i]
+		if got.Name != want.Name || got.DatabaseType != want.DatabaseType {
+			t.Errorf("Columns[%d] = %+v, want %+v", i, got, want)
+		}
+		if got.Nullable == nil || *got.Nullable != *want.Nullable {
+			t.Errorf("Columns[%d].Nullable = %v, want %v", i, got.Nullable, *want.Nullable)
+		}
+	}
+
+	queries := set.Queries()
+	want := "SELECT id, email, created_at FROM users WHERE id = $1\nLIMIT 0"
+	if len(queries) != 1 || queries[0] != want {
+		t.Errorf("Expected query %q, got %v", want, queries)
+	}
+	if set.Closed() != 1 {
+		t.Errorf("Expected the result set to be closed once, got %d", set.Closed())
+	}
+}
+
+func TestQueryHandler_QueryColumns_DuplicateNames(t *testing.T) {
+	set := &mockResultSet{
+		columns: []string{"id", "id", "name"},
+		types:   []string{"INT", "INT", "VARCHAR"},
+	}
+
+	handler := NewQueryHandler(newSelectMock(t, "mysql", set), createTestConfig())
+
+	query := "SELECT u.id, o.id, u.name FROM users u JOIN orders o ON o.user_id = u.id LIMIT 10"
+	result, err := handler.QueryColumns(context.Background(), query)
+	if err != nil {
+		t.Fatalf("QueryColumns() error = %v", err)
+	}
+
+	var names []string
+	for _, column := range result.Columns {
+		names = append(names, column.Name)
+	}
+	if strings.Join(names, ",") != "id,id,name" {
+		t.Errorf("Expected columns id,id,name, got %v", names)
+	}
+
+	// Duplicate names cannot be selected from a derived table, so LIMIT 0 replaces the query's own limit
+	want := "SELECT u.id, o.id, u.name FROM users u JOIN orders o ON o.user_id = u.id LIMIT 0"
+	if queries := set.Queries(); len(queries) != 1 || queries[0] != want {
+		t.Errorf("Expected query %q, got %v", want, queries)
+	}
+}
+
+func TestQueryHandler_QueryColumns_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{name: "empty query", query: " ; ", wantErr: "query cannot be empty"},
+		{name: "update", query: "UPDATE users SET active = false", wantErr: "only SELECT queries"},
+		{name: "ddl", query: "CREATE TABLE t (id INT)", wantErr: "only SELECT queries"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &mockResultSet{columns: []string{"id"}}
+			handler := NewQueryHandler(newSelectMock(t, "mysql", set), createTestConfig())
+
+			_, err := handler.QueryColumns(context.Background(), tt.query)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("QueryColumns() error = %v, want %q", err, tt.wantErr)
+			}
+			if queries := set.Queries(); len(queries) != 0 {
+				t.Errorf("Expected nothing to be executed, got %v", queries)
+			}
+		})
+	}
+}
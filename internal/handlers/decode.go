@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/jhoffmann/go-database-mcp/internal/config"
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+// binaryTypes are database type names whose []byte values are raw bytes rather than text.
+var binaryTypes = map[string]bool{
+	"BINARY":     true,
+	"VARBINARY":  true,
+	"BLOB":       true,
+	"TINYBLOB":   true,
+	"MEDIUMBLOB": true,
+	"LONGBLOB":   true,
+	"BYTEA":      true,
+	"GEOMETRY":   true,
+}
+
+// integerTypes are database type names whose []byte values are decimal integers.
+// The MySQL driver returns these as text when a query is not prepared.
+var integerTypes = map[string]bool{
+	"TINYINT":            true,
+	"SMALLINT":           true,
+	"MEDIUMINT":          true,
+	"INT":                true,
+	"INTEGER":            true,
+	"BIGINT":             true,
+	"YEAR":               true,
+	"INT2":               true,
+	"INT4":               true,
+	"INT8":               true,
+	"UNSIGNED TINYINT":   true,
+	"UNSIGNED SMALLINT":  true,
+	"UNSIGNED MEDIUMINT": true,
+	"UNSIGNED INT":       true,
+	"UNSIGNED BIGINT":    true,
+}
+
+// floatTypes are database type names whose []byte values are floating point numbers.
+var floatTypes = map[string]bool{
+	"FLOAT":  true,
+	"FLOAT4": true,
+	"FLOAT8": true,
+	"DOUBLE": true,
+	"REAL":   true,
+}
+
+// decimalTypes are database type names whose []byte values are exact decimal numbers.
+// They are kept as json.Number so no precision is lost to float64.
+var decimalTypes = map[string]bool{
+	"DECIMAL":          true,
+	"NUMERIC":          true,
+	"UNSIGNED DECIMAL": true,
+}
+
+// decodeBytes converts a []byte value scanned from a column into a JSON-friendly value
+// based on the column's database type. Binary columns are base64 encoded, numeric
+// columns become numbers, MySQL dates become times (with zero and invalid dates handled
+// according to zeroDates) and everything else, including unknown types, is decoded as text.
+func decodeBytes(b []byte, columnType *sql.ColumnType, zeroDates config.ZeroDateBehavior) any {
+	if columnType == nil {
+		return string(b)
+	}
+
+	typeName := strings.ToUpper(columnType.DatabaseTypeName())
+	switch {
+	case database.IsMySQLDateTimeType(typeName):
+		return database.DecodeMySQLDateTime(b, zeroDates)
+
+	case binaryTypes[typeName]:
+		return base64.StdEncoding.EncodeToString(b)
+
+	case typeName == "BIT":
+		// MySQL BIT(n) values are big-endian bit fields
+		var bits uint64
+		for _, octet := range b {
+			bits = bits<<8 | uint64(octet)
+		}
+		return bits
+
+	case integerTypes[typeName]:
+		if strings.HasPrefix(typeName, "UNSIGNED") {
+			if n, err := strconv.ParseUint(string(b), 10, 64); err == nil {
+				return n
+			}
+		} else if n, err := strconv.ParseInt(string(b), 10, 64); err == nil {
+			return n
+		}
+
+	case floatTypes[typeName]:
+		if f, err := strconv.ParseFloat(string(b), 64); err == nil {
+			return f
+		}
+
+	case decimalTypes[typeName]:
+		if _, err := strconv.ParseFloat(string(b), 64); err == nil {
+			return json.Number(b)
+		}
+	}
+
+	return string(b)
+}
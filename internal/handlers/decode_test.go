@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jhoffmann/go-database-mcp/internal/config"
+)
+
+func TestDecodeBytes(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType string
+		value  []byte
+		want   any
+	}{
+		{"varchar as text", "VARCHAR", []byte("alice"), "alice"},
+		{"postgres text", "TEXT", []byte("hello"), "hello"},
+		{"blob base64 encoded", "BLOB", []byte{0xff, 0x00, 0xfe}, "/wD+"},
+		{"varbinary base64 encoded", "VARBINARY", []byte("ab"), "YWI="},
+		{"bytea base64 encoded", "BYTEA", []byte{0x01, 0x02}, "AQI="},
+		{"bit field", "BIT", []byte{0x01, 0x01}, uint64(257)},
+		{"signed integer", "BIGINT", []byte("-42"), int64(-42)},
+		{"unsigned integer", "UNSIGNED BIGINT", []byte("18446744073709551615"), uint64(18446744073709551615)},
+		{"float", "DOUBLE", []byte("1.5"), 1.5},
+		{"decimal keeps precision", "DECIMAL", []byte("12345678901234567890.123"), json.Number("12345678901234567890.123")},
+		{"postgres numeric", "NUMERIC", []byte("9.99"), json.Number("9.99")},
+		{"unparsable number falls back to text", "NUMERIC", []byte("NaN?"), "NaN?"},
+		{"unknown type as text", "", []byte("raw"), "raw"},
+		{"lowercase type name", "blob", []byte("ab"), "YWI="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &mockResultSet{
+				columns: []string{"value"},
+				types:   []string{tt.dbType},
+				rows:    [][]driver.Value{{tt.value}},
+			}
+			handler := NewQueryHandler(newSelectMock(t, "mysql", set), createTestConfig())
+
+			result, err := handler.ExecuteQuery(context.Background(), "SELECT value FROM things")
+			if err != nil {
+				t.Fatalf("ExecuteQuery() error = %v", err)
+			}
+
+			got := result.Rows[0]["value"]
+			if got != tt.want {
+				t.Errorf("value = %#v (%T), want %#v (%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeBytes_NilColumnType(t *testing.T) {
+	if got := decodeBytes([]byte("text"), nil, config.ZeroDateNull); got != "text" {
+		t.Errorf("decodeBytes() = %#v, want text", got)
+	}
+}
+
+func TestQueryHandler_ExecuteQuery_BinaryColumnJSON(t *testing.T) {
+	set := &mockResultSet{
+		columns: []string{"id", "avatar", "price"},
+		types:   []string{"INT", "LONGBLOB", "DECIMAL"},
+		rows:    [][]driver.Value{{[]byte("7"), []byte{0x89, 'P', 'N', 'G', 0x00}, []byte("19.90")}},
+	}
+	handler := NewQueryHandler(newSelectMock(t, "mysql", set), createTestConfig())
+
+	result, err := handler.ExecuteQuery(context.Background(), "SELECT id, avatar, price FROM products")
+	if err != nil {
+		t.Fatalf("ExecuteQuery() error = %v", err)
+	}
+
+	formatted, err := handler.FormatResult(*result, "json")
+	if err != nil {
+		t.Fatalf("FormatResult() error = %v", err)
+	}
+	for _, want := range []string{`"id": 7`, `"avatar": "iVBORwA="`, `"price": 19.90`} {
+		if !containsString(formatted, want) {
+			t.Errorf("Expected JSON to contain %s, got %s", want, formatted)
+		}
+	}
+}
+
+func TestQueryHandler_ExecuteQuery_MySQLZeroDates(t *testing.T) {
+	rows := [][]driver.Value{
+		{int64(1), []byte("0000-00-00 00:00:00"), []byte("2024-03-15")},
+		{int64(2), []byte("2024-02-30 12:00:00"), []byte("0000-00-00")},
+	}
+
+	tests := []struct {
+		name      string
+		behavior  string
+		wantFirst any
+		wantLast  any
+	}{
+		{name: "default returns null", behavior: "", wantFirst: nil, wantLast: nil},
+		{name: "null", behavior: "null", wantFirst: nil, wantLast: nil},
+		{name: "string", behavior: "string", wantFirst: "0000-00-00 00:00:00", wantLast: "0000-00-00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &mockResultSet{
+				columns: []string{"id", "updated_at", "born_on"},
+				types:   []string{"INT", "DATETIME", "DATE"},
+				rows:    rows,
+			}
+			cfg := createTestConfig()
+			cfg.ZeroDateBehavior = tt.behavior
+			handler := NewQueryHandler(newSelectMock(t, "mysql", set), cfg)
+
+			result, err := handler.ExecuteQuery(context.Background(), "SELECT id, updated_at, born_on FROM legacy")
+			if err != nil {
+				t.Fatalf("ExecuteQuery() error = %v, want zero dates to be handled", err)
+			}
+
+			if got := result.Rows[0]["updated_at"]; got != tt.wantFirst {
+				t.Errorf("Zero datetime = %#v, want %#v", got, tt.wantFirst)
+			}
+			if got := result.Rows[1]["born_on"]; got != tt.wantLast {
+				t.Errorf("Zero date = %#v, want %#v", got, tt.wantLast)
+			}
+			if got := result.Rows[0]["born_on"]; got != time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC) {
+				t.Errorf("Valid date = %#v, want 2024-03-15", got)
+			}
+			if _, invalid := result.Rows[1]["updated_at"].(time.Time); invalid {
+				t.Error("Invalid datetime 2024-02-30 should not decode to a time")
+			}
+		})
+	}
+}
+
+func TestQueryHandler_ExecuteQuery_BooleanOutput(t *testing.T) {
+	drivers := []struct {
+		driver string
+		set    func() *mockResultSet
+	}{
+		{
+			driver: "postgres",
+			set: func() *mockResultSet {
+				return &mockResultSet{
+					columns: []string{"id", "active"},
+					types:   []string{"INT4", "BOOL"},
+					rows:    [][]driver.Value{{int64(1), true}, {int64(2), false}, {int64(3), nil}},
+				}
+			},
+		},
+		{
+			driver: "mysql",
+			set: func() *mockResultSet {
+				return &mockResultSet{
+					columns: []string{"id", "active"},
+					types:   []string{"INT", "TINYINT"},
+					rows:    [][]driver.Value{{int64(1), []byte("1")}, {int64(2), []byte("0")}, {int64(3), nil}},
+				}
+			},
+		},
+	}
+
+	tests := []struct {
+		output string
+		want   []any
+	}{
+		{output: "bool", want: []any{true, false, nil}},
+		{output: "int", want: []any{int64(1), int64(0), nil}},
+	}
+
+	for _, d := range drivers {
+		for _, tt := range tests {
+			t.Run(d.driver+" "+tt.output, func(t *testing.T) {
+				cfg := createTestConfig()
+				cfg.BooleanOutput = tt.output
+				handler := NewQueryHandler(newSelectMock(t, d.driver, d.set()), cfg)
+
+				result, err := handler.ExecuteQuery(context.Background(), "SELECT id, active FROM users")
+				if err != nil {
+					t.Fatalf("ExecuteQuery() error = %v", err)
+				}
+
+				for i, want := range tt.want {
+					if got := result.Rows[i]["active"]; got != want {
+						t.Errorf("Row %d active = %#v, want %#v", i, got, want)
+					}
+				}
+				if got := result.Rows[0]["id"]; got != int64(1) {
+					t.Errorf("Non-boolean column id = %#v, want 1", got)
+				}
+			})
+		}
+	}
+
+	t.Run("native keeps driver values", func(t *testing.T) {
+		handler := NewQueryHandler(newSelectMock(t, "mysql", drivers[1].set()), createTestConfig())
+		result, err := handler.ExecuteQuery(context.Background(), "SELECT id, active FROM users")
+		if err != nil {
+			t.Fatalf("ExecuteQuery() error = %v", err)
+		}
+		if got := result.Rows[0]["active"]; got != int64(1) {
+			t.Errorf("Native MySQL boolean = %#v, want 1", got)
+		}
+	})
+}
+
+func TestQueryHandler_ExecuteQuery_TrimStrings(t *testing.T) {
+	set := func() *mockResultSet {
+		return &mockResultSet{
+			columns: []string{"code", "name", "payload"},
+			types:   []string{"BPCHAR", "VARCHAR", "BYTEA"},
+			rows:    [][]driver.Value{{"AB   ", []byte("  Jane  Doe \t"), []byte{' ', 0x01, ' '}}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		trim     bool
+		wantCode string
+		wantName string
+	}{
+		{name: "disabled by default", wantCode: "AB   ", wantName: "  Jane  Doe \t"},
+		{name: "enabled", trim: true, wantCode: "AB", wantName: "Jane  Doe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.TrimStrings = tt.trim
+			handler := NewQueryHandler(newSelectMock(t, "postgres", set()), cfg)
+
+			result, err := handler.ExecuteQuery(context.Background(), "SELECT code, name, payload FROM accounts")
+			if err != nil {
+				t.Fatalf("ExecuteQuery() error = %v", err)
+			}
+
+			row := result.Rows[0]
+			if row["code"] != tt.wantCode || row["name"] != tt.wantName {
+				t.Errorf("Got code %q and name %q, want %q and %q", row["code"], row["name"], tt.wantCode, tt.wantName)
+			}
+			// Binary values are never trimmed
+			if got := row["payload"]; got != "IAEg" {
+				t.Errorf("payload = %#v, want the base64 of the untrimmed bytes", got)
+			}
+		})
+	}
+}
+
+func TestQueryHandler_ExecuteQuery_MaxResultBytes(t *testing.T) {
+	payload := make([]byte, 1024)
+	newSet := func() *mockResultSet {
+		return &mockResultSet{
+			columns: []string{"id", "payload"},
+			types:   []string{"INT8", "BYTEA"},
+			rows:    [][]driver.Value{{int64(1), payload}, {int64(2), payload}, {int64(3), payload}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		maxBytes int
+		wantErr  bool
+	}{
+		{name: "no limit", maxBytes: 0},
+		{name: "under the limit", maxBytes: 4096},
+		{name: "crossed on the third row", maxBytes: 2500, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := newSet()
+			cfg := createTestConfig()
+			cfg.MaxResultBytes = tt.maxBytes
+			handler := NewQueryHandler(newSelectMock(t, "postgres", set), cfg)
+
+			result, err := handler.ExecuteQuery(context.Background(), "SELECT id, payload FROM blobs")
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "result exceeded 2500 bytes") {
+					t.Fatalf("ExecuteQuery() error = %v, want a result size error", err)
+				}
+				if result != nil {
+					t.Error("Expected the partial result to be discarded")
+				}
+			} else if err != nil {
+				t.Fatalf("ExecuteQuery() error = %v", err)
+			} else if result.RowCount != 3 {
+				t.Errorf("Expected 3 rows, got %d", result.RowCount)
+			}
+
+			// The rows are released whether or not the query was aborted
+			if closed := set.Closed(); closed != 1 {
+				t.Errorf("Expected the rows to be closed once, got %d", closed)
+			}
+		})
+	}
+}
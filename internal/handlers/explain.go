@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// PlanSummary holds the headline figures of the root node of an execution plan. Fields the
+// plan does not report are omitted.
+type PlanSummary struct {
+	NodeType        string   `json:"node_type,omitempty"`         // Root operation, e.g. Seq Scan or Hash Join (PostgreSQL)
+	EstimatedCost   *float64 `json:"estimated_cost,omitempty"`    // Planner's total cost estimate, in the database's own units
+	EstimatedRows   *float64 `json:"estimated_rows,omitempty"`    // Planner's estimate of the rows produced
+	ActualRows      *float64 `json:"actual_rows,omitempty"`       // Rows actually produced (PostgreSQL EXPLAIN ANALYZE)
+	PlanningTimeMS  *float64 `json:"planning_time_ms,omitempty"`  // Planning time (PostgreSQL EXPLAIN ANALYZE)
+	ExecutionTimeMS *float64 `json:"execution_time_ms,omitempty"` // Execution time (PostgreSQL EXPLAIN ANALYZE)
+}
+
+// postgresPlan is the part of PostgreSQL's EXPLAIN (FORMAT JSON) output that is summarized.
+type postgresPlan struct {
+	Plan struct {
+		NodeType   string   `json:"Node Type"`
+		TotalCost  *float64 `json:"Total Cost"`
+		PlanRows   *float64 `json:"Plan Rows"`
+		ActualRows *float64 `json:"Actual Rows"`
+	} `json:"Plan"`
+	PlanningTime  *float64 `json:"Planning Time"`
+	ExecutionTime *float64 `json:"Execution Time"`
+}
+
+// mysqlTable is the part of a table access in MySQL's EXPLAIN FORMAT=JSON output that is
+// summarized.
+type mysqlTable struct {
+	RowsProducedPerJoin *float64 `json:"rows_produced_per_join"`
+}
+
+// mysqlPlan is the part of MySQL's EXPLAIN FORMAT=JSON output that is summarized.
+type mysqlPlan struct {
+	QueryBlock *struct {
+		CostInfo struct {
+			QueryCost string `json:"query_cost"`
+		} `json:"cost_info"`
+		Table      *mysqlTable `json:"table"`
+		NestedLoop []struct {
+			Table *mysqlTable `json:"table"`
+		} `json:"nested_loop"`
+	} `json:"query_block"`
+}
+
+// parsePlan returns the plan as raw JSON together with a summary of its root node, when it is
+// a PostgreSQL or MySQL JSON plan. Other plans, such as MySQL's EXPLAIN ANALYZE tree, return
+// nil for both.
+func parsePlan(plan string) (json.RawMessage, *PlanSummary) {
+	trimmed := strings.TrimSpace(plan)
+	if !json.Valid([]byte(trimmed)) {
+		return nil, nil
+	}
+	raw := json.RawMessage(trimmed)
+
+	switch {
+	case strings.HasPrefix(trimmed, "["):
+		var plans []postgresPlan
+		if err := json.Unmarshal(raw, &plans); err != nil || len(plans) == 0 {
+			return raw, nil
+		}
+		root := plans[0]
+		return raw, &PlanSummary{
+			NodeType:        root.Plan.NodeType,
+			EstimatedCost:   root.Plan.TotalCost,
+			EstimatedRows:   root.Plan.PlanRows,
+			ActualRows:      root.Plan.ActualRows,
+			PlanningTimeMS:  root.PlanningTime,
+			ExecutionTimeMS: root.ExecutionTime,
+		}
+
+	case strings.HasPrefix(trimmed, "{"):
+		var plan mysqlPlan
+		if err := json.Unmarshal(raw, &plan); err != nil || plan.QueryBlock == nil {
+			return raw, nil
+		}
+		summary := &PlanSummary{}
+		// MySQL reports the cost as a string, e.g. "1.20"
+		if cost, err := strconv.ParseFloat(plan.QueryBlock.CostInfo.QueryCost, 64); err == nil {
+			summary.EstimatedCost = &cost
+		}
+		// A join produces the rows of its last table
+		table := plan.QueryBlock.Table
+		if n := len(plan.QueryBlock.NestedLoop); n > 0 {
+			table = plan.QueryBlock.NestedLoop[n-1].Table
+		}
+		if table != nil {
+			summary.EstimatedRows = table.RowsProducedPerJoin
+		}
+		return raw, summary
+	}
+	return raw, nil
+}
+
+// String renders the summary on one line, e.g. "Seq Scan, estimated cost 35.5, estimated
+// rows 2550".
+func (s *PlanSummary) String() string {
+	var parts []string
+	if s.NodeType != "" {
+		parts = append(parts, s.NodeType)
+	}
+	for _, figure := range []struct {
+		label string
+		value *float64
+		unit  string
+	}{
+		{"estimated cost", s.EstimatedCost, ""},
+		{"estimated rows", s.EstimatedRows, ""},
+		{"actual rows", s.ActualRows, ""},
+		{"planning time", s.PlanningTimeMS, " ms"},
+		{"execution time", s.ExecutionTimeMS, " ms"},
+	} {
+		if figure.value != nil {
+			parts = append(parts, figure.label+" "+strconv.FormatFloat(*figure.value, 'f', -1, 64)+figure.unit)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
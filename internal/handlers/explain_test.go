@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParsePlan(t *testing.T) {
+	float := func(f float64) *float64 { return &f }
+
+	tests := []struct {
+		name     string
+		plan     string
+		wantJSON bool
+		want     *PlanSummary
+		wantText string
+	}{
+		{
+			name:     "postgres estimate",
+			plan:     `[{"Plan": {"Node Type": "Seq Scan", "Relation Name": "users", "Startup Cost": 0.00, "Total Cost": 35.50, "Plan Rows": 2550}}]`,
+			wantJSON: true,
+			want:     &PlanSummary{NodeType: "Seq Scan", EstimatedCost: float(35.5), EstimatedRows: float(2550)},
+			wantText: "Seq Scan, estimated cost 35.5, estimated rows 2550",
+		},
+		{
+			name: "postgres analyze",
+			plan: `[{"Plan": {"Node Type": "Hash Join", "Total Cost": 120.25, "Plan Rows": 40, "Actual Rows": 38, "Plans": [{"Node Type": "Seq Scan"}]},
+				"Planning Time": 0.12, "Execution Time": 1.5}]`,
+			wantJSON: true,
+			want: &PlanSummary{NodeType: "Hash Join", EstimatedCost: float(120.25), EstimatedRows: float(40),
+				ActualRows: float(38), PlanningTimeMS: float(0.12), ExecutionTimeMS: float(1.5)},
+			wantText: "Hash Join, estimated cost 120.25, estimated rows 40, actual rows 38, planning time 0.12 ms, execution time 1.5 ms",
+		},
+		{
+			name:     "mysql single table",
+			plan:     `{"query_block": {"select_id": 1, "cost_info": {"query_cost": "1.20"}, "table": {"table_name": "users", "access_type": "ALL", "rows_produced_per_join": 10}}}`,
+			wantJSON: true,
+			want:     &PlanSummary{EstimatedCost: float(1.2), EstimatedRows: float(10)},
+			wantText: "estimated cost 1.2, estimated rows 10",
+		},
+		{
+			name: "mysql join",
+			plan: `{"query_block": {"cost_info": {"query_cost": "8.75"}, "nested_loop": [
+				{"table": {"table_name": "users", "rows_produced_per_join": 10}},
+				{"table": {"table_name": "orders", "rows_produced_per_join": 25}}]}}`,
+			wantJSON: true,
+			want:     &PlanSummary{EstimatedCost: float(8.75), EstimatedRows: float(25)},
+		},
+		{
+			name:     "unrecognized JSON",
+			plan:     `{"Plan": {"Node Type": "Index Scan"}}`,
+			wantJSON: true,
+		},
+		{
+			name: "mysql analyze tree",
+			plan: "-> Table scan on users  (cost=1.25 rows=10) (actual time=0.05..0.07 rows=10 loops=1)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, summary := parsePlan(tt.plan)
+			if (raw != nil) != tt.wantJSON {
+				t.Errorf("plan JSON set = %v, want %v", raw != nil, tt.wantJSON)
+			}
+			if (summary == nil) != (tt.want == nil) {
+				t.Fatalf("summary = %+v, want %+v", summary, tt.want)
+			}
+			if summary == nil {
+				return
+			}
+
+			if summary.NodeType != tt.want.NodeType {
+				t.Errorf("NodeType = %q, want %q", summary.NodeType, tt.want.NodeType)
+			}
+			for _, field := range []struct {
+				name      string
+				got, want *float64
+			}{
+				{"EstimatedCost", summary.EstimatedCost, tt.want.EstimatedCost},
+				{"EstimatedRows", summary.EstimatedRows, tt.want.EstimatedRows},
+				{"ActualRows", summary.ActualRows, tt.want.ActualRows},
+				{"PlanningTimeMS", summary.PlanningTimeMS, tt.want.PlanningTimeMS},
+				{"ExecutionTimeMS", summary.ExecutionTimeMS, tt.want.ExecutionTimeMS},
+			} {
+				if (field.got == nil) != (field.want == nil) || (field.got != nil && *field.got != *field.want) {
+					t.Errorf("%s = %v, want %v", field.name, deref(field.got), deref(field.want))
+				}
+			}
+			if tt.wantText != "" && summary.String() != tt.wantText {
+				t.Errorf("String() = %q, want %q", summary.String(), tt.wantText)
+			}
+		})
+	}
+}
+
+// deref renders an optional figure for error messages.
+func deref(f *float64) any {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
+
+func TestSchemaHandler_ExplainQuery_Summary(t *testing.T) {
+	mockDB := &MockSchemaDatabase{
+		explainResult: `[{"Plan": {"Node Type": "Index Scan", "Total Cost": 8.29, "Plan Rows": 1}}]`,
+	}
+	mockDB.driver = "postgres"
+	handler := NewSchemaHandler(mockDB, createTestConfig())
+
+	result, err := handler.ExplainQuery(context.Background(), "SELECT * FROM users WHERE id = 1", false)
+	if err != nil {
+		t.Fatalf("ExplainQuery() error = %v", err)
+	}
+	if len(result.PlanJSON) == 0 || string(result.PlanJSON) != result.Plan {
+		t.Errorf("Expected the plan as structured JSON, got %s", result.PlanJSON)
+	}
+	if result.Summary == nil || result.Summary.EstimatedCost == nil || *result.Summary.EstimatedCost != 8.29 {
+		t.Errorf("Expected an estimated cost of 8.29, got %+v", result.Summary)
+	}
+}
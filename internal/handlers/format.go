@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// validateDocumentFormat checks that format is one formatDocument can render.
+func validateDocumentFormat(format string) error {
+	switch format {
+	case "json", "yaml":
+		return nil
+	default:
+		return fmt.Errorf("unsupported format: %s. Supported formats: json, yaml", format)
+	}
+}
+
+// formatDocument renders a tool result as a JSON or YAML document.
+// YAML output uses the same field names and ordering as the JSON output.
+func formatDocument(value any, format string) (string, error) {
+	switch format {
+	case "json":
+		jsonData, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal result to JSON: %w", err)
+		}
+		return string(jsonData), nil
+
+	case "yaml":
+		yamlData, err := marshalYAML(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal result to YAML: %w", err)
+		}
+		return string(yamlData), nil
+
+	default:
+		return "", validateDocumentFormat(format)
+	}
+}
+
+// FormatBytes renders a byte count as a human-readable size using binary units,
+// e.g. 512 B, 1.5 KB, 20.0 MB or 3.2 GB.
+func FormatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	value := float64(bytes)
+	units := []string{"KB", "MB", "GB", "TB", "PB"}
+	for i, suffix := range units {
+		value /= unit
+		if value < unit || i == len(units)-1 {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+	}
+	return fmt.Sprintf("%d B", bytes)
+}
+
+// marshalYAML encodes value as block-style YAML. The value is first encoded as
+// JSON so that json struct tags, omitempty and custom marshalers apply, then
+// re-encoded through a yaml.Node to keep the original key order.
+func marshalYAML(value any) ([]byte, error) {
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonData, &node); err != nil {
+		return nil, err
+	}
+	resetYAMLStyle(&node)
+
+	return yaml.Marshal(&node)
+}
+
+// resetYAMLStyle clears the flow and quoting styles inherited from JSON so the
+// encoder chooses plain block style, quoting scalars only where required.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
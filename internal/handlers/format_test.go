@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFormatDocument_YAML(t *testing.T) {
+	result := QueryResult{
+		Type:     "select",
+		Columns:  []string{"id", "code"},
+		Rows:     []map[string]any{{"id": int64(1), "code": "007"}},
+		RowCount: 1,
+	}
+
+	formatted, err := formatDocument(result, "yaml")
+	if err != nil {
+		t.Fatalf("formatDocument() error = %v", err)
+	}
+
+	// Field names follow the JSON tags and keep struct order
+	if !strings.HasPrefix(formatted, "type: select\n") {
+		t.Errorf("Expected YAML to start with the type field, got:\n%s", formatted)
+	}
+	if !strings.Contains(formatted, "row_count: 1") {
+		t.Errorf("Expected snake_case row_count field, got:\n%s", formatted)
+	}
+	if strings.Contains(formatted, "{") {
+		t.Errorf("Expected block-style YAML, got:\n%s", formatted)
+	}
+
+	var parsed struct {
+		Rows []map[string]any `yaml:"rows"`
+	}
+	if err := yaml.Unmarshal([]byte(formatted), &parsed); err != nil {
+		t.Fatalf("Result is not valid YAML: %v", err)
+	}
+	if parsed.Rows[0]["code"] != "007" {
+		t.Errorf("Expected numeric-looking string to stay a string, got %#v", parsed.Rows[0]["code"])
+	}
+	if parsed.Rows[0]["id"] != 1 {
+		t.Errorf("Expected integer id, got %#v", parsed.Rows[0]["id"])
+	}
+}
+
+func TestFormatDocument_YAMLNulls(t *testing.T) {
+	result := QueryResult{
+		Type: "select",
+		Rows: []map[string]any{{
+			"deleted_at": nil,
+			"note":       "null",
+			"meta":       map[string]any{"owner": nil},
+		}},
+		RowCount: 1,
+	}
+
+	formatted, err := formatDocument(result, "yaml")
+	if err != nil {
+		t.Fatalf("formatDocument() error = %v", err)
+	}
+
+	var parsed struct {
+		Rows []map[string]any `yaml:"rows"`
+	}
+	if err := yaml.Unmarshal([]byte(formatted), &parsed); err != nil {
+		t.Fatalf("Result is not valid YAML: %v", err)
+	}
+
+	row := parsed.Rows[0]
+	if value, ok := row["deleted_at"]; !ok || value != nil {
+		t.Errorf("Expected NULL to round-trip as null, got %#v", value)
+	}
+	if row["note"] != "null" {
+		t.Errorf("Expected the string \"null\" to stay a string, got %#v", row["note"])
+	}
+	meta, ok := row["meta"].(map[string]any)
+	if !ok || meta["owner"] != nil {
+		t.Errorf("Expected nested map with a null owner, got %#v", row["meta"])
+	}
+	if strings.Contains(formatted, "{") {
+		t.Errorf("Expected nested maps in block style, got:\n%s", formatted)
+	}
+}
+
+func TestFormatDocument_UnsupportedFormat(t *testing.T) {
+	if _, err := formatDocument(QueryResult{}, "xml"); err == nil || !strings.Contains(err.Error(), "unsupported format") {
+		t.Errorf("Expected unsupported format error, got %v", err)
+	}
+}
+
+func TestQueryHandler_FormatResult_YAML(t *testing.T) {
+	handler := &QueryHandler{}
+	formatted, err := handler.FormatResult(QueryResult{Type: "insert", RowsAffected: 2, RowCount: 2}, "yaml")
+	if err != nil {
+		t.Fatalf("FormatResult() error = %v", err)
+	}
+	if !strings.Contains(formatted, "rows_affected: 2") {
+		t.Errorf("Expected rows_affected in YAML output, got:\n%s", formatted)
+	}
+}
+
+func TestSchemaHandler_FormatResult(t *testing.T) {
+	result := &TableSchemaResult{
+		Schema: &database.TableSchema{
+			TableName: "users",
+			Columns:   []database.ColumnInfo{{Name: "id", Type: "integer", IsPrimaryKey: true}},
+		},
+	}
+
+	handler := NewSchemaHandler(&MockSchemaDatabase{}, createTestConfig())
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			formatted, err := handler.FormatResult(result, format)
+			if err != nil {
+				t.Fatalf("FormatResult() error = %v", err)
+			}
+			if !strings.Contains(formatted, "users") || !strings.Contains(formatted, "is_primary_key") {
+				t.Errorf("Expected formatted schema to contain table and column details, got:\n%s", formatted)
+			}
+		})
+	}
+
+	if _, err := handler.FormatResult(result, "table"); err == nil {
+		t.Error("Expected error for unsupported schema format")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		want  string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{5 * 1024 * 1024, "5.0 MB"},
+		{3*1024*1024*1024 + 200*1024*1024, "3.2 GB"},
+		{2 * 1024 * 1024 * 1024 * 1024, "2.0 TB"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatBytes(tt.bytes); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.bytes, got, tt.want)
+		}
+	}
+}
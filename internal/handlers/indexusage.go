@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+// IndexUsageReport is an index's usage statistics together with whether it is a candidate
+// for removal.
+type IndexUsageReport struct {
+	database.IndexUsageInfo
+	RemovalCandidate bool `json:"removal_candidate"` // Never scanned and not enforcing uniqueness
+}
+
+// IndexUsageResult represents the result of an index usage analysis.
+type IndexUsageResult struct {
+	Indexes     []IndexUsageReport `json:"indexes"`      // One report per index, least scanned first
+	UnusedCount int                `json:"unused_count"` // Number of removal candidates
+	UnusedBytes int64              `json:"unused_bytes"` // Combined size of the removal candidates
+}
+
+// GetIndexUsage reports how often each index has been scanned and flags indexes with zero
+// scans as candidates for removal, since they slow down writes and take up storage without
+// speeding up reads. Unique indexes are never flagged, as they enforce a constraint. The
+// counts only cover the time since statistics were last reset, so an index used by rare
+// jobs such as month-end reports may show zero scans.
+func (h *AdminHandler) GetIndexUsage(ctx context.Context) (*IndexUsageResult, error) {
+	usage, err := h.db.GetIndexUsage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index usage: %w", err)
+	}
+
+	result := &IndexUsageResult{Indexes: make([]IndexUsageReport, 0, len(usage))}
+	for _, index := range usage {
+		report := IndexUsageReport{
+			IndexUsageInfo:   index,
+			RemovalCandidate: index.IndexScans == 0 && !index.IsUnique,
+		}
+		if report.RemovalCandidate {
+			result.UnusedCount++
+			result.UnusedBytes += index.SizeBytes
+		}
+		result.Indexes = append(result.Indexes, report)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+func TestAdminHandler_GetIndexUsage(t *testing.T) {
+	mockDB := &MockDatabase{
+		driver: "postgres",
+		indexUsage: []database.IndexUsageInfo{
+			{TableName: "orders", IndexName: "orders_legacy_idx", SizeBytes: 8192},
+			{TableName: "orders", IndexName: "orders_reference_key", SizeBytes: 4096, IsUnique: true},
+			{TableName: "users", IndexName: "users_status_idx", SizeBytes: 16384},
+			{TableName: "users", IndexName: "users_email_idx", IndexScans: 120, TuplesRead: 130, TuplesFetched: 120, SizeBytes: 32768},
+		},
+	}
+	handler := NewAdminHandler(mockDB)
+
+	result, err := handler.GetIndexUsage(context.Background())
+	if err != nil {
+		t.Fatalf("GetIndexUsage() error = %v", err)
+	}
+	if len(result.Indexes) != 4 {
+		t.Fatalf("Expected 4 index reports, got %d", len(result.Indexes))
+	}
+
+	wantCandidates := []bool{true, false, true, false}
+	for i, index := range result.Indexes {
+		if index.RemovalCandidate != wantCandidates[i] {
+			t.Errorf("%s: RemovalCandidate = %v, want %v", index.IndexName, index.RemovalCandidate, wantCandidates[i])
+		}
+	}
+	if result.UnusedCount != 2 || result.UnusedBytes != 8192+16384 {
+		t.Errorf("Expected 2 unused indexes totalling 24576 bytes, got %d totalling %d", result.UnusedCount, result.UnusedBytes)
+	}
+}
+
+func TestAdminHandler_GetIndexUsage_Error(t *testing.T) {
+	mockDB := &MockDatabase{driver: "mysql", shouldReturnError: true, errorMessage: "sys schema not installed"}
+	handler := NewAdminHandler(mockDB)
+
+	_, err := handler.GetIndexUsage(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to get index usage") {
+		t.Errorf("GetIndexUsage() error = %v, want error containing %q", err, "failed to get index usage")
+	}
+}
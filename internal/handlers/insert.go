@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+// InsertTemplateResult represents a parameterized INSERT statement for a table.
+type InsertTemplateResult struct {
+	TableName  string            `json:"table_name"` // Name of the table
+	Statement  string            `json:"statement"`  // INSERT statement with one placeholder per column, annotated with comments
+	Parameters []InsertParameter `json:"parameters"` // The statement's parameters, in placeholder order
+}
+
+// InsertParameter describes the column bound to one placeholder of an insert template.
+type InsertParameter struct {
+	Position      int     `json:"position"`                 // 1-based parameter position
+	Placeholder   string  `json:"placeholder"`              // Placeholder in the statement: $n (PostgreSQL) or ? (MySQL)
+	Column        string  `json:"column"`                   // Column name
+	Type          string  `json:"type"`                     // Column data type
+	Nullable      bool    `json:"nullable"`                 // Whether the column accepts NULL
+	Default       *string `json:"default,omitempty"`        // Column default, if any
+	AutoIncrement bool    `json:"auto_increment,omitempty"` // Whether the database generates the value
+	Optional      bool    `json:"optional"`                 // Whether the column can be left out of the INSERT
+}
+
+// GenerateInsertTemplate describes a table and returns a parameterized INSERT statement
+// listing every column, with placeholders in the database's syntax ($1, $2, ... for
+// PostgreSQL, ? for MySQL). Auto-increment columns and columns with a default are marked
+// with a comment, since they can be left out. The statement's comments must be removed
+// before it is run unless DB_ALLOW_COMMENTS is set.
+func (h *SchemaHandler) GenerateInsertTemplate(ctx context.Context, tableName string) (*InsertTemplateResult, error) {
+	if err := h.ValidateTableName(tableName); err != nil {
+		return nil, err
+	}
+	if err := h.requireTable(ctx, tableName); err != nil {
+		return nil, err
+	}
+
+	schema, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) (*database.TableSchema, error) {
+		return h.db.DescribeTable(ctx, tableName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe table %s: %w", tableName, err)
+	}
+	if len(schema.Columns) == 0 {
+		return nil, fmt.Errorf("table %s has no columns", tableName)
+	}
+
+	driver := h.db.GetDriverName()
+	parameters := make([]InsertParameter, len(schema.Columns))
+	columns := make([]string, len(schema.Columns))
+	placeholders := make([]string, len(schema.Columns))
+	for i, column := range schema.Columns {
+		placeholder := "?"
+		if driver == "postgres" {
+			placeholder = fmt.Sprintf("$%d", i+1)
+		}
+
+		parameter := InsertParameter{
+			Position:      i + 1,
+			Placeholder:   placeholder,
+			Column:        column.Name,
+			Type:          column.Type,
+			Nullable:      column.IsNullable,
+			AutoIncrement: column.IsAutoIncrement,
+		}
+		if hasDefault(column) {
+			parameter.Default = column.DefaultValue
+		}
+		parameter.Optional = parameter.AutoIncrement || parameter.Default != nil || parameter.Nullable
+		parameters[i] = parameter
+
+		columns[i] = database.QuoteIdentifier(driver, column.Name)
+		placeholders[i] = placeholder
+	}
+
+	return &InsertTemplateResult{
+		TableName:  tableName,
+		Statement:  insertStatement(database.QuoteIdentifier(driver, tableName), columns, placeholders, parameters),
+		Parameters: parameters,
+	}, nil
+}
+
+// hasDefault reports whether a column has a default value other than NULL.
+func hasDefault(column database.ColumnInfo) bool {
+	if column.DefaultValue == nil {
+		return false
+	}
+	value := strings.TrimSpace(*column.DefaultValue)
+	return value != "" && !strings.EqualFold(value, "NULL") && !strings.HasPrefix(strings.ToUpper(value), "NULL::")
+}
+
+// insertStatement renders the INSERT statement with one column per line, each annotated
+// with a comment when the database generates or defaults its value.
+func insertStatement(table string, columns, placeholders []string, parameters []InsertParameter) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "INSERT INTO %s (\n", table)
+	for i, column := range columns {
+		line := "    " + column
+		if i < len(columns)-1 {
+			line += ","
+		}
+
+		switch parameter := parameters[i]; {
+		case parameter.AutoIncrement:
+			line += " -- auto-increment"
+		case parameter.Default != nil:
+			line += " -- default " + strings.ReplaceAll(*parameter.Default, "\n", " ")
+		}
+		b.WriteString(line + "\n")
+	}
+	fmt.Fprintf(&b, ") VALUES (%s)", strings.Join(placeholders, ", "))
+	return b.String()
+}
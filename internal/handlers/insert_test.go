@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+func TestSchemaHandler_GenerateInsertTemplate(t *testing.T) {
+	stringPtr := func(s string) *string { return &s }
+	usersSchema := &database.TableSchema{
+		TableName: "users",
+		Columns: []database.ColumnInfo{
+			{Name: "id", Type: "integer", IsAutoIncrement: true, DefaultValue: stringPtr("nextval('users_id_seq'::regclass)")},
+			{Name: "email", Type: "varchar(255)"},
+			{Name: "status", Type: "text", DefaultValue: stringPtr("'active'")},
+			{Name: "nickname", Type: "text", IsNullable: true, DefaultValue: stringPtr("NULL::text")},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		driver        string
+		tableName     string
+		schema        *database.TableSchema
+		describeErr   error
+		wantStatement string
+		wantErr       string
+	}{
+		{
+			name:      "postgres placeholders",
+			driver:    "postgres",
+			tableName: "users",
+			schema:    usersSchema,
+			wantStatement: "INSERT INTO \"users\" (\n" +
+				"    \"id\", -- auto-increment\n" +
+				"    \"email\",\n" +
+				"    \"status\", -- default 'active'\n" +
+				"    \"nickname\"\n" +
+				") VALUES ($1, $2, $3, $4)",
+		},
+		{
+			name:      "mysql placeholders",
+			driver:    "mysql",
+			tableName: "users",
+			schema:    usersSchema,
+			wantStatement: "INSERT INTO `users` (\n" +
+				"    `id`, -- auto-increment\n" +
+				"    `email`,\n" +
+				"    `status`, -- default 'active'\n" +
+				"    `nickname`\n" +
+				") VALUES (?, ?, ?, ?)",
+		},
+		{
+			name:        "describe error",
+			driver:      "postgres",
+			tableName:   "users",
+			describeErr: errors.New("permission denied"),
+			wantErr:     "failed to describe table users",
+		},
+		{
+			name:      "no columns",
+			driver:    "postgres",
+			tableName: "users",
+			schema:    &database.TableSchema{TableName: "users"},
+			wantErr:   "table users has no columns",
+		},
+		{
+			name:      "dangerous table name",
+			driver:    "postgres",
+			tableName: "users; DROP TABLE users",
+			wantErr:   "potentially dangerous",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{tables: []string{tt.tableName}, tableSchema: tt.schema, describeErr: tt.describeErr}
+			mockDB.driver = tt.driver
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+
+			result, err := handler.GenerateInsertTemplate(context.Background(), tt.tableName)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("GenerateInsertTemplate() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GenerateInsertTemplate() unexpected error = %v", err)
+			}
+
+			if result.Statement != tt.wantStatement {
+				t.Errorf("Statement = %q, want %q", result.Statement, tt.wantStatement)
+			}
+			if len(result.Parameters) != len(tt.schema.Columns) {
+				t.Fatalf("Expected %d parameters, got %d", len(tt.schema.Columns), len(result.Parameters))
+			}
+
+			id, email, nickname := result.Parameters[0], result.Parameters[1], result.Parameters[3]
+			if id.Position != 1 || id.Column != "id" || !id.AutoIncrement || !id.Optional {
+				t.Errorf("Unexpected id parameter %+v", id)
+			}
+			if email.Type != "varchar(255)" || email.Optional || email.Default != nil {
+				t.Errorf("Unexpected email parameter %+v", email)
+			}
+			if nickname.Default != nil || !nickname.Optional {
+				t.Errorf("Expected a NULL default to be dropped, got %+v", nickname)
+			}
+		})
+	}
+}
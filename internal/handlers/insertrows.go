@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+// maxInsertParameters is the largest number of bound parameters a single INSERT may use.
+// Both PostgreSQL and MySQL reject statements with more than 65535 placeholders, so larger
+// inserts are split into several statements.
+const maxInsertParameters = 65535
+
+// InsertRowsResult represents the outcome of an InsertRows call.
+type InsertRowsResult struct {
+	TableName     string   `json:"table_name"`                // Name of the table
+	Columns       []string `json:"columns"`                   // Inserted columns, in table order
+	RowsAffected  int64    `json:"rows_affected"`             // Number of rows inserted
+	GeneratedIDs  []any    `json:"generated_ids,omitempty"`   // Generated values of the auto-increment column, one per row in input order (PostgreSQL)
+	FirstInsertID *int64   `json:"first_insert_id,omitempty"` // ID generated for the first row (MySQL)
+	ExecutionTime string   `json:"execution_time,omitempty"`  // Statement execution time
+	Message       string   `json:"message,omitempty"`         // Success/info message
+}
+
+// InsertRows inserts rows into a table with a parameterized multi-row INSERT. Each row maps
+// column names to values; every row must set the same columns, and every column must exist
+// in the table. At most DB_MAX_INSERT_ROWS rows are accepted per call. Values are bound as
+// parameters, never interpolated; objects and arrays are bound as JSON text. Rows needing
+// more than 65535 parameters are split into several INSERTs that run in one transaction, so
+// either every row is inserted or none is. Each statement passes the same validation as
+// ExecuteQuery.
+//
+// On PostgreSQL the values of the table's auto-increment columns are returned with
+// RETURNING. MySQL only reports the ID generated for the first row; the IDs of the other
+// rows are not necessarily consecutive.
+func (h *QueryHandler) InsertRows(ctx context.Context, tableName string, rows []map[string]any) (*InsertRowsResult, error) {
+	start := time.Now()
+	statement, args, result, err := h.insertRows(ctx, tableName, rows)
+	var queryResult *QueryResult
+	if result != nil {
+		queryResult = &QueryResult{Type: "insert", RowsAffected: result.RowsAffected, RowCount: int(result.RowsAffected)}
+	}
+	if statement != "" {
+		h.recordExecution(statement, len(args), queryResult, err, time.Since(start))
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.ExecutionTime = time.Since(start).String()
+	return result, nil
+}
+
+// insertRows builds and runs the INSERT statements, returning the statements it ran, joined
+// by semicolons, and their arguments, which are empty when the input was rejected before a
+// statement was built.
+func (h *QueryHandler) insertRows(ctx context.Context, tableName string, rows []map[string]any) (string, []any, *InsertRowsResult, error) {
+	if strings.TrimSpace(tableName) == "" {
+		return "", nil, nil, fmt.Errorf("table name cannot be empty")
+	}
+	if len(rows) == 0 {
+		return "", nil, nil, fmt.Errorf("rows cannot be empty")
+	}
+	if len(rows) > h.maxInsert {
+		return "", nil, nil, fmt.Errorf("%d rows exceed the limit of %d rows per call (DB_MAX_INSERT_ROWS); split the rows into chunks of at most %d and insert each separately",
+			len(rows), h.maxInsert, h.maxInsert)
+	}
+
+	schema, err := runWithTimeout(ctx, h.timeout, func(ctx context.Context) (*database.TableSchema, error) {
+		return h.db.DescribeTable(ctx, tableName)
+	})
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to describe table %s: %w", tableName, err)
+	}
+	if schema == nil || len(schema.Columns) == 0 {
+		return "", nil, nil, fmt.Errorf("table not found: %s", tableName)
+	}
+
+	columns, err := insertColumns(schema, rows)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	driver := h.db.GetDriverName()
+	var returning []string
+	if driver == "postgres" {
+		for _, column := range schema.Columns {
+			if column.IsAutoIncrement {
+				returning = append(returning, column.Name)
+			}
+		}
+	}
+
+	statements, statementArgs, err := insertRowsStatements(driver, h.db.QuoteTable(ctx, tableName), columns, rows, returning)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	statement := strings.Join(statements, ";\n")
+	args := slices.Concat(statementArgs...)
+
+	for _, s := range statements {
+		if err := h.validator.ValidateQuery(s); err != nil {
+			return statement, args, nil, h.validator.SanitizeErrorMessage(err)
+		}
+	}
+
+	queryCtx, cancel := withQueryTimeout(ctx, h.timeout)
+	defer cancel()
+
+	result := &InsertRowsResult{TableName: tableName, Columns: columns}
+	err = h.insertStatements(queryCtx, statements, statementArgs, returning, result)
+	if err != nil {
+		if queryCtx.Err() != nil {
+			err = describeContextError(ctx, queryCtx, h.timeout, err)
+		}
+		return statement, args, nil, err
+	}
+
+	result.Message = fmt.Sprintf("INSERT executed successfully. %d rows affected.", result.RowsAffected)
+	if len(statements) > 1 {
+		result.Message = fmt.Sprintf("INSERT executed successfully as %d statements in one transaction. %d rows affected.",
+			len(statements), result.RowsAffected)
+	}
+	return statement, args, result, nil
+}
+
+// insertStatements runs the INSERT statements, adding the rows affected and generated IDs of
+// each to result. Several statements run in one transaction, which is rolled back if any of
+// them fails.
+func (h *QueryHandler) insertStatements(ctx context.Context, statements []string, args [][]any, returning []string, result *InsertRowsResult) error {
+	runner := h
+	var tx *sql.Tx
+	if len(statements) > 1 {
+		var err error
+		tx, err = h.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+
+		// Run the statements through a copy of the handler whose database is the transaction
+		inTx := *h
+		inTx.db = &txDatabase{Database: h.db, tx: tx, config: h.dbConfig}
+		runner = &inTx
+	}
+
+	for i, statement := range statements {
+		if len(returning) > 0 {
+			ids, err := runner.insertReturning(ctx, statement, args[i], returning)
+			if err != nil {
+				return err
+			}
+			result.GeneratedIDs = append(result.GeneratedIDs, ids...)
+			result.RowsAffected += int64(len(ids))
+			continue
+		}
+
+		var chunk InsertRowsResult
+		if err := runner.insertExec(ctx, statement, args[i], &chunk); err != nil {
+			return err
+		}
+		result.RowsAffected += chunk.RowsAffected
+		if result.FirstInsertID == nil {
+			result.FirstInsertID = chunk.FirstInsertID
+		}
+	}
+
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit insert: %w", err)
+		}
+	}
+	return nil
+}
+
+// insertColumns checks that every row sets the same columns and that each of them exists
+// in the table, returning the columns in table order.
+func insertColumns(schema *database.TableSchema, rows []map[string]any) ([]string, error) {
+	first := slices.Sorted(maps.Keys(rows[0]))
+	if len(first) == 0 {
+		return nil, fmt.Errorf("row 1 has no columns")
+	}
+	for _, name := range first {
+		if !slices.ContainsFunc(schema.Columns, func(c database.ColumnInfo) bool { return c.Name == name }) {
+			return nil, fmt.Errorf("column %s does not exist in table %s", name, schema.TableName)
+		}
+	}
+	for i, row := range rows[1:] {
+		if names := slices.Sorted(maps.Keys(row)); !slices.Equal(names, first) {
+			return nil, fmt.Errorf("row %d has columns (%s), expected (%s) as in row 1",
+				i+2, strings.Join(names, ", "), strings.Join(first, ", "))
+		}
+	}
+
+	columns := make([]string, 0, len(first))
+	for _, column := range schema.Columns {
+		if _, ok := rows[0][column.Name]; ok {
+			columns = append(columns, column.Name)
+		}
+	}
+	return columns, nil
+}
+
+// insertRowsStatements renders the multi-row INSERTs into the already quoted table with
+// quoted columns and one placeholder per value, returning the statements and the arguments
+// of each in placeholder order. The rows are split so that no statement has more than
+// maxInsertParameters placeholders. Returning columns are added in a RETURNING clause.
+func insertRowsStatements(driver, quotedTable string, columns []string, rows []map[string]any, returning []string) ([]string, [][]any, error) {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = database.QuoteIdentifier(driver, column)
+	}
+
+	var returningClause string
+	if len(returning) > 0 {
+		quotedReturning := make([]string, len(returning))
+		for i, column := range returning {
+			quotedReturning[i] = database.QuoteIdentifier(driver, column)
+		}
+		returningClause = " RETURNING " + strings.Join(quotedReturning, ", ")
+	}
+
+	rowsPerStatement := maxInsertParameters / len(columns)
+	var statements []string
+	var statementArgs [][]any
+	for first := 0; first < len(rows); first += rowsPerStatement {
+		chunk := rows[first:min(first+rowsPerStatement, len(rows))]
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "INSERT INTO %s (%s) VALUES ", quotedTable, strings.Join(quoted, ", "))
+
+		args := make([]any, 0, len(chunk)*len(columns))
+		placeholders := make([]string, len(columns))
+		for i, row := range chunk {
+			for j, column := range columns {
+				value, err := insertValue(row[column])
+				if err != nil {
+					return nil, nil, fmt.Errorf("row %d column %s: %w", first+i+1, column, err)
+				}
+				args = append(args, value)
+
+				placeholders[j] = "?"
+				if driver == "postgres" {
+					placeholders[j] = fmt.Sprintf("$%d", len(args))
+				}
+			}
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "(%s)", strings.Join(placeholders, ", "))
+		}
+		b.WriteString(returningClause)
+
+		statements = append(statements, b.String())
+		statementArgs = append(statementArgs, args)
+	}
+	return statements, statementArgs, nil
+}
+
+// insertValue converts a decoded JSON value to a parameter the drivers accept: objects and
+// arrays become JSON text, everything else is bound as is.
+func insertValue(value any) (any, error) {
+	switch value.(type) {
+	case map[string]any, []any:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode value as JSON: %w", err)
+		}
+		return string(data), nil
+	default:
+		return value, nil
+	}
+}
+
+// insertReturning runs an INSERT ... RETURNING and collects the returned values, one per
+// row: the bare value for a single returning column, otherwise a map by column name.
+func (h *QueryHandler) insertReturning(ctx context.Context, statement string, args []any, returning []string) ([]any, error) {
+	rows, err := h.db.Query(ctx, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query execution failed: %w", err)
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column types: %w", err)
+	}
+
+	var ids []any
+	for rows.Next() {
+		values := make([]any, len(returning))
+		valuePtrs := make([]any, len(returning))
+		for i := range values {
+			valuePtrs[i] = &values[i]
+		}
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return nil, fmt.Errorf("failed to scan generated values: %w", err)
+		}
+
+		if len(returning) == 1 {
+			ids = append(ids, h.convertValue(values[0], columnTypes[0]))
+			continue
+		}
+		row := make(map[string]any, len(returning))
+		for i, column := range returning {
+			row[column] = h.convertValue(values[i], columnTypes[i])
+		}
+		ids = append(ids, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query execution failed: %w", err)
+	}
+	return ids, nil
+}
+
+// insertExec runs an INSERT without a RETURNING clause, filling in the rows affected and,
+// where the driver reports it, the first generated ID.
+func (h *QueryHandler) insertExec(ctx context.Context, statement string, args []any, result *InsertRowsResult) error {
+	res, err := h.db.Exec(ctx, statement, args...)
+	if err != nil {
+		return fmt.Errorf("query execution failed: %w", err)
+	}
+
+	result.RowsAffected, err = res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if lastID, err := res.LastInsertId(); err == nil && lastID > 0 {
+		result.FirstInsertID = &lastID
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+func newInsertRowsSchema() *database.TableSchema {
+	return &database.TableSchema{
+		TableName: "orders",
+		Columns: []database.ColumnInfo{
+			{Name: "id", Type: "integer", IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "customer", Type: "text"},
+			{Name: "total", Type: "numeric"},
+			{Name: "details", Type: "jsonb", IsNullable: true},
+		},
+	}
+}
+
+func TestQueryHandler_InsertRows_PostgreSQL(t *testing.T) {
+	set := &mockResultSet{columns: []string{"id"}, types: []string{"INT4"}, rows: [][]driver.Value{{int64(7)}, {int64(8)}}}
+	mockDB := newSelectMock(t, "postgres", set)
+	mockDB.tableSchema = newInsertRowsSchema()
+
+	var gotArgs []any
+	queryFunc := mockDB.queryFunc
+	mockDB.queryFunc = func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+		gotArgs = args
+		return queryFunc(ctx, query, args...)
+	}
+
+	handler := NewQueryHandler(mockDB, createTestConfig())
+	result, err := handler.InsertRows(context.Background(), "orders", []map[string]any{
+		{"total": 12.5, "customer": "ada", "details": map[string]any{"gift": true}},
+		{"customer": "o'brien", "total": 3.0, "details": nil},
+	})
+	if err != nil {
+		t.Fatalf("InsertRows() unexpected error = %v", err)
+	}
+
+	wantQuery := `INSERT INTO "orders" ("customer", "total", "details") VALUES ($1, $2, $3), ($4, $5, $6) RETURNING "id"`
+	if queries := set.Queries(); len(queries) != 1 || queries[0] != wantQuery {
+		t.Errorf("Expected query %q, got %v", wantQuery, queries)
+	}
+	wantArgs := []any{"ada", 12.5, `{"gift":true}`, "o'brien", 3.0, nil}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Errorf("Expected arguments %v, got %v", wantArgs, gotArgs)
+	}
+
+	if result.RowsAffected != 2 {
+		t.Errorf("Expected 2 rows affected, got %d", result.RowsAffected)
+	}
+	if !reflect.DeepEqual(result.GeneratedIDs, []any{int64(7), int64(8)}) {
+		t.Errorf("Expected generated IDs [7 8], got %v", result.GeneratedIDs)
+	}
+	if !reflect.DeepEqual(result.Columns, []string{"customer", "total", "details"}) {
+		t.Errorf("Expected columns in table order, got %v", result.Columns)
+	}
+}
+
+func TestQueryHandler_InsertRows_SchemaQualified(t *testing.T) {
+	set := &mockResultSet{columns: []string{"id"}, types: []string{"INT4"}, rows: [][]driver.Value{{int64(1)}}}
+	mockDB := newSelectMock(t, "postgres", set)
+	mockDB.tableSchema = newInsertRowsSchema()
+
+	handler := NewQueryHandler(mockDB, createTestConfig())
+	if _, err := handler.InsertRows(context.Background(), "sales.orders", []map[string]any{{"customer": "ada", "total": 1}}); err != nil {
+		t.Fatalf("InsertRows() unexpected error = %v", err)
+	}
+
+	wantQuery := `INSERT INTO "sales"."orders" ("customer", "total") VALUES ($1, $2) RETURNING "id"`
+	if queries := set.Queries(); len(queries) != 1 || queries[0] != wantQuery {
+		t.Errorf("Expected query %q, got %v", wantQuery, queries)
+	}
+}
+
+func TestQueryHandler_InsertRows_MySQL(t *testing.T) {
+	var gotQuery string
+	mockDB := &MockDatabase{
+		driver:      "mysql",
+		tableSchema: newInsertRowsSchema(),
+		execFunc: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+			gotQuery = query
+			return &MockResult{rowsAffected: 2, lastInsertID: 41}, nil
+		},
+	}
+
+	handler := NewQueryHandler(mockDB, createTestConfig())
+	result, err := handler.InsertRows(context.Background(), "orders", []map[string]any{
+		{"customer": "ada", "total": 1},
+		{"customer": "grace", "total": 2},
+	})
+	if err != nil {
+		t.Fatalf("InsertRows() unexpected error = %v", err)
+	}
+
+	wantQuery := "INSERT INTO `orders` (`customer`, `total`) VALUES (?, ?), (?, ?)"
+	if gotQuery != wantQuery {
+		t.Errorf("Expected query %q, got %q", wantQuery, gotQuery)
+	}
+	if result.RowsAffected != 2 || result.FirstInsertID == nil || *result.FirstInsertID != 41 {
+		t.Errorf("Expected 2 rows affected and first insert ID 41, got %+v", result)
+	}
+}
+
+func TestQueryHandler_InsertRows_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   string
+		schema  *database.TableSchema
+		rows    []map[string]any
+		wantErr string
+	}{
+		{name: "empty table name", table: " ", rows: []map[string]any{{"customer": "ada"}}, wantErr: "table name cannot be empty"},
+		{name: "no rows", table: "orders", wantErr: "rows cannot be empty"},
+		{name: "unknown table", table: "missing", rows: []map[string]any{{"customer": "ada"}}, wantErr: "table not found: missing"},
+		{name: "empty row", table: "orders", schema: newInsertRowsSchema(), rows: []map[string]any{{}}, wantErr: "row 1 has no columns"},
+		{
+			name:    "unknown column",
+			table:   "orders",
+			schema:  newInsertRowsSchema(),
+			rows:    []map[string]any{{"customer": "ada", `total"); DROP TABLE orders; --`: 1}},
+			wantErr: "does not exist in table orders",
+		},
+		{
+			name:    "differing columns",
+			table:   "orders",
+			schema:  newInsertRowsSchema(),
+			rows:    []map[string]any{{"customer": "ada", "total": 1}, {"customer": "grace"}},
+			wantErr: "row 2 has columns (customer), expected (customer, total) as in row 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executed := false
+			mockDB := &MockDatabase{
+				driver:      "mysql",
+				tableSchema: tt.schema,
+				execFunc: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					executed = true
+					return &MockResult{rowsAffected: 1}, nil
+				},
+			}
+
+			handler := NewQueryHandler(mockDB, createTestConfig())
+			_, err := handler.InsertRows(context.Background(), tt.table, tt.rows)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("InsertRows() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			if executed {
+				t.Error("Expected no statement to be executed")
+			}
+		})
+	}
+}
+
+func TestQueryHandler_InsertRows_ParameterLimit(t *testing.T) {
+	// Two columns fit 32767 rows in a statement, so the rows at the cap need three statements
+	rowsPerStatement := maxInsertParameters / 2
+	rows := make([]map[string]any, 2*rowsPerStatement+1)
+	for i := range rows {
+		rows[i] = map[string]any{"customer": "ada", "total": i}
+	}
+	cfg := createTestConfig()
+	cfg.MaxInsertRows = len(rows)
+
+	t.Run("split into statements", func(t *testing.T) {
+		set := &mockResultSet{columns: []string{"id"}, types: []string{"INT4"}, rows: [][]driver.Value{{int64(1)}}}
+		mockDB := &MockDatabase{driver: "postgres", tableSchema: newInsertRowsSchema(), sqlDB: newMockSQLDB(t, set)}
+
+		handler := NewQueryHandler(mockDB, cfg)
+		result, err := handler.InsertRows(context.Background(), "orders", rows)
+		if err != nil {
+			t.Fatalf("InsertRows() unexpected error = %v", err)
+		}
+
+		queries := set.Queries()
+		if len(queries) != 3 {
+			t.Fatalf("Expected 3 statements, got %d", len(queries))
+		}
+		last := fmt.Sprintf("($%d, $%d) RETURNING \"id\"", 2*rowsPerStatement-1, 2*rowsPerStatement)
+		for i, query := range queries[:2] {
+			if !strings.HasSuffix(query, last) {
+				t.Errorf("Expected statement %d to end with %q, got %q", i+1, last, query[len(query)-len(last):])
+			}
+		}
+		if want := `INSERT INTO "orders" ("customer", "total") VALUES ($1, $2) RETURNING "id"`; queries[2] != want {
+			t.Errorf("Expected the remaining row in %q, got %q", want, queries[2])
+		}
+		if commits, rollbacks := set.Transactions(); commits != 1 || rollbacks != 0 {
+			t.Errorf("Expected the statements to commit in one transaction, got %d commits and %d rollbacks", commits, rollbacks)
+		}
+		if result.RowsAffected != 3 || !strings.Contains(result.Message, "as 3 statements in one transaction") {
+			t.Errorf("Expected the results of all statements, got %d rows affected and message %q", result.RowsAffected, result.Message)
+		}
+	})
+
+	t.Run("failed statement rolls back", func(t *testing.T) {
+		set := &mockResultSet{failExec: "INSERT"}
+		mockDB := &MockDatabase{driver: "mysql", tableSchema: newInsertRowsSchema(), sqlDB: newMockSQLDB(t, set)}
+
+		handler := NewQueryHandler(mockDB, cfg)
+		if _, err := handler.InsertRows(context.Background(), "orders", rows); err == nil || !strings.Contains(err.Error(), "mock exec failure") {
+			t.Errorf("InsertRows() error = %v, want the statement failure", err)
+		}
+		if commits, rollbacks := set.Transactions(); commits != 0 || rollbacks != 1 {
+			t.Errorf("Expected the transaction to roll back, got %d commits and %d rollbacks", commits, rollbacks)
+		}
+	})
+}
+
+func TestQueryHandler_InsertRows_MaxRows(t *testing.T) {
+	tests := []struct {
+		name      string
+		rows      int
+		wantQuery string
+		wantErr   string
+	}{
+		{name: "at the cap", rows: 3, wantQuery: "INSERT INTO `orders` (`customer`, `total`) VALUES (?, ?), (?, ?), (?, ?)"},
+		{name: "over the cap", rows: 4, wantErr: "4 rows exceed the limit of 3 rows per call (DB_MAX_INSERT_ROWS); split the rows into chunks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []string
+			mockDB := &MockDatabase{
+				driver:      "mysql",
+				tableSchema: newInsertRowsSchema(),
+				execFunc: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					queries = append(queries, query)
+					return &MockResult{rowsAffected: int64(len(args) / 2)}, nil
+				},
+			}
+			rows := make([]map[string]any, tt.rows)
+			for i := range rows {
+				rows[i] = map[string]any{"customer": "ada", "total": i}
+			}
+
+			cfg := createTestConfig()
+			cfg.MaxInsertRows = 3
+			result, err := NewQueryHandler(mockDB, cfg).InsertRows(context.Background(), "orders", rows)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("InsertRows() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				if len(queries) != 0 {
+					t.Errorf("Expected no statement to be executed, got %q", queries)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("InsertRows() unexpected error = %v", err)
+			}
+			if len(queries) != 1 || queries[0] != tt.wantQuery {
+				t.Errorf("Expected the single statement %q, got %q", tt.wantQuery, queries)
+			}
+			if result.RowsAffected != int64(tt.rows) {
+				t.Errorf("Expected %d rows affected, got %d", tt.rows, result.RowsAffected)
+			}
+		})
+	}
+}
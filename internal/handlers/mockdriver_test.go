@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// mockResultSet describes the rows returned by the mock SQL driver and records how it was used.
+type mockResultSet struct {
+	columns  []string         // Column names returned by every query
+	types    []string         // Optional database type names, matching columns by position
+	nullable []bool           // Optional column nullability, matching columns by position
+	rows     [][]driver.Value // Row values returned by every query
+	failExec string           // Exec fails for statements containing this text, when set
+	failPrep string           // Prepare fails for statements containing this text, when set
+
+	mu        sync.Mutex
+	queries   []string // SQL text of every executed statement
+	closed    int      // Number of result sets closed
+	commits   int      // Number of committed transactions
+	rollbacks int      // Number of rolled back transactions
+}
+
+// Queries returns a copy of the SQL statements executed against the result set.
+func (s *mockResultSet) Queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.queries...)
+}
+
+// Transactions returns the number of committed and rolled back transactions.
+func (s *mockResultSet) Transactions() (commits, rollbacks int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.commits, s.rollbacks
+}
+
+// Closed returns the number of times a result set was closed.
+func (s *mockResultSet) Closed() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+func (s *mockResultSet) record(query string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queries = append(s.queries, query)
+}
+
+var (
+	mockDriverOnce  sync.Once
+	mockDriverCount atomic.Int64
+	mockResultSets  sync.Map
+)
+
+// newMockSQLDB opens a *sql.DB whose queries return the given result set.
+func newMockSQLDB(t *testing.T, set *mockResultSet) *sql.DB {
+	t.Helper()
+	mockDriverOnce.Do(func() {
+		sql.Register("handlers-mock", mockDriver{})
+	})
+
+	name := fmt.Sprintf("set-%d", mockDriverCount.Add(1))
+	mockResultSets.Store(name, set)
+
+	db, err := sql.Open("handlers-mock", name)
+	if err != nil {
+		t.Fatalf("failed to open mock database: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		mockResultSets.Delete(name)
+	})
+	return db
+}
+
+// newSelectMock returns a MockDatabase whose Query calls are served by the given result set.
+func newSelectMock(t *testing.T, driverName string, set *mockResultSet) *MockDatabase {
+	t.Helper()
+	db := newMockSQLDB(t, set)
+	return &MockDatabase{
+		driver: driverName,
+		queryFunc: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+			return db.QueryContext(ctx, query, args...)
+		},
+	}
+}
+
+type mockDriver struct{}
+
+func (mockDriver) Open(name string) (driver.Conn, error) {
+	set, ok := mockResultSets.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown mock result set %q", name)
+	}
+	return &mockConn{set: set.(*mockResultSet)}, nil
+}
+
+type mockConn struct {
+	set *mockResultSet
+}
+
+func (c *mockConn) Prepare(query string) (driver.Stmt, error) {
+	if c.set.failPrep != "" && strings.Contains(query, c.set.failPrep) {
+		return nil, fmt.Errorf("mock prepare failure")
+	}
+	return &mockStmt{set: c.set, query: query}, nil
+}
+func (c *mockConn) Close() error              { return nil }
+func (c *mockConn) Begin() (driver.Tx, error) { return mockTx{set: c.set}, nil }
+
+type mockTx struct {
+	set *mockResultSet
+}
+
+func (tx mockTx) Commit() error {
+	tx.set.mu.Lock()
+	defer tx.set.mu.Unlock()
+	tx.set.commits++
+	return nil
+}
+
+func (tx mockTx) Rollback() error {
+	tx.set.mu.Lock()
+	defer tx.set.mu.Unlock()
+	tx.set.rollbacks++
+	return nil
+}
+
+type mockStmt struct {
+	set   *mockResultSet
+	query string
+}
+
+func (s *mockStmt) Close() error  { return nil }
+func (s *mockStmt) NumInput() int { return -1 }
+
+func (s *mockStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.set.record(s.query)
+	if s.set.failExec != "" && strings.Contains(s.query, s.set.failExec) {
+		return nil, fmt.Errorf("mock exec failure")
+	}
+	return driver.RowsAffected(len(s.set.rows)), nil
+}
+
+func (s *mockStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.set.record(s.query)
+	return &mockRows{set: s.set}, nil
+}
+
+type mockRows struct {
+	set  *mockResultSet
+	next int
+}
+
+func (r *mockRows) Columns() []string { return r.set.columns }
+
+func (r *mockRows) Close() error {
+	r.set.mu.Lock()
+	defer r.set.mu.Unlock()
+	r.set.closed++
+	return nil
+}
+
+func (r *mockRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.set.rows) {
+		return io.EOF
+	}
+	copy(dest, r.set.rows[r.next])
+	r.next++
+	return nil
+}
+
+func (r *mockRows) ColumnTypeDatabaseTypeName(index int) string {
+	if index < len(r.set.types) {
+		return r.set.types[index]
+	}
+	return ""
+}
+
+func (r *mockRows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	if index < len(r.set.nullable) {
+		return r.set.nullable[index], true
+	}
+	return false, false
+}
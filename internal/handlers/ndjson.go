@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// writeNDJSON writes result as newline-delimited JSON: a header line {"columns":[...]},
+// one object per row with its values in column order, and a final {"row_count":N} line.
+// Each line is written as soon as it is encoded, so w never holds more than one row.
+func writeNDJSON(result QueryResult, w io.Writer) error {
+	out := bufio.NewWriter(w)
+
+	columns := result.Columns
+	if columns == nil {
+		columns = []string{}
+	}
+	if err := writeNDJSONLine(out, struct {
+		Columns []string `json:"columns"`
+	}{columns}); err != nil {
+		return err
+	}
+
+	// Object keys are written in column order rather than json.Marshal's sorted map order
+	var line bytes.Buffer
+	for _, row := range result.Rows {
+		line.Reset()
+		line.WriteByte('{')
+		for i, column := range columns {
+			if i > 0 {
+				line.WriteByte(',')
+			}
+			key, err := json.Marshal(column)
+			if err != nil {
+				return fmt.Errorf("failed to marshal column name: %w", err)
+			}
+			value, err := json.Marshal(row[column])
+			if err != nil {
+				return fmt.Errorf("failed to marshal value of %s: %w", column, err)
+			}
+			line.Write(key)
+			line.WriteByte(':')
+			line.Write(value)
+		}
+		line.WriteString("}\n")
+		if _, err := out.Write(line.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if err := writeNDJSONLine(out, struct {
+		RowCount int `json:"row_count"`
+	}{result.RowCount}); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// writeNDJSONLine writes value as a single line of JSON.
+func writeNDJSONLine(w io.Writer, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result to NDJSON: %w", err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
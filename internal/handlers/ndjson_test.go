@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQueryHandler_FormatResult_NDJSON(t *testing.T) {
+	result := QueryResult{
+		Type:    "select",
+		Columns: []string{"name", "id"},
+		Rows: []map[string]any{
+			{"id": int64(1), "name": "Alice"},
+			{"id": int64(2), "name": nil},
+		},
+		RowCount: 2,
+	}
+
+	formatted, err := (&QueryHandler{}).FormatResult(result, "ndjson")
+	if err != nil {
+		t.Fatalf("FormatResult() error = %v", err)
+	}
+
+	want := `{"columns":["name","id"]}
+{"name":"Alice","id":1}
+{"name":null,"id":2}
+{"row_count":2}
+`
+	if formatted != want {
+		t.Errorf("FormatResult() =\n%s\nwant\n%s", formatted, want)
+	}
+
+	for i, line := range strings.Split(strings.TrimSuffix(formatted, "\n"), "\n") {
+		if !json.Valid([]byte(line)) {
+			t.Errorf("Line %d is not valid JSON: %s", i+1, line)
+		}
+	}
+}
+
+func TestQueryHandler_FormatResultStream(t *testing.T) {
+	result := QueryResult{
+		Type:     "select",
+		Columns:  []string{"id"},
+		Rows:     []map[string]any{{"id": int64(7)}},
+		RowCount: 1,
+	}
+	handler := &QueryHandler{}
+
+	for _, format := range []string{"ndjson", "json", "yaml", "table"} {
+		t.Run(format, func(t *testing.T) {
+			var streamed strings.Builder
+			if err := handler.FormatResultStream(result, format, &streamed); err != nil {
+				t.Fatalf("FormatResultStream() error = %v", err)
+			}
+
+			formatted, err := handler.FormatResult(result, format)
+			if err != nil {
+				t.Fatalf("FormatResult() error = %v", err)
+			}
+			if streamed.String() != formatted {
+				t.Errorf("Streamed output differs from FormatResult():\n%s\nwant\n%s", streamed.String(), formatted)
+			}
+		})
+	}
+
+	t.Run("unsupported format", func(t *testing.T) {
+		var streamed strings.Builder
+		if err := handler.FormatResultStream(result, "csv", &streamed); err == nil || streamed.Len() != 0 {
+			t.Errorf("Expected an error and no output, got %v and %q", err, streamed.String())
+		}
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		if err := handler.FormatResultStream(result, "ndjson", failingWriter{}); err == nil {
+			t.Error("Expected the write error to be returned")
+		}
+	})
+}
+
+func TestQueryHandler_FormatResult_NDJSONNonSelect(t *testing.T) {
+	result := QueryResult{Type: "update", RowCount: 3, RowsAffected: 3}
+
+	formatted, err := (&QueryHandler{}).FormatResult(result, "ndjson")
+	if err != nil {
+		t.Fatalf("FormatResult() error = %v", err)
+	}
+	if want := "{\"columns\":[]}\n{\"row_count\":3}\n"; formatted != want {
+		t.Errorf("FormatResult() = %q, want %q", formatted, want)
+	}
+}
+
+// failingWriter is an io.Writer whose writes always fail.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
@@ -3,11 +3,13 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
+	"database/sql"
 	"fmt"
-	"regexp"
+	"io"
+	"log/slog"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/jhoffmann/go-database-mcp/internal/config"
 	"github.com/jhoffmann/go-database-mcp/internal/database"
@@ -18,6 +20,22 @@ import (
 type QueryHandler struct {
 	db        database.Database
 	validator *security.QueryValidator
+	maxRows   int                     // Maximum number of rows returned by a SELECT
+	maxBytes  int                     // Largest SELECT result in bytes of row data (zero means no limit)
+	maxInsert int                     // Most rows a single InsertRows call may insert
+	typed     bool                    // Return SELECT values as TypedValue instead of bare values
+	colTypes  bool                    // Include ColumnTypes metadata in SELECT results
+	colOrder  []string                // Columns to list first in SELECT results, in this order
+	zeroDates config.ZeroDateBehavior // How MySQL zero and invalid dates are returned
+	booleans  config.BooleanOutput    // How boolean column values are returned
+	trim      bool                    // Trim leading and trailing whitespace from string values
+	timeout   time.Duration           // Per-query execution timeout (zero means no timeout)
+	dbConfig  *config.DatabaseConfig  // Row filters and statement prefix applied to statements run in a transaction
+	audit     *AuditLogger            // Optional audit log receiving one entry per execution
+	client    string                  // MCP client identity recorded in audit entries
+	stats     *SessionStats           // Optional session counters receiving every execution
+	pageSize  int                     // Rows per page when a SELECT is paginated (zero disables paging)
+	page      int                     // 1-based page requested with pageSize
 }
 
 // QueryResult represents the result of a SQL query execution.
@@ -30,19 +48,145 @@ type QueryResult struct {
 	LastInsertID  *int64           `json:"last_insert_id,omitempty"` // Last insert ID for INSERT queries
 	ExecutionTime string           `json:"execution_time,omitempty"` // Query execution time
 	Message       string           `json:"message,omitempty"`        // Success/info message
+	Truncated     bool             `json:"truncated,omitempty"`      // True when a SELECT returned more rows than the configured cap
+	ColumnTypes   []ColumnTypeInfo `json:"column_types,omitempty"`   // Database column types for SELECT queries, when requested
+	Page          int              `json:"page,omitempty"`           // Page returned when the SELECT was paginated
+	NextPage      int              `json:"next_page,omitempty"`      // Page to request next, when a paginated SELECT has more rows
+}
+
+// ColumnTypeInfo describes the type of a result column as reported by the database driver,
+// so clients can tell an integer from a timestamp even when every returned value is NULL.
+type ColumnTypeInfo struct {
+	Name         string `json:"name"`               // Column name
+	DatabaseType string `json:"database_type"`      // Database type name (e.g. INT4, VARCHAR, TIMESTAMP)
+	Nullable     *bool  `json:"nullable,omitempty"` // Whether the column may contain NULL, if the driver knows
+}
+
+// TypedValue wraps a result value with explicit type information so clients
+// can distinguish numbers, strings and timestamps without guessing from JSON.
+type TypedValue struct {
+	Type         string `json:"type"`                    // Go type of the value (e.g. int64, string, time.Time), or "null"
+	DatabaseType string `json:"database_type,omitempty"` // Column type reported by the database driver
+	Value        any    `json:"value"`                   // The value itself
 }
 
 // NewQueryHandler creates a new QueryHandler instance.
-func NewQueryHandler(db database.Database, config *config.DatabaseConfig) *QueryHandler {
+func NewQueryHandler(db database.Database, cfg *config.DatabaseConfig) *QueryHandler {
+	maxRows := cfg.MaxResultRows
+	if maxRows <= 0 {
+		maxRows = config.DefaultMaxResultRows
+	}
+
+	maxInsert := cfg.MaxInsertRows
+	if maxInsert <= 0 {
+		maxInsert = config.DefaultMaxInsertRows
+	}
+
+	// The behavior is validated when configuration is loaded; anything else falls back to NULL
+	zeroDates, _ := config.ParseZeroDateBehavior(cfg.ZeroDateBehavior)
+	booleans, _ := config.ParseBooleanOutput(cfg.BooleanOutput)
+
 	return &QueryHandler{
 		db:        db,
-		validator: security.NewQueryValidator(config),
+		validator: security.NewQueryValidator(cfg),
+		maxRows:   maxRows,
+		maxBytes:  cfg.MaxResultBytes,
+		maxInsert: maxInsert,
+		zeroDates: zeroDates,
+		booleans:  booleans,
+		trim:      cfg.TrimStrings,
+		timeout:   cfg.QueryTimeout,
+		dbConfig:  cfg,
 	}
 }
 
+// SetTypedValues controls whether SELECT results wrap each value in a TypedValue.
+func (h *QueryHandler) SetTypedValues(typed bool) {
+	h.typed = typed
+}
+
+// SetColumnTypes controls whether SELECT results include ColumnTypes metadata.
+func (h *QueryHandler) SetColumnTypes(include bool) {
+	h.colTypes = include
+}
+
+// SetColumnOrder reorders the columns of SELECT results: the listed columns come first, in
+// the listed order, followed by the remaining columns in select-list order. Listing a column
+// the result does not have fails the query before any rows are read.
+func (h *QueryHandler) SetColumnOrder(order []string) {
+	h.colOrder = order
+}
+
+// SetPagination pages SELECT results: maxRows rows are returned per page and page selects
+// which one, starting at 1. Zero values disable paging. The values are validated when the
+// query runs.
+func (h *QueryHandler) SetPagination(maxRows, page int) {
+	h.pageSize = maxRows
+	h.page = page
+}
+
+// SetAuditLogger enables audit logging of every execution on behalf of the given client.
+func (h *QueryHandler) SetAuditLogger(audit *AuditLogger, client string) {
+	h.audit = audit
+	h.client = client
+}
+
+// SetSessionStats enables accumulating every execution in stats, which is typically shared
+// by all handlers of the server process.
+func (h *QueryHandler) SetSessionStats(stats *SessionStats) {
+	h.stats = stats
+}
+
 // ExecuteQuery executes a SQL query and returns formatted results.
 // It supports both SELECT queries (which return data) and non-SELECT queries (INSERT, UPDATE, DELETE, DDL).
+// When an audit logger or session stats are configured, every execution is recorded, including failed ones.
 func (h *QueryHandler) ExecuteQuery(ctx context.Context, query string, args ...any) (*QueryResult, error) {
+	start := time.Now()
+	result, err := h.executeQuery(ctx, query, args...)
+	h.recordExecution(query, len(args), result, err, time.Since(start))
+	return result, err
+}
+
+// recordExecution reports a finished execution to the audit log and session stats, when
+// configured.
+func (h *QueryHandler) recordExecution(query string, argsCount int, result *QueryResult, execErr error, duration time.Duration) {
+	if h.audit != nil {
+		h.recordAudit(query, argsCount, result, execErr, duration)
+	}
+	if h.stats != nil {
+		var rows int64
+		if result != nil {
+			rows = int64(result.RowCount)
+		}
+		h.stats.Record(h.determineQueryType(query), rows, execErr, duration)
+	}
+}
+
+// recordAudit writes an audit entry for a finished execution. Audit write failures
+// are logged but never fail the query itself.
+func (h *QueryHandler) recordAudit(query string, argsCount int, result *QueryResult, execErr error, duration time.Duration) {
+	entry := AuditEntry{
+		Timestamp:  time.Now().UTC(),
+		Client:     h.client,
+		Query:      h.validator.RedactSensitive(query),
+		ArgsCount:  argsCount,
+		Type:       h.determineQueryType(query),
+		DurationMS: duration.Milliseconds(),
+	}
+	if result != nil {
+		entry.RowsAffected = int64(result.RowCount)
+	}
+	if execErr != nil {
+		entry.Error = h.validator.RedactSensitive(execErr.Error())
+	}
+
+	if err := h.audit.Log(entry); err != nil {
+		slog.Error("Audit log write failed", "error", err)
+	}
+}
+
+// executeQuery validates and runs a query, dispatching on its type.
+func (h *QueryHandler) executeQuery(ctx context.Context, query string, args ...any) (*QueryResult, error) {
 	// Security validation
 	if err := h.validator.ValidateQuery(query); err != nil {
 		return nil, h.validator.SanitizeErrorMessage(err)
@@ -56,16 +200,65 @@ func (h *QueryHandler) ExecuteQuery(ctx context.Context, query string, args ...a
 
 	// Determine query type
 	queryType := h.determineQueryType(trimmedQuery)
+	if h.pageSize != 0 || h.page != 0 {
+		paged, err := h.paginate(trimmedQuery, queryType)
+		if err != nil {
+			return nil, err
+		}
+		query = paged
+	}
+	query = h.bindPlaceholders(query, args)
+
+	// Apply the configured statement timeout
+	queryCtx, cancel := withQueryTimeout(ctx, h.timeout)
+	defer cancel()
 
 	// Execute based on query type
+	var result *QueryResult
+	var err error
 	if queryType == "select" {
-		return h.executeSelectQuery(ctx, query, args...)
+		result, err = h.executeSelectQuery(queryCtx, query, args...)
+	} else {
+		result, err = h.executeNonSelectQuery(queryCtx, query, queryType, args...)
+	}
+
+	// Report our own deadline or the client's cancellation instead of the driver's generic error
+	if err != nil && queryCtx.Err() != nil {
+		return nil, describeContextError(ctx, queryCtx, h.timeout, err)
 	}
 
-	return h.executeNonSelectQuery(ctx, query, queryType, args...)
+	return result, err
+}
+
+// paginate validates the paging settings and appends a LIMIT and OFFSET clause selecting
+// the requested page to a SELECT. One row beyond the page is fetched to tell whether
+// another page follows.
+func (h *QueryHandler) paginate(query, queryType string) (string, error) {
+	switch {
+	case h.pageSize < 0:
+		return "", fmt.Errorf("max_rows must be positive")
+	case h.pageSize == 0:
+		return "", fmt.Errorf("page requires max_rows")
+	case h.pageSize > h.maxRows:
+		return "", fmt.Errorf("max_rows %d exceeds the %d row limit", h.pageSize, h.maxRows)
+	case h.page < 0:
+		return "", fmt.Errorf("page must be at least 1")
+	case queryType != "select":
+		return "", fmt.Errorf("max_rows and page only apply to SELECT queries")
+	case security.HasRowLimit(query):
+		return "", fmt.Errorf("query already has a LIMIT, OFFSET or FETCH clause; remove it to use max_rows and page")
+	}
+
+	if h.page == 0 {
+		h.page = 1
+	}
+	return security.AppendRowLimit(query, h.pageSize+1, (h.page-1)*h.pageSize), nil
 }
 
 // executeSelectQuery handles SELECT queries that return rows.
+// Scanning stops once the configured row cap, or the page size when paginating, is
+// reached; the result is marked as truncated, or given a next page, when the database
+// had more rows to return.
 func (h *QueryHandler) executeSelectQuery(ctx context.Context, query string, args ...any) (*QueryResult, error) {
 	rows, err := h.db.Query(ctx, query, args...)
 	if err != nil {
@@ -79,9 +272,35 @@ func (h *QueryHandler) executeSelectQuery(ctx context.Context, query string, arg
 		return nil, fmt.Errorf("failed to get column names: %w", err)
 	}
 
+	// Column types decide how []byte values are decoded
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column types: %w", err)
+	}
+
+	// Unknown columns in the requested order are reported before any rows are read
+	var positions []int
+	if len(h.colOrder) > 0 {
+		if positions, err = columnPermutation(columns, h.colOrder); err != nil {
+			return nil, err
+		}
+	}
+
 	// Process rows
+	limit := h.maxRows
+	if h.pageSize > 0 {
+		limit = h.pageSize
+	}
+
 	var resultRows []map[string]any
+	truncated := false
+	size := 0
 	for rows.Next() {
+		if len(resultRows) >= limit {
+			truncated = true
+			break
+		}
+
 		// Create slice of interface{} for Scan
 		values := make([]any, len(columns))
 		valuePtrs := make([]any, len(columns))
@@ -94,15 +313,17 @@ func (h *QueryHandler) executeSelectQuery(ctx context.Context, query string, arg
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
+		// Stop reading before an oversized result is held in memory
+		if h.maxBytes > 0 {
+			if size += database.RowSize(values); size > h.maxBytes {
+				return nil, database.ResultSizeError(h.maxBytes)
+			}
+		}
+
 		// Convert to map
 		rowMap := make(map[string]any)
 		for i, col := range columns {
-			// Handle byte slices (common for text fields in some drivers)
-			if b, ok := values[i].([]byte); ok {
-				rowMap[col] = string(b)
-			} else {
-				rowMap[col] = values[i]
-			}
+			rowMap[col] = h.convertValue(values[i], columnTypes[i])
 		}
 		resultRows = append(resultRows, rowMap)
 	}
@@ -111,13 +332,88 @@ func (h *QueryHandler) executeSelectQuery(ctx context.Context, query string, arg
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
-	return &QueryResult{
+	result := &QueryResult{
 		Type:     "select",
 		Columns:  columns,
 		Rows:     resultRows,
 		RowCount: len(resultRows),
 		Message:  fmt.Sprintf("Query executed successfully. %d rows returned.", len(resultRows)),
-	}, nil
+	}
+	switch {
+	case h.pageSize > 0:
+		result.Page = h.page
+		if truncated {
+			result.NextPage = h.page + 1
+			result.Message = fmt.Sprintf("Query executed successfully. %d rows returned (page %d; more rows on page %d).", len(resultRows), h.page, result.NextPage)
+		} else {
+			result.Message = fmt.Sprintf("Query executed successfully. %d rows returned (page %d, last page).", len(resultRows), h.page)
+		}
+	case truncated:
+		result.Truncated = true
+		result.Message = fmt.Sprintf("Query executed successfully. %d rows returned (truncated at the %d row limit).", len(resultRows), h.maxRows)
+	}
+	if h.colTypes {
+		result.ColumnTypes = newColumnTypeInfos(columnTypes)
+	}
+	if positions != nil {
+		// Rows are keyed by name, so only the column lists need reordering
+		result.Columns = permute(result.Columns, positions)
+		if result.ColumnTypes != nil {
+			result.ColumnTypes = permute(result.ColumnTypes, positions)
+		}
+	}
+
+	return result, nil
+}
+
+// convertValue turns a value scanned from a result column into its output form, applying
+// the configured string trimming, byte decoding, boolean output and typed values.
+func (h *QueryHandler) convertValue(value any, columnType *sql.ColumnType) any {
+	if h.trim {
+		value = database.TrimString(value, columnType.DatabaseTypeName())
+	}
+	// Drivers return text, numeric and binary columns as byte slices
+	if b, ok := value.([]byte); ok {
+		value = decodeBytes(b, columnType, h.zeroDates)
+	}
+	if h.booleans != config.BooleanNative && database.IsBooleanType(columnType.DatabaseTypeName()) {
+		value = database.NormalizeBoolean(value, h.booleans)
+	}
+
+	if h.typed {
+		return newTypedValue(value, columnType)
+	}
+	return value
+}
+
+// newColumnTypeInfos converts driver column types into ColumnTypeInfo metadata.
+func newColumnTypeInfos(columnTypes []*sql.ColumnType) []ColumnTypeInfo {
+	infos := make([]ColumnTypeInfo, len(columnTypes))
+	for i, columnType := range columnTypes {
+		infos[i] = ColumnTypeInfo{
+			Name:         columnType.Name(),
+			DatabaseType: columnType.DatabaseTypeName(),
+		}
+		if nullable, ok := columnType.Nullable(); ok {
+			infos[i].Nullable = &nullable
+		}
+	}
+	return infos
+}
+
+// newTypedValue tags a scanned value with its Go type and the driver-reported column type.
+func newTypedValue(value any, columnType *sql.ColumnType) TypedValue {
+	typed := TypedValue{
+		Type:  "null",
+		Value: value,
+	}
+	if value != nil {
+		typed.Type = fmt.Sprintf("%T", value)
+	}
+	if columnType != nil {
+		typed.DatabaseType = columnType.DatabaseTypeName()
+	}
+	return typed
 }
 
 // executeNonSelectQuery handles INSERT, UPDATE, DELETE, and DDL queries.
@@ -166,57 +462,123 @@ func (h *QueryHandler) executeNonSelectQuery(ctx context.Context, query string,
 	return queryResult, nil
 }
 
+// bindPlaceholders rewrites ? placeholders to $1..$n when parameters are bound on a
+// PostgreSQL connection, so the same parameterized query works against either backend.
+func (h *QueryHandler) bindPlaceholders(query string, args []any) string {
+	if len(args) == 0 || h.db.GetDriverName() != "postgres" {
+		return query
+	}
+	return security.RewritePlaceholders(query)
+}
+
 // determineQueryType determines the type of SQL query based on its content.
 func (h *QueryHandler) determineQueryType(query string) string {
-	// Normalize query for analysis
-	normalized := strings.ToUpper(strings.TrimSpace(query))
+	return security.DetermineQueryType(query, h.db.GetDriverName())
+}
+
+// ValidateFormat checks that format is supported by FormatResult.
+func (h *QueryHandler) ValidateFormat(format string) error {
+	switch format {
+	case "json", "yaml", "table", "ndjson":
+		return nil
+	default:
+		return fmt.Errorf("unsupported format: %s. Supported formats: json, yaml, table, ndjson", format)
+	}
+}
+
+// ExecuteAndFormat executes a SQL query and formats the result in the specified format.
+// The format is validated first, so an unsupported format fails without running the query.
+func (h *QueryHandler) ExecuteAndFormat(ctx context.Context, query string, format string, args ...any) (*QueryResult, string, error) {
+	if err := h.ValidateFormat(format); err != nil {
+		return nil, "", err
+	}
 
-	// Remove leading comments and whitespace
-	normalized = regexp.MustCompile(`^\s*(--[^\n]*\n\s*)*`).ReplaceAllString(normalized, "")
-	normalized = regexp.MustCompile(`^\s*(/\*.*?\*/\s*)*`).ReplaceAllString(normalized, "")
+	result, err := h.ExecuteQuery(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
 
-	// Determine query type by first keyword
-	if strings.HasPrefix(normalized, "SELECT") || strings.HasPrefix(normalized, "WITH") {
-		return "select"
+	formatted, err := h.FormatResult(*result, format)
+	if err != nil {
+		return nil, "", err
 	}
-	if strings.HasPrefix(normalized, "INSERT") {
-		return "insert"
+
+	return result, formatted, nil
+}
+
+// FormattedResult is a query result rendered in one output format.
+type FormattedResult struct {
+	Format string // Format name (json, yaml, table or ndjson)
+	Text   string // Result rendered in Format
+}
+
+// ExecuteAndFormatAll executes a SQL query once and renders the result in each of the
+// specified formats, in order. Every format is validated before the query runs.
+func (h *QueryHandler) ExecuteAndFormatAll(ctx context.Context, query string, formats []string, args ...any) (*QueryResult, []FormattedResult, error) {
+	if len(formats) == 0 {
+		return nil, nil, fmt.Errorf("at least one format is required")
 	}
-	if strings.HasPrefix(normalized, "UPDATE") {
-		return "update"
+	for _, format := range formats {
+		if err := h.ValidateFormat(format); err != nil {
+			return nil, nil, err
+		}
 	}
-	if strings.HasPrefix(normalized, "DELETE") {
-		return "delete"
+
+	result, err := h.ExecuteQuery(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// DDL statements
-	ddlKeywords := []string{"CREATE", "ALTER", "DROP", "TRUNCATE", "RENAME"}
-	for _, keyword := range ddlKeywords {
-		if strings.HasPrefix(normalized, keyword) {
-			return "ddl"
+	formatted := make([]FormattedResult, 0, len(formats))
+	for _, format := range formats {
+		text, err := h.FormatResult(*result, format)
+		if err != nil {
+			return nil, nil, err
 		}
+		formatted = append(formatted, FormattedResult{Format: format, Text: text})
 	}
 
-	// Default to ddl for any other statements
-	return "ddl"
+	return result, formatted, nil
 }
 
-// FormatResult formats the query result in the specified format.
+// FormatResult formats the query result in the specified format: json, yaml, table or
+// ndjson (a {"columns":[...]} header line, one line per row and a {"row_count":N} line).
 func (h *QueryHandler) FormatResult(result QueryResult, format string) (string, error) {
-	switch format {
-	case "json":
-		jsonData, err := json.MarshalIndent(result, "", "  ")
-		if err != nil {
-			return "", fmt.Errorf("failed to marshal result to JSON: %w", err)
-		}
-		return string(jsonData), nil
+	if err := h.ValidateFormat(format); err != nil {
+		return "", err
+	}
 
+	switch format {
 	case "table":
 		return h.formatAsTable(result)
+	case "ndjson":
+		var output strings.Builder
+		if err := writeNDJSON(result, &output); err != nil {
+			return "", err
+		}
+		return output.String(), nil
+	}
+	return formatDocument(result, format)
+}
 
-	default:
-		return "", fmt.Errorf("unsupported format: %s. Supported formats: json, table", format)
+// FormatResultStream writes a query result to w in the specified format. The ndjson
+// format is written one row at a time, so large results need not be rendered into a
+// single string first; other formats are rendered with FormatResult and then written.
+func (h *QueryHandler) FormatResultStream(result QueryResult, format string, w io.Writer) error {
+	if err := h.ValidateFormat(format); err != nil {
+		return err
+	}
+
+	if format == "ndjson" {
+		return writeNDJSON(result, w)
 	}
+
+	formatted, err := h.FormatResult(result, format)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, formatted)
+	return err
 }
 
 // formatAsTable formats SELECT results as an ASCII table.
@@ -245,7 +607,11 @@ func (h *QueryHandler) formatAsTable(result QueryResult) (string, error) {
 	for _, row := range result.Rows {
 		values := make([]string, len(result.Columns))
 		for i, col := range result.Columns {
-			if val := row[col]; val != nil {
+			val := row[col]
+			if typed, ok := val.(TypedValue); ok {
+				val = typed.Value
+			}
+			if val != nil {
 				values[i] = fmt.Sprintf("%v", val)
 			} else {
 				values[i] = "<NULL>"
@@ -262,6 +628,15 @@ func (h *QueryHandler) formatAsTable(result QueryResult) (string, error) {
 	return output.String(), nil
 }
 
+// AnalyzeQuery reports the operation type and the tables query reads from and writes to,
+// using static analysis only. The query is never executed, so no connection is required.
+func AnalyzeQuery(query string) (*security.QueryAnalysis, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+	return security.AnalyzeQuery(query), nil
+}
+
 // ValidateQuery performs basic validation on SQL queries to prevent dangerous operations.
 func (h *QueryHandler) ValidateQuery(query string) error {
 	normalized := strings.ToUpper(strings.TrimSpace(query))
@@ -3,8 +3,10 @@ package handlers
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,26 +19,159 @@ type MockDatabase struct {
 	execFunc          func(ctx context.Context, query string, args ...any) (sql.Result, error)
 	queryRowFunc      func(ctx context.Context, query string, args ...any) *sql.Row
 	driver            string
+	isolationLevel    string
+	serverSettings    []database.ServerSetting
+	serverVersion     string
+	serverReadOnly    bool
 	shouldReturnError bool
 	errorMessage      string
+	sqlDB             *sql.DB
+	tableBloat        []database.TableBloatStats
+	indexUsage        []database.IndexUsageInfo
+	serverInfo        *database.ServerInfo
+	activeConns       []database.ActiveConnection
+	killedPID         int64 // PID passed to the last KillConnection call
+	tableSchema       *database.TableSchema
 }
 
 func (m *MockDatabase) Connect(ctx context.Context) error                   { return nil }
 func (m *MockDatabase) Close() error                                        { return nil }
 func (m *MockDatabase) Ping(ctx context.Context) error                      { return nil }
-func (m *MockDatabase) GetDB() *sql.DB                                      { return nil }
+func (m *MockDatabase) GetDB() *sql.DB                                      { return m.sqlDB }
 func (m *MockDatabase) GetDriverName() string                               { return m.driver }
 func (m *MockDatabase) ListTables(ctx context.Context) ([]string, error)    { return nil, nil }
 func (m *MockDatabase) ListDatabases(ctx context.Context) ([]string, error) { return nil, nil }
+func (m *MockDatabase) ListViews(ctx context.Context) ([]string, error)     { return nil, nil }
+func (m *MockDatabase) DescribeView(ctx context.Context, viewName string) (*database.ViewSchema, error) {
+	return nil, nil
+}
 func (m *MockDatabase) DescribeTable(ctx context.Context, tableName string) (*database.TableSchema, error) {
+	return m.tableSchema, nil
+}
+func (m *MockDatabase) QuoteTable(ctx context.Context, tableName string) string {
+	return database.QuoteIdentifier(m.driver, tableName)
+}
+func (m *MockDatabase) EstimateColumnStats(ctx context.Context, tableName string) (map[string]database.ColumnStatsEstimate, error) {
+	return nil, nil
+}
+func (m *MockDatabase) ListForeignKeys(ctx context.Context) ([]database.ForeignKeyRelationship, error) {
 	return nil, nil
 }
-func (m *MockDatabase) GetTableData(ctx context.Context, tableName string, limit int, offset int) (*database.TableData, error) {
+func (m *MockDatabase) GetConstraints(ctx context.Context, tableName string) ([]database.ConstraintInfo, error) {
+	return nil, nil
+}
+func (m *MockDatabase) GetTableData(ctx context.Context, tableName string, limit int, offset int, opts database.TableDataOptions) (*database.TableData, error) {
 	return nil, nil
 }
 func (m *MockDatabase) ExplainQuery(ctx context.Context, query string) (string, error) {
 	return "", nil
 }
+func (m *MockDatabase) ExplainAnalyzeQuery(ctx context.Context, query string) (string, error) {
+	return "", nil
+}
+func (m *MockDatabase) GetColumnStatistics(ctx context.Context, tableName string) ([]database.ColumnStatistics, error) {
+	return nil, nil
+}
+func (m *MockDatabase) GetCreateStatement(ctx context.Context, tableName string) (string, error) {
+	return "", nil
+}
+func (m *MockDatabase) SearchTables(ctx context.Context, pattern string) ([]string, error) {
+	return nil, nil
+}
+func (m *MockDatabase) GetTableSize(ctx context.Context, tableName string) (*database.TableSizeInfo, error) {
+	return nil, nil
+}
+func (m *MockDatabase) ListTableSizes(ctx context.Context) ([]database.TableSizeInfo, error) {
+	return nil, nil
+}
+func (m *MockDatabase) GetTableBloat(ctx context.Context, tableName string) ([]database.TableBloatStats, error) {
+	if m.shouldReturnError {
+		return nil, errors.New(m.errorMessage)
+	}
+	return m.tableBloat, nil
+}
+func (m *MockDatabase) GetIndexUsage(ctx context.Context) ([]database.IndexUsageInfo, error) {
+	if m.shouldReturnError {
+		return nil, errors.New(m.errorMessage)
+	}
+	return m.indexUsage, nil
+}
+func (m *MockDatabase) ProfileTable(ctx context.Context, tableName string, maxDistinctValues int) (*database.TableProfile, error) {
+	return nil, nil
+}
+func (m *MockDatabase) ListenForNotifications(ctx context.Context, channel string, handler func(payload string)) error {
+	if m.shouldReturnError {
+		return errors.New(m.errorMessage)
+	}
+	return nil
+}
+func (m *MockDatabase) Unlisten(ctx context.Context, channel string) error {
+	if m.shouldReturnError {
+		return errors.New(m.errorMessage)
+	}
+	return nil
+}
+func (m *MockDatabase) GetColumnValues(ctx context.Context, tableName, columnName string, limit int) (*database.ColumnValues, error) {
+	return nil, nil
+}
+func (m *MockDatabase) ListFunctions(ctx context.Context) ([]database.FunctionInfo, error) {
+	return nil, nil
+}
+func (m *MockDatabase) DescribeFunction(ctx context.Context, name string) (*database.FunctionSchema, error) {
+	return nil, nil
+}
+func (m *MockDatabase) SearchColumns(ctx context.Context, pattern string) ([]database.ColumnSearchResult, error) {
+	return nil, nil
+}
+func (m *MockDatabase) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	if m.sqlDB != nil {
+		return m.sqlDB.BeginTx(ctx, opts)
+	}
+	return nil, errors.New("mock not configured")
+}
+func (m *MockDatabase) GetIsolationLevel(ctx context.Context) (string, error) {
+	if m.shouldReturnError {
+		return "", errors.New(m.errorMessage)
+	}
+	return m.isolationLevel, nil
+}
+func (m *MockDatabase) GetServerSettings(ctx context.Context, prefix string) ([]database.ServerSetting, error) {
+	if m.shouldReturnError {
+		return nil, errors.New(m.errorMessage)
+	}
+	return m.serverSettings, nil
+}
+func (m *MockDatabase) GetServerVersion(ctx context.Context) (string, error) {
+	if m.shouldReturnError {
+		return "", errors.New(m.errorMessage)
+	}
+	return m.serverVersion, nil
+}
+func (m *MockDatabase) IsServerReadOnly(ctx context.Context) (bool, error) {
+	if m.shouldReturnError {
+		return false, errors.New(m.errorMessage)
+	}
+	return m.serverReadOnly, nil
+}
+func (m *MockDatabase) GetServerInfo(ctx context.Context) (*database.ServerInfo, error) {
+	if m.shouldReturnError {
+		return nil, errors.New(m.errorMessage)
+	}
+	return m.serverInfo, nil
+}
+func (m *MockDatabase) GetActiveConnections(ctx context.Context) ([]database.ActiveConnection, error) {
+	if m.shouldReturnError {
+		return nil, errors.New(m.errorMessage)
+	}
+	return m.activeConns, nil
+}
+func (m *MockDatabase) KillConnection(ctx context.Context, pid int64) error {
+	if m.shouldReturnError {
+		return errors.New(m.errorMessage)
+	}
+	m.killedPID = pid
+	return nil
+}
 
 func (m *MockDatabase) Query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
 	if m.shouldReturnError {
@@ -108,11 +243,12 @@ func TestQueryHandler_DetermineQueryType(t *testing.T) {
 		{"DROP TABLE test", "ddl"},
 		{"ALTER TABLE users ADD COLUMN age INT", "ddl"},
 		{"WITH cte AS (SELECT 1) SELECT * FROM cte", "select"},
+		{"WITH cte AS (SELECT id FROM users) DELETE FROM users WHERE id IN (SELECT id FROM cte)", "delete"},
 		{"/* comment */ SELECT 1", "select"},
 		{"-- comment\nSELECT 1", "select"},
 	}
 
-	handler := &QueryHandler{}
+	handler := &QueryHandler{db: &MockDatabase{driver: "postgres"}}
 	for _, tt := range tests {
 		t.Run(tt.query, func(t *testing.T) {
 			result := handler.determineQueryType(tt.query)
@@ -209,6 +345,52 @@ func TestQueryHandler_ExecuteQuery_NonSelect(t *testing.T) {
 	}
 }
 
+func TestQueryHandler_ExecuteQuery_SelectRowCap(t *testing.T) {
+	tests := []struct {
+		name          string
+		rowCount      int
+		maxRows       int
+		wantRows      int
+		wantTruncated bool
+	}{
+		{name: "below cap", rowCount: 2, maxRows: 5, wantRows: 2},
+		{name: "exactly at cap", rowCount: 3, maxRows: 3, wantRows: 3},
+		{name: "above cap", rowCount: 10, maxRows: 4, wantRows: 4, wantTruncated: true},
+		{name: "zero uses default", rowCount: 3, maxRows: 0, wantRows: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &mockResultSet{columns: []string{"id", "name"}}
+			for i := 0; i < tt.rowCount; i++ {
+				set.rows = append(set.rows, []driver.Value{int64(i), []byte("row")})
+			}
+
+			cfg := createTestConfig()
+			cfg.MaxResultRows = tt.maxRows
+			handler := NewQueryHandler(newSelectMock(t, "postgres", set), cfg)
+
+			result, err := handler.ExecuteQuery(context.Background(), "SELECT id, name FROM users")
+			if err != nil {
+				t.Fatalf("ExecuteQuery() error = %v", err)
+			}
+
+			if result.RowCount != tt.wantRows || len(result.Rows) != tt.wantRows {
+				t.Errorf("Expected %d rows, got RowCount=%d len(Rows)=%d", tt.wantRows, result.RowCount, len(result.Rows))
+			}
+			if result.Truncated != tt.wantTruncated {
+				t.Errorf("Expected Truncated=%v, got %v", tt.wantTruncated, result.Truncated)
+			}
+			if result.Rows[0]["name"] != "row" {
+				t.Errorf("Expected byte values to be converted to strings, got %#v", result.Rows[0]["name"])
+			}
+			if set.Closed() != 1 {
+				t.Errorf("Expected rows to be closed once, got %d", set.Closed())
+			}
+		})
+	}
+}
+
 func TestQueryHandler_ExecuteQuery_Errors(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -271,6 +453,195 @@ func TestQueryHandler_ExecuteQuery_Errors(t *testing.T) {
 	}
 }
 
+func TestQueryHandler_ExecuteQuery_TypedValues(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	set := &mockResultSet{
+		columns: []string{"id", "name", "created_at", "deleted_at"},
+		types:   []string{"INT8", "TEXT", "TIMESTAMP", "TIMESTAMP"},
+		rows:    [][]driver.Value{{int64(42), []byte("alice"), created, nil}},
+	}
+
+	handler := NewQueryHandler(newSelectMock(t, "postgres", set), createTestConfig())
+	handler.SetTypedValues(true)
+
+	result, err := handler.ExecuteQuery(context.Background(), "SELECT id, name, created_at, deleted_at FROM users")
+	if err != nil {
+		t.Fatalf("ExecuteQuery() error = %v", err)
+	}
+
+	expected := map[string]TypedValue{
+		"id":         {Type: "int64", DatabaseType: "INT8", Value: int64(42)},
+		"name":       {Type: "string", DatabaseType: "TEXT", Value: "alice"},
+		"created_at": {Type: "time.Time", DatabaseType: "TIMESTAMP", Value: created},
+		"deleted_at": {Type: "null", DatabaseType: "TIMESTAMP", Value: nil},
+	}
+	for col, want := range expected {
+		got, ok := result.Rows[0][col].(TypedValue)
+		if !ok {
+			t.Fatalf("Expected TypedValue for column %s, got %T", col, result.Rows[0][col])
+		}
+		if got != want {
+			t.Errorf("Column %s: expected %+v, got %+v", col, want, got)
+		}
+	}
+
+	formatted, err := handler.FormatResult(*result, "json")
+	if err != nil {
+		t.Fatalf("FormatResult() error = %v", err)
+	}
+	if !containsString(formatted, `"type": "int64"`) || !containsString(formatted, `"value": 42`) {
+		t.Errorf("Expected JSON to contain tagged values, got %s", formatted)
+	}
+
+	table, err := handler.FormatResult(*result, "table")
+	if err != nil {
+		t.Fatalf("FormatResult() error = %v", err)
+	}
+	if !containsString(table, "alice") || containsString(table, "TEXT") {
+		t.Errorf("Expected table format to show bare values, got %s", table)
+	}
+}
+
+func TestQueryHandler_ExecuteQuery_ColumnTypes(t *testing.T) {
+	set := &mockResultSet{
+		columns:  []string{"id", "deleted_at", "note"},
+		types:    []string{"INT4", "TIMESTAMP"},
+		nullable: []bool{false, true},
+		rows:     [][]driver.Value{{int64(1), nil, nil}, {int64(2), nil, nil}},
+	}
+
+	handler := NewQueryHandler(newSelectMock(t, "postgres", set), createTestConfig())
+
+	result, err := handler.ExecuteQuery(context.Background(), "SELECT id, deleted_at, note FROM users")
+	if err != nil {
+		t.Fatalf("ExecuteQuery() error = %v", err)
+	}
+	if result.ColumnTypes != nil {
+		t.Errorf("Expected no column types by default, got %+v", result.ColumnTypes)
+	}
+
+	handler.SetColumnTypes(true)
+	result, err = handler.ExecuteQuery(context.Background(), "SELECT id, deleted_at, note FROM users")
+	if err != nil {
+		t.Fatalf("ExecuteQuery() error = %v", err)
+	}
+
+	notNull, nullable := false, true
+	expected := []ColumnTypeInfo{
+		{Name: "id", DatabaseType: "INT4", Nullable: &notNull},
+		{Name: "deleted_at", DatabaseType: "TIMESTAMP", Nullable: &nullable},
+		{Name: "note", DatabaseType: "", Nullable: nil}, // Driver does not report type or nullability
+	}
+	if len(result.ColumnTypes) != len(expected) {
+		t.Fatalf("Expected %d column types, got %+v", len(expected), result.ColumnTypes)
+	}
+	for i, want := range expected {
+		got := result.ColumnTypes[i]
+		if got.Name != want.Name || got.DatabaseType != want.DatabaseType {
+			t.Errorf("ColumnTypes[%d] = %+v, want %+v", i, got, want)
+		}
+		if (got.Nullable == nil) != (want.Nullable == nil) || (got.Nullable != nil && *got.Nullable != *want.Nullable) {
+			t.Errorf("ColumnTypes[%d].Nullable = %v, want %v", i, got.Nullable, want.Nullable)
+		}
+	}
+
+	// Values stay untyped; the metadata describes the all-NULL column on its own
+	if _, ok := result.Rows[0]["deleted_at"].(TypedValue); ok {
+		t.Error("Expected bare values when only column types are requested")
+	}
+
+	formatted, err := handler.FormatResult(*result, "json")
+	if err != nil {
+		t.Fatalf("FormatResult() error = %v", err)
+	}
+	if !containsString(formatted, `"column_types"`) || !containsString(formatted, `"database_type": "TIMESTAMP"`) {
+		t.Errorf("Expected JSON to include column types, got %s", formatted)
+	}
+}
+
+func TestQueryHandler_ExecuteQuery_UntypedByDefault(t *testing.T) {
+	set := &mockResultSet{
+		columns: []string{"id"},
+		types:   []string{"INT8"},
+		rows:    [][]driver.Value{{int64(7)}},
+	}
+
+	handler := NewQueryHandler(newSelectMock(t, "postgres", set), createTestConfig())
+	result, err := handler.ExecuteQuery(context.Background(), "SELECT id FROM users")
+	if err != nil {
+		t.Fatalf("ExecuteQuery() error = %v", err)
+	}
+
+	if result.Rows[0]["id"] != int64(7) {
+		t.Errorf("Expected bare int64 value, got %#v", result.Rows[0]["id"])
+	}
+}
+
+func TestQueryHandler_ExecuteQuery_ReadOnly(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{name: "insert blocked", query: "INSERT INTO users (name) VALUES ('a')", wantErr: true},
+		{name: "update blocked", query: "UPDATE users SET name = 'b'", wantErr: true},
+		{name: "delete blocked", query: "DELETE FROM users WHERE id = 1", wantErr: true},
+		{name: "ddl blocked", query: "DROP TABLE users", wantErr: true},
+		{name: "select allowed", query: "SELECT id FROM users", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &mockResultSet{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}}
+			mockDB := newSelectMock(t, "postgres", set)
+			execCalled := false
+			mockDB.execFunc = func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+				execCalled = true
+				return &MockResult{rowsAffected: 1}, nil
+			}
+
+			cfg := createTestConfig()
+			cfg.ReadOnly = true
+			handler := NewQueryHandler(mockDB, cfg)
+
+			_, err := handler.ExecuteQuery(context.Background(), tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExecuteQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				if err.Error() != "read-only mode: DML and DDL statements are not permitted" {
+					t.Errorf("Unexpected error message: %v", err)
+				}
+				if execCalled || len(set.Queries()) > 0 {
+					t.Error("Blocked query should not reach the database")
+				}
+			}
+		})
+	}
+}
+
+func TestQueryHandler_ExecuteQuery_AllowedStatements(t *testing.T) {
+	set := &mockResultSet{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}}
+	mockDB := newSelectMock(t, "postgres", set)
+	mockDB.execFunc = func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+		t.Fatalf("Expected no statement to run, got %q", query)
+		return nil, nil
+	}
+
+	cfg := createTestConfig()
+	cfg.AllowedStatements = []string{"select", "insert"}
+	handler := NewQueryHandler(mockDB, cfg)
+
+	if _, err := handler.ExecuteQuery(context.Background(), "SELECT id FROM users"); err != nil {
+		t.Errorf("Expected SELECT to be permitted, got %v", err)
+	}
+	_, err := handler.ExecuteQuery(context.Background(), "DELETE FROM users WHERE id = 1")
+	if err == nil || err.Error() != "statement type 'delete' is not permitted" {
+		t.Errorf("Expected DELETE to be rejected, got %v", err)
+	}
+}
+
 func TestQueryHandler_FormatResult_JSON(t *testing.T) {
 	result := &QueryResult{
 		Type:     "select",
@@ -363,6 +734,59 @@ func TestQueryHandler_FormatResult_InvalidFormat(t *testing.T) {
 	}
 }
 
+func TestQueryHandler_ExecuteAndFormat_InvalidFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "select", query: "SELECT * FROM users"},
+		{name: "non-select", query: "DELETE FROM users"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			mockDB := &MockDatabase{
+				driver: "postgres",
+				queryFunc: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+					called = true
+					return nil, errors.New("should not be called")
+				},
+				execFunc: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					called = true
+					return &MockResult{rowsAffected: 1}, nil
+				},
+			}
+
+			handler := NewQueryHandler(mockDB, createTestConfig())
+			_, _, err := handler.ExecuteAndFormat(context.Background(), tt.query, "xml")
+
+			if err == nil || !containsString(err.Error(), "unsupported format") {
+				t.Errorf("Expected unsupported format error, got %v", err)
+			}
+			if called {
+				t.Error("Query should not be executed when the format is invalid")
+			}
+		})
+	}
+}
+
+func TestQueryHandler_ExecuteAndFormat(t *testing.T) {
+	set := &mockResultSet{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}}
+	handler := NewQueryHandler(newSelectMock(t, "postgres", set), createTestConfig())
+
+	result, formatted, err := handler.ExecuteAndFormat(context.Background(), "SELECT id FROM users", "table")
+	if err != nil {
+		t.Fatalf("ExecuteAndFormat() error = %v", err)
+	}
+	if result.RowCount != 1 {
+		t.Errorf("Expected 1 row, got %d", result.RowCount)
+	}
+	if !containsString(formatted, "1 rows returned") {
+		t.Errorf("Expected table output, got %q", formatted)
+	}
+}
+
 func TestQueryHandler_Context_Timeout(t *testing.T) {
 	// Test that query execution respects context timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Nanosecond)
@@ -389,6 +813,47 @@ func TestQueryHandler_Context_Timeout(t *testing.T) {
 	}
 }
 
+func TestQueryHandler_ExecuteQuery_ConfiguredTimeout(t *testing.T) {
+	blockUntilDone := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "select", query: "SELECT pg_sleep(10)"},
+		{name: "non-select", query: "UPDATE users SET name = 'x'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockDatabase{
+				driver: "postgres",
+				queryFunc: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+					return nil, blockUntilDone(ctx)
+				},
+				execFunc: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					return nil, blockUntilDone(ctx)
+				},
+			}
+
+			cfg := createTestConfig()
+			cfg.QueryTimeout = 10 * time.Millisecond
+			handler := NewQueryHandler(mockDB, cfg)
+
+			_, err := handler.ExecuteQuery(context.Background(), tt.query)
+			if err == nil {
+				t.Fatal("Expected timeout error")
+			}
+			if err.Error() != "query exceeded timeout of 10ms" {
+				t.Errorf("Expected clear timeout message, got %q", err.Error())
+			}
+		})
+	}
+}
+
 func TestQueryHandler_ValidateQuery(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -442,3 +907,243 @@ func containsString(s, substr string) bool {
 			return false
 		}()))
 }
+
+func TestQueryHandler_ExecuteAndFormatAll(t *testing.T) {
+	set := &mockResultSet{
+		columns: []string{"id", "name"},
+		rows:    [][]driver.Value{{int64(1), "alice"}, {int64(2), "bob"}},
+	}
+	handler := NewQueryHandler(newSelectMock(t, "postgres", set), createTestConfig())
+
+	result, sections, err := handler.ExecuteAndFormatAll(context.Background(), "SELECT id, name FROM users", []string{"table", "json"})
+	if err != nil {
+		t.Fatalf("ExecuteAndFormatAll() error = %v", err)
+	}
+
+	if len(set.Queries()) != 1 {
+		t.Errorf("Expected the query to run once, ran %d times", len(set.Queries()))
+	}
+	if len(sections) != 2 || sections[0].Format != "table" || sections[1].Format != "json" {
+		t.Fatalf("Expected table and json sections in request order, got %+v", sections)
+	}
+
+	wantTable, err := handler.FormatResult(*result, "table")
+	if err != nil {
+		t.Fatalf("FormatResult(table) error = %v", err)
+	}
+	if sections[0].Text != wantTable {
+		t.Errorf("Table section = %q, want %q", sections[0].Text, wantTable)
+	}
+
+	var decoded QueryResult
+	if err := json.Unmarshal([]byte(sections[1].Text), &decoded); err != nil {
+		t.Fatalf("JSON section is not valid JSON: %v", err)
+	}
+	if decoded.RowCount != 2 || decoded.Rows[1]["name"] != "bob" {
+		t.Errorf("JSON section does not describe the same result: %+v", decoded)
+	}
+	if !containsString(sections[0].Text, "alice") || !containsString(sections[0].Text, "bob") {
+		t.Errorf("Table section missing rows: %s", sections[0].Text)
+	}
+}
+
+func TestQueryHandler_ExecuteAndFormatAll_InvalidFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		formats []string
+		wantErr string
+	}{
+		{name: "no formats", formats: nil, wantErr: "at least one format"},
+		{name: "one unsupported format", formats: []string{"json", "xml"}, wantErr: "unsupported format: xml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &mockResultSet{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}}
+			handler := NewQueryHandler(newSelectMock(t, "postgres", set), createTestConfig())
+
+			_, _, err := handler.ExecuteAndFormatAll(context.Background(), "SELECT id FROM users", tt.formats)
+			if err == nil || !containsString(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if len(set.Queries()) != 0 {
+				t.Error("Query should not be executed when a format is invalid")
+			}
+		})
+	}
+}
+
+func TestAnalyzeQuery(t *testing.T) {
+	result, err := AnalyzeQuery("INSERT INTO audit_copy SELECT * FROM audit")
+	if err != nil {
+		t.Fatalf("AnalyzeQuery() error = %v", err)
+	}
+	if result.Operation != "insert" {
+		t.Errorf("Expected operation insert, got %q", result.Operation)
+	}
+	if len(result.Reads) != 1 || result.Reads[0] != "audit" {
+		t.Errorf("Expected reads [audit], got %v", result.Reads)
+	}
+	if len(result.Writes) != 1 || result.Writes[0] != "audit_copy" {
+		t.Errorf("Expected writes [audit_copy], got %v", result.Writes)
+	}
+
+	if _, err := AnalyzeQuery("   "); err == nil {
+		t.Error("Expected error for empty query")
+	}
+}
+
+func TestQueryHandler_ExecuteQuery_Placeholders(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver string
+		query  string
+		args   []any
+		want   string
+	}{
+		{
+			name:   "postgres rewrites question marks",
+			driver: "postgres",
+			query:  "SELECT id FROM users WHERE name = 'who?' AND id = ? AND active = ?",
+			args:   []any{1, true},
+			want:   "SELECT id FROM users WHERE name = 'who?' AND id = $1 AND active = $2",
+		},
+		{
+			name:   "postgres keeps positional parameters",
+			driver: "postgres",
+			query:  "SELECT id FROM users WHERE id = $1",
+			args:   []any{1},
+			want:   "SELECT id FROM users WHERE id = $1",
+		},
+		{
+			name:   "postgres without args keeps the query",
+			driver: "postgres",
+			query:  "SELECT id FROM docs WHERE data ? 'key'",
+			want:   "SELECT id FROM docs WHERE data ? 'key'",
+		},
+		{
+			name:   "mysql keeps question marks",
+			driver: "mysql",
+			query:  "SELECT id FROM users WHERE id = ?",
+			args:   []any{1},
+			want:   "SELECT id FROM users WHERE id = ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &mockResultSet{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}}
+			handler := NewQueryHandler(newSelectMock(t, tt.driver, set), createTestConfig())
+
+			if _, err := handler.ExecuteQuery(context.Background(), tt.query, tt.args...); err != nil {
+				t.Fatalf("ExecuteQuery() error = %v", err)
+			}
+
+			queries := set.Queries()
+			if len(queries) != 1 || queries[0] != tt.want {
+				t.Errorf("Expected query %q, got %v", tt.want, queries)
+			}
+		})
+	}
+}
+
+func TestQueryHandler_ExecuteQuery_Pagination(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowCount     int
+		maxRows      int
+		page         int
+		wantQuery    string
+		wantRows     int
+		wantPage     int
+		wantNextPage int
+	}{
+		{
+			name:         "first page with more rows",
+			rowCount:     3,
+			maxRows:      2,
+			wantQuery:    "SELECT id FROM users ORDER BY id\nLIMIT 3 OFFSET 0",
+			wantRows:     2,
+			wantPage:     1,
+			wantNextPage: 2,
+		},
+		{
+			name:      "last page",
+			rowCount:  1,
+			maxRows:   2,
+			page:      3,
+			wantQuery: "SELECT id FROM users ORDER BY id\nLIMIT 3 OFFSET 4",
+			wantRows:  1,
+			wantPage:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &mockResultSet{columns: []string{"id"}}
+			for i := 0; i < tt.rowCount; i++ {
+				set.rows = append(set.rows, []driver.Value{int64(i)})
+			}
+			handler := NewQueryHandler(newSelectMock(t, "postgres", set), createTestConfig())
+			handler.SetPagination(tt.maxRows, tt.page)
+
+			result, err := handler.ExecuteQuery(context.Background(), "SELECT id FROM users ORDER BY id;")
+			if err != nil {
+				t.Fatalf("ExecuteQuery() error = %v", err)
+			}
+
+			if queries := set.Queries(); len(queries) != 1 || queries[0] != tt.wantQuery {
+				t.Errorf("Expected query %q, got %q", tt.wantQuery, queries)
+			}
+			if result.RowCount != tt.wantRows {
+				t.Errorf("Expected %d rows, got %d", tt.wantRows, result.RowCount)
+			}
+			if result.Page != tt.wantPage || result.NextPage != tt.wantNextPage {
+				t.Errorf("Expected page %d and next page %d, got %d and %d", tt.wantPage, tt.wantNextPage, result.Page, result.NextPage)
+			}
+			if result.Truncated {
+				t.Error("Expected a paginated result not to be marked truncated")
+			}
+		})
+	}
+}
+
+func TestQueryHandler_ExecuteQuery_PaginationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		maxRows int
+		page    int
+		wantErr string
+	}{
+		{name: "page without max_rows", query: "SELECT id FROM users", page: 2, wantErr: "page requires max_rows"},
+		{name: "negative max_rows", query: "SELECT id FROM users", maxRows: -1, wantErr: "max_rows must be positive"},
+		{name: "negative page", query: "SELECT id FROM users", maxRows: 10, page: -1, wantErr: "page must be at least 1"},
+		{name: "max_rows above row limit", query: "SELECT id FROM users", maxRows: 20000, wantErr: "exceeds the 10000 row limit"},
+		{name: "existing limit", query: "SELECT id FROM users LIMIT 5", maxRows: 10, wantErr: "already has a LIMIT"},
+		{name: "non-select query", query: "DELETE FROM users", maxRows: 10, wantErr: "only apply to SELECT queries"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockDatabase{
+				driver: "postgres",
+				queryFunc: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+					t.Fatalf("Expected no query to run, got %q", query)
+					return nil, nil
+				},
+				execFunc: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					t.Fatalf("Expected no statement to run, got %q", query)
+					return nil, nil
+				},
+			}
+			handler := NewQueryHandler(mockDB, createTestConfig())
+			handler.SetPagination(tt.maxRows, tt.page)
+
+			_, err := handler.ExecuteQuery(context.Background(), tt.query)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -3,17 +3,25 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jhoffmann/go-database-mcp/internal/config"
 	"github.com/jhoffmann/go-database-mcp/internal/database"
+	"github.com/jhoffmann/go-database-mcp/internal/security"
 )
 
 // SchemaHandler handles database schema inspection tools.
 type SchemaHandler struct {
-	db     database.Database
-	config *config.DatabaseConfig
+	db        database.Database
+	config    *config.DatabaseConfig
+	validator *security.QueryValidator // Applies the query security checks to explained queries
+
+	// knownTables caches the names of existing tables and views for requireTable. A handler
+	// is created per tool call, so the database is listed at most once per request.
+	knownTables map[string]bool
 }
 
 // TablesResult represents the result of listing tables.
@@ -28,33 +36,158 @@ type DatabasesResult struct {
 	Count     int      `json:"count"`     // Number of databases
 }
 
+// SearchTablesResult represents the result of searching table names.
+type SearchTablesResult struct {
+	Pattern string   `json:"pattern"` // Search pattern
+	Tables  []string `json:"tables"`  // Matching table names, most relevant first
+	Count   int      `json:"count"`   // Number of matching tables
+}
+
+// SearchColumnsResult represents the result of searching column names.
+type SearchColumnsResult struct {
+	Pattern string                        `json:"pattern"` // Search pattern
+	Columns []database.ColumnSearchResult `json:"columns"` // Matching columns, most relevant first
+	Count   int                           `json:"count"`   // Number of matching columns
+}
+
+// ViewsResult represents the result of listing views.
+type ViewsResult struct {
+	Views []string `json:"views"` // List of view names
+	Count int      `json:"count"` // Number of views
+}
+
+// ViewSchemaResult represents the result of describing a view.
+type ViewSchemaResult struct {
+	Schema *database.ViewSchema `json:"schema"` // View definition and columns
+}
+
+// FunctionsResult represents the result of listing stored functions and procedures.
+type FunctionsResult struct {
+	Functions []database.FunctionInfo `json:"functions"` // Stored functions and procedures, ordered by name
+	Count     int                     `json:"count"`     // Number of routines
+}
+
+// FunctionSchemaResult represents the result of describing a stored function or procedure.
+type FunctionSchemaResult struct {
+	Schema *database.FunctionSchema `json:"schema"` // Routine signature and source body
+}
+
+// ForeignKeysResult represents the result of listing all foreign key relationships.
+type ForeignKeysResult struct {
+	ForeignKeys []database.ForeignKeyRelationship `json:"foreign_keys"` // Foreign key relationships between tables
+	Count       int                               `json:"count"`        // Number of relationships
+}
+
+// ConstraintsResult represents the constraints defined on a table.
+type ConstraintsResult struct {
+	TableName   string                    `json:"table_name"`  // Name of the table
+	Constraints []database.ConstraintInfo `json:"constraints"` // Constraints, keys first and not-null constraints last
+	Count       int                       `json:"count"`       // Number of constraints
+}
+
+// ColumnStatisticsResult represents the result of profiling the columns of a table.
+type ColumnStatisticsResult struct {
+	TableName string                      `json:"table_name"` // Name of the profiled table
+	Columns   []database.ColumnStatistics `json:"columns"`    // Statistics for each column, in table order
+	Count     int                         `json:"count"`      // Number of columns
+}
+
+// CreateTableDDLResult represents the SQL that recreates a table.
+type CreateTableDDLResult struct {
+	TableName string `json:"table_name"` // Name of the table
+	DDL       string `json:"ddl"`        // CREATE TABLE statement followed by any CREATE INDEX statements
+}
+
+// DatabaseSizeResult represents the storage used by all tables in the current database.
+type DatabaseSizeResult struct {
+	Tables         []database.TableSizeInfo `json:"tables"`           // Size of each table, largest first
+	TableCount     int                      `json:"table_count"`      // Number of tables
+	DataSizeBytes  int64                    `json:"data_size_bytes"`  // Combined data size of all tables
+	IndexSizeBytes int64                    `json:"index_size_bytes"` // Combined index size of all tables
+	TotalSizeBytes int64                    `json:"total_size_bytes"` // Combined total size of all tables
+	RowEstimate    int64                    `json:"row_estimate"`     // Combined approximate row count
+}
+
 // TableSchemaResult represents the result of describing a table.
 type TableSchemaResult struct {
 	Schema *database.TableSchema `json:"schema"` // Complete table schema
 }
 
+// DatabaseSchemaResult represents the schema of every table in the current database.
+type DatabaseSchemaResult struct {
+	Tables []*database.TableSchema `json:"tables"` // Schema of each table, in ListTables order
+	Count  int                     `json:"count"`  // Number of tables
+}
+
 // TableDataResult represents the result of getting table data.
 type TableDataResult struct {
 	Data *database.TableData `json:"data"` // Table data with pagination info
 }
 
+// ColumnValuesResult represents the distinct values of a table column.
+type ColumnValuesResult struct {
+	Values *database.ColumnValues `json:"column_values"` // Distinct values with their total count
+	Count  int                    `json:"count"`         // Number of values returned
+}
+
 // ExplainResult represents the result of explaining a query.
 type ExplainResult struct {
-	Query string `json:"query"` // The original query
-	Plan  string `json:"plan"`  // Query execution plan (JSON format)
+	Query    string          `json:"query"`               // The original query
+	Plan     string          `json:"plan"`                // Query execution plan, as returned by the database
+	PlanJSON json.RawMessage `json:"plan_json,omitempty"` // The plan as structured JSON, when the database returned JSON
+	Summary  *PlanSummary    `json:"summary,omitempty"`   // Cost and row estimates of the plan's root node, when available
+	Analyzed bool            `json:"analyzed"`            // Whether the query was executed to collect actual timings
+	Warning  string          `json:"warning,omitempty"`   // Side effects of analyzing the query
 }
 
 // NewSchemaHandler creates a new SchemaHandler instance.
 func NewSchemaHandler(db database.Database, config *config.DatabaseConfig) *SchemaHandler {
 	return &SchemaHandler{
-		db:     db,
-		config: config,
+		db:        db,
+		config:    config,
+		validator: security.NewQueryValidator(config),
+	}
+}
+
+// queryTimeout returns the configured per-query timeout applied to schema queries.
+func (h *SchemaHandler) queryTimeout() time.Duration {
+	if h.config == nil {
+		return 0
+	}
+	return h.config.QueryTimeout
+}
+
+// requireTable returns a "table not found" error unless tableName names an existing table
+// or view. Since table names are interpolated into some queries, this rejects anything that
+// is not a real table, including names crafted to escape their quoting, before SQL is built.
+func (h *SchemaHandler) requireTable(ctx context.Context, tableName string) error {
+	if strings.TrimSpace(tableName) == "" {
+		return fmt.Errorf("table name cannot be empty")
+	}
+
+	if h.knownTables == nil {
+		tables, err := runWithTimeout(ctx, h.queryTimeout(), h.db.ListTables)
+		if err != nil {
+			return fmt.Errorf("failed to list tables: %w", err)
+		}
+		// Views are readable like tables; failing to list them only narrows the check
+		views, _ := runWithTimeout(ctx, h.queryTimeout(), h.db.ListViews)
+
+		h.knownTables = make(map[string]bool, len(tables)+len(views))
+		for _, name := range append(tables, views...) {
+			h.knownTables[name] = true
+		}
 	}
+
+	if !h.knownTables[tableName] {
+		return fmt.Errorf("table not found: %s", tableName)
+	}
+	return nil
 }
 
 // ListTables retrieves all table names from the current database.
 func (h *SchemaHandler) ListTables(ctx context.Context) (*TablesResult, error) {
-	tables, err := h.db.ListTables(ctx)
+	tables, err := runWithTimeout(ctx, h.queryTimeout(), h.db.ListTables)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tables: %w", err)
 	}
@@ -65,10 +198,58 @@ func (h *SchemaHandler) ListTables(ctx context.Context) (*TablesResult, error) {
 	}, nil
 }
 
+// SearchTables finds tables whose name contains pattern, across all allowed databases.
+func (h *SchemaHandler) SearchTables(ctx context.Context, pattern string) (*SearchTablesResult, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil, fmt.Errorf("search pattern cannot be empty")
+	}
+
+	tables, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) ([]string, error) {
+		return h.db.SearchTables(ctx, pattern)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tables: %w", err)
+	}
+	if tables == nil {
+		tables = []string{}
+	}
+
+	return &SearchTablesResult{
+		Pattern: pattern,
+		Tables:  tables,
+		Count:   len(tables),
+	}, nil
+}
+
+// SearchColumns finds columns whose name contains pattern, across all allowed databases.
+func (h *SchemaHandler) SearchColumns(ctx context.Context, pattern string) (*SearchColumnsResult, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil, fmt.Errorf("search pattern cannot be empty")
+	}
+
+	columns, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) ([]database.ColumnSearchResult, error) {
+		return h.db.SearchColumns(ctx, pattern)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search columns: %w", err)
+	}
+	if columns == nil {
+		columns = []database.ColumnSearchResult{}
+	}
+
+	return &SearchColumnsResult{
+		Pattern: pattern,
+		Columns: columns,
+		Count:   len(columns),
+	}, nil
+}
+
 // ListDatabases retrieves all available database names on the server.
 // Only returns databases that are allowed by the configuration.
 func (h *SchemaHandler) ListDatabases(ctx context.Context) (*DatabasesResult, error) {
-	databases, err := h.db.ListDatabases(ctx)
+	databases, err := runWithTimeout(ctx, h.queryTimeout(), h.db.ListDatabases)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list databases: %w", err)
 	}
@@ -87,14 +268,127 @@ func (h *SchemaHandler) ListDatabases(ctx context.Context) (*DatabasesResult, er
 	}, nil
 }
 
+// ListViews retrieves all view names from the current database.
+func (h *SchemaHandler) ListViews(ctx context.Context) (*ViewsResult, error) {
+	views, err := runWithTimeout(ctx, h.queryTimeout(), h.db.ListViews)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list views: %w", err)
+	}
+
+	return &ViewsResult{
+		Views: views,
+		Count: len(views),
+	}, nil
+}
+
+// ListFunctions retrieves the stored functions and procedures in the current database.
+func (h *SchemaHandler) ListFunctions(ctx context.Context) (*FunctionsResult, error) {
+	functions, err := runWithTimeout(ctx, h.queryTimeout(), h.db.ListFunctions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list functions: %w", err)
+	}
+
+	if functions == nil {
+		functions = []database.FunctionInfo{}
+	}
+
+	return &FunctionsResult{
+		Functions: functions,
+		Count:     len(functions),
+	}, nil
+}
+
+// DescribeFunction retrieves the signature and source body of a stored function or procedure.
+func (h *SchemaHandler) DescribeFunction(ctx context.Context, name string) (*FunctionSchemaResult, error) {
+	// Validate input
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("function name cannot be empty")
+	}
+
+	schema, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) (*database.FunctionSchema, error) {
+		return h.db.DescribeFunction(ctx, name)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe function %s: %w", name, err)
+	}
+
+	return &FunctionSchemaResult{
+		Schema: schema,
+	}, nil
+}
+
+// ListForeignKeys retrieves every foreign key relationship in the current database.
+func (h *SchemaHandler) ListForeignKeys(ctx context.Context) (*ForeignKeysResult, error) {
+	relationships, err := runWithTimeout(ctx, h.queryTimeout(), h.db.ListForeignKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list foreign keys: %w", err)
+	}
+
+	if relationships == nil {
+		relationships = []database.ForeignKeyRelationship{}
+	}
+
+	return &ForeignKeysResult{
+		ForeignKeys: relationships,
+		Count:       len(relationships),
+	}, nil
+}
+
+// GetConstraints retrieves the primary key, foreign key, unique, check and not-null
+// constraints of a specific table.
+func (h *SchemaHandler) GetConstraints(ctx context.Context, tableName string) (*ConstraintsResult, error) {
+	if err := h.ValidateTableName(tableName); err != nil {
+		return nil, err
+	}
+	if err := h.requireTable(ctx, tableName); err != nil {
+		return nil, err
+	}
+
+	constraints, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) ([]database.ConstraintInfo, error) {
+		return h.db.GetConstraints(ctx, tableName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get constraints of table %s: %w", tableName, err)
+	}
+	if constraints == nil {
+		constraints = []database.ConstraintInfo{}
+	}
+
+	return &ConstraintsResult{
+		TableName:   tableName,
+		Constraints: constraints,
+		Count:       len(constraints),
+	}, nil
+}
+
+// DescribeView retrieves the definition and columns of a specific view.
+func (h *SchemaHandler) DescribeView(ctx context.Context, viewName string) (*ViewSchemaResult, error) {
+	// Validate input
+	if strings.TrimSpace(viewName) == "" {
+		return nil, fmt.Errorf("view name cannot be empty")
+	}
+
+	schema, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) (*database.ViewSchema, error) {
+		return h.db.DescribeView(ctx, viewName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe view %s: %w", viewName, err)
+	}
+
+	return &ViewSchemaResult{
+		Schema: schema,
+	}, nil
+}
+
 // DescribeTable retrieves detailed schema information about a specific table.
 func (h *SchemaHandler) DescribeTable(ctx context.Context, tableName string) (*TableSchemaResult, error) {
-	// Validate input
-	if strings.TrimSpace(tableName) == "" {
-		return nil, fmt.Errorf("table name cannot be empty")
+	if err := h.requireTable(ctx, tableName); err != nil {
+		return nil, err
 	}
 
-	schema, err := h.db.DescribeTable(ctx, tableName)
+	schema, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) (*database.TableSchema, error) {
+		return h.db.DescribeTable(ctx, tableName)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe table %s: %w", tableName, err)
 	}
@@ -104,8 +398,192 @@ func (h *SchemaHandler) DescribeTable(ctx context.Context, tableName string) (*T
 	}, nil
 }
 
-// GetTableData retrieves paginated data from a specific table.
-func (h *SchemaHandler) GetTableData(ctx context.Context, tableName string, limit int, offset int) (*TableDataResult, error) {
+// GetDatabaseSchema describes every table in the current database, with its columns, indexes
+// and foreign keys, so the whole schema can be loaded at once. The query timeout applies to
+// each table separately.
+func (h *SchemaHandler) GetDatabaseSchema(ctx context.Context) (*DatabaseSchemaResult, error) {
+	tables, err := runWithTimeout(ctx, h.queryTimeout(), h.db.ListTables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	result := &DatabaseSchemaResult{Tables: make([]*database.TableSchema, 0, len(tables))}
+	for _, tableName := range tables {
+		schema, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) (*database.TableSchema, error) {
+			return h.db.DescribeTable(ctx, tableName)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe table %s: %w", tableName, err)
+		}
+		result.Tables = append(result.Tables, schema)
+	}
+
+	result.Count = len(result.Tables)
+	return result, nil
+}
+
+// DescribeTableWithStats retrieves the table schema and augments each column with the
+// approximate null fraction and distinct count from the database's planner statistics.
+// Statistics are omitted gracefully when the database cannot provide them.
+func (h *SchemaHandler) DescribeTableWithStats(ctx context.Context, tableName string) (*TableSchemaResult, error) {
+	result, err := h.DescribeTable(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) (map[string]database.ColumnStatsEstimate, error) {
+		return h.db.EstimateColumnStats(ctx, tableName)
+	})
+	if err != nil {
+		return result, nil
+	}
+
+	for i := range result.Schema.Columns {
+		column := &result.Schema.Columns[i]
+		if estimate, ok := stats[column.Name]; ok {
+			column.NullFraction = estimate.NullFraction
+			column.DistinctCount = estimate.DistinctCount
+		}
+	}
+
+	return result, nil
+}
+
+// GetColumnStatistics profiles the columns of a specific table. The table name is
+// validated before any query runs, since profiling scans the whole table.
+func (h *SchemaHandler) GetColumnStatistics(ctx context.Context, tableName string) (*ColumnStatisticsResult, error) {
+	if err := h.ValidateTableName(tableName); err != nil {
+		return nil, err
+	}
+	if err := h.requireTable(ctx, tableName); err != nil {
+		return nil, err
+	}
+
+	columns, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) ([]database.ColumnStatistics, error) {
+		return h.db.GetColumnStatistics(ctx, tableName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column statistics for %s: %w", tableName, err)
+	}
+
+	if columns == nil {
+		columns = []database.ColumnStatistics{}
+	}
+
+	return &ColumnStatisticsResult{
+		TableName: tableName,
+		Columns:   columns,
+		Count:     len(columns),
+	}, nil
+}
+
+// ProfileTable returns the value distribution of every column of a specific table: null
+// percentage, distinct count, most common values and, for numeric and date/time columns,
+// minimum and maximum. maxDistinctValues limits the number of most common values per column;
+// 0 defaults to 20 and values above 100 are capped. The table name is validated before any
+// query runs, since profiling scans the whole table.
+func (h *SchemaHandler) ProfileTable(ctx context.Context, tableName string, maxDistinctValues int) (*database.TableProfile, error) {
+	if err := h.ValidateTableName(tableName); err != nil {
+		return nil, err
+	}
+	if maxDistinctValues < 0 {
+		return nil, fmt.Errorf("max_distinct_values cannot be negative")
+	}
+
+	if maxDistinctValues == 0 {
+		maxDistinctValues = 20
+	}
+	if maxDistinctValues > 100 {
+		maxDistinctValues = 100
+	}
+
+	if err := h.requireTable(ctx, tableName); err != nil {
+		return nil, err
+	}
+
+	profile, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) (*database.TableProfile, error) {
+		return h.db.ProfileTable(ctx, tableName, maxDistinctValues)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to profile table %s: %w", tableName, err)
+	}
+
+	return profile, nil
+}
+
+// ShowCreateTable returns the CREATE statement, including constraints and indexes, that
+// recreates a specific table, as reported by Database.GetCreateStatement.
+func (h *SchemaHandler) ShowCreateTable(ctx context.Context, tableName string) (*CreateTableDDLResult, error) {
+	if err := h.ValidateTableName(tableName); err != nil {
+		return nil, err
+	}
+	if err := h.requireTable(ctx, tableName); err != nil {
+		return nil, err
+	}
+
+	statement, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) (string, error) {
+		return h.db.GetCreateStatement(ctx, tableName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CREATE statement for %s: %w", tableName, err)
+	}
+
+	return &CreateTableDDLResult{
+		TableName: tableName,
+		DDL:       statement,
+	}, nil
+}
+
+// GetTableSize returns the storage used by a specific table.
+func (h *SchemaHandler) GetTableSize(ctx context.Context, tableName string) (*database.TableSizeInfo, error) {
+	if err := h.ValidateTableName(tableName); err != nil {
+		return nil, err
+	}
+	if err := h.requireTable(ctx, tableName); err != nil {
+		return nil, err
+	}
+
+	size, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) (*database.TableSizeInfo, error) {
+		return h.db.GetTableSize(ctx, tableName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get size of table %s: %w", tableName, err)
+	}
+
+	return size, nil
+}
+
+// GetDatabaseSize returns the storage used by every table in the current database
+// along with the totals across all of them.
+func (h *SchemaHandler) GetDatabaseSize(ctx context.Context) (*DatabaseSizeResult, error) {
+	tables, err := runWithTimeout(ctx, h.queryTimeout(), h.db.ListTableSizes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database size: %w", err)
+	}
+
+	result := &DatabaseSizeResult{
+		Tables:     tables,
+		TableCount: len(tables),
+	}
+	if result.Tables == nil {
+		result.Tables = []database.TableSizeInfo{}
+	}
+	for _, table := range tables {
+		result.DataSizeBytes += table.DataSizeBytes
+		result.IndexSizeBytes += table.IndexSizeBytes
+		result.TotalSizeBytes += table.TotalSizeBytes
+		result.RowEstimate += table.RowEstimate
+	}
+
+	return result, nil
+}
+
+// GetTableData retrieves paginated data from a specific table. opts may restrict the
+// returned columns and rows and set their order; requested, filter and sort columns are
+// checked against the table schema first so that an unknown column is reported clearly instead of surfacing as a
+// SQL error. opts.EstimateCount reports an approximate total from the table statistics
+// instead of counting every row, which cannot be combined with a filter.
+func (h *SchemaHandler) GetTableData(ctx context.Context, tableName string, limit int, offset int, opts database.TableDataOptions) (*TableDataResult, error) {
 	// Validate input
 	if strings.TrimSpace(tableName) == "" {
 		return nil, fmt.Errorf("table name cannot be empty")
@@ -125,7 +603,63 @@ func (h *SchemaHandler) GetTableData(ctx context.Context, tableName string, limi
 		limit = 1000 // Maximum page size to prevent memory issues
 	}
 
-	data, err := h.db.GetTableData(ctx, tableName, limit, offset)
+	if err := h.requireTable(ctx, tableName); err != nil {
+		return nil, err
+	}
+
+	if opts.Filter != nil {
+		if opts.EstimateCount {
+			return nil, fmt.Errorf("estimated row counts cannot be combined with a filter")
+		}
+		if err := opts.Filter.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	switch strings.ToUpper(strings.TrimSpace(opts.OrderDir)) {
+	case "", "ASC", "DESC":
+	default:
+		return nil, fmt.Errorf("unsupported order direction %q. Supported directions: ASC, DESC", opts.OrderDir)
+	}
+	if opts.OrderDir != "" && opts.OrderBy == "" {
+		return nil, fmt.Errorf("order direction requires an order by column")
+	}
+
+	if opts.Cursor != "" {
+		if opts.OrderBy != "" {
+			return nil, fmt.Errorf("a cursor cannot be combined with an order by column; cursor pages are ordered by the primary key")
+		}
+		if offset > 0 {
+			return nil, fmt.Errorf("a cursor cannot be combined with an offset")
+		}
+	}
+
+	schema, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) (*database.TableSchema, error) {
+		return h.db.DescribeTable(ctx, tableName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe table %s: %w", tableName, err)
+	}
+	if err := validateTableDataColumns(schema, tableName, opts); err != nil {
+		return nil, err
+	}
+
+	// Without an explicit sort, pages follow the primary key so they can be resumed by cursor
+	if opts.OrderBy == "" {
+		opts.KeyColumns = schema.PrimaryKey
+	}
+	if opts.Cursor != "" {
+		if len(opts.KeyColumns) == 0 {
+			return nil, fmt.Errorf("cursor pagination requires table %s to have a primary key", tableName)
+		}
+		if _, err := database.DecodeCursor(opts.Cursor, len(opts.KeyColumns)); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) (*database.TableData, error) {
+		return h.db.GetTableData(ctx, tableName, limit, offset, opts)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get table data for %s: %w", tableName, err)
 	}
@@ -135,34 +669,145 @@ func (h *SchemaHandler) GetTableData(ctx context.Context, tableName string, limi
 	}, nil
 }
 
-// ExplainQuery retrieves the execution plan for a SQL query.
-func (h *SchemaHandler) ExplainQuery(ctx context.Context, query string) (*ExplainResult, error) {
+// validateTableDataColumns checks that every requested column, the filter column and the
+// sort column of opts exist in the schema of tableName, listing all missing requested
+// columns in the error.
+func validateTableDataColumns(schema *database.TableSchema, tableName string, opts database.TableDataOptions) error {
+	existing := make(map[string]bool, len(schema.Columns))
+	for _, column := range schema.Columns {
+		existing[column.Name] = true
+	}
+
+	var missing []string
+	for _, column := range opts.Columns {
+		if !existing[column] {
+			missing = append(missing, column)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("columns do not exist in table %s: %s", tableName, strings.Join(missing, ", "))
+	}
+
+	if opts.Filter != nil && !existing[opts.Filter.Column] {
+		return fmt.Errorf("filter column %q does not exist in table %s", opts.Filter.Column, tableName)
+	}
+	if opts.OrderBy != "" && !existing[opts.OrderBy] {
+		return fmt.Errorf("order by column %q does not exist in table %s", opts.OrderBy, tableName)
+	}
+	return nil
+}
+
+// GetColumnValues returns the distinct values of a column, sorted, for building filters.
+// Both the table and the column are checked against the schema before the query is built.
+// If limit is 0 it defaults to 100 values, and it is capped at 1000; the result reports
+// whether more distinct values exist.
+func (h *SchemaHandler) GetColumnValues(ctx context.Context, tableName, columnName string, limit int) (*ColumnValuesResult, error) {
+	if err := h.ValidateTableName(tableName); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(columnName) == "" {
+		return nil, fmt.Errorf("column name cannot be empty")
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("limit cannot be negative")
+	}
+
+	if limit == 0 {
+		limit = 100
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	if err := h.requireTable(ctx, tableName); err != nil {
+		return nil, err
+	}
+	schema, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) (*database.TableSchema, error) {
+		return h.db.DescribeTable(ctx, tableName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe table %s: %w", tableName, err)
+	}
+	if err := validateTableDataColumns(schema, tableName, database.TableDataOptions{Columns: []string{columnName}}); err != nil {
+		return nil, err
+	}
+
+	values, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) (*database.ColumnValues, error) {
+		return h.db.GetColumnValues(ctx, tableName, columnName, limit)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get values of %s.%s: %w", tableName, columnName, err)
+	}
+
+	return &ColumnValuesResult{
+		Values: values,
+		Count:  len(values.Values),
+	}, nil
+}
+
+// ExplainQuery retrieves the execution plan for a SQL query. The query passes the same
+// security validation as executed queries (database access, blocked patterns, read-only
+// mode and complexity), so it is rejected before reaching the database if it would be.
+//
+// When analyze is true the query is actually executed (EXPLAIN ANALYZE) to report real
+// row counts and timings. It runs in a transaction that is rolled back afterwards, so DML
+// changes are discarded; the result carries a warning saying so. DDL cannot be analyzed.
+//
+// JSON plans are also returned as structured JSON with a summary of the root node's
+// estimated cost and rows. MySQL's EXPLAIN ANALYZE returns a text tree, which is not parsed.
+func (h *SchemaHandler) ExplainQuery(ctx context.Context, query string, analyze bool) (*ExplainResult, error) {
 	// Validate input
 	if strings.TrimSpace(query) == "" {
 		return nil, fmt.Errorf("query cannot be empty")
 	}
 
-	plan, err := h.db.ExplainQuery(ctx, query)
+	if err := h.validator.ValidateQuery(query); err != nil {
+		return nil, h.validator.SanitizeErrorMessage(err)
+	}
+
+	var warning string
+	if analyze {
+		switch security.DetermineQueryType(query, h.db.GetDriverName()) {
+		case "select":
+			warning = "EXPLAIN ANALYZE executed the query to measure it"
+		case "ddl":
+			return nil, fmt.Errorf("DDL statements cannot be analyzed")
+		default:
+			warning = "EXPLAIN ANALYZE executed the statement inside a transaction and rolled it back; " +
+				"data changes were discarded, but side effects such as sequence increments and triggers' external effects may persist"
+		}
+	}
+
+	plan, err := runWithTimeout(ctx, h.queryTimeout(), func(ctx context.Context) (string, error) {
+		if analyze {
+			return h.db.ExplainAnalyzeQuery(ctx, query)
+		}
+		return h.db.ExplainQuery(ctx, query)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to explain query: %w", err)
 	}
 
+	planJSON, summary := parsePlan(plan)
 	return &ExplainResult{
-		Query: query,
-		Plan:  plan,
+		Query:    query,
+		Plan:     plan,
+		PlanJSON: planJSON,
+		Summary:  summary,
+		Analyzed: analyze,
+		Warning:  warning,
 	}, nil
 }
 
 // GetTableStatistics provides statistical information about a table (if available).
 func (h *SchemaHandler) GetTableStatistics(ctx context.Context, tableName string) (map[string]any, error) {
-	// Validate input
-	if strings.TrimSpace(tableName) == "" {
-		return nil, fmt.Errorf("table name cannot be empty")
+	if err := h.requireTable(ctx, tableName); err != nil {
+		return nil, err
 	}
 
 	// This could be extended to provide table statistics like row count, size, etc.
 	// For now, we'll use a simple query to get row count
-	query := fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s", h.db.QuoteTable(ctx, tableName))
 
 	row := h.db.QueryRow(ctx, query)
 	var rowCount int64
@@ -196,3 +841,13 @@ func (h *SchemaHandler) ValidateTableName(tableName string) error {
 
 	return nil
 }
+
+// ValidateFormat checks that format is supported by FormatResult.
+func (h *SchemaHandler) ValidateFormat(format string) error {
+	return validateDocumentFormat(format)
+}
+
+// FormatResult renders a schema tool result in the specified format ("json" or "yaml").
+func (h *SchemaHandler) FormatResult(result any, format string) (string, error) {
+	return formatDocument(result, format)
+}
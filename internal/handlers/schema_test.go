@@ -2,7 +2,11 @@ package handlers
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/jhoffmann/go-database-mcp/internal/database"
@@ -13,17 +17,55 @@ type MockSchemaDatabase struct {
 	MockDatabase
 	tables        []string
 	databases     []string
+	views         []string
+	viewSchema    *database.ViewSchema
 	tableSchema   *database.TableSchema
+	columnStats   map[string]database.ColumnStatsEstimate
+	foreignKeys   []database.ForeignKeyRelationship
+	foreignKeyErr error
+	constraints   []database.ConstraintInfo
+	constraintErr error
+	statistics    []database.ColumnStatistics
+	statisticsErr error
+	statsErr      error
+	createDDL     string
+	createDDLErr  error
+	searchTables  []string
+	searchColumns []database.ColumnSearchResult
+	searchErr     error
+	tableSize     *database.TableSizeInfo
+	tableSizes    []database.TableSizeInfo
+	sizeErr       error
 	tableData     *database.TableData
+	dataOptions   *database.TableDataOptions // Options passed to the last GetTableData call
+	columnValues  *database.ColumnValues
+	functions     []database.FunctionInfo
+	functionInfo  *database.FunctionSchema
+	functionErr   error
+	valuesLimit   int // Limit passed to the last GetColumnValues call
+	valuesErr     error
 	explainResult string
+	analyzeResult string
 	listTablesErr error
+	listCalls     int // Number of ListTables calls
 	listDBErr     error
+	listViewsErr  error
+	viewErr       error
 	describeErr   error
 	tableDataErr  error
 	explainErr    error
+	profile       *database.TableProfile
+	profileErr    error
+	profileLimit  int // maxDistinctValues passed to the last ProfileTable call
+}
+
+func (m *MockSchemaDatabase) ProfileTable(ctx context.Context, tableName string, maxDistinctValues int) (*database.TableProfile, error) {
+	m.profileLimit = maxDistinctValues
+	return m.profile, m.profileErr
 }
 
 func (m *MockSchemaDatabase) ListTables(ctx context.Context) ([]string, error) {
+	m.listCalls++
 	return m.tables, m.listTablesErr
 }
 
@@ -31,11 +73,83 @@ func (m *MockSchemaDatabase) ListDatabases(ctx context.Context) ([]string, error
 	return m.databases, m.listDBErr
 }
 
+func (m *MockSchemaDatabase) ListViews(ctx context.Context) ([]string, error) {
+	return m.views, m.listViewsErr
+}
+
+func (m *MockSchemaDatabase) DescribeView(ctx context.Context, viewName string) (*database.ViewSchema, error) {
+	return m.viewSchema, m.viewErr
+}
+
 func (m *MockSchemaDatabase) DescribeTable(ctx context.Context, tableName string) (*database.TableSchema, error) {
 	return m.tableSchema, m.describeErr
 }
 
-func (m *MockSchemaDatabase) GetTableData(ctx context.Context, tableName string, limit int, offset int) (*database.TableData, error) {
+func (m *MockSchemaDatabase) EstimateColumnStats(ctx context.Context, tableName string) (map[string]database.ColumnStatsEstimate, error) {
+	return m.columnStats, m.statsErr
+}
+
+func (m *MockSchemaDatabase) ListForeignKeys(ctx context.Context) ([]database.ForeignKeyRelationship, error) {
+	return m.foreignKeys, m.foreignKeyErr
+}
+
+func (m *MockSchemaDatabase) GetConstraints(ctx context.Context, tableName string) ([]database.ConstraintInfo, error) {
+	return m.constraints, m.constraintErr
+}
+
+func (m *MockSchemaDatabase) GetColumnStatistics(ctx context.Context, tableName string) ([]database.ColumnStatistics, error) {
+	return m.statistics, m.statisticsErr
+}
+
+func (m *MockSchemaDatabase) GetCreateStatement(ctx context.Context, tableName string) (string, error) {
+	return m.createDDL, m.createDDLErr
+}
+
+func (m *MockSchemaDatabase) SearchTables(ctx context.Context, pattern string) ([]string, error) {
+	return m.searchTables, m.searchErr
+}
+
+func (m *MockSchemaDatabase) SearchColumns(ctx context.Context, pattern string) ([]database.ColumnSearchResult, error) {
+	return m.searchColumns, m.searchErr
+}
+
+func (m *MockSchemaDatabase) GetTableSize(ctx context.Context, tableName string) (*database.TableSizeInfo, error) {
+	return m.tableSize, m.sizeErr
+}
+
+func (m *MockSchemaDatabase) ListTableSizes(ctx context.Context) ([]database.TableSizeInfo, error) {
+	return m.tableSizes, m.sizeErr
+}
+
+func (m *MockSchemaDatabase) GetServerInfo(ctx context.Context) (*database.ServerInfo, error) {
+	return nil, nil
+}
+func (m *MockSchemaDatabase) GetActiveConnections(ctx context.Context) ([]database.ActiveConnection, error) {
+	return nil, nil
+}
+func (m *MockSchemaDatabase) KillConnection(ctx context.Context, pid int64) error {
+	return nil
+}
+
+func (m *MockSchemaDatabase) GetTableBloat(ctx context.Context, tableName string) ([]database.TableBloatStats, error) {
+	return nil, nil
+}
+
+func (m *MockSchemaDatabase) ListFunctions(ctx context.Context) ([]database.FunctionInfo, error) {
+	return m.functions, m.functionErr
+}
+
+func (m *MockSchemaDatabase) DescribeFunction(ctx context.Context, name string) (*database.FunctionSchema, error) {
+	return m.functionInfo, m.functionErr
+}
+
+func (m *MockSchemaDatabase) GetColumnValues(ctx context.Context, tableName, columnName string, limit int) (*database.ColumnValues, error) {
+	m.valuesLimit = limit
+	return m.columnValues, m.valuesErr
+}
+
+func (m *MockSchemaDatabase) GetTableData(ctx context.Context, tableName string, limit int, offset int, opts database.TableDataOptions) (*database.TableData, error) {
+	m.dataOptions = &opts
 	return m.tableData, m.tableDataErr
 }
 
@@ -43,6 +157,10 @@ func (m *MockSchemaDatabase) ExplainQuery(ctx context.Context, query string) (st
 	return m.explainResult, m.explainErr
 }
 
+func (m *MockSchemaDatabase) ExplainAnalyzeQuery(ctx context.Context, query string) (string, error) {
+	return m.analyzeResult, m.explainErr
+}
+
 func TestNewSchemaHandler(t *testing.T) {
 	mockDB := &MockSchemaDatabase{}
 
@@ -195,6 +313,190 @@ func TestSchemaHandler_ListDatabases(t *testing.T) {
 	}
 }
 
+func TestSchemaHandler_ListForeignKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		foreignKeys []database.ForeignKeyRelationship
+		error       error
+		wantErr     bool
+		wantCount   int
+	}{
+		{
+			name: "relationships found",
+			foreignKeys: []database.ForeignKeyRelationship{
+				{
+					ConstraintName: "orders_user_id_fkey",
+					FromTable:      "orders",
+					FromColumns:    []string{"user_id"},
+					ToTable:        "users",
+					ToColumns:      []string{"id"},
+					OnDelete:       "CASCADE",
+					OnUpdate:       "NO ACTION",
+				},
+			},
+			wantCount: 1,
+		},
+		{
+			name:      "no relationships",
+			wantCount: 0,
+		},
+		{
+			name:    "database error",
+			error:   errors.New("database connection failed"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{
+				foreignKeys:   tt.foreignKeys,
+				foreignKeyErr: tt.error,
+			}
+			mockDB.driver = "postgres"
+
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+			result, err := handler.ListForeignKeys(context.Background())
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListForeignKeys() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr {
+				if result.Count != tt.wantCount {
+					t.Errorf("Expected count %d, got %d", tt.wantCount, result.Count)
+				}
+				if result.ForeignKeys == nil {
+					t.Error("Expected non-nil foreign key slice so JSON encodes an empty array")
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_ListViews(t *testing.T) {
+	tests := []struct {
+		name      string
+		views     []string
+		error     error
+		wantErr   bool
+		wantCount int
+	}{
+		{
+			name:      "successful list with views",
+			views:     []string{"active_users", "order_totals"},
+			wantCount: 2,
+		},
+		{
+			name:      "no views",
+			views:     []string{},
+			wantCount: 0,
+		},
+		{
+			name:    "database error",
+			error:   errors.New("database connection failed"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{
+				views:        tt.views,
+				listViewsErr: tt.error,
+			}
+			mockDB.driver = "postgres"
+
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+			result, err := handler.ListViews(context.Background())
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListViews() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr {
+				if result.Count != tt.wantCount {
+					t.Errorf("Expected count %d, got %d", tt.wantCount, result.Count)
+				}
+
+				for i, expectedView := range tt.views {
+					if result.Views[i] != expectedView {
+						t.Errorf("Expected view %s, got %s", expectedView, result.Views[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_DescribeView(t *testing.T) {
+	sampleView := &database.ViewSchema{
+		ViewName:   "active_users",
+		Definition: "SELECT id, name FROM users WHERE active = true",
+		Columns: []database.ColumnInfo{
+			{Name: "id", Type: "integer"},
+			{Name: "name", Type: "character varying", IsNullable: true},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		viewName    string
+		schema      *database.ViewSchema
+		error       error
+		wantErr     bool
+		wantColumns int
+	}{
+		{
+			name:        "successful describe",
+			viewName:    "active_users",
+			schema:      sampleView,
+			wantColumns: 2,
+		},
+		{
+			name:     "view not found",
+			viewName: "missing",
+			error:    errors.New("view missing not found"),
+			wantErr:  true,
+		},
+		{
+			name:     "empty view name",
+			viewName: "  ",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{
+				viewSchema: tt.schema,
+				viewErr:    tt.error,
+			}
+			mockDB.driver = "postgres"
+
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+			result, err := handler.DescribeView(context.Background(), tt.viewName)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DescribeView() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr {
+				if result.Schema.Definition == "" {
+					t.Error("Expected non-empty view definition")
+				}
+
+				if len(result.Schema.Columns) != tt.wantColumns {
+					t.Errorf("Expected %d columns, got %d", tt.wantColumns, len(result.Schema.Columns))
+				}
+			}
+		})
+	}
+}
+
 func TestSchemaHandler_DescribeTable(t *testing.T) {
 	sampleSchema := &database.TableSchema{
 		TableName: "users",
@@ -233,6 +535,16 @@ func TestSchemaHandler_DescribeTable(t *testing.T) {
 				IsUnique: true,
 			},
 		},
+		ForeignKeys: []database.ForeignKeyInfo{
+			{
+				ConstraintName:    "fk_users_org",
+				Columns:           []string{"org_id"},
+				ReferencedTable:   "organizations",
+				ReferencedColumns: []string{"id"},
+				OnDelete:          "CASCADE",
+				OnUpdate:          "NO ACTION",
+			},
+		},
 	}
 
 	tests := []struct {
@@ -243,6 +555,7 @@ func TestSchemaHandler_DescribeTable(t *testing.T) {
 		wantErr     bool
 		wantColumns int
 		wantIndexes int
+		wantFKs     int
 	}{
 		{
 			name:        "successful describe",
@@ -252,6 +565,7 @@ func TestSchemaHandler_DescribeTable(t *testing.T) {
 			wantErr:     false,
 			wantColumns: 3,
 			wantIndexes: 2,
+			wantFKs:     1,
 		},
 		{
 			name:        "table not found",
@@ -276,6 +590,7 @@ func TestSchemaHandler_DescribeTable(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockDB := &MockSchemaDatabase{
+				tables:      []string{tt.tableName},
 				tableSchema: tt.schema,
 				describeErr: tt.error,
 			}
@@ -302,6 +617,10 @@ func TestSchemaHandler_DescribeTable(t *testing.T) {
 					t.Errorf("Expected %d indexes, got %d", tt.wantIndexes, len(result.Schema.Indexes))
 				}
 
+				if len(result.Schema.ForeignKeys) != tt.wantFKs {
+					t.Errorf("Expected %d foreign keys, got %d", tt.wantFKs, len(result.Schema.ForeignKeys))
+				}
+
 				if result.Schema.TableName != tt.tableName {
 					t.Errorf("Expected table name %s, got %s", tt.tableName, result.Schema.TableName)
 				}
@@ -310,6 +629,73 @@ func TestSchemaHandler_DescribeTable(t *testing.T) {
 	}
 }
 
+func TestSchemaHandler_DescribeTableWithStats(t *testing.T) {
+	newSchema := func() *database.TableSchema {
+		return &database.TableSchema{
+			TableName: "users",
+			Columns: []database.ColumnInfo{
+				{Name: "id", Type: "integer", IsPrimaryKey: true},
+				{Name: "email", Type: "character varying", IsNullable: true},
+			},
+		}
+	}
+	stats := map[string]database.ColumnStatsEstimate{
+		"id":    {NullFraction: ptr(0.0), DistinctCount: ptr(int64(1000))},
+		"email": {NullFraction: ptr(0.25), DistinctCount: ptr(int64(740))},
+	}
+
+	t.Run("stats included when requested", func(t *testing.T) {
+		mockDB := &MockSchemaDatabase{tables: []string{"users"}, tableSchema: newSchema(), columnStats: stats}
+		mockDB.driver = "postgres"
+
+		handler := NewSchemaHandler(mockDB, createTestConfig())
+		result, err := handler.DescribeTableWithStats(context.Background(), "users")
+		if err != nil {
+			t.Fatalf("DescribeTableWithStats() error = %v", err)
+		}
+
+		email := result.Schema.Columns[1]
+		if email.NullFraction == nil || *email.NullFraction != 0.25 {
+			t.Errorf("Expected email null fraction 0.25, got %v", email.NullFraction)
+		}
+		if email.DistinctCount == nil || *email.DistinctCount != 740 {
+			t.Errorf("Expected email distinct count 740, got %v", email.DistinctCount)
+		}
+	})
+
+	t.Run("stats absent by default", func(t *testing.T) {
+		mockDB := &MockSchemaDatabase{tables: []string{"users"}, tableSchema: newSchema(), columnStats: stats}
+		mockDB.driver = "postgres"
+
+		handler := NewSchemaHandler(mockDB, createTestConfig())
+		result, err := handler.DescribeTable(context.Background(), "users")
+		if err != nil {
+			t.Fatalf("DescribeTable() error = %v", err)
+		}
+
+		for _, column := range result.Schema.Columns {
+			if column.NullFraction != nil || column.DistinctCount != nil {
+				t.Errorf("Expected no statistics on column %s without stats option", column.Name)
+			}
+		}
+	})
+
+	t.Run("stats unavailable are omitted", func(t *testing.T) {
+		mockDB := &MockSchemaDatabase{tables: []string{"users"}, tableSchema: newSchema(), statsErr: errors.New("permission denied for pg_stats")}
+		mockDB.driver = "postgres"
+
+		handler := NewSchemaHandler(mockDB, createTestConfig())
+		result, err := handler.DescribeTableWithStats(context.Background(), "users")
+		if err != nil {
+			t.Fatalf("DescribeTableWithStats() should not fail when stats are unavailable, got %v", err)
+		}
+
+		if result.Schema.Columns[0].NullFraction != nil {
+			t.Error("Expected statistics to be omitted when unavailable")
+		}
+	})
+}
+
 func TestSchemaHandler_GetTableData(t *testing.T) {
 	sampleData := &database.TableData{
 		TableName: "users",
@@ -395,13 +781,15 @@ func TestSchemaHandler_GetTableData(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockDB := &MockSchemaDatabase{
+				tables:       []string{tt.tableName},
+				tableSchema:  &database.TableSchema{TableName: tt.tableName, Columns: []database.ColumnInfo{{Name: "id"}}},
 				tableData:    tt.data,
 				tableDataErr: tt.error,
 			}
 			mockDB.driver = "postgres"
 
 			handler := NewSchemaHandler(mockDB, createTestConfig())
-			result, err := handler.GetTableData(context.Background(), tt.tableName, tt.limit, tt.offset)
+			result, err := handler.GetTableData(context.Background(), tt.tableName, tt.limit, tt.offset, database.TableDataOptions{})
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetTableData() error = %v, wantErr %v", err, tt.wantErr)
@@ -480,7 +868,7 @@ func TestSchemaHandler_ExplainQuery(t *testing.T) {
 			mockDB.driver = "postgres"
 
 			handler := NewSchemaHandler(mockDB, createTestConfig())
-			result, err := handler.ExplainQuery(context.Background(), tt.query)
+			result, err := handler.ExplainQuery(context.Background(), tt.query, false)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ExplainQuery() error = %v, wantErr %v", err, tt.wantErr)
@@ -517,19 +905,1032 @@ func TestSchemaHandler_Validation(t *testing.T) {
 	}
 
 	// Test pagination validation
-	_, err = handler.GetTableData(context.Background(), "users", -1, 0)
+	_, err = handler.GetTableData(context.Background(), "users", -1, 0, database.TableDataOptions{})
 	if err == nil {
 		t.Error("Expected error for negative limit")
 	}
 
-	_, err = handler.GetTableData(context.Background(), "users", 10, -1)
+	_, err = handler.GetTableData(context.Background(), "users", 10, -1, database.TableDataOptions{})
 	if err == nil {
 		t.Error("Expected error for negative offset")
 	}
 
 	// Test query validation
-	_, err = handler.ExplainQuery(context.Background(), "")
+	_, err = handler.ExplainQuery(context.Background(), "", false)
 	if err == nil {
 		t.Error("Expected error for empty query")
 	}
 }
+
+func TestSchemaHandler_GetColumnStatistics(t *testing.T) {
+	distinct := int64(3)
+	tests := []struct {
+		name       string
+		tableName  string
+		statistics []database.ColumnStatistics
+		error      error
+		wantErr    string
+		wantCount  int
+	}{
+		{
+			name:      "columns profiled",
+			tableName: "users",
+			statistics: []database.ColumnStatistics{
+				{Name: "id", DataType: "integer", DistinctCount: &distinct},
+				{Name: "email", DataType: "text", NullCount: 1},
+			},
+			wantCount: 2,
+		},
+		{
+			name:      "no statistics",
+			tableName: "empty",
+			wantCount: 0,
+		},
+		{
+			name:      "database error",
+			tableName: "huge",
+			error:     errors.New("table huge has more than 1000000 rows"),
+			wantErr:   "more than 1000000 rows",
+		},
+		{
+			name:      "empty table name",
+			tableName: "  ",
+			wantErr:   "table name cannot be empty",
+		},
+		{
+			name:      "dangerous table name",
+			tableName: "users; DROP TABLE users",
+			wantErr:   "potentially dangerous",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{tables: []string{tt.tableName}, statistics: tt.statistics, statisticsErr: tt.error}
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+
+			result, err := handler.GetColumnStatistics(context.Background(), tt.tableName)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("GetColumnStatistics() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetColumnStatistics() unexpected error = %v", err)
+			}
+
+			if result.Count != tt.wantCount || len(result.Columns) != tt.wantCount {
+				t.Errorf("Expected %d columns, got count %d and %d columns", tt.wantCount, result.Count, len(result.Columns))
+			}
+			if result.Columns == nil {
+				t.Error("Expected non-nil column slice so JSON encodes an empty array")
+			}
+			if result.TableName != tt.tableName {
+				t.Errorf("Expected table name %s, got %s", tt.tableName, result.TableName)
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_GetTableStatistics(t *testing.T) {
+	tests := []struct {
+		name      string
+		driver    string
+		tableName string
+		wantQuery string
+	}{
+		{name: "postgres", driver: "postgres", tableName: "order items", wantQuery: `SELECT COUNT(*) FROM "order items"`},
+		{name: "mysql", driver: "mysql", tableName: "order items", wantQuery: "SELECT COUNT(*) FROM `order items`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &mockResultSet{columns: []string{"count"}, rows: [][]driver.Value{{int64(3)}}}
+			sqlDB := newMockSQLDB(t, set)
+			mockDB := &MockSchemaDatabase{tables: []string{tt.tableName}}
+			mockDB.driver = tt.driver
+			mockDB.queryRowFunc = func(ctx context.Context, query string, args ...any) *sql.Row {
+				return sqlDB.QueryRowContext(ctx, query, args...)
+			}
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+
+			stats, err := handler.GetTableStatistics(context.Background(), tt.tableName)
+			if err != nil {
+				t.Fatalf("GetTableStatistics() unexpected error = %v", err)
+			}
+			if queries := set.Queries(); len(queries) != 1 || queries[0] != tt.wantQuery {
+				t.Errorf("Expected query %q, got %q", tt.wantQuery, queries)
+			}
+			if stats["row_count"] != int64(3) {
+				t.Errorf("Expected row count 3, got %v", stats["row_count"])
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_ShowCreateTable(t *testing.T) {
+	tests := []struct {
+		name      string
+		tables    []string
+		tableName string
+		statement string
+		error     error
+		wantErr   string
+	}{
+		{
+			name:      "statement returned",
+			tables:    []string{"users"},
+			tableName: "users",
+			statement: "CREATE TABLE `users` (\n  `id` int NOT NULL\n);\n",
+		},
+		{
+			name:      "rebuilt statement returned",
+			tables:    []string{"users"},
+			tableName: "users",
+			statement: "CREATE TABLE \"users\" (\n    \"id\" integer NOT NULL\n);\n",
+		},
+		{
+			name:      "unknown table",
+			tables:    []string{"users"},
+			tableName: "orders",
+			wantErr:   "table not found",
+		},
+		{
+			name:      "database error",
+			tables:    []string{"users"},
+			tableName: "users",
+			error:     errors.New("access denied"),
+			wantErr:   "failed to get CREATE statement for users",
+		},
+		{
+			name:      "empty table name",
+			tableName: "",
+			wantErr:   "table name cannot be empty",
+		},
+		{
+			name:      "dangerous table name",
+			tables:    []string{"users; DROP TABLE users"},
+			tableName: "users; DROP TABLE users",
+			wantErr:   "potentially dangerous",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{tables: tt.tables, createDDL: tt.statement, createDDLErr: tt.error}
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+
+			result, err := handler.ShowCreateTable(context.Background(), tt.tableName)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ShowCreateTable() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ShowCreateTable() unexpected error = %v", err)
+			}
+
+			if result.TableName != tt.tableName || result.DDL != tt.statement {
+				t.Errorf("ShowCreateTable() = %+v", result)
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_SearchTables(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		tables    []string
+		error     error
+		wantErr   string
+		wantCount int
+	}{
+		{name: "matches", pattern: " user ", tables: []string{"users", "reporting.user_events"}, wantCount: 2},
+		{name: "no matches", pattern: "zzz", wantCount: 0},
+		{name: "empty pattern", pattern: "  ", wantErr: "search pattern cannot be empty"},
+		{name: "database error", pattern: "user", error: errors.New("connection lost"), wantErr: "failed to search tables"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{searchTables: tt.tables, searchErr: tt.error}
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+
+			result, err := handler.SearchTables(context.Background(), tt.pattern)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("SearchTables() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SearchTables() unexpected error = %v", err)
+			}
+
+			if result.Count != tt.wantCount || len(result.Tables) != tt.wantCount {
+				t.Errorf("Expected %d tables, got %+v", tt.wantCount, result)
+			}
+			if result.Tables == nil {
+				t.Error("Expected non-nil table slice")
+			}
+			if result.Pattern != strings.TrimSpace(tt.pattern) {
+				t.Errorf("Expected trimmed pattern, got %q", result.Pattern)
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_SearchColumns(t *testing.T) {
+	columns := []database.ColumnSearchResult{
+		{Database: "app", TableName: "users", ColumnName: "email", DataType: "text"},
+	}
+
+	handler := NewSchemaHandler(&MockSchemaDatabase{searchColumns: columns}, createTestConfig())
+	result, err := handler.SearchColumns(context.Background(), "email")
+	if err != nil {
+		t.Fatalf("SearchColumns() error = %v", err)
+	}
+	if result.Count != 1 || result.Columns[0].TableName != "users" {
+		t.Errorf("SearchColumns() = %+v", result)
+	}
+
+	empty, err := NewSchemaHandler(&MockSchemaDatabase{}, createTestConfig()).SearchColumns(context.Background(), "email")
+	if err != nil || empty.Columns == nil || empty.Count != 0 {
+		t.Errorf("Expected empty non-nil result, got %+v (error %v)", empty, err)
+	}
+
+	if _, err := handler.SearchColumns(context.Background(), ""); err == nil {
+		t.Error("Expected error for empty pattern")
+	}
+
+	failing := NewSchemaHandler(&MockSchemaDatabase{searchErr: errors.New("boom")}, createTestConfig())
+	if _, err := failing.SearchColumns(context.Background(), "email"); err == nil || !strings.Contains(err.Error(), "failed to search columns") {
+		t.Errorf("Expected wrapped database error, got %v", err)
+	}
+}
+
+func TestSchemaHandler_GetTableSize(t *testing.T) {
+	size := &database.TableSizeInfo{TableName: "users", DataSizeBytes: 8192, IndexSizeBytes: 4096, TotalSizeBytes: 16384, RowEstimate: 42}
+
+	handler := NewSchemaHandler(&MockSchemaDatabase{tables: []string{"users"}, tableSize: size}, createTestConfig())
+	result, err := handler.GetTableSize(context.Background(), "users")
+	if err != nil {
+		t.Fatalf("GetTableSize() error = %v", err)
+	}
+	if *result != *size {
+		t.Errorf("GetTableSize() = %+v, want %+v", result, size)
+	}
+
+	if _, err := handler.GetTableSize(context.Background(), "users; DROP TABLE users"); err == nil {
+		t.Error("Expected dangerous table name to be rejected")
+	}
+
+	failing := NewSchemaHandler(&MockSchemaDatabase{tables: []string{"missing"}, sizeErr: errors.New("table missing not found")}, createTestConfig())
+	if _, err := failing.GetTableSize(context.Background(), "missing"); err == nil || !strings.Contains(err.Error(), "failed to get size of table missing") {
+		t.Errorf("Expected wrapped error, got %v", err)
+	}
+}
+
+func TestSchemaHandler_GetDatabaseSize(t *testing.T) {
+	tables := []database.TableSizeInfo{
+		{TableName: "events", DataSizeBytes: 1000, IndexSizeBytes: 500, TotalSizeBytes: 1600, RowEstimate: 100},
+		{TableName: "users", DataSizeBytes: 200, IndexSizeBytes: 100, TotalSizeBytes: 300, RowEstimate: 10},
+	}
+
+	handler := NewSchemaHandler(&MockSchemaDatabase{tableSizes: tables}, createTestConfig())
+	result, err := handler.GetDatabaseSize(context.Background())
+	if err != nil {
+		t.Fatalf("GetDatabaseSize() error = %v", err)
+	}
+
+	if result.TableCount != 2 || result.DataSizeBytes != 1200 || result.IndexSizeBytes != 600 ||
+		result.TotalSizeBytes != 1900 || result.RowEstimate != 110 {
+		t.Errorf("GetDatabaseSize() totals = %+v", result)
+	}
+
+	empty, err := NewSchemaHandler(&MockSchemaDatabase{}, createTestConfig()).GetDatabaseSize(context.Background())
+	if err != nil || empty.Tables == nil || empty.TotalSizeBytes != 0 {
+		t.Errorf("Expected empty result with non-nil tables, got %+v (error %v)", empty, err)
+	}
+}
+
+// explainRecordingDatabase records whether ExplainQuery reached the database.
+type explainRecordingDatabase struct {
+	MockSchemaDatabase
+	explained []string
+}
+
+func (m *explainRecordingDatabase) ExplainQuery(ctx context.Context, query string) (string, error) {
+	m.explained = append(m.explained, query)
+	return `{"Plan": {}}`, nil
+}
+
+func TestSchemaHandler_ExplainQuery_SecurityValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		readOnly bool
+		wantErr  string
+	}{
+		{
+			name:    "disallowed database",
+			query:   "SELECT * FROM secretdb.users",
+			wantErr: "access denied: database 'secretdb'",
+		},
+		{
+			name:    "disallowed USE statement",
+			query:   "USE secretdb; SELECT * FROM users",
+			wantErr: "access denied",
+		},
+		{
+			name:    "blocked pattern",
+			query:   "SELECT LOAD_FILE('/etc/passwd')",
+			wantErr: "potentially dangerous",
+		},
+		{
+			name:     "mutating statement in read-only mode",
+			query:    "UPDATE users SET name = 'x'",
+			readOnly: true,
+			wantErr:  "read-only mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &explainRecordingDatabase{}
+			cfg := createTestConfig()
+			cfg.ReadOnly = tt.readOnly
+			handler := NewSchemaHandler(mockDB, cfg)
+
+			_, err := handler.ExplainQuery(context.Background(), tt.query, false)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ExplainQuery() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			if len(mockDB.explained) != 0 {
+				t.Errorf("Expected query to be rejected before reaching the database, explained %v", mockDB.explained)
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_ExplainQuery_AllowedDatabase(t *testing.T) {
+	mockDB := &explainRecordingDatabase{}
+	cfg := createTestConfig()
+	cfg.AllowedDatabases = []string{"reporting"}
+	handler := NewSchemaHandler(mockDB, cfg)
+
+	if _, err := handler.ExplainQuery(context.Background(), "SELECT * FROM reporting.events", false); err != nil {
+		t.Fatalf("ExplainQuery() error = %v", err)
+	}
+	if len(mockDB.explained) != 1 {
+		t.Errorf("Expected the query to be explained once, got %v", mockDB.explained)
+	}
+}
+
+func TestSchemaHandler_ExplainQuery_Analyze(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		readOnly    bool
+		wantErr     bool
+		wantWarning string
+	}{
+		{name: "select is analyzed", query: "SELECT * FROM users", wantWarning: "executed the query"},
+		{name: "CTE select is analyzed", query: "WITH recent AS (SELECT * FROM users) SELECT * FROM recent", wantWarning: "executed the query"},
+		{name: "update is analyzed and rolled back", query: "UPDATE users SET name = 'x'", wantWarning: "rolled it back"},
+		{name: "delete is analyzed and rolled back", query: "DELETE FROM users", wantWarning: "rolled it back"},
+		{name: "ddl is rejected", query: "CREATE INDEX idx ON users (name)", wantErr: true},
+		{name: "update is rejected in read-only mode", query: "UPDATE users SET name = 'x'", readOnly: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{
+				explainResult: "plain plan",
+				analyzeResult: "analyzed plan",
+			}
+			cfg := createTestConfig()
+			cfg.ReadOnly = tt.readOnly
+			handler := NewSchemaHandler(mockDB, cfg)
+
+			result, err := handler.ExplainQuery(context.Background(), tt.query, true)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExplainQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if result.Plan != "analyzed plan" {
+				t.Errorf("Expected the analyzed plan, got %q", result.Plan)
+			}
+			if !result.Analyzed {
+				t.Error("Expected result to be marked as analyzed")
+			}
+			if !strings.Contains(result.Warning, tt.wantWarning) {
+				t.Errorf("Expected warning containing %q, got %q", tt.wantWarning, result.Warning)
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_GetTableData_Filter(t *testing.T) {
+	schema := &database.TableSchema{
+		TableName: "users",
+		Columns: []database.ColumnInfo{
+			{Name: "id", Type: "integer"},
+			{Name: "status", Type: "text"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		filter      *database.TableFilter
+		describeErr error
+		wantErr     string
+	}{
+		{
+			name:   "known column",
+			filter: &database.TableFilter{Column: "status", Operator: "=", Value: "active"},
+		},
+		{
+			name:    "column not in schema",
+			filter:  &database.TableFilter{Column: "password", Operator: "=", Value: "x"},
+			wantErr: `filter column "password" does not exist in table users`,
+		},
+		{
+			name:    "injected column name",
+			filter:  &database.TableFilter{Column: "id = 1 OR 1", Operator: "=", Value: "1"},
+			wantErr: "does not exist in table users",
+		},
+		{
+			name:    "unsupported operator",
+			filter:  &database.TableFilter{Column: "id", Operator: ">=", Value: "1"},
+			wantErr: "unsupported filter operator",
+		},
+		{
+			name:        "describe failure",
+			filter:      &database.TableFilter{Column: "id", Operator: "IS NULL"},
+			describeErr: errors.New("table does not exist"),
+			wantErr:     "failed to describe table users",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{
+				tables:      []string{"users"},
+				tableSchema: schema,
+				describeErr: tt.describeErr,
+				tableData:   &database.TableData{TableName: "users", Rows: []map[string]any{}},
+			}
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+
+			_, err := handler.GetTableData(context.Background(), "users", 10, 0, database.TableDataOptions{Filter: tt.filter})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GetTableData() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				if mockDB.dataOptions != nil {
+					t.Error("Expected the filter to be rejected before querying table data")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetTableData() error = %v", err)
+			}
+			if mockDB.dataOptions == nil || mockDB.dataOptions.Filter != tt.filter {
+				t.Errorf("Expected filter %+v to be passed to the database, got %+v", tt.filter, mockDB.dataOptions)
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_GetTableData_EstimateCount(t *testing.T) {
+	mockDB := &MockSchemaDatabase{
+		tables:      []string{"users"},
+		tableSchema: &database.TableSchema{TableName: "users", Columns: []database.ColumnInfo{{Name: "id"}}},
+		tableData:   &database.TableData{TableName: "users", Rows: []map[string]any{}, Total: 5000000, CountIsEstimate: true},
+	}
+	handler := NewSchemaHandler(mockDB, createTestConfig())
+
+	result, err := handler.GetTableData(context.Background(), "users", 10, 0, database.TableDataOptions{EstimateCount: true})
+	if err != nil {
+		t.Fatalf("GetTableData() error = %v", err)
+	}
+	if mockDB.dataOptions == nil || !mockDB.dataOptions.EstimateCount {
+		t.Error("Expected estimateCount to be passed to the database")
+	}
+	if !result.Data.CountIsEstimate {
+		t.Error("Expected the result to report an estimated count")
+	}
+
+	filter := &database.TableFilter{Column: "id", Operator: "=", Value: "1"}
+	if _, err := handler.GetTableData(context.Background(), "users", 10, 0, database.TableDataOptions{Filter: filter, EstimateCount: true}); err == nil {
+		t.Error("Expected an error when combining an estimated count with a filter")
+	}
+}
+
+func TestSchemaHandler_GetTableData_Cursor(t *testing.T) {
+	keyed := &database.TableSchema{
+		TableName:  "users",
+		Columns:    []database.ColumnInfo{{Name: "id"}, {Name: "name"}},
+		PrimaryKey: []string{"id"},
+	}
+	unkeyed := &database.TableSchema{TableName: "users", Columns: []database.ColumnInfo{{Name: "id"}}}
+	cursor, _ := database.EncodeCursor([]any{int64(42)})
+
+	tests := []struct {
+		name           string
+		schema         *database.TableSchema
+		offset         int
+		opts           database.TableDataOptions
+		wantErr        string
+		wantKeyColumns []string
+	}{
+		{
+			name:           "first page is ordered by the primary key",
+			schema:         keyed,
+			wantKeyColumns: []string{"id"},
+		},
+		{
+			name:           "cursor continues by primary key",
+			schema:         keyed,
+			opts:           database.TableDataOptions{Cursor: cursor},
+			wantKeyColumns: []string{"id"},
+		},
+		{
+			name:   "explicit order disables cursor pagination",
+			schema: keyed,
+			opts:   database.TableDataOptions{OrderBy: "name"},
+		},
+		{
+			name:   "table without primary key",
+			schema: unkeyed,
+		},
+		{
+			name:    "cursor on table without primary key",
+			schema:  unkeyed,
+			opts:    database.TableDataOptions{Cursor: cursor},
+			wantErr: "requires table users to have a primary key",
+		},
+		{
+			name:    "cursor with order by",
+			schema:  keyed,
+			opts:    database.TableDataOptions{Cursor: cursor, OrderBy: "name"},
+			wantErr: "cannot be combined with an order by column",
+		},
+		{
+			name:    "cursor with offset",
+			schema:  keyed,
+			offset:  10,
+			opts:    database.TableDataOptions{Cursor: cursor},
+			wantErr: "cannot be combined with an offset",
+		},
+		{
+			name:    "malformed cursor",
+			schema:  keyed,
+			opts:    database.TableDataOptions{Cursor: "not-a-cursor"},
+			wantErr: "invalid cursor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{
+				tables:      []string{"users"},
+				tableSchema: tt.schema,
+				tableData:   &database.TableData{TableName: "users", Rows: []map[string]any{}},
+			}
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+
+			_, err := handler.GetTableData(context.Background(), "users", 10, tt.offset, tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GetTableData() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				if mockDB.dataOptions != nil {
+					t.Error("Expected the cursor to be rejected before querying table data")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetTableData() error = %v", err)
+			}
+			if got := mockDB.dataOptions.KeyColumns; !reflect.DeepEqual(got, tt.wantKeyColumns) {
+				t.Errorf("KeyColumns = %v, want %v", got, tt.wantKeyColumns)
+			}
+			if mockDB.dataOptions.Cursor != tt.opts.Cursor {
+				t.Errorf("Cursor = %q, want %q", mockDB.dataOptions.Cursor, tt.opts.Cursor)
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_GetTableData_Columns(t *testing.T) {
+	schema := &database.TableSchema{
+		TableName: "users",
+		Columns: []database.ColumnInfo{
+			{Name: "id"},
+			{Name: "name"},
+			{Name: "email"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		columns []string
+		wantErr string
+	}{
+		{name: "existing columns", columns: []string{"email", "id"}},
+		{name: "one missing column", columns: []string{"id", "password"}, wantErr: "columns do not exist in table users: password"},
+		{name: "all missing columns listed", columns: []string{"ssn", "id", "password"}, wantErr: "columns do not exist in table users: ssn, password"},
+		{name: "injected column", columns: []string{"id FROM users; --"}, wantErr: "columns do not exist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{
+				tables:      []string{"users"},
+				tableSchema: schema,
+				tableData:   &database.TableData{TableName: "users", Columns: tt.columns, Rows: []map[string]any{}},
+			}
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+
+			result, err := handler.GetTableData(context.Background(), "users", 10, 0, database.TableDataOptions{Columns: tt.columns})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GetTableData() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				if mockDB.dataOptions != nil {
+					t.Error("Expected missing columns to be rejected before querying table data")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetTableData() error = %v", err)
+			}
+			if mockDB.dataOptions == nil || !reflect.DeepEqual(mockDB.dataOptions.Columns, tt.columns) {
+				t.Errorf("Expected columns %v to be passed to the database, got %+v", tt.columns, mockDB.dataOptions)
+			}
+			if !reflect.DeepEqual(result.Data.Columns, tt.columns) {
+				t.Errorf("Expected result columns %v, got %v", tt.columns, result.Data.Columns)
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_GetTableData_OrderBy(t *testing.T) {
+	schema := &database.TableSchema{
+		TableName: "users",
+		Columns:   []database.ColumnInfo{{Name: "id"}, {Name: "created_at"}},
+	}
+
+	tests := []struct {
+		name     string
+		orderBy  string
+		orderDir string
+		wantErr  string
+	}{
+		{name: "known column", orderBy: "created_at"},
+		{name: "known column descending", orderBy: "id", orderDir: "desc"},
+		{name: "unknown column", orderBy: "password", wantErr: `order by column "password" does not exist in table users`},
+		{name: "injected column", orderBy: "id; DROP TABLE users", wantErr: "does not exist in table users"},
+		{name: "invalid direction", orderBy: "id", orderDir: "sideways", wantErr: "unsupported order direction"},
+		{name: "direction without column", orderDir: "DESC", wantErr: "requires an order by column"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{
+				tables:      []string{"users"},
+				tableSchema: schema,
+				tableData:   &database.TableData{TableName: "users", Rows: []map[string]any{}},
+			}
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+
+			opts := database.TableDataOptions{OrderBy: tt.orderBy, OrderDir: tt.orderDir}
+			_, err := handler.GetTableData(context.Background(), "users", 10, 0, opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GetTableData() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				if mockDB.dataOptions != nil {
+					t.Error("Expected the ordering to be rejected before querying table data")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetTableData() error = %v", err)
+			}
+			if mockDB.dataOptions == nil || mockDB.dataOptions.OrderBy != tt.orderBy {
+				t.Errorf("Expected order by %q to be passed to the database, got %+v", tt.orderBy, mockDB.dataOptions)
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_RequireTable(t *testing.T) {
+	schema := &database.TableSchema{
+		TableName: "users",
+		Columns:   []database.ColumnInfo{{Name: "id", Type: "integer"}},
+	}
+
+	tests := []struct {
+		name      string
+		tableName string
+		listErr   error
+		wantErr   string
+	}{
+		{name: "existing table", tableName: "users"},
+		{name: "existing view", tableName: "active_users"},
+		{name: "unknown table", tableName: "orders", wantErr: "table not found: orders"},
+		{name: "names are case sensitive", tableName: "Users", wantErr: "table not found: Users"},
+		{name: "injected table name", tableName: `users"; DROP TABLE users; --`, wantErr: "table not found"},
+		{name: "listing fails", tableName: "users", listErr: errors.New("connection reset"), wantErr: "failed to list tables: connection reset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{
+				tables:        []string{"users"},
+				views:         []string{"active_users"},
+				listTablesErr: tt.listErr,
+				tableSchema:   schema,
+				tableData:     &database.TableData{TableName: tt.tableName, Rows: []map[string]any{}},
+			}
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+
+			_, err := handler.GetTableData(context.Background(), tt.tableName, 10, 0, database.TableDataOptions{})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GetTableData() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				if mockDB.dataOptions != nil {
+					t.Error("GetTableData() queried the database for a table that does not exist")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetTableData() unexpected error = %v", err)
+			}
+		})
+	}
+
+	t.Run("tables listed once per handler", func(t *testing.T) {
+		mockDB := &MockSchemaDatabase{tables: []string{"users"}, tableSchema: schema}
+		handler := NewSchemaHandler(mockDB, createTestConfig())
+
+		for range 3 {
+			if _, err := handler.DescribeTable(context.Background(), "users"); err != nil {
+				t.Fatalf("DescribeTable() error = %v", err)
+			}
+		}
+		if _, err := handler.DescribeTable(context.Background(), "missing"); err == nil {
+			t.Error("Expected error for missing table")
+		}
+		if mockDB.listCalls != 1 {
+			t.Errorf("ListTables called %d times, want 1", mockDB.listCalls)
+		}
+	})
+}
+
+func TestSchemaHandler_GetColumnValues(t *testing.T) {
+	schema := &database.TableSchema{
+		TableName: "orders",
+		Columns:   []database.ColumnInfo{{Name: "id", Type: "integer"}, {Name: "status", Type: "text"}},
+	}
+	values := &database.ColumnValues{
+		TableName:     "orders",
+		ColumnName:    "status",
+		Values:        []any{"open", "shipped"},
+		DistinctCount: 5,
+		Truncated:     true,
+	}
+
+	tests := []struct {
+		name      string
+		tableName string
+		column    string
+		limit     int
+		valuesErr error
+		wantLimit int
+		wantErr   string
+	}{
+		{name: "default limit", tableName: "orders", column: "status", wantLimit: 100},
+		{name: "explicit limit", tableName: "orders", column: "status", limit: 2, wantLimit: 2},
+		{name: "limit capped", tableName: "orders", column: "status", limit: 5000, wantLimit: 1000},
+		{name: "negative limit", tableName: "orders", column: "status", limit: -1, wantErr: "limit cannot be negative"},
+		{name: "empty column", tableName: "orders", column: " ", wantErr: "column name cannot be empty"},
+		{name: "unknown table", tableName: "invoices", column: "status", wantErr: "table not found: invoices"},
+		{name: "unknown column", tableName: "orders", column: "state", wantErr: "columns do not exist in table orders: state"},
+		{name: "injected column", tableName: "orders", column: `status" FROM users; --`, wantErr: "columns do not exist"},
+		{name: "database error", tableName: "orders", column: "status", valuesErr: errors.New("boom"), wantErr: "failed to get values of orders.status: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{
+				tables:       []string{"orders"},
+				tableSchema:  schema,
+				columnValues: values,
+				valuesErr:    tt.valuesErr,
+			}
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+
+			result, err := handler.GetColumnValues(context.Background(), tt.tableName, tt.column, tt.limit)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GetColumnValues() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetColumnValues() unexpected error = %v", err)
+			}
+
+			if mockDB.valuesLimit != tt.wantLimit {
+				t.Errorf("GetColumnValues() passed limit %d, want %d", mockDB.valuesLimit, tt.wantLimit)
+			}
+			if result.Count != 2 || !result.Values.Truncated || result.Values.DistinctCount != 5 {
+				t.Errorf("GetColumnValues() = %+v, want 2 of 5 values, truncated", result.Values)
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_ListFunctions(t *testing.T) {
+	functions := []database.FunctionInfo{
+		{Name: "add_tax", Type: "function", Language: "plpgsql", ReturnType: "numeric", ArgumentTypes: []string{"numeric"}},
+		{Name: "refresh_stats", Type: "procedure", Language: "sql", ArgumentTypes: []string{}},
+	}
+
+	handler := NewSchemaHandler(&MockSchemaDatabase{functions: functions}, createTestConfig())
+	result, err := handler.ListFunctions(context.Background())
+	if err != nil {
+		t.Fatalf("ListFunctions() error = %v", err)
+	}
+	if result.Count != 2 || !reflect.DeepEqual(result.Functions, functions) {
+		t.Errorf("ListFunctions() = %+v, want %+v", result, functions)
+	}
+
+	empty, err := NewSchemaHandler(&MockSchemaDatabase{}, createTestConfig()).ListFunctions(context.Background())
+	if err != nil {
+		t.Fatalf("ListFunctions() error = %v", err)
+	}
+	if empty.Functions == nil || empty.Count != 0 {
+		t.Errorf("Expected an empty, non-nil list, got %+v", empty)
+	}
+
+	failing := NewSchemaHandler(&MockSchemaDatabase{functionErr: errors.New("permission denied")}, createTestConfig())
+	if _, err := failing.ListFunctions(context.Background()); err == nil || !strings.Contains(err.Error(), "failed to list functions") {
+		t.Errorf("Expected wrapped error, got %v", err)
+	}
+}
+
+func TestSchemaHandler_DescribeFunction(t *testing.T) {
+	schema := &database.FunctionSchema{
+		FunctionInfo: database.FunctionInfo{Name: "add_tax", Type: "function", Language: "plpgsql", ReturnType: "numeric", ArgumentTypes: []string{"numeric"}},
+		Arguments:    []database.FunctionArgument{{Name: "amount", Type: "numeric", Mode: "IN"}},
+		Definition:   "BEGIN RETURN amount * 1.2; END;",
+	}
+
+	tests := []struct {
+		name    string
+		fnName  string
+		err     error
+		wantErr string
+	}{
+		{name: "described", fnName: "add_tax"},
+		{name: "empty name", fnName: " ", wantErr: "function name cannot be empty"},
+		{name: "not found", fnName: "missing", err: errors.New("function missing not found"), wantErr: "failed to describe function missing: function missing not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewSchemaHandler(&MockSchemaDatabase{functionInfo: schema, functionErr: tt.err}, createTestConfig())
+
+			result, err := handler.DescribeFunction(context.Background(), tt.fnName)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("DescribeFunction() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DescribeFunction() unexpected error = %v", err)
+			}
+			if result.Schema != schema {
+				t.Errorf("DescribeFunction() = %+v, want %+v", result.Schema, schema)
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_GetConstraints(t *testing.T) {
+	constraints := []database.ConstraintInfo{
+		{Name: "orders_pkey", Type: database.ConstraintPrimaryKey, Columns: []string{"id"}},
+		{Name: "orders_amount_check", Type: database.ConstraintCheck, Columns: []string{"amount"}, CheckClause: "(amount > 0)"},
+	}
+
+	handler := NewSchemaHandler(&MockSchemaDatabase{tables: []string{"orders"}, constraints: constraints}, createTestConfig())
+	result, err := handler.GetConstraints(context.Background(), "orders")
+	if err != nil {
+		t.Fatalf("GetConstraints() error = %v", err)
+	}
+	if result.TableName != "orders" || result.Count != 2 || !reflect.DeepEqual(result.Constraints, constraints) {
+		t.Errorf("GetConstraints() = %+v", result)
+	}
+
+	if _, err := handler.GetConstraints(context.Background(), "orders; DROP TABLE orders"); err == nil {
+		t.Error("Expected dangerous table name to be rejected")
+	}
+
+	empty := NewSchemaHandler(&MockSchemaDatabase{tables: []string{"logs"}}, createTestConfig())
+	if result, err := empty.GetConstraints(context.Background(), "logs"); err != nil || result.Constraints == nil || result.Count != 0 {
+		t.Errorf("Expected an empty constraint list, got %+v, %v", result, err)
+	}
+
+	failing := NewSchemaHandler(&MockSchemaDatabase{tables: []string{"orders"}, constraintErr: errors.New("boom")}, createTestConfig())
+	if _, err := failing.GetConstraints(context.Background(), "orders"); err == nil || !strings.Contains(err.Error(), "failed to get constraints of table orders") {
+		t.Errorf("Expected wrapped error, got %v", err)
+	}
+}
+
+func TestSchemaHandler_GetDatabaseSchema(t *testing.T) {
+	schema := &database.TableSchema{
+		TableName: "users",
+		Columns:   []database.ColumnInfo{{Name: "id", Type: "integer"}},
+	}
+
+	mock := &MockSchemaDatabase{tables: []string{"users", "orders"}, tableSchema: schema}
+	result, err := NewSchemaHandler(mock, createTestConfig()).GetDatabaseSchema(context.Background())
+	if err != nil {
+		t.Fatalf("GetDatabaseSchema() error = %v", err)
+	}
+	if result.Count != 2 || len(result.Tables) != 2 || result.Tables[0] != schema {
+		t.Errorf("GetDatabaseSchema() = %+v", result)
+	}
+
+	empty := NewSchemaHandler(&MockSchemaDatabase{}, createTestConfig())
+	if result, err := empty.GetDatabaseSchema(context.Background()); err != nil || result.Tables == nil || result.Count != 0 {
+		t.Errorf("Expected an empty schema, got %+v, %v", result, err)
+	}
+
+	failing := NewSchemaHandler(&MockSchemaDatabase{tables: []string{"users"}, describeErr: errors.New("boom")}, createTestConfig())
+	if _, err := failing.GetDatabaseSchema(context.Background()); err == nil || !strings.Contains(err.Error(), "failed to describe table users") {
+		t.Errorf("Expected wrapped error, got %v", err)
+	}
+}
+
+func TestSchemaHandler_ProfileTable(t *testing.T) {
+	profile := &database.TableProfile{TableName: "orders", RowCount: 2, Columns: []database.ColumnProfile{{Name: "id"}}}
+
+	tests := []struct {
+		name              string
+		tableName         string
+		maxDistinctValues int
+		profileErr        error
+		wantLimit         int
+		wantErr           string
+	}{
+		{name: "default limit", tableName: "orders", wantLimit: 20},
+		{name: "explicit limit", tableName: "orders", maxDistinctValues: 5, wantLimit: 5},
+		{name: "limit capped", tableName: "orders", maxDistinctValues: 500, wantLimit: 100},
+		{name: "negative limit", tableName: "orders", maxDistinctValues: -1, wantErr: "max_distinct_values cannot be negative"},
+		{name: "table too large", tableName: "orders", profileErr: errors.New("table orders has more than 500000 rows"), wantErr: "failed to profile table orders"},
+		{name: "dangerous table name", tableName: "orders; DROP TABLE orders", wantErr: "potentially dangerous"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockSchemaDatabase{tables: []string{tt.tableName}, profile: profile, profileErr: tt.profileErr}
+			handler := NewSchemaHandler(mockDB, createTestConfig())
+
+			result, err := handler.ProfileTable(context.Background(), tt.tableName, tt.maxDistinctValues)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ProfileTable() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ProfileTable() unexpected error = %v", err)
+			}
+
+			if result != profile {
+				t.Errorf("ProfileTable() = %+v, want the database profile", result)
+			}
+			if mockDB.profileLimit != tt.wantLimit {
+				t.Errorf("Expected %d distinct values to be requested, got %d", tt.wantLimit, mockDB.profileLimit)
+			}
+		})
+	}
+}
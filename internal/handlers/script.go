@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+	"github.com/jhoffmann/go-database-mcp/internal/security"
+)
+
+// ScriptResult represents the outcome of a script executed by ExecuteScript.
+type ScriptResult struct {
+	StatementCount int                     `json:"statement_count"` // Number of statements executed
+	RowsAffected   int64                   `json:"rows_affected"`   // Total rows affected across all statements
+	Statements     []ScriptStatementResult `json:"statements"`      // Per-statement results, in execution order
+	ExecutionTime  string                  `json:"execution_time"`  // Time taken to run and commit the script
+}
+
+// ScriptStatementResult describes one statement of an executed script.
+type ScriptStatementResult struct {
+	Index        int    `json:"index"`         // 1-based position of the statement in the script
+	Type         string `json:"type"`          // Statement type: select, insert, update, delete, ddl
+	RowsAffected int64  `json:"rows_affected"` // Rows affected by the statement
+}
+
+// ScriptError reports the statement that caused a script to fail. The transaction
+// has been rolled back, so none of the script's statements took effect.
+type ScriptError struct {
+	Index     int    // 1-based position of the failing statement
+	Statement string // The failing statement
+	Err       error  // Underlying validation or execution error
+}
+
+// Error implements the error interface.
+func (e *ScriptError) Error() string {
+	return fmt.Sprintf("statement %d failed, transaction rolled back: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ScriptError) Unwrap() error {
+	return e.Err
+}
+
+// ExecuteScript splits a semicolon-separated script into statements and runs them in order
+// inside a single transaction, committing only if every statement succeeds. Every statement
+// is validated before the transaction starts, so a script containing a disallowed statement
+// never runs at all. Configured row filters and the statement prefix are applied to each
+// statement, as for single queries. The configured query timeout applies to the script as
+// a whole. Failures are reported as a *ScriptError identifying the failing statement. When
+// an audit logger is configured, every executed statement is recorded.
+func (h *QueryHandler) ExecuteScript(ctx context.Context, script string) (*ScriptResult, error) {
+	statements := security.SplitStatements(script, h.db.GetDriverName())
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("script contains no statements")
+	}
+
+	types := make([]string, len(statements))
+	for i, statement := range statements {
+		if err := h.validator.ValidateQuery(statement); err != nil {
+			return nil, &ScriptError{Index: i + 1, Statement: statement, Err: h.validator.SanitizeErrorMessage(err)}
+		}
+		types[i] = h.determineQueryType(statement)
+		if err := h.validator.ValidateQueryType(types[i]); err != nil {
+			return nil, &ScriptError{Index: i + 1, Statement: statement, Err: err}
+		}
+	}
+
+	start := time.Now()
+	queryCtx, cancel := withQueryTimeout(ctx, h.timeout)
+	defer cancel()
+
+	tx, err := h.db.BeginTx(queryCtx, nil)
+	if err != nil {
+		return nil, describeContextError(ctx, queryCtx, h.timeout, err)
+	}
+	defer tx.Rollback()
+
+	result := &ScriptResult{Statements: make([]ScriptStatementResult, 0, len(statements))}
+	for i, statement := range statements {
+		statementStart := time.Now()
+		prepared, args, err := database.PrepareStatement(h.dbConfig, statement, nil)
+		if err != nil {
+			return nil, &ScriptError{Index: i + 1, Statement: statement, Err: err}
+		}
+		execResult, err := tx.ExecContext(queryCtx, prepared, args...)
+		if err != nil {
+			err = describeContextError(ctx, queryCtx, h.timeout, err)
+			h.recordExecution(statement, 0, nil, err, time.Since(statementStart))
+			return nil, &ScriptError{Index: i + 1, Statement: statement, Err: err}
+		}
+
+		// Some drivers cannot report affected rows for DDL; treat that as zero
+		rowsAffected, err := execResult.RowsAffected()
+		if err != nil {
+			rowsAffected = 0
+		}
+		h.recordExecution(statement, 0, &QueryResult{RowCount: int(rowsAffected)}, nil, time.Since(statementStart))
+
+		result.Statements = append(result.Statements, ScriptStatementResult{
+			Index:        i + 1,
+			Type:         types[i],
+			RowsAffected: rowsAffected,
+		})
+		result.RowsAffected += rowsAffected
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit script: %w", describeContextError(ctx, queryCtx, h.timeout, err))
+	}
+
+	result.StatementCount = len(statements)
+	result.ExecutionTime = time.Since(start).String()
+	return result, nil
+}
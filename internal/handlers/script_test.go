@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestQueryHandler_ExecuteScript(t *testing.T) {
+	set := &mockResultSet{}
+	mockDB := &MockDatabase{driver: "postgres", sqlDB: newMockSQLDB(t, set)}
+	handler := NewQueryHandler(mockDB, createTestConfig())
+
+	script := `CREATE TABLE audit (id int);
+CREATE FUNCTION log_change() RETURNS trigger AS $$
+BEGIN
+  INSERT INTO audit VALUES (1);
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+INSERT INTO audit VALUES (2);`
+
+	result, err := handler.ExecuteScript(context.Background(), script)
+	if err != nil {
+		t.Fatalf("ExecuteScript() error = %v", err)
+	}
+
+	if result.StatementCount != 3 || len(result.Statements) != 3 {
+		t.Fatalf("Expected 3 statements, got %+v", result)
+	}
+	queries := set.Queries()
+	if len(queries) != 3 || !strings.HasPrefix(queries[1], "CREATE FUNCTION") || !strings.HasSuffix(queries[1], "LANGUAGE plpgsql") {
+		t.Errorf("Expected the function body to be executed as one statement, got %q", queries)
+	}
+	if result.Statements[2].Type != "insert" || result.Statements[2].Index != 3 {
+		t.Errorf("Unexpected result for the last statement: %+v", result.Statements[2])
+	}
+
+	commits, rollbacks := set.Transactions()
+	if commits != 1 || rollbacks != 0 {
+		t.Errorf("Expected 1 commit and no rollbacks, got %d commits and %d rollbacks", commits, rollbacks)
+	}
+}
+
+func TestQueryHandler_ExecuteScript_RollbackOnFailure(t *testing.T) {
+	set := &mockResultSet{failExec: "broken"}
+	mockDB := &MockDatabase{driver: "postgres", sqlDB: newMockSQLDB(t, set)}
+	handler := NewQueryHandler(mockDB, createTestConfig())
+
+	script := "INSERT INTO a VALUES (1); UPDATE broken SET x = 1; INSERT INTO a VALUES (2)"
+	_, err := handler.ExecuteScript(context.Background(), script)
+
+	var scriptErr *ScriptError
+	if !errors.As(err, &scriptErr) {
+		t.Fatalf("Expected a *ScriptError, got %v", err)
+	}
+	if scriptErr.Index != 2 || scriptErr.Statement != "UPDATE broken SET x = 1" {
+		t.Errorf("Expected statement 2 to be reported, got %d: %q", scriptErr.Index, scriptErr.Statement)
+	}
+	if !strings.Contains(err.Error(), "statement 2 failed, transaction rolled back") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+
+	if queries := set.Queries(); len(queries) != 2 {
+		t.Errorf("Expected execution to stop at the failing statement, got %q", queries)
+	}
+	commits, rollbacks := set.Transactions()
+	if commits != 0 || rollbacks != 1 {
+		t.Errorf("Expected a rollback and no commit, got %d commits and %d rollbacks", commits, rollbacks)
+	}
+}
+
+func TestQueryHandler_ExecuteScript_Validation(t *testing.T) {
+	tests := []struct {
+		name      string
+		script    string
+		readOnly  bool
+		wantIndex int
+		wantErr   string
+	}{
+		{name: "empty script", script: " ; ;", wantErr: "script contains no statements"},
+		{name: "blocked pattern", script: "SELECT 1; SELECT LOAD_FILE('/etc/passwd')", wantIndex: 2, wantErr: "potentially dangerous"},
+		{name: "disallowed database", script: "INSERT INTO a VALUES (1); DELETE FROM secretdb.users", wantIndex: 2, wantErr: "access denied"},
+		{name: "read-only mode", script: "SELECT 1; INSERT INTO a VALUES (1)", readOnly: true, wantIndex: 2, wantErr: "read-only mode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &mockResultSet{}
+			mockDB := &MockDatabase{driver: "postgres", sqlDB: newMockSQLDB(t, set)}
+			cfg := createTestConfig()
+			cfg.ReadOnly = tt.readOnly
+			handler := NewQueryHandler(mockDB, cfg)
+
+			_, err := handler.ExecuteScript(context.Background(), tt.script)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ExecuteScript() error = %v, want error containing %q", err, tt.wantErr)
+			}
+
+			var scriptErr *ScriptError
+			if tt.wantIndex > 0 && (!errors.As(err, &scriptErr) || scriptErr.Index != tt.wantIndex) {
+				t.Errorf("Expected statement %d to be reported, got %v", tt.wantIndex, err)
+			}
+
+			// Validation happens before the transaction starts, so nothing runs
+			if queries := set.Queries(); len(queries) != 0 {
+				t.Errorf("Expected no statements to run, got %q", queries)
+			}
+		})
+	}
+}
+
+func TestQueryHandler_ExecuteScript_RowFilters(t *testing.T) {
+	set := &mockResultSet{}
+	mockDB := &MockDatabase{driver: "postgres", sqlDB: newMockSQLDB(t, set)}
+	cfg := createTestConfig()
+	cfg.RowFilters = "orders: tenant_id = :tenant"
+	cfg.RowFilterParams = "tenant=42"
+	handler := NewQueryHandler(mockDB, cfg)
+
+	if _, err := handler.ExecuteScript(context.Background(), "UPDATE orders SET status = 'shipped'; DELETE FROM users WHERE id = 1"); err != nil {
+		t.Fatalf("ExecuteScript() error = %v", err)
+	}
+
+	want := []string{"UPDATE orders SET status = 'shipped' WHERE tenant_id = $1", "DELETE FROM users WHERE id = 1"}
+	if queries := set.Queries(); len(queries) != 2 || queries[0] != want[0] || queries[1] != want[1] {
+		t.Errorf("Expected queries %q, got %q", want, queries)
+	}
+
+	// Without the parameter, the script is rejected rather than run unfiltered
+	set = &mockResultSet{}
+	cfg.RowFilterParams = ""
+	handler = NewQueryHandler(&MockDatabase{driver: "postgres", sqlDB: newMockSQLDB(t, set)}, cfg)
+	if _, err := handler.ExecuteScript(context.Background(), "DELETE FROM orders"); err == nil || !strings.Contains(err.Error(), "tenant") {
+		t.Errorf("Expected a missing parameter error, got %v", err)
+	}
+}
+
+func TestQueryHandler_ExecuteScript_StatementPrefix(t *testing.T) {
+	set := &mockResultSet{}
+	mockDB := &MockDatabase{driver: "postgres", sqlDB: newMockSQLDB(t, set)}
+	cfg := createTestConfig()
+	cfg.StatementPrefix = "service=mcp"
+	handler := NewQueryHandler(mockDB, cfg)
+
+	if _, err := handler.ExecuteScript(context.Background(), "INSERT INTO a VALUES (1); DELETE FROM a"); err != nil {
+		t.Fatalf("ExecuteScript() error = %v", err)
+	}
+
+	want := []string{"/* service=mcp */ INSERT INTO a VALUES (1)", "/* service=mcp */ DELETE FROM a"}
+	if queries := set.Queries(); !slices.Equal(queries, want) {
+		t.Errorf("Expected queries %q, got %q", want, queries)
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+)
+
+// SessionStats accumulates execution counters across every query run by the server process.
+// It is safe for concurrent use by multiple goroutines.
+type SessionStats struct {
+	mu      sync.Mutex
+	started time.Time
+	queries int64
+	byType  map[string]int64
+	rows    int64
+	errors  int64
+	elapsed time.Duration
+}
+
+// SessionStatsSummary is a snapshot of SessionStats.
+type SessionStatsSummary struct {
+	Since           time.Time        `json:"since"`             // When counting started (server start or last reset)
+	TotalQueries    int64            `json:"total_queries"`     // Number of executed statements, including failed ones
+	QueriesByType   map[string]int64 `json:"queries_by_type"`   // Statement count by type: select, insert, update, delete, ddl
+	TotalRows       int64            `json:"total_rows"`        // Rows returned (SELECT) or affected (other statements)
+	ErrorCount      int64            `json:"error_count"`       // Number of failed statements
+	TotalDuration   string           `json:"total_duration"`    // Cumulative execution time
+	TotalDurationMS int64            `json:"total_duration_ms"` // Cumulative execution time in milliseconds
+}
+
+// NewSessionStats creates empty session statistics.
+func NewSessionStats() *SessionStats {
+	return &SessionStats{started: time.Now(), byType: make(map[string]int64)}
+}
+
+// Record adds one executed statement of the given type to the counters.
+func (s *SessionStats) Record(queryType string, rows int64, execErr error, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queries++
+	s.byType[queryType]++
+	s.rows += rows
+	if execErr != nil {
+		s.errors++
+	}
+	s.elapsed += duration
+}
+
+// Snapshot returns the current counters.
+func (s *SessionStats) Snapshot() SessionStatsSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byType := make(map[string]int64, len(s.byType))
+	for queryType, count := range s.byType {
+		byType[queryType] = count
+	}
+	return SessionStatsSummary{
+		Since:           s.started,
+		TotalQueries:    s.queries,
+		QueriesByType:   byType,
+		TotalRows:       s.rows,
+		ErrorCount:      s.errors,
+		TotalDuration:   s.elapsed.String(),
+		TotalDurationMS: s.elapsed.Milliseconds(),
+	}
+}
+
+// Reset clears the counters and restarts counting from now.
+func (s *SessionStats) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.started = time.Now()
+	s.queries = 0
+	s.byType = make(map[string]int64)
+	s.rows = 0
+	s.errors = 0
+	s.elapsed = 0
+}
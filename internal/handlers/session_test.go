@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestQueryHandler_SessionStats(t *testing.T) {
+	mockDB := newSelectMock(t, "postgres", &mockResultSet{
+		columns: []string{"id"},
+		types:   []string{"INT8"},
+		rows:    [][]driver.Value{{int64(1)}, {int64(2)}, {int64(3)}},
+	})
+	stats := NewSessionStats()
+	handler := NewQueryHandler(mockDB, createTestConfig())
+	handler.SetSessionStats(stats)
+
+	queries := []struct {
+		query   string
+		wantErr bool
+	}{
+		{query: "SELECT id FROM users"},
+		{query: "SELECT id FROM users WHERE id > 0"},
+		{query: "UPDATE users SET active = true"},                // The mock reports one affected row
+		{query: "DELETE FROM users WHERE id = 1"},                // The mock reports one affected row
+		{query: "SELECT * FROM users -- comment", wantErr: true}, // Rejected by the validator
+	}
+	for _, q := range queries {
+		if _, err := handler.ExecuteQuery(context.Background(), q.query); (err != nil) != q.wantErr {
+			t.Fatalf("ExecuteQuery(%q) error = %v, wantErr %v", q.query, err, q.wantErr)
+		}
+	}
+
+	summary := stats.Snapshot()
+	if summary.TotalQueries != 5 {
+		t.Errorf("Expected 5 queries, got %d", summary.TotalQueries)
+	}
+	wantTypes := map[string]int64{"select": 3, "update": 1, "delete": 1}
+	if !reflect.DeepEqual(summary.QueriesByType, wantTypes) {
+		t.Errorf("Expected queries by type %v, got %v", wantTypes, summary.QueriesByType)
+	}
+	if summary.TotalRows != 8 {
+		t.Errorf("Expected 8 rows (3 + 3 returned, 1 + 1 affected), got %d", summary.TotalRows)
+	}
+	if summary.ErrorCount != 1 {
+		t.Errorf("Expected 1 error, got %d", summary.ErrorCount)
+	}
+	if summary.TotalDuration == "" {
+		t.Error("Expected total duration to be set")
+	}
+
+	// A second handler sharing the stats adds to the same counters
+	other := NewQueryHandler(mockDB, createTestConfig())
+	other.SetSessionStats(stats)
+	if _, err := other.ExecuteQuery(context.Background(), "SELECT id FROM users"); err != nil {
+		t.Fatalf("ExecuteQuery() error = %v", err)
+	}
+	if got := stats.Snapshot().QueriesByType["select"]; got != 4 {
+		t.Errorf("Expected 4 selects across handlers, got %d", got)
+	}
+
+	stats.Reset()
+	summary = stats.Snapshot()
+	if summary.TotalQueries != 0 || summary.TotalRows != 0 || summary.ErrorCount != 0 || len(summary.QueriesByType) != 0 {
+		t.Errorf("Expected counters to be cleared after reset, got %+v", summary)
+	}
+}
+
+func TestSessionStats_ConcurrentRecords(t *testing.T) {
+	stats := NewSessionStats()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stats.Record("select", 2, nil, 0)
+		}()
+	}
+	wg.Wait()
+
+	if summary := stats.Snapshot(); summary.TotalQueries != 50 || summary.TotalRows != 100 {
+		t.Errorf("Expected 50 queries and 100 rows, got %+v", summary)
+	}
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RowStream iterates over the rows of a SELECT one at a time, so Go callers can process
+// results of any size without holding them in memory. Call Next to advance, then Row or
+// Scan to read the current row. The underlying rows are closed when Next returns false;
+// call Close to stop early.
+type RowStream struct {
+	handler     *QueryHandler
+	rows        *sql.Rows
+	columns     []string
+	columnTypes []*sql.ColumnType
+
+	ctx      context.Context    // Caller's context, to tell cancellation from the timeout
+	queryCtx context.Context    // Context the query runs under
+	cancel   context.CancelFunc // Releases queryCtx
+
+	query     string
+	argsCount int
+	start     time.Time
+
+	count     int   // Rows returned by Next so far
+	truncated bool  // Whether iteration stopped at the row cap
+	err       error // First iteration error
+	closed    bool
+}
+
+// Stream validates and runs a SELECT query and returns a RowStream over its rows. Values
+// read with Row are converted as in ExecuteQuery, and the stream stops after the
+// DB_MAX_RESULT_ROWS cap, which Truncated reports. Pagination settings do not apply.
+// The configured query timeout covers the whole iteration. With an audit logger or session
+// stats, the execution is recorded when the stream is closed, with the number of rows read.
+func (h *QueryHandler) Stream(ctx context.Context, query string, args ...any) (*RowStream, error) {
+	start := time.Now()
+	stream, err := h.openStream(ctx, query, args...)
+	if err != nil {
+		h.recordExecution(query, len(args), nil, err, time.Since(start))
+		return nil, err
+	}
+	stream.start = start
+	return stream, nil
+}
+
+// openStream validates query and starts it, returning the stream over its rows.
+func (h *QueryHandler) openStream(ctx context.Context, query string, args ...any) (*RowStream, error) {
+	if err := h.validator.ValidateQuery(query); err != nil {
+		return nil, h.validator.SanitizeErrorMessage(err)
+	}
+
+	trimmedQuery := strings.TrimSpace(query)
+	if trimmedQuery == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+	if queryType := h.determineQueryType(trimmedQuery); queryType != "select" {
+		return nil, fmt.Errorf("only SELECT queries can be streamed, got %s", queryType)
+	}
+
+	// The timeout is released when the stream is closed, not when this function returns
+	queryCtx, cancel := withQueryTimeout(ctx, h.timeout)
+	rows, err := h.db.Query(queryCtx, h.bindPlaceholders(query, args), args...)
+	if err != nil {
+		err = describeContextError(ctx, queryCtx, h.timeout, err)
+		cancel()
+		return nil, fmt.Errorf("query execution failed: %w", err)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		cancel()
+		return nil, fmt.Errorf("failed to get column names: %w", err)
+	}
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		rows.Close()
+		cancel()
+		return nil, fmt.Errorf("failed to get column types: %w", err)
+	}
+
+	return &RowStream{
+		handler:     h,
+		rows:        rows,
+		columns:     columns,
+		columnTypes: columnTypes,
+		ctx:         ctx,
+		queryCtx:    queryCtx,
+		cancel:      cancel,
+		query:       query,
+		argsCount:   len(args),
+	}, nil
+}
+
+// Columns returns the names of the result columns.
+func (s *RowStream) Columns() []string {
+	return s.columns
+}
+
+// Next advances to the next row, returning false when there are no more rows, the row
+// cap was reached or an error occurred (see Err). The stream is closed when Next
+// returns false.
+func (s *RowStream) Next() bool {
+	if s.closed {
+		return false
+	}
+	if !s.rows.Next() {
+		if err := s.rows.Err(); err != nil {
+			s.err = describeContextError(s.ctx, s.queryCtx, s.handler.timeout, err)
+		}
+		s.Close()
+		return false
+	}
+	if s.count >= s.handler.maxRows {
+		s.truncated = true
+		s.Close()
+		return false
+	}
+	s.count++
+	return true
+}
+
+// Scan copies the columns of the current row into dest, as sql.Rows.Scan does.
+func (s *RowStream) Scan(dest ...any) error {
+	if s.closed {
+		return fmt.Errorf("row stream is closed")
+	}
+	return s.rows.Scan(dest...)
+}
+
+// Row returns the current row as a map from column name to value, converted as in
+// ExecuteQuery results.
+func (s *RowStream) Row() (map[string]any, error) {
+	values := make([]any, len(s.columns))
+	valuePtrs := make([]any, len(s.columns))
+	for i := range values {
+		valuePtrs[i] = &values[i]
+	}
+	if err := s.Scan(valuePtrs...); err != nil {
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	row := make(map[string]any, len(s.columns))
+	for i, column := range s.columns {
+		row[column] = s.handler.convertValue(values[i], s.columnTypes[i])
+	}
+	return row, nil
+}
+
+// Err returns the error that ended iteration, if any.
+func (s *RowStream) Err() error {
+	return s.err
+}
+
+// Truncated reports whether iteration stopped at the DB_MAX_RESULT_ROWS cap.
+func (s *RowStream) Truncated() bool {
+	return s.truncated
+}
+
+// Close releases the rows and the query context. It is safe to call more than once.
+func (s *RowStream) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	err := s.rows.Close()
+	s.cancel()
+
+	s.handler.recordExecution(s.query, s.argsCount, &QueryResult{RowCount: s.count}, s.err, time.Since(s.start))
+	return err
+}
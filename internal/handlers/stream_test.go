@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func newStreamResultSet() *mockResultSet {
+	return &mockResultSet{
+		columns: []string{"id", "name"},
+		types:   []string{"INT8", "VARCHAR"},
+		rows: [][]driver.Value{
+			{int64(1), []byte("Alice")},
+			{int64(2), []byte("Bob")},
+			{int64(3), []byte("Carol")},
+		},
+	}
+}
+
+func TestQueryHandler_Stream(t *testing.T) {
+	set := newStreamResultSet()
+	handler := NewQueryHandler(newSelectMock(t, "postgres", set), createTestConfig())
+
+	stream, err := handler.Stream(context.Background(), "SELECT id, name FROM users")
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if got := strings.Join(stream.Columns(), ","); got != "id,name" {
+		t.Errorf("Columns() = %s, want id,name", got)
+	}
+
+	var names []string
+	for stream.Next() {
+		row, err := stream.Row()
+		if err != nil {
+			t.Fatalf("Row() error = %v", err)
+		}
+		names = append(names, row["name"].(string))
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	if got := strings.Join(names, ","); got != "Alice,Bob,Carol" {
+		t.Errorf("Streamed names = %s, want Alice,Bob,Carol", got)
+	}
+	if stream.Truncated() {
+		t.Error("Expected the stream not to be truncated")
+	}
+	// Reaching the end closes the rows without an explicit Close
+	if closed := set.Closed(); closed != 1 {
+		t.Errorf("Expected the rows to be closed once, got %d", closed)
+	}
+	if err := stream.Close(); err != nil || set.Closed() != 1 {
+		t.Errorf("Expected Close() after completion to be a no-op, got %v and %d closes", err, set.Closed())
+	}
+	if stream.Next() {
+		t.Error("Expected Next() to return false after the stream ended")
+	}
+}
+
+func TestQueryHandler_Stream_EarlyClose(t *testing.T) {
+	set := newStreamResultSet()
+	handler := NewQueryHandler(newSelectMock(t, "postgres", set), createTestConfig())
+
+	stream, err := handler.Stream(context.Background(), "SELECT id, name FROM users")
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	if !stream.Next() {
+		t.Fatalf("Expected a first row, got error %v", stream.Err())
+	}
+	var id int64
+	var name string
+	if err := stream.Scan(&id, &name); err != nil || id != 1 || name != "Alice" {
+		t.Fatalf("Scan() = %d, %q, %v; want 1, Alice", id, name, err)
+	}
+
+	if closed := set.Closed(); closed != 0 {
+		t.Fatalf("Expected the rows to stay open while iterating, got %d closes", closed)
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if closed := set.Closed(); closed != 1 {
+		t.Errorf("Expected stopping early to close the rows, got %d closes", closed)
+	}
+
+	if stream.Next() {
+		t.Error("Expected Next() to return false after Close()")
+	}
+	if err := stream.Scan(&id, &name); err == nil {
+		t.Error("Expected Scan() to fail after Close()")
+	}
+}
+
+func TestQueryHandler_Stream_RowCap(t *testing.T) {
+	set := newStreamResultSet()
+	cfg := createTestConfig()
+	cfg.MaxResultRows = 2
+	handler := NewQueryHandler(newSelectMock(t, "postgres", set), cfg)
+
+	stream, err := handler.Stream(context.Background(), "SELECT id, name FROM users")
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	count := 0
+	for stream.Next() {
+		count++
+	}
+	if count != 2 || !stream.Truncated() {
+		t.Errorf("Expected 2 rows and a truncated stream, got %d rows, truncated %v", count, stream.Truncated())
+	}
+	if closed := set.Closed(); closed != 1 {
+		t.Errorf("Expected the rows to be closed at the cap, got %d closes", closed)
+	}
+}
+
+func TestQueryHandler_Stream_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{name: "empty query", query: "   ", wantErr: "empty"},
+		{name: "not a select", query: "DELETE FROM users WHERE id = 1", wantErr: "only SELECT queries can be streamed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := newStreamResultSet()
+			handler := NewQueryHandler(newSelectMock(t, "postgres", set), createTestConfig())
+
+			if _, err := handler.Stream(context.Background(), tt.query); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Stream() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			if queries := set.Queries(); len(queries) != 0 {
+				t.Errorf("Expected no query to run, got %v", queries)
+			}
+		})
+	}
+}
+
+func TestQueryHandler_Stream_Audit(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewQueryHandler(newSelectMock(t, "postgres", newStreamResultSet()), createTestConfig())
+	handler.SetAuditLogger(NewAuditLogger(&buf), "client")
+
+	stream, err := handler.Stream(context.Background(), "SELECT id, name FROM users")
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	stream.Next()
+	stream.Next()
+	if buf.Len() != 0 {
+		t.Fatalf("Expected no audit entry before the stream is closed, got %s", buf.String())
+	}
+	stream.Close()
+
+	var entry AuditEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Invalid audit entry %q: %v", buf.String(), err)
+	}
+	if entry.RowsAffected != 2 || entry.Type != "select" {
+		t.Errorf("Expected a select entry with 2 rows, got %+v", entry)
+	}
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// withQueryTimeout derives the context a database call runs under. A zero timeout
+// leaves ctx unchanged apart from adding a cancel function.
+func withQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// describeContextError replaces the driver's generic context error when a call failed
+// because a context ended. ctx is the client's request context and queryCtx the context
+// derived from it by withQueryTimeout; whichever ended first decides the message.
+// Errors unrelated to either context are returned unchanged.
+func describeContextError(ctx, queryCtx context.Context, timeout time.Duration, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case ctx.Err() != nil:
+		return fmt.Errorf("query cancelled by client")
+	case queryCtx.Err() != nil:
+		return fmt.Errorf("query exceeded timeout of %s", timeout)
+	default:
+		return err
+	}
+}
+
+// runWithTimeout calls fn under the configured query timeout, describing
+// timeouts and client cancellations as in describeContextError.
+func runWithTimeout[T any](ctx context.Context, timeout time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	queryCtx, cancel := withQueryTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := fn(queryCtx)
+	return result, describeContextError(ctx, queryCtx, timeout, err)
+}
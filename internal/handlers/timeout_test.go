@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+// blockingSchemaDatabase blocks ListTables and DescribeTable until their context ends.
+// When tables is set, ListTables returns it immediately instead of blocking.
+type blockingSchemaDatabase struct {
+	MockDatabase
+	started chan struct{}
+	tables  []string
+}
+
+func (m *blockingSchemaDatabase) ListTables(ctx context.Context) ([]string, error) {
+	if m.tables != nil {
+		return m.tables, nil
+	}
+	close(m.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (m *blockingSchemaDatabase) DescribeTable(ctx context.Context, tableName string) (*database.TableSchema, error) {
+	close(m.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestDescribeContextError(t *testing.T) {
+	driverErr := errors.New("pq: canceling statement due to user request")
+
+	t.Run("timeout", func(t *testing.T) {
+		queryCtx, cancel := withQueryTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		<-queryCtx.Done()
+
+		err := describeContextError(context.Background(), queryCtx, 2*time.Second, driverErr)
+		if err == nil || err.Error() != "query exceeded timeout of 2s" {
+			t.Errorf("Expected timeout message, got %v", err)
+		}
+	})
+
+	t.Run("client cancellation", func(t *testing.T) {
+		ctx, cancelClient := context.WithCancel(context.Background())
+		queryCtx, cancel := withQueryTimeout(ctx, time.Minute)
+		defer cancel()
+		cancelClient()
+
+		err := describeContextError(ctx, queryCtx, time.Minute, driverErr)
+		if err == nil || err.Error() != "query cancelled by client" {
+			t.Errorf("Expected cancellation message, got %v", err)
+		}
+	})
+
+	t.Run("unrelated error", func(t *testing.T) {
+		queryCtx, cancel := withQueryTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		if err := describeContextError(context.Background(), queryCtx, time.Minute, driverErr); err != driverErr {
+			t.Errorf("Expected the original error, got %v", err)
+		}
+		if err := describeContextError(context.Background(), queryCtx, time.Minute, nil); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+	})
+}
+
+func TestQueryHandler_ExecuteQuery_ClientCancelled(t *testing.T) {
+	started := make(chan struct{})
+	mockDB := &MockDatabase{
+		driver: "postgres",
+		queryFunc: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+
+	cfg := createTestConfig()
+	cfg.QueryTimeout = time.Minute
+	handler := NewQueryHandler(mockDB, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err := handler.ExecuteQuery(ctx, "SELECT pg_sleep(10)")
+	if err == nil || err.Error() != "query cancelled by client" {
+		t.Errorf("Expected cancellation message, got %v", err)
+	}
+}
+
+func TestSchemaHandler_Timeouts(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		cancel   bool
+		tables   []string
+		call     func(h *SchemaHandler, ctx context.Context) error
+		wantText string
+	}{
+		{
+			name:    "list tables timeout",
+			timeout: 10 * time.Millisecond,
+			call: func(h *SchemaHandler, ctx context.Context) error {
+				_, err := h.ListTables(ctx)
+				return err
+			},
+			wantText: "failed to list tables: query exceeded timeout of 10ms",
+		},
+		{
+			name:    "describe table cancelled",
+			timeout: time.Minute,
+			cancel:  true,
+			tables:  []string{"users"},
+			call: func(h *SchemaHandler, ctx context.Context) error {
+				_, err := h.DescribeTable(ctx, "users")
+				return err
+			},
+			wantText: "failed to describe table users: query cancelled by client",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &blockingSchemaDatabase{started: make(chan struct{}), tables: tt.tables}
+			cfg := createTestConfig()
+			cfg.QueryTimeout = tt.timeout
+			handler := NewSchemaHandler(mockDB, cfg)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				go func() {
+					<-mockDB.started
+					cancel()
+				}()
+			}
+
+			err := tt.call(handler, ctx)
+			if err == nil || err.Error() != tt.wantText {
+				t.Errorf("Expected %q, got %v", tt.wantText, err)
+			}
+		})
+	}
+}
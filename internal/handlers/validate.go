@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// QueryValidation reports whether a query would be accepted by the query tool.
+type QueryValidation struct {
+	Valid bool   `json:"valid"`           // Whether the query passed every check
+	Type  string `json:"type"`            // Query type: select, insert, update, delete, ddl
+	Stage string `json:"stage,omitempty"` // Check that rejected the query: security or prepare
+	Error string `json:"error,omitempty"` // Reason the query was rejected
+}
+
+// ValidateOnly checks query the way ExecuteQuery would without running it: the security
+// validation is applied and the database prepares the statement, which parses it and
+// resolves the objects it references. Preparing never executes the statement, so unlike
+// EXPLAIN it cannot have side effects. A rejected query is reported in the result rather
+// than as an error.
+func (h *QueryHandler) ValidateOnly(ctx context.Context, query string, args ...any) (*QueryValidation, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+
+	result := &QueryValidation{Type: h.determineQueryType(query)}
+	if err := h.validator.ValidateQuery(query); err != nil {
+		result.Stage = "security"
+		result.Error = h.validator.SanitizeErrorMessage(err).Error()
+		return result, nil
+	}
+
+	sqlDB := h.db.GetDB()
+	if sqlDB == nil {
+		return nil, fmt.Errorf("database connection is not available")
+	}
+
+	queryCtx, cancel := withQueryTimeout(ctx, h.timeout)
+	defer cancel()
+
+	stmt, err := sqlDB.PrepareContext(queryCtx, h.bindPlaceholders(query, args))
+	if err != nil {
+		if queryCtx.Err() != nil {
+			return nil, describeContextError(ctx, queryCtx, h.timeout, err)
+		}
+		result.Stage = "prepare"
+		result.Error = err.Error()
+		return result, nil
+	}
+	stmt.Close()
+
+	result.Valid = true
+	return result, nil
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func TestQueryHandler_ValidateOnly(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		args     []any
+		readOnly bool
+		want     QueryValidation
+	}{
+		{
+			name:  "valid select",
+			query: "SELECT id FROM users WHERE id = ?",
+			args:  []any{1},
+			want:  QueryValidation{Valid: true, Type: "select"},
+		},
+		{
+			name:  "valid ddl",
+			query: "CREATE TABLE notes (id INT)",
+			want:  QueryValidation{Valid: true, Type: "ddl"},
+		},
+		{
+			name:     "rejected by security validation",
+			query:    "DELETE FROM users",
+			readOnly: true,
+			want: QueryValidation{
+				Type:  "delete",
+				Stage: "security",
+				Error: "read-only mode: DML and DDL statements are not permitted",
+			},
+		},
+		{
+			name:  "rejected by the database",
+			query: "SELECT id FROM missing_table",
+			want:  QueryValidation{Type: "select", Stage: "prepare", Error: "mock prepare failure"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &mockResultSet{failPrep: "missing_table"}
+			mockDB := &MockDatabase{driver: "postgres", sqlDB: newMockSQLDB(t, set)}
+			mockDB.execFunc = func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+				t.Fatalf("Expected no statement to run, got %q", query)
+				return nil, nil
+			}
+
+			cfg := createTestConfig()
+			cfg.ReadOnly = tt.readOnly
+			handler := NewQueryHandler(mockDB, cfg)
+
+			got, err := handler.ValidateOnly(context.Background(), tt.query, tt.args...)
+			if err != nil {
+				t.Fatalf("ValidateOnly() error = %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("ValidateOnly() = %+v, want %+v", *got, tt.want)
+			}
+			if queries := set.Queries(); len(queries) != 0 {
+				t.Errorf("Expected nothing to execute, got %v", queries)
+			}
+		})
+	}
+}
+
+func TestQueryHandler_ValidateOnly_EmptyQuery(t *testing.T) {
+	handler := NewQueryHandler(&MockDatabase{driver: "postgres"}, createTestConfig())
+	if _, err := handler.ValidateOnly(context.Background(), "  "); err == nil {
+		t.Error("Expected an error for an empty query")
+	}
+}
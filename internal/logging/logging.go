@@ -0,0 +1,143 @@
+// Package logging configures the server's log output and records MCP tool calls.
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jhoffmann/go-database-mcp/internal/config"
+	"github.com/jhoffmann/go-database-mcp/internal/security"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Setup installs the default slog logger for the given format, discarding records below
+// level. Text records keep going through the standard log package, as before; JSON
+// records are written to w, and anything still logged through the log package is
+// converted to JSON as well.
+func Setup(format config.LogFormat, level slog.Level, w io.Writer) {
+	if format == config.LogFormatJSON {
+		slog.SetDefault(NewJSONLogger(w, level))
+		return
+	}
+	slog.SetLogLoggerLevel(level)
+}
+
+// NewJSONLogger returns a logger writing one JSON object per record at or above level to
+// w, with the fields level, ts and msg followed by the record's attributes.
+func NewJSONLogger(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.TimeKey {
+				attr.Key = "ts"
+			}
+			return attr
+		},
+	}))
+}
+
+// ToolCallMiddleware returns MCP middleware logging every tool call to logger with the
+// fields tool_name, duration_ms, query_type (for tools taking a query argument) and
+// row_count (for query results). Successful calls are logged at debug level and failed
+// ones at error level with an error field. Query text, parameters and other arguments
+// are never logged.
+func ToolCallMiddleware(logger *slog.Logger) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			params, ok := req.GetParams().(*mcp.CallToolParams)
+			if method != "tools/call" || !ok {
+				return next(ctx, method, req)
+			}
+
+			start := time.Now()
+			result, err := next(ctx, method, req)
+
+			attrs := []slog.Attr{
+				slog.String("tool_name", params.Name),
+				slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+			}
+			if queryType := toolQueryType(params.Arguments); queryType != "" {
+				attrs = append(attrs, slog.String("query_type", queryType))
+			}
+
+			level := slog.LevelDebug
+			if message := toolError(result, err); message != "" {
+				level = slog.LevelError
+				attrs = append(attrs, slog.String("error", message))
+			} else if rowCount, ok := toolRowCount(result); ok {
+				attrs = append(attrs, slog.Int("row_count", rowCount))
+			}
+			logger.LogAttrs(ctx, level, "tool call", attrs...)
+
+			return result, err
+		}
+	}
+}
+
+// toolQueryType classifies the query argument of a tool call, returning "" when the
+// tool has none.
+func toolQueryType(arguments any) string {
+	raw, ok := arguments.(json.RawMessage)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(arguments); err != nil {
+			return ""
+		}
+	}
+
+	var args struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil || strings.TrimSpace(args.Query) == "" {
+		return ""
+	}
+	return security.DetermineQueryType(args.Query, "")
+}
+
+// toolRowCount returns the RowCount field of a tool's structured result, if it has one.
+func toolRowCount(result mcp.Result) (int, bool) {
+	res, ok := result.(*mcp.CallToolResult)
+	if !ok || res.StructuredContent == nil {
+		return 0, false
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(res.StructuredContent))
+	if value.Kind() != reflect.Struct {
+		return 0, false
+	}
+	field := value.FieldByName("RowCount")
+	if !field.IsValid() || !field.CanInt() {
+		return 0, false
+	}
+	return int(field.Int()), true
+}
+
+// toolError returns the error message of a failed tool call, or "" on success. Tools
+// report most failures as a text result starting with "Error: " rather than as an error.
+func toolError(result mcp.Result, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+
+	res, ok := result.(*mcp.CallToolResult)
+	if !ok || len(res.Content) == 0 {
+		return ""
+	}
+	text, ok := res.Content[0].(*mcp.TextContent)
+	if !ok {
+		return ""
+	}
+	if message, found := strings.CutPrefix(text.Text, "Error: "); found {
+		return message
+	}
+	if res.IsError {
+		return text.Text
+	}
+	return ""
+}
@@ -0,0 +1,187 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// decodeRecords parses the JSON lines written by a JSON logger.
+func decodeRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Log line is not JSON: %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestNewJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	NewJSONLogger(&buf, slog.LevelDebug).Info("Database connected successfully", "db_type", "postgres", "db_host", "db.internal")
+
+	records := decodeRecords(t, &buf)
+	if len(records) != 1 {
+		t.Fatalf("Expected one record, got %d", len(records))
+	}
+
+	record := records[0]
+	for key, want := range map[string]any{
+		"level":   "INFO",
+		"msg":     "Database connected successfully",
+		"db_type": "postgres",
+		"db_host": "db.internal",
+	} {
+		if record[key] != want {
+			t.Errorf("%s = %v, want %v", key, record[key], want)
+		}
+	}
+	if _, ok := record["ts"]; !ok {
+		t.Errorf("Expected a ts field, got %v", record)
+	}
+	if _, ok := record["time"]; ok {
+		t.Errorf("Expected time to be renamed to ts, got %v", record)
+	}
+}
+
+func TestToolCallMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		tool          string
+		arguments     string
+		result        mcp.Result
+		err           error
+		wantLevel     string
+		wantQueryType string
+		wantRowCount  float64
+		wantError     string
+	}{
+		{
+			name:      "successful query",
+			tool:      "query",
+			arguments: `{"query": "DELETE FROM sessions WHERE expires_at < $1"}`,
+			result: &mcp.CallToolResult{
+				Content:           []mcp.Content{&mcp.TextContent{Text: "ok"}},
+				StructuredContent: &struct{ RowCount int }{RowCount: 3},
+			},
+			wantLevel:     "DEBUG",
+			wantQueryType: "delete",
+			wantRowCount:  3,
+		},
+		{
+			name:      "tool without a query",
+			tool:      "list_tables",
+			arguments: `{}`,
+			result:    &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "Tables: users"}}},
+			wantLevel: "DEBUG",
+		},
+		{
+			name:      "error result",
+			tool:      "describe_table",
+			arguments: `{"table_name": "missing"}`,
+			result:    &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "Error: table not found: missing"}}},
+			wantLevel: "ERROR",
+			wantError: "table not found: missing",
+		},
+		{
+			name:      "handler error",
+			tool:      "connection_info",
+			arguments: `{}`,
+			err:       errors.New("database not connected"),
+			wantLevel: "ERROR",
+			wantError: "database not connected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+				return tt.result, tt.err
+			}
+			handler := ToolCallMiddleware(NewJSONLogger(&buf, slog.LevelDebug))(next)
+
+			req := &mcp.ServerRequest[*mcp.CallToolParams]{
+				Params: &mcp.CallToolParams{Name: tt.tool, Arguments: json.RawMessage(tt.arguments)},
+			}
+			if _, err := handler(context.Background(), "tools/call", req); err != tt.err {
+				t.Errorf("Expected the handler error to be passed through, got %v", err)
+			}
+
+			records := decodeRecords(t, &buf)
+			if len(records) != 1 {
+				t.Fatalf("Expected one record, got %d", len(records))
+			}
+			record := records[0]
+
+			if record["msg"] != "tool call" || record["tool_name"] != tt.tool || record["level"] != tt.wantLevel {
+				t.Errorf("Unexpected record %v", record)
+			}
+			if _, ok := record["duration_ms"].(float64); !ok {
+				t.Errorf("Expected numeric duration_ms, got %v", record["duration_ms"])
+			}
+			if got, _ := record["query_type"].(string); got != tt.wantQueryType {
+				t.Errorf("query_type = %q, want %q", got, tt.wantQueryType)
+			}
+			if got, _ := record["row_count"].(float64); got != tt.wantRowCount {
+				t.Errorf("row_count = %v, want %v", got, tt.wantRowCount)
+			}
+			if got, _ := record["error"].(string); got != tt.wantError {
+				t.Errorf("error = %q, want %q", got, tt.wantError)
+			}
+			if strings.Contains(buf.String(), "sessions") {
+				t.Errorf("Query text must not be logged: %s", buf.String())
+			}
+		})
+	}
+}
+
+func TestToolCallMiddleware_IgnoresOtherMethods(t *testing.T) {
+	var buf bytes.Buffer
+	called := false
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		called = true
+		return nil, nil
+	}
+
+	req := &mcp.ServerRequest[*mcp.ListToolsParams]{Params: &mcp.ListToolsParams{}}
+	if _, err := ToolCallMiddleware(NewJSONLogger(&buf, slog.LevelDebug))(next)(context.Background(), "tools/list", req); err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+
+	if !called {
+		t.Error("Expected the request to be passed on")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing to be logged, got %s", buf.String())
+	}
+}
+
+func TestToolCallMiddleware_Levels(t *testing.T) {
+	var buf bytes.Buffer
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil
+	}
+	handler := ToolCallMiddleware(NewJSONLogger(&buf, slog.LevelInfo))(next)
+
+	req := &mcp.ServerRequest[*mcp.CallToolParams]{Params: &mcp.CallToolParams{Name: "list_tables"}}
+	if _, err := handler(context.Background(), "tools/call", req); err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected successful calls to be logged at debug level only, got %s", buf.String())
+	}
+}
@@ -0,0 +1,138 @@
+// Package metrics exposes query and connection pool telemetry in Prometheus format.
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+	"github.com/jhoffmann/go-database-mcp/internal/security"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the query and connection pool metrics served on /metrics.
+type Metrics struct {
+	registry *prometheus.Registry
+	queries  *prometheus.CounterVec   // db_queries_total{type, status}
+	duration *prometheus.HistogramVec // db_query_duration_seconds{type}
+}
+
+// New creates the metrics and registers them, together with a collector reporting the pool
+// statistics of the databases returned by pools, keyed by connection name. pools is called
+// on every scrape, so connections that are not connected yet may be omitted or nil.
+func New(pools func() map[string]database.Database) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		queries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "db_queries_total",
+			Help: "Number of executed queries by type (select, insert, update, delete, ddl) and status (ok, error).",
+		}, []string{"type", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Query execution time by type.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"type"}),
+	}
+
+	m.registry.MustRegister(m.queries, m.duration, newPoolCollector(pools))
+	return m
+}
+
+// Handler returns an HTTP handler serving the metrics in Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Wrap returns db instrumented so that every statement executed through Query, QueryRow
+// or Exec is counted and timed. Statements run inside transactions started with BeginTx
+// and the schema inspection methods are not instrumented.
+func (m *Metrics) Wrap(db database.Database) database.Database {
+	return &instrumentedDatabase{Database: db, metrics: m}
+}
+
+// observe records one statement executed on a database of type dbType.
+func (m *Metrics) observe(dbType, query string, start time.Time, err error) {
+	queryType := security.DetermineQueryType(query, dbType)
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+
+	m.queries.WithLabelValues(queryType, status).Inc()
+	m.duration.WithLabelValues(queryType).Observe(time.Since(start).Seconds())
+}
+
+// instrumentedDatabase is a Database decorator recording query metrics.
+type instrumentedDatabase struct {
+	database.Database
+	metrics *Metrics
+}
+
+// Query executes query and records its outcome. The duration covers the time until
+// the first rows are available, not reading the whole result.
+func (d *instrumentedDatabase) Query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := d.Database.Query(ctx, query, args...)
+	d.metrics.observe(d.GetDriverName(), query, start, err)
+	return rows, err
+}
+
+// QueryRow executes query and records its outcome.
+func (d *instrumentedDatabase) QueryRow(ctx context.Context, query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := d.Database.QueryRow(ctx, query, args...)
+	d.metrics.observe(d.GetDriverName(), query, start, row.Err())
+	return row
+}
+
+// Exec executes query and records its outcome.
+func (d *instrumentedDatabase) Exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	result, err := d.Database.Exec(ctx, query, args...)
+	d.metrics.observe(d.GetDriverName(), query, start, err)
+	return result, err
+}
+
+// poolCollector reports connection pool statistics, read from sql.DB.Stats at scrape time.
+type poolCollector struct {
+	pools func() map[string]database.Database
+	open  *prometheus.Desc
+	idle  *prometheus.Desc
+	max   *prometheus.Desc
+}
+
+// newPoolCollector creates a collector for the pools of the databases returned by pools.
+func newPoolCollector(pools func() map[string]database.Database) *poolCollector {
+	labels := []string{"connection"}
+	return &poolCollector{
+		pools: pools,
+		open:  prometheus.NewDesc("db_pool_open_connections", "Number of established connections, both in use and idle.", labels, nil),
+		idle:  prometheus.NewDesc("db_pool_idle_connections", "Number of idle connections.", labels, nil),
+		max:   prometheus.NewDesc("db_pool_max_connections", "Maximum number of open connections (0 means unlimited).", labels, nil),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.open
+	ch <- c.idle
+	ch <- c.max
+}
+
+// Collect implements prometheus.Collector.
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	for name, db := range c.pools() {
+		if db == nil || db.GetDB() == nil {
+			continue
+		}
+
+		stats := db.GetDB().Stats()
+		ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(stats.OpenConnections), name)
+		ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.Idle), name)
+		ch <- prometheus.MustNewConstMetric(c.max, prometheus.GaugeValue, float64(stats.MaxOpenConnections), name)
+	}
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+// noopDriver lets sql.Open create a pool without ever connecting.
+type noopDriver struct{}
+
+func (noopDriver) Open(string) (driver.Conn, error) { return nil, errors.New("not connected") }
+
+func init() {
+	sql.Register("metrics-noop", noopDriver{})
+}
+
+// fakeDatabase implements the statement methods of database.Database; any other
+// method panics through the nil embedded interface.
+type fakeDatabase struct {
+	database.Database
+	sqlDB   *sql.DB
+	execErr error
+}
+
+func (f *fakeDatabase) Query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return nil, nil
+}
+
+func (f *fakeDatabase) Exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return nil, f.execErr
+}
+
+func (f *fakeDatabase) GetDB() *sql.DB {
+	return f.sqlDB
+}
+
+func (f *fakeDatabase) GetDriverName() string {
+	return "postgres"
+}
+
+// scrape returns the metrics served by m in Prometheus text format.
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		t.Fatalf("Failed to read metrics: %v", err)
+	}
+	return string(body)
+}
+
+func TestMetrics_QueryTelemetry(t *testing.T) {
+	m := New(func() map[string]database.Database { return nil })
+
+	ok := m.Wrap(&fakeDatabase{})
+	failing := m.Wrap(&fakeDatabase{execErr: errors.New("deadlock")})
+
+	_, _ = ok.Query(context.Background(), "SELECT * FROM users")
+	_, _ = ok.Query(context.Background(), "WITH t AS (SELECT 1) SELECT * FROM t")
+	_, _ = ok.Exec(context.Background(), "UPDATE users SET active = true")
+	_, _ = failing.Exec(context.Background(), "DELETE FROM users")
+
+	body := scrape(t, m)
+	for _, want := range []string{
+		`db_queries_total{status="ok",type="select"} 2`,
+		`db_queries_total{status="ok",type="update"} 1`,
+		`db_queries_total{status="error",type="delete"} 1`,
+		`db_query_duration_seconds_count{type="select"} 2`,
+		`db_query_duration_seconds_count{type="delete"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Metrics do not contain %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestMetrics_PoolStatistics(t *testing.T) {
+	sqlDB, err := sql.Open("metrics-noop", "")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(7)
+
+	m := New(func() map[string]database.Database {
+		return map[string]database.Database{
+			"default":   &fakeDatabase{sqlDB: sqlDB},
+			"analytics": nil, // Not connected yet
+		}
+	})
+
+	body := scrape(t, m)
+	for _, want := range []string{
+		`db_pool_open_connections{connection="default"} 0`,
+		`db_pool_idle_connections{connection="default"} 0`,
+		`db_pool_max_connections{connection="default"} 7`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Metrics do not contain %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, `connection="analytics"`) {
+		t.Errorf("Unconnected connection should be omitted:\n%s", body)
+	}
+}
@@ -0,0 +1,280 @@
+// Package schema compares the table structure of two databases.
+package schema
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+// SchemaDiff describes how the tables of a target database differ from those of a source
+// database, e.g. production compared with staging. Tables are matched by name, and columns
+// and indexes by name within tables present in both databases.
+type SchemaDiff struct {
+	TablesMissingFromTarget []string     `json:"tables_missing_from_target"` // Tables only present in the source
+	TablesMissingFromSource []string     `json:"tables_missing_from_source"` // Tables only present in the target
+	ColumnDiffs             []ColumnDiff `json:"column_diffs"`               // Columns missing from one side or defined differently
+	IndexDiffs              []IndexDiff  `json:"index_diffs"`                // Indexes missing from one side or defined differently
+	TablesCompared          int          `json:"tables_compared"`            // Number of tables present in both databases
+}
+
+// ColumnDiff describes a column that differs between the source and target table.
+type ColumnDiff struct {
+	Table       string               `json:"table"`                 // Table name
+	Column      string               `json:"column"`                // Column name
+	Source      *database.ColumnInfo `json:"source,omitempty"`      // Definition in the source, nil if the column is missing there
+	Target      *database.ColumnInfo `json:"target,omitempty"`      // Definition in the target, nil if the column is missing there
+	Differences []string             `json:"differences,omitempty"` // Differing attributes: "type", "nullable" or "default"
+}
+
+// IndexDiff describes an index that differs between the source and target table.
+type IndexDiff struct {
+	Table       string              `json:"table"`                 // Table name
+	Index       string              `json:"index"`                 // Index name
+	Source      *database.IndexInfo `json:"source,omitempty"`      // Definition in the source, nil if the index is missing there
+	Target      *database.IndexInfo `json:"target,omitempty"`      // Definition in the target, nil if the index is missing there
+	Differences []string            `json:"differences,omitempty"` // Differing attributes: "columns", "unique" or "primary"
+}
+
+// IsEmpty reports whether the two schemas are identical.
+func (d *SchemaDiff) IsEmpty() bool {
+	return len(d.TablesMissingFromTarget) == 0 && len(d.TablesMissingFromSource) == 0 &&
+		len(d.ColumnDiffs) == 0 && len(d.IndexDiffs) == 0
+}
+
+// CompareSchemas lists the tables of source and target and describes every table present
+// in both, returning the tables, columns and indexes that differ. Column types are compared
+// case-insensitively; comparing databases of different types reports most types as changed.
+func CompareSchemas(ctx context.Context, source, target database.Database) (*SchemaDiff, error) {
+	sourceTables, err := source.ListTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source tables: %w", err)
+	}
+	targetTables, err := target.ListTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list target tables: %w", err)
+	}
+
+	diff := &SchemaDiff{
+		TablesMissingFromTarget: difference(sourceTables, targetTables),
+		TablesMissingFromSource: difference(targetTables, sourceTables),
+		ColumnDiffs:             []ColumnDiff{},
+		IndexDiffs:              []IndexDiff{},
+	}
+
+	common := intersection(sourceTables, targetTables)
+	for _, table := range common {
+		sourceSchema, err := source.DescribeTable(ctx, table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe source table %s: %w", table, err)
+		}
+		targetSchema, err := target.DescribeTable(ctx, table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe target table %s: %w", table, err)
+		}
+
+		diff.ColumnDiffs = append(diff.ColumnDiffs, compareColumns(table, sourceSchema.Columns, targetSchema.Columns)...)
+		diff.IndexDiffs = append(diff.IndexDiffs, compareIndexes(table, sourceSchema.Indexes, targetSchema.Indexes)...)
+	}
+	diff.TablesCompared = len(common)
+
+	return diff, nil
+}
+
+// compareColumns returns the differences between the columns of a table in the source and
+// target, in source column order followed by the columns only present in the target.
+func compareColumns(table string, source, target []database.ColumnInfo) []ColumnDiff {
+	var diffs []ColumnDiff
+	for i := range source {
+		column := &source[i]
+		j := slices.IndexFunc(target, func(c database.ColumnInfo) bool { return c.Name == column.Name })
+		if j < 0 {
+			diffs = append(diffs, ColumnDiff{Table: table, Column: column.Name, Source: column})
+			continue
+		}
+
+		other := &target[j]
+		var differences []string
+		if !strings.EqualFold(column.Type, other.Type) {
+			differences = append(differences, "type")
+		}
+		if column.IsNullable != other.IsNullable {
+			differences = append(differences, "nullable")
+		}
+		if !equalDefaults(column.DefaultValue, other.DefaultValue) {
+			differences = append(differences, "default")
+		}
+		if len(differences) > 0 {
+			diffs = append(diffs, ColumnDiff{Table: table, Column: column.Name, Source: column, Target: other, Differences: differences})
+		}
+	}
+
+	for i := range target {
+		column := &target[i]
+		if !slices.ContainsFunc(source, func(c database.ColumnInfo) bool { return c.Name == column.Name }) {
+			diffs = append(diffs, ColumnDiff{Table: table, Column: column.Name, Target: column})
+		}
+	}
+	return diffs
+}
+
+// compareIndexes returns the differences between the indexes of a table in the source and
+// target, in source index order followed by the indexes only present in the target.
+func compareIndexes(table string, source, target []database.IndexInfo) []IndexDiff {
+	var diffs []IndexDiff
+	for i := range source {
+		index := &source[i]
+		j := slices.IndexFunc(target, func(x database.IndexInfo) bool { return x.Name == index.Name })
+		if j < 0 {
+			diffs = append(diffs, IndexDiff{Table: table, Index: index.Name, Source: index})
+			continue
+		}
+
+		other := &target[j]
+		var differences []string
+		if !slices.Equal(index.Columns, other.Columns) {
+			differences = append(differences, "columns")
+		}
+		if index.IsUnique != other.IsUnique {
+			differences = append(differences, "unique")
+		}
+		if index.IsPrimary != other.IsPrimary {
+			differences = append(differences, "primary")
+		}
+		if len(differences) > 0 {
+			diffs = append(diffs, IndexDiff{Table: table, Index: index.Name, Source: index, Target: other, Differences: differences})
+		}
+	}
+
+	for i := range target {
+		index := &target[i]
+		if !slices.ContainsFunc(source, func(x database.IndexInfo) bool { return x.Name == index.Name }) {
+			diffs = append(diffs, IndexDiff{Table: table, Index: index.Name, Target: index})
+		}
+	}
+	return diffs
+}
+
+// equalDefaults reports whether two column defaults are the same, treating two missing
+// defaults as equal.
+func equalDefaults(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// difference returns the names in a that are not in b, sorted.
+func difference(a, b []string) []string {
+	names := []string{}
+	for _, name := range a {
+		if !slices.Contains(b, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// intersection returns the names present in both a and b, sorted.
+func intersection(a, b []string) []string {
+	var names []string
+	for _, name := range a {
+		if slices.Contains(b, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Summary renders the diff as human-readable text, one line per difference, for example
+// "users.email: type varchar(100) -> varchar(255)".
+func (d *SchemaDiff) Summary() string {
+	if d.IsEmpty() {
+		return fmt.Sprintf("Schemas are identical (%d tables compared)", d.TablesCompared)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Schema differences (%d tables compared):", d.TablesCompared)
+	if len(d.TablesMissingFromTarget) > 0 {
+		fmt.Fprintf(&b, "\nTables missing from target: %s", strings.Join(d.TablesMissingFromTarget, ", "))
+	}
+	if len(d.TablesMissingFromSource) > 0 {
+		fmt.Fprintf(&b, "\nTables missing from source: %s", strings.Join(d.TablesMissingFromSource, ", "))
+	}
+
+	if len(d.ColumnDiffs) > 0 {
+		b.WriteString("\nColumns:")
+		for _, c := range d.ColumnDiffs {
+			fmt.Fprintf(&b, "\n  %s.%s: %s", c.Table, c.Column, describeColumnDiff(c))
+		}
+	}
+	if len(d.IndexDiffs) > 0 {
+		b.WriteString("\nIndexes:")
+		for _, x := range d.IndexDiffs {
+			fmt.Fprintf(&b, "\n  %s.%s: %s", x.Table, x.Index, describeIndexDiff(x))
+		}
+	}
+	return b.String()
+}
+
+// describeColumnDiff renders a column difference as "missing from target" or as the
+// changed attributes, e.g. "type int -> bigint, nullable false -> true".
+func describeColumnDiff(c ColumnDiff) string {
+	switch {
+	case c.Target == nil:
+		return "missing from target"
+	case c.Source == nil:
+		return "missing from source"
+	}
+
+	changes := make([]string, 0, len(c.Differences))
+	for _, attribute := range c.Differences {
+		switch attribute {
+		case "type":
+			changes = append(changes, fmt.Sprintf("type %s -> %s", c.Source.Type, c.Target.Type))
+		case "nullable":
+			changes = append(changes, fmt.Sprintf("nullable %v -> %v", c.Source.IsNullable, c.Target.IsNullable))
+		case "default":
+			changes = append(changes, fmt.Sprintf("default %s -> %s", formatDefault(c.Source.DefaultValue), formatDefault(c.Target.DefaultValue)))
+		}
+	}
+	return strings.Join(changes, ", ")
+}
+
+// describeIndexDiff renders an index difference as "missing from target" or as the
+// changed attributes, e.g. "columns (email) -> (email, tenant_id)".
+func describeIndexDiff(x IndexDiff) string {
+	switch {
+	case x.Target == nil:
+		return "missing from target"
+	case x.Source == nil:
+		return "missing from source"
+	}
+
+	changes := make([]string, 0, len(x.Differences))
+	for _, attribute := range x.Differences {
+		switch attribute {
+		case "columns":
+			changes = append(changes, fmt.Sprintf("columns (%s) -> (%s)",
+				strings.Join(x.Source.Columns, ", "), strings.Join(x.Target.Columns, ", ")))
+		case "unique":
+			changes = append(changes, fmt.Sprintf("unique %v -> %v", x.Source.IsUnique, x.Target.IsUnique))
+		case "primary":
+			changes = append(changes, fmt.Sprintf("primary %v -> %v", x.Source.IsPrimary, x.Target.IsPrimary))
+		}
+	}
+	return strings.Join(changes, ", ")
+}
+
+// formatDefault renders a column default, or "none" when the column has no default.
+func formatDefault(value *string) string {
+	if value == nil {
+		return "none"
+	}
+	return *value
+}
@@ -0,0 +1,181 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+)
+
+// fakeDatabase serves ListTables and DescribeTable from a fixed set of table schemas.
+// Calling any other Database method panics.
+type fakeDatabase struct {
+	database.Database
+	tables  map[string]*database.TableSchema
+	listErr error
+}
+
+func (f *fakeDatabase) ListTables(ctx context.Context) ([]string, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	names := make([]string, 0, len(f.tables))
+	for name := range f.tables {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (f *fakeDatabase) DescribeTable(ctx context.Context, tableName string) (*database.TableSchema, error) {
+	schema, ok := f.tables[tableName]
+	if !ok {
+		return nil, fmt.Errorf("table %s not found", tableName)
+	}
+	return schema, nil
+}
+
+func stringPtr(s string) *string { return &s }
+
+func TestCompareSchemas(t *testing.T) {
+	source := &fakeDatabase{tables: map[string]*database.TableSchema{
+		"users": {
+			TableName: "users",
+			Columns: []database.ColumnInfo{
+				{Name: "id", Type: "integer"},
+				{Name: "email", Type: "varchar(100)"},
+				{Name: "status", Type: "text", DefaultValue: stringPtr("'active'")},
+				{Name: "legacy_id", Type: "integer", IsNullable: true},
+			},
+			Indexes: []database.IndexInfo{
+				{Name: "users_pkey", Columns: []string{"id"}, IsUnique: true, IsPrimary: true},
+				{Name: "users_email_idx", Columns: []string{"email"}},
+			},
+		},
+		"audit_log": {TableName: "audit_log"},
+	}}
+	target := &fakeDatabase{tables: map[string]*database.TableSchema{
+		"users": {
+			TableName: "users",
+			Columns: []database.ColumnInfo{
+				{Name: "id", Type: "INTEGER"},
+				{Name: "email", Type: "varchar(255)", IsNullable: true},
+				{Name: "status", Type: "text"},
+				{Name: "created_at", Type: "timestamp"},
+			},
+			Indexes: []database.IndexInfo{
+				{Name: "users_pkey", Columns: []string{"id"}, IsUnique: true, IsPrimary: true},
+				{Name: "users_email_idx", Columns: []string{"email"}, IsUnique: true},
+				{Name: "users_created_idx", Columns: []string{"created_at"}},
+			},
+		},
+		"sessions": {TableName: "sessions"},
+	}}
+
+	diff, err := CompareSchemas(context.Background(), source, target)
+	if err != nil {
+		t.Fatalf("CompareSchemas() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(diff.TablesMissingFromTarget, []string{"audit_log"}) {
+		t.Errorf("Expected audit_log missing from target, got %v", diff.TablesMissingFromTarget)
+	}
+	if !reflect.DeepEqual(diff.TablesMissingFromSource, []string{"sessions"}) {
+		t.Errorf("Expected sessions missing from source, got %v", diff.TablesMissingFromSource)
+	}
+	if diff.TablesCompared != 1 {
+		t.Errorf("Expected 1 table compared, got %d", diff.TablesCompared)
+	}
+
+	wantColumns := map[string][]string{
+		"email":      {"type", "nullable"},
+		"status":     {"default"},
+		"legacy_id":  nil, // Missing from target
+		"created_at": nil, // Missing from source
+	}
+	if len(diff.ColumnDiffs) != len(wantColumns) {
+		t.Fatalf("Expected %d column diffs, got %+v", len(wantColumns), diff.ColumnDiffs)
+	}
+	for _, c := range diff.ColumnDiffs {
+		want, ok := wantColumns[c.Column]
+		if !ok {
+			t.Errorf("Unexpected column diff for %s", c.Column)
+			continue
+		}
+		if !reflect.DeepEqual(c.Differences, want) {
+			t.Errorf("Column %s: expected differences %v, got %v", c.Column, want, c.Differences)
+		}
+	}
+
+	wantIndexes := map[string][]string{
+		"users_email_idx":   {"unique"},
+		"users_created_idx": nil, // Missing from source
+	}
+	if len(diff.IndexDiffs) != len(wantIndexes) {
+		t.Fatalf("Expected %d index diffs, got %+v", len(wantIndexes), diff.IndexDiffs)
+	}
+	for _, x := range diff.IndexDiffs {
+		if want, ok := wantIndexes[x.Index]; !ok || !reflect.DeepEqual(x.Differences, want) {
+			t.Errorf("Index %s: expected differences %v, got %v", x.Index, want, x.Differences)
+		}
+	}
+
+	summary := diff.Summary()
+	for _, want := range []string{
+		"Tables missing from target: audit_log",
+		"Tables missing from source: sessions",
+		"users.email: type varchar(100) -> varchar(255), nullable false -> true",
+		"users.status: default 'active' -> none",
+		"users.legacy_id: missing from target",
+		"users.created_at: missing from source",
+		"users.users_email_idx: unique false -> true",
+		"users.users_created_idx: missing from source",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Expected %q in summary:\n%s", want, summary)
+		}
+	}
+}
+
+func TestCompareSchemas_Identical(t *testing.T) {
+	db := &fakeDatabase{tables: map[string]*database.TableSchema{
+		"users": {TableName: "users", Columns: []database.ColumnInfo{{Name: "id", Type: "integer"}}},
+	}}
+
+	diff, err := CompareSchemas(context.Background(), db, db)
+	if err != nil {
+		t.Fatalf("CompareSchemas() error = %v", err)
+	}
+	if !diff.IsEmpty() {
+		t.Errorf("Expected no differences, got %+v", diff)
+	}
+	if summary := diff.Summary(); summary != "Schemas are identical (1 tables compared)" {
+		t.Errorf("Unexpected summary %q", summary)
+	}
+}
+
+func TestCompareSchemas_Errors(t *testing.T) {
+	ok := &fakeDatabase{tables: map[string]*database.TableSchema{"users": {TableName: "users"}}}
+	failing := &fakeDatabase{listErr: fmt.Errorf("permission denied")}
+
+	tests := []struct {
+		name    string
+		source  database.Database
+		target  database.Database
+		wantErr string
+	}{
+		{name: "source", source: failing, target: ok, wantErr: "failed to list source tables: permission denied"},
+		{name: "target", source: ok, target: failing, wantErr: "failed to list target tables: permission denied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompareSchemas(context.Background(), tt.source, tt.target)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("CompareSchemas() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
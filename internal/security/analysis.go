@@ -0,0 +1,348 @@
+package security
+
+import (
+	"strings"
+)
+
+// QueryAnalysis describes the side effects of a SQL statement as determined by static
+// analysis, without executing it.
+type QueryAnalysis struct {
+	Operation string   `json:"operation"` // Statement type as reported by DetermineQueryType
+	Reads     []string `json:"reads"`     // Tables the statement reads from, in order of appearance
+	Writes    []string `json:"writes"`    // Tables the statement modifies, in order of appearance
+}
+
+// tableKeywords are the words that may not be read as a table alias after a table reference.
+var tableKeywords = map[string]bool{
+	"WHERE": true, "JOIN": true, "INNER": true, "LEFT": true, "RIGHT": true, "FULL": true,
+	"OUTER": true, "CROSS": true, "NATURAL": true, "ON": true, "USING": true, "GROUP": true,
+	"ORDER": true, "HAVING": true, "LIMIT": true, "OFFSET": true, "UNION": true, "EXCEPT": true,
+	"INTERSECT": true, "SET": true, "VALUES": true, "SELECT": true, "FROM": true, "RETURNING": true,
+	"WINDOW": true, "FOR": true, "LATERAL": true, "AS": true, "PARTITION": true, "DEFAULT": true,
+	"STRAIGHT_JOIN": true, "FETCH": true, "OUTFILE": true, "DUMPFILE": true,
+}
+
+// AnalyzeQuery statically determines which tables query reads from and writes to.
+// Table references are recognised after FROM, JOIN, USING, UPDATE, INTO and the
+// TABLE keyword of DDL statements; comma-separated FROM lists are followed. Names
+// defined by WITH clauses, string literals, comments and FROM inside function calls
+// such as EXTRACT(YEAR FROM d) are ignored. The analysis is best effort: it does not
+// resolve views, triggers or function bodies, which may touch further tables.
+func AnalyzeQuery(query string) *QueryAnalysis {
+	analysis := &QueryAnalysis{
+		Operation: DetermineQueryType(query, ""),
+		Reads:     []string{},
+		Writes:    []string{},
+	}
+
+	tokens := tokenizeSQL(query)
+	cteNames := map[string]bool{}
+	statement := ""     // Leading keyword of the current statement
+	var subquery []bool // For each open parenthesis, whether it encloses a query
+
+	add := func(list *[]string) func(string) {
+		return func(name string) {
+			if cteNames[strings.ToLower(name)] {
+				return
+			}
+			for _, existing := range *list {
+				if existing == name {
+					return
+				}
+			}
+			*list = append(*list, name)
+		}
+	}
+	addRead, addWrite := add(&analysis.Reads), add(&analysis.Writes)
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		if token.kind == tokenPunct {
+			switch token.text {
+			case "(":
+				next := ""
+				if i+1 < len(tokens) {
+					next = strings.ToUpper(tokens[i+1].text)
+				}
+				subquery = append(subquery, next == "SELECT" || next == "WITH")
+			case ")":
+				if len(subquery) > 0 {
+					subquery = subquery[:len(subquery)-1]
+				}
+			case ";":
+				statement = ""
+				subquery = nil
+			}
+			continue
+		}
+		if token.kind != tokenWord {
+			continue
+		}
+
+		word := strings.ToUpper(token.text)
+		if statement == "" {
+			statement = word
+		}
+		previous := ""
+		if i > 0 {
+			previous = strings.ToUpper(tokens[i-1].text)
+		}
+
+		switch word {
+		case "WITH":
+			collectCTENames(tokens, i+1, cteNames)
+
+		case "FROM", "JOIN", "USING":
+			// FROM inside a function call, e.g. EXTRACT(YEAR FROM d), is not a table reference
+			if len(subquery) > 0 && !subquery[len(subquery)-1] {
+				continue
+			}
+			if word == "FROM" && previous == "DELETE" {
+				i = readTableList(tokens, i+1, false, addWrite)
+			} else {
+				i = readTableList(tokens, i+1, word == "FROM", addRead)
+			}
+
+		case "UPDATE":
+			// Skip ON DUPLICATE KEY UPDATE and FOR UPDATE
+			if statement == "UPDATE" || statement == "WITH" {
+				i = readTableList(tokens, i+1, false, addWrite)
+			}
+
+		case "INTO":
+			i = readTableList(tokens, i+1, false, addWrite)
+
+		case "TABLE", "TRUNCATE":
+			if word == "TABLE" && statement != "CREATE" && statement != "DROP" && statement != "ALTER" &&
+				statement != "TRUNCATE" && statement != "RENAME" {
+				continue
+			}
+			if word == "TRUNCATE" && i+1 < len(tokens) && strings.ToUpper(tokens[i+1].text) == "TABLE" {
+				continue
+			}
+			j := i + 1
+			for j < len(tokens) && ddlTableModifiers[strings.ToUpper(tokens[j].text)] {
+				j++
+			}
+			i = readNameList(tokens, j, addWrite)
+		}
+	}
+
+	return analysis
+}
+
+// ddlTableModifiers are the words that may appear between TABLE and the table name.
+var ddlTableModifiers = map[string]bool{"IF": true, "NOT": true, "EXISTS": true, "ONLY": true}
+
+// collectCTENames records the names defined by the WITH clause whose first CTE starts at
+// tokens[start], so that references to them are not reported as tables.
+func collectCTENames(tokens []sqlToken, start int, names map[string]bool) {
+	i := start
+	if i < len(tokens) && strings.ToUpper(tokens[i].text) == "RECURSIVE" {
+		i++
+	}
+
+	for i < len(tokens) && tokens[i].kind != tokenPunct {
+		names[strings.ToLower(tokens[i].text)] = true
+		i++
+
+		if i < len(tokens) && tokens[i].text == "(" {
+			i = skipParentheses(tokens, i) // Column list
+		}
+		if i >= len(tokens) || strings.ToUpper(tokens[i].text) != "AS" {
+			return
+		}
+		i++
+		for i < len(tokens) && (strings.ToUpper(tokens[i].text) == "NOT" || strings.ToUpper(tokens[i].text) == "MATERIALIZED") {
+			i++
+		}
+		if i >= len(tokens) || tokens[i].text != "(" {
+			return
+		}
+		i = skipParentheses(tokens, i)
+		if i >= len(tokens) || tokens[i].text != "," {
+			return
+		}
+		i++
+	}
+}
+
+// skipParentheses returns the index following the parenthesis that closes tokens[open].
+func skipParentheses(tokens []sqlToken, open int) int {
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		if tokens[i].kind != tokenPunct {
+			continue
+		}
+		switch tokens[i].text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(tokens)
+}
+
+// readTableList reads a table reference and its optional alias starting at tokens[start],
+// reporting the table through add. When list is set, further comma-separated references
+// are read as well. It returns the index of the last token consumed.
+func readTableList(tokens []sqlToken, start int, list bool, add func(string)) int {
+	i := start
+	for {
+		// Skip ONLY (PostgreSQL) and LATERAL before the reference
+		for i < len(tokens) && tokens[i].kind == tokenWord &&
+			(strings.ToUpper(tokens[i].text) == "ONLY" || strings.ToUpper(tokens[i].text) == "LATERAL") {
+			i++
+		}
+
+		name, next := readQualifiedName(tokens, i)
+		if name == "" {
+			return i - 1
+		}
+		add(name)
+		i = next
+
+		// Skip an optional alias
+		if i+1 < len(tokens) && strings.ToUpper(tokens[i].text) == "AS" && tokens[i+1].kind != tokenPunct {
+			i += 2
+		} else if i < len(tokens) && tokens[i].kind != tokenPunct && !tableKeywords[strings.ToUpper(tokens[i].text)] {
+			i++
+		}
+
+		if !list || i >= len(tokens) || tokens[i].text != "," {
+			return i - 1
+		}
+		i++
+	}
+}
+
+// readNameList reads comma-separated table names without aliases starting at tokens[start],
+// reporting each through add. It returns the index of the last token consumed.
+func readNameList(tokens []sqlToken, start int, add func(string)) int {
+	i := start
+	for {
+		name, next := readQualifiedName(tokens, i)
+		if name == "" {
+			return i - 1
+		}
+		add(name)
+		i = next
+
+		if i >= len(tokens) || tokens[i].text != "," {
+			return i - 1
+		}
+		i++
+	}
+}
+
+// readQualifiedName reads a possibly qualified identifier such as db.table starting at
+// tokens[i]. It returns the name and the index of the token following it, or an empty
+// name if tokens[i] is not an identifier.
+func readQualifiedName(tokens []sqlToken, i int) (string, int) {
+	if i >= len(tokens) || tokens[i].kind == tokenPunct ||
+		tokens[i].kind == tokenWord && tableKeywords[strings.ToUpper(tokens[i].text)] {
+		return "", i
+	}
+
+	parts := []string{tokens[i].text}
+	i++
+	for i+1 < len(tokens) && tokens[i].text == "." && tokens[i+1].kind != tokenPunct {
+		parts = append(parts, tokens[i+1].text)
+		i += 2
+	}
+	return strings.Join(parts, "."), i
+}
+
+// sqlTokenKind classifies a token produced by tokenizeSQL.
+type sqlTokenKind int
+
+const (
+	tokenWord   sqlTokenKind = iota // Keyword or unquoted identifier
+	tokenQuoted                     // Quoted identifier, with the quotes removed
+	tokenPunct                      // Single punctuation character
+)
+
+// sqlToken is a lexical token of a SQL statement.
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+}
+
+// tokenizeSQL splits query into words, quoted identifiers and punctuation. String
+// literals, numbers, operators and comments are dropped. Double-quoted and backtick
+// identifiers are returned without their quotes; doubled quote characters inside them
+// are unescaped.
+func tokenizeSQL(query string) []sqlToken {
+	var tokens []sqlToken
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return tokens
+			}
+			i += end + 3
+		case c == '\'':
+			for i++; i < len(query); i++ {
+				if query[i] == '\\' {
+					i++
+				} else if query[i] == '\'' {
+					if i+1 < len(query) && query[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+		case c == '"' || c == '`':
+			var name strings.Builder
+			for i++; i < len(query); i++ {
+				if query[i] == c {
+					if i+1 < len(query) && query[i+1] == c {
+						name.WriteByte(c)
+						i++
+						continue
+					}
+					break
+				}
+				name.WriteByte(query[i])
+			}
+			tokens = append(tokens, sqlToken{kind: tokenQuoted, text: name.String()})
+		case isWordStart(c):
+			start := i
+			for i+1 < len(query) && isWordPart(query[i+1]) {
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind: tokenWord, text: query[start : i+1]})
+		case c >= '0' && c <= '9':
+			for i+1 < len(query) && (isWordPart(query[i+1]) || query[i+1] == '.') {
+				i++
+			}
+		case c == '(' || c == ')' || c == ',' || c == '.' || c == ';':
+			tokens = append(tokens, sqlToken{kind: tokenPunct, text: string(c)})
+		}
+	}
+
+	return tokens
+}
+
+// isWordStart reports whether c can start an unquoted identifier or keyword.
+func isWordStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c >= 0x80
+}
+
+// isWordPart reports whether c can continue an unquoted identifier or keyword.
+func isWordPart(c byte) bool {
+	return isWordStart(c) || c >= '0' && c <= '9' || c == '$'
+}
@@ -0,0 +1,117 @@
+package security
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		operation string
+		reads     []string
+		writes    []string
+	}{
+		{
+			name:      "select with joins",
+			query:     "SELECT u.name, o.total FROM users u JOIN orders o ON o.user_id = u.id LEFT JOIN payments AS p USING (order_id)",
+			operation: "select",
+			reads:     []string{"users", "orders", "payments"},
+			writes:    []string{},
+		},
+		{
+			name:      "comma-separated from list",
+			query:     "SELECT * FROM users u, orders o WHERE o.user_id = u.id",
+			operation: "select",
+			reads:     []string{"users", "orders"},
+			writes:    []string{},
+		},
+		{
+			name:      "insert select",
+			query:     "INSERT INTO archive_orders (id, total) SELECT o.id, o.total FROM orders o JOIN users u ON u.id = o.user_id WHERE u.active = false",
+			operation: "insert",
+			reads:     []string{"orders", "users"},
+			writes:    []string{"archive_orders"},
+		},
+		{
+			name:      "update from",
+			query:     "UPDATE accounts a SET balance = a.balance - t.amount FROM transfers t WHERE t.account_id = a.id",
+			operation: "update",
+			reads:     []string{"transfers"},
+			writes:    []string{"accounts"},
+		},
+		{
+			name:      "mysql multi-table update",
+			query:     "UPDATE orders o JOIN users u ON u.id = o.user_id SET o.status = 'closed' WHERE u.deleted = 1",
+			operation: "update",
+			reads:     []string{"users"},
+			writes:    []string{"orders"},
+		},
+		{
+			name:      "delete with subquery",
+			query:     "DELETE FROM sessions WHERE user_id IN (SELECT id FROM users WHERE banned)",
+			operation: "delete",
+			reads:     []string{"users"},
+			writes:    []string{"sessions"},
+		},
+		{
+			name:      "qualified and quoted names",
+			query:     `SELECT * FROM analytics.events e JOIN "Order Items" oi ON oi.event_id = e.id JOIN ` + "`shop`.`carts`" + ` c ON c.id = oi.cart_id`,
+			operation: "select",
+			reads:     []string{"analytics.events", "Order Items", "shop.carts"},
+			writes:    []string{},
+		},
+		{
+			name:      "CTE names are not tables",
+			query:     "WITH recent AS (SELECT * FROM orders WHERE created_at > now()), totals (n) AS (SELECT count(*) FROM recent) SELECT * FROM totals",
+			operation: "select",
+			reads:     []string{"orders"},
+			writes:    []string{},
+		},
+		{
+			name:      "FROM inside functions, literals and comments",
+			query:     "SELECT EXTRACT(YEAR FROM created_at), 'FROM fake' FROM orders -- FROM commented\n/* JOIN hidden */",
+			operation: "select",
+			reads:     []string{"orders"},
+			writes:    []string{},
+		},
+		{
+			name:      "create table as select",
+			query:     "CREATE TABLE IF NOT EXISTS order_summary AS SELECT user_id, sum(total) FROM orders GROUP BY user_id",
+			operation: "ddl",
+			reads:     []string{"orders"},
+			writes:    []string{"order_summary"},
+		},
+		{
+			name:      "drop and truncate",
+			query:     "DROP TABLE old_a, old_b; TRUNCATE staging",
+			operation: "ddl",
+			reads:     []string{},
+			writes:    []string{"old_a", "old_b", "staging"},
+		},
+		{
+			name:      "select for update",
+			query:     "SELECT * FROM jobs WHERE state = 'queued' FOR UPDATE",
+			operation: "select",
+			reads:     []string{"jobs"},
+			writes:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := AnalyzeQuery(tt.query)
+
+			if analysis.Operation != tt.operation {
+				t.Errorf("Expected operation %q, got %q", tt.operation, analysis.Operation)
+			}
+			if !reflect.DeepEqual(analysis.Reads, tt.reads) {
+				t.Errorf("Expected reads %v, got %v", tt.reads, analysis.Reads)
+			}
+			if !reflect.DeepEqual(analysis.Writes, tt.writes) {
+				t.Errorf("Expected writes %v, got %v", tt.writes, analysis.Writes)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package security
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HasRowLimit reports whether query limits its own result at the top level with a LIMIT,
+// OFFSET or FETCH clause. Clauses inside subqueries, string literals and comments are
+// ignored.
+func HasRowLimit(query string) bool {
+	depth := 0
+	for _, token := range tokenizeSQL(query) {
+		switch {
+		case token.kind == tokenPunct && token.text == "(":
+			depth++
+		case token.kind == tokenPunct && token.text == ")":
+			depth--
+		case token.kind == tokenWord && depth == 0:
+			switch strings.ToUpper(token.text) {
+			case "LIMIT", "OFFSET", "FETCH":
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AppendRowLimit appends a LIMIT and OFFSET clause to a SELECT statement. A trailing
+// semicolon is removed, and the clause starts on a new line so that a trailing line
+// comment cannot swallow it. Both PostgreSQL and MySQL accept the syntax.
+func AppendRowLimit(query string, limit, offset int) string {
+	query = strings.TrimRight(strings.TrimSpace(query), ";")
+	return fmt.Sprintf("%s\nLIMIT %d OFFSET %d", strings.TrimSpace(query), limit, offset)
+}
+
+// rowLimitClauseStarts are the keywords that start the trailing row limiting and locking
+// clauses of a SELECT.
+var rowLimitClauseStarts = map[string]bool{"LIMIT": true, "OFFSET": true, "FETCH": true, "FOR": true, "LOCK": true}
+
+// ZeroRowLimit rewrites a SELECT statement to return no rows, so that running it yields
+// the result columns without reading any data. The top-level LIMIT, OFFSET, FETCH and
+// locking clauses are replaced by LIMIT 0, or LIMIT 0 is appended when there are none.
+// It reports false, leaving query unchanged, when those clauses bind parameters, since
+// removing them would leave the arguments without placeholders.
+func ZeroRowLimit(query, dbType string) (string, bool) {
+	query = strings.TrimRight(strings.TrimSpace(query), ";")
+	tokens := lexRowFilterSQL(query, dbType == "mysql")
+
+	depth := 0
+	for i, token := range tokens {
+		switch {
+		case token.kind == rfPunct && token.text == "(":
+			depth++
+		case token.kind == rfPunct && token.text == ")":
+			depth--
+		case token.kind == rfWord && depth == 0 && rowLimitClauseStarts[strings.ToUpper(token.text)]:
+			for _, rest := range tokens[i:] {
+				if isParameterToken(rest) {
+					return query, false
+				}
+			}
+			return query[:token.start] + "LIMIT 0", true
+		}
+	}
+	return fmt.Sprintf("%s\nLIMIT 0", strings.TrimSpace(query)), true
+}
+
+// isParameterToken reports whether token is a ? or $n parameter placeholder.
+func isParameterToken(token rowFilterToken) bool {
+	if token.kind == rfPlaceholder {
+		return true
+	}
+	return token.kind == rfOther && len(token.text) > 1 && token.text[0] == '$' && token.text[1] >= '0' && token.text[1] <= '9'
+}
@@ -0,0 +1,77 @@
+package security
+
+import "testing"
+
+func TestHasRowLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{name: "no limit", query: "SELECT * FROM users", want: false},
+		{name: "limit", query: "SELECT * FROM users LIMIT 10", want: true},
+		{name: "lowercase offset", query: "select * from users order by id offset 5", want: true},
+		{name: "fetch first", query: "SELECT * FROM users FETCH FIRST 5 ROWS ONLY", want: true},
+		{name: "limit in subquery", query: "SELECT * FROM (SELECT * FROM users LIMIT 5) AS u", want: false},
+		{name: "limit in string literal", query: "SELECT * FROM notes WHERE body = 'LIMIT 5'", want: false},
+		{name: "limit in comment", query: "SELECT * FROM users -- LIMIT 5", want: false},
+		{name: "quoted column named limit", query: `SELECT "limit" FROM quotas`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasRowLimit(tt.query); got != tt.want {
+				t.Errorf("HasRowLimit(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendRowLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "plain query", query: "SELECT * FROM users", want: "SELECT * FROM users\nLIMIT 11 OFFSET 20"},
+		{name: "trailing semicolon", query: "SELECT * FROM users;  ", want: "SELECT * FROM users\nLIMIT 11 OFFSET 20"},
+		{name: "trailing comment", query: "SELECT * FROM users -- all users", want: "SELECT * FROM users -- all users\nLIMIT 11 OFFSET 20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AppendRowLimit(tt.query, 11, 20); got != tt.want {
+				t.Errorf("AppendRowLimit() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZeroRowLimit(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		dbType string
+		want   string
+		wantOK bool
+	}{
+		{name: "no limit", query: "SELECT * FROM users;", dbType: "postgres", want: "SELECT * FROM users\nLIMIT 0", wantOK: true},
+		{name: "own limit replaced", query: "SELECT * FROM users ORDER BY id LIMIT 10 OFFSET 5", dbType: "postgres", want: "SELECT * FROM users ORDER BY id LIMIT 0", wantOK: true},
+		{name: "fetch first", query: "SELECT * FROM users FETCH FIRST 5 ROWS ONLY", dbType: "postgres", want: "SELECT * FROM users LIMIT 0", wantOK: true},
+		{name: "locking clause", query: "SELECT * FROM users FOR UPDATE", dbType: "mysql", want: "SELECT * FROM users LIMIT 0", wantOK: true},
+		{name: "limit in subquery", query: "SELECT * FROM (SELECT * FROM users LIMIT 5) AS u", dbType: "postgres", want: "SELECT * FROM (SELECT * FROM users LIMIT 5) AS u\nLIMIT 0", wantOK: true},
+		{name: "limit in string literal", query: "SELECT * FROM notes WHERE body = 'LIMIT 5'", dbType: "mysql", want: "SELECT * FROM notes WHERE body = 'LIMIT 5'\nLIMIT 0", wantOK: true},
+		{name: "union", query: "SELECT id FROM a UNION SELECT id FROM b LIMIT 3", dbType: "mysql", want: "SELECT id FROM a UNION SELECT id FROM b LIMIT 0", wantOK: true},
+		{name: "parameterized limit", query: "SELECT * FROM users LIMIT $1", dbType: "postgres", want: "SELECT * FROM users LIMIT $1", wantOK: false},
+		{name: "mysql parameterized offset", query: "SELECT * FROM users LIMIT 10 OFFSET ?", dbType: "mysql", want: "SELECT * FROM users LIMIT 10 OFFSET ?", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ZeroRowLimit(tt.query, tt.dbType)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ZeroRowLimit(%q) = %q, %v, want %q, %v", tt.query, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package security
+
+import (
+	"fmt"
+	"strings"
+)
+
+// whereClauseEnd lists the keywords that end a WHERE clause at the same nesting level.
+var whereClauseEnd = map[string]bool{
+	"GROUP": true, "HAVING": true, "ORDER": true, "LIMIT": true, "OFFSET": true,
+	"FETCH": true, "FOR": true, "WINDOW": true, "RETURNING": true,
+	"UNION": true, "INTERSECT": true, "EXCEPT": true,
+}
+
+// validateParameterized rejects queries that compare against inline string or numeric
+// literals in a WHERE clause (see DB_REQUIRE_PARAMS), so values are bound as parameters
+// instead. Literals elsewhere, such as in the select list, SET or VALUES clauses and LIMIT,
+// are allowed, as are NULL, TRUE and FALSE. Subqueries start a new scope, so the select
+// list of WHERE EXISTS (SELECT 1 ...) is allowed while the subquery's own WHERE is checked.
+// In MySQL, double-quoted text is a string literal; in PostgreSQL it is an identifier.
+func (v *QueryValidator) validateParameterized(query string) error {
+	mysql := v.config.Type == "mysql"
+	postgres := !mysql
+
+	// One entry per open parenthesis, recording whether that level is inside a WHERE clause
+	inWhere := []bool{false}
+	reject := func(kind string) error {
+		return fmt.Errorf("inline %s literal in WHERE clause; bind the value as a query parameter instead (DB_REQUIRE_PARAMS is enabled)", kind)
+	}
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		level := len(inWhere) - 1
+		switch {
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return nil
+			}
+			i += end + 3
+		case c == '\'' || (c == '"' && mysql):
+			if inWhere[level] {
+				return reject("string")
+			}
+			i = skipQuoted(query, i, mysql)
+		case c == '"' || c == '`':
+			i = skipQuoted(query, i, false)
+		case c == '$' && i+1 < len(query) && query[i+1] >= '0' && query[i+1] <= '9':
+			// Positional parameter such as $1
+			for i+1 < len(query) && query[i+1] >= '0' && query[i+1] <= '9' {
+				i++
+			}
+		case c == '$' && postgres:
+			if tag, ok := dollarQuoteTag(query, i); ok {
+				if inWhere[level] {
+					return reject("string")
+				}
+				end := strings.Index(query[i+len(tag):], tag)
+				if end < 0 {
+					return nil
+				}
+				i += len(tag) + end + len(tag) - 1
+			}
+		case c >= '0' && c <= '9':
+			if inWhere[level] {
+				return reject("numeric")
+			}
+			for i+1 < len(query) && (isWordPart(query[i+1]) || query[i+1] == '.') {
+				i++
+			}
+		case isWordStart(c):
+			start := i
+			for i+1 < len(query) && isWordPart(query[i+1]) {
+				i++
+			}
+			switch word := strings.ToUpper(query[start : i+1]); {
+			case word == "WHERE":
+				inWhere[level] = true
+			case word == "SELECT" || whereClauseEnd[word]:
+				inWhere[level] = false
+			}
+		case c == '(':
+			inWhere = append(inWhere, inWhere[level])
+		case c == ')':
+			if level > 0 {
+				inWhere = inWhere[:level]
+			}
+		case c == ';':
+			inWhere = []bool{false}
+		}
+	}
+
+	return nil
+}
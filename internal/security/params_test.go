@@ -0,0 +1,67 @@
+package security
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQueryValidator_ValidateQuery_RequireParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		dbType  string
+		query   string
+		wantErr string
+	}{
+		{name: "string literal in where", query: "SELECT * FROM users WHERE email = 'a@example.com'", wantErr: "string literal"},
+		{name: "numeric literal in where", query: "SELECT * FROM users WHERE id = 5", wantErr: "numeric literal"},
+		{name: "positional parameter", query: "SELECT * FROM users WHERE id = $1"},
+		{name: "question mark parameter", dbType: "mysql", query: "SELECT * FROM users WHERE id = ? AND email = ?"},
+		{name: "literals in select list", query: "SELECT 1 AS one, 'x' AS tag, id FROM users WHERE id = $1"},
+		{name: "constant expression without where", query: "SELECT 2 * 21"},
+		{name: "null and booleans", query: "SELECT * FROM users WHERE deleted_at IS NULL AND active = TRUE"},
+		{name: "limit after where", query: "SELECT * FROM users WHERE id > $1 ORDER BY id LIMIT 10"},
+		{name: "identifier with digits", query: "SELECT * FROM t1 WHERE col2 = $1"},
+		{name: "quoted identifier", query: `SELECT * FROM users WHERE "order 1" = $1`},
+		{name: "exists subquery select list", query: "SELECT * FROM users u WHERE EXISTS (SELECT 1 FROM orders o WHERE o.user_id = u.id)"},
+		{name: "literal in subquery where", query: "SELECT * FROM users WHERE id IN (SELECT user_id FROM orders WHERE total > 100)", wantErr: "numeric literal"},
+		{name: "literal after closing subquery", query: "SELECT * FROM users WHERE id IN (SELECT user_id FROM orders) AND age > 18", wantErr: "numeric literal"},
+		{name: "function call in where", query: "SELECT * FROM users WHERE lower(email) = lower($1)"},
+		{name: "literal in function call", query: "SELECT * FROM users WHERE coalesce(score, 0) > $1", wantErr: "numeric literal"},
+		{name: "update set literal allowed", query: "UPDATE users SET active = 'yes' WHERE id = $1"},
+		{name: "update where literal", query: "UPDATE users SET active = $1 WHERE id = 7", wantErr: "numeric literal"},
+		{name: "insert values", query: "INSERT INTO users (name, age) VALUES ('a', 30)"},
+		{name: "delete where literal", query: "DELETE FROM users WHERE name = 'bob'", wantErr: "string literal"},
+		{name: "dollar-quoted literal", query: "SELECT * FROM users WHERE name = $$bob$$", wantErr: "string literal"},
+		{name: "mysql double-quoted string", dbType: "mysql", query: `SELECT * FROM users WHERE name = "bob"`, wantErr: "string literal"},
+		{name: "mysql backtick identifier", dbType: "mysql", query: "SELECT * FROM users WHERE `name` = ?"},
+		{name: "union resets where", query: "SELECT id FROM a WHERE x = $1 UNION SELECT 0 FROM b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig(nil)
+			if tt.dbType != "" {
+				cfg.Type = tt.dbType
+			}
+			cfg.RequireParams = true
+
+			err := NewQueryValidator(cfg).ValidateQuery(tt.query)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateQuery() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateQuery() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQueryValidator_ValidateQuery_RequireParamsDisabled(t *testing.T) {
+	validator := NewQueryValidator(createTestConfig(nil))
+	if err := validator.ValidateQuery("SELECT * FROM users WHERE id = 5"); err != nil {
+		t.Errorf("Expected inline literals to be allowed by default, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package security
+
+import (
+	"strconv"
+	"strings"
+)
+
+// placeholderKeywords are the keywords a ? placeholder may follow. After any other word, a
+// ? follows an operand and is a JSONB operator.
+var placeholderKeywords = map[string]bool{
+	"ALL": true, "AND": true, "ANY": true, "BETWEEN": true, "BY": true, "CASE": true,
+	"DISTINCT": true, "ELSE": true, "ESCAPE": true, "EXISTS": true, "FETCH": true, "FIRST": true,
+	"FOR": true, "FROM": true, "HAVING": true, "ILIKE": true, "IN": true, "INTERVAL": true,
+	"IS": true, "LIKE": true, "LIMIT": true, "NEXT": true, "NOT": true, "OFFSET": true,
+	"ON": true, "OR": true, "RETURN": true, "SELECT": true, "SET": true, "SIMILAR": true,
+	"SOME": true, "THEN": true, "TO": true, "VALUES": true, "WHEN": true, "WHERE": true,
+}
+
+// RewritePlaceholders converts MySQL-style ? placeholders in query to PostgreSQL's
+// positional $1..$n, numbering them in order of appearance. Question marks inside string
+// literals (including E'...' escape strings), quoted identifiers, comments and dollar-quoted
+// bodies are left alone.
+//
+// A ? directly after an operand, such as a column, literal or closing parenthesis, is taken
+// as one of the JSONB operators ?, ?| and ?& and left alone, as in data ? 'key' AND id = ?.
+// The operators can only be told from placeholders this way, so a query using them is best
+// written with $n placeholders: a query that already uses $n parameters is returned unchanged.
+func RewritePlaceholders(query string) string {
+	var out strings.Builder
+	out.Grow(len(query) + 8)
+
+	param := 0
+	start := 0 // Start of the text not yet copied to out
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += end + 3
+			}
+		case c == '\'' || c == '"':
+			i = skipQuoted(query, i, c == '\'' && isEscapeStringPrefix(query, i))
+		case c == '$':
+			if i+1 < len(query) && query[i+1] >= '0' && query[i+1] <= '9' && (i == 0 || !isWordPart(query[i-1])) {
+				return query
+			}
+			if tag, ok := dollarQuoteTag(query, i); ok {
+				end := strings.Index(query[i+len(tag):], tag)
+				if end < 0 {
+					i = len(query)
+				} else {
+					i += len(tag) + end + len(tag) - 1
+				}
+			}
+		case c == '?':
+			if followsOperand(query, i) {
+				continue
+			}
+			param++
+			out.WriteString(query[start:i])
+			out.WriteString("$" + strconv.Itoa(param))
+			start = i + 1
+		}
+	}
+
+	if param == 0 {
+		return query
+	}
+	out.WriteString(query[start:])
+	return out.String()
+}
+
+// followsOperand reports whether the ? at query[i] comes directly after an operand: a word
+// other than a keyword in placeholderKeywords, a number, a quoted literal or identifier, or a
+// closing parenthesis or bracket. Whitespace before the ? is skipped.
+func followsOperand(query string, i int) bool {
+	end := i
+	for end > 0 && (query[end-1] == ' ' || query[end-1] == '\t' || query[end-1] == '\n' || query[end-1] == '\r') {
+		end--
+	}
+	if end == 0 {
+		return false
+	}
+
+	switch c := query[end-1]; {
+	case c == '\'' || c == '"' || c == ')' || c == ']':
+		return true
+	case isWordPart(c):
+		start := end - 1
+		for start > 0 && isWordPart(query[start-1]) {
+			start--
+		}
+		return !placeholderKeywords[strings.ToUpper(query[start:end])]
+	default:
+		return false
+	}
+}
+
+// isEscapeStringPrefix reports whether the single quote at query[quote] opens a PostgreSQL
+// escape string such as E'it\'s', in which a backslash escapes the quote.
+func isEscapeStringPrefix(query string, quote int) bool {
+	if quote == 0 || (query[quote-1] != 'E' && query[quote-1] != 'e') {
+		return false
+	}
+	return quote == 1 || !isWordPart(query[quote-2])
+}
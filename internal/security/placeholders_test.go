@@ -0,0 +1,100 @@
+package security
+
+import "testing"
+
+func TestRewritePlaceholders(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "positional placeholders",
+			query: "SELECT * FROM users WHERE id = ? AND status = ?",
+			want:  "SELECT * FROM users WHERE id = $1 AND status = $2",
+		},
+		{
+			name:  "no placeholders",
+			query: "SELECT * FROM users",
+			want:  "SELECT * FROM users",
+		},
+		{
+			name:  "question mark in string literal",
+			query: "SELECT * FROM faq WHERE question = 'Why?' AND id = ?",
+			want:  "SELECT * FROM faq WHERE question = 'Why?' AND id = $1",
+		},
+		{
+			name:  "doubled quotes in literal",
+			query: "SELECT 'it''s ?', ? FROM t",
+			want:  "SELECT 'it''s ?', $1 FROM t",
+		},
+		{
+			name:  "escape string with backslash-escaped quote",
+			query: `SELECT E'it\'s ?', ? FROM t`,
+			want:  `SELECT E'it\'s ?', $1 FROM t`,
+		},
+		{
+			name:  "backslash in standard literal does not escape",
+			query: `SELECT 'C:\', ? FROM t`,
+			want:  `SELECT 'C:\', $1 FROM t`,
+		},
+		{
+			name:  "quoted identifier",
+			query: `SELECT "what?" FROM t WHERE id = ?`,
+			want:  `SELECT "what?" FROM t WHERE id = $1`,
+		},
+		{
+			name:  "comments",
+			query: "SELECT ? -- really?\n/* or ? */ FROM t WHERE id = ?",
+			want:  "SELECT $1 -- really?\n/* or ? */ FROM t WHERE id = $2",
+		},
+		{
+			name:  "dollar-quoted body",
+			query: "SELECT $$what?$$, $tag$ ? $tag$, ?",
+			want:  "SELECT $$what?$$, $tag$ ? $tag$, $1",
+		},
+		{
+			name:  "already positional",
+			query: "SELECT * FROM docs WHERE data ? 'key' AND id = $1",
+			want:  "SELECT * FROM docs WHERE data ? 'key' AND id = $1",
+		},
+		{
+			name:  "jsonb operators after an operand",
+			query: "SELECT * FROM docs WHERE data ? 'key' AND tags ?| ARRAY['a'] AND (meta->'x') ?& ? AND id = ?",
+			want:  "SELECT * FROM docs WHERE data ? 'key' AND tags ?| ARRAY['a'] AND (meta->'x') ?& $1 AND id = $2",
+		},
+		{
+			name:  "jsonb operator after a quoted identifier",
+			query: `SELECT "data"?'key' FROM docs WHERE id = ?`,
+			want:  `SELECT "data"?'key' FROM docs WHERE id = $1`,
+		},
+		{
+			name:  "placeholders after keywords",
+			query: "SELECT ?, ? || 'x' FROM t WHERE a BETWEEN ? AND ? OR b IN (?, ?) LIMIT ? OFFSET ?",
+			want:  "SELECT $1, $2 || 'x' FROM t WHERE a BETWEEN $3 AND $4 OR b IN ($5, $6) LIMIT $7 OFFSET $8",
+		},
+		{
+			name:  "placeholders after operators",
+			query: "UPDATE t SET a = ?, b = -? WHERE c <> ? AND d = ANY(?)",
+			want:  "UPDATE t SET a = $1, b = -$2 WHERE c <> $3 AND d = ANY($4)",
+		},
+		{
+			name:  "identifier containing a dollar sign is not positional",
+			query: "SELECT price$1 FROM t WHERE id = ?",
+			want:  "SELECT price$1 FROM t WHERE id = $1",
+		},
+		{
+			name:  "unterminated literal",
+			query: "SELECT ? FROM t WHERE name = 'oops?",
+			want:  "SELECT $1 FROM t WHERE name = 'oops?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RewritePlaceholders(tt.query); got != tt.want {
+				t.Errorf("RewritePlaceholders(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,583 @@
+package security
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ApplyRowFilters rewrites query so that every reference to a table in filters (keyed by
+// lowercased table name, see config.ParseRowFilters) only sees the rows matching that
+// table's predicate:
+//
+//   - tables read in FROM, JOIN or USING clauses, including parenthesized ones such as
+//     "FROM (orders CROSS JOIN customers)", are replaced by a filtered derived table,
+//     "(SELECT * FROM orders WHERE tenant_id = $1) AS orders", keeping any alias;
+//   - a TABLE statement, "TABLE orders", is rewritten to select from that derived table;
+//   - the target of an UPDATE or DELETE gets the predicate ANDed into its WHERE clause.
+//
+// The predicates' :name placeholders are bound to params as extra query arguments, using
+// $n numbering after args for PostgreSQL and ? placeholders in statement order for MySQL,
+// so the returned arguments replace args. INSERT targets are not filtered. A parameter
+// missing from params is bound as NULL, so the filter matches no rows, and reported as an
+// error; callers that cannot report errors may still run the returned query safely.
+//
+// Any other mention of a filtered table, for example as the target of DDL, cannot be
+// restricted and fails closed: the statement is rejected with an error and an empty query
+// is returned in its place. The query is returned unchanged when it references no
+// filtered table.
+func ApplyRowFilters(query string, args []any, filters map[string]string, params map[string]any, dbType string) (string, []any, error) {
+	if len(filters) == 0 {
+		return query, args, nil
+	}
+
+	tokens := lexRowFilterSQL(query, dbType == "mysql")
+	edits, err := findRowFilterEdits(query, tokens, filters, dbType)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(edits) == 0 {
+		return query, args, nil
+	}
+
+	var out strings.Builder
+	out.Grow(len(query) + 64*len(edits))
+
+	mysql := dbType == "mysql"
+	outArgs := make([]any, 0, len(args)+len(edits))
+	var filterArgs []any
+	var missing error
+	used := 0 // User arguments already placed (MySQL)
+	placeholder := 0
+	placeholders := placeholderOffsets(tokens)
+
+	copySegment := func(start, end int) {
+		out.WriteString(query[start:end])
+		if !mysql {
+			return
+		}
+		for placeholder < len(placeholders) && placeholders[placeholder] < end {
+			if used < len(args) {
+				outArgs = append(outArgs, args[used])
+				used++
+			}
+			placeholder++
+		}
+	}
+	bind := func(table, name string) {
+		value, ok := params[name]
+		if !ok && missing == nil {
+			missing = fmt.Errorf("row filter for table %s requires the %q parameter", table, name)
+		}
+		if mysql {
+			outArgs = append(outArgs, value)
+			out.WriteString("?")
+			return
+		}
+		filterArgs = append(filterArgs, value)
+		out.WriteString("$" + strconv.Itoa(len(args)+len(filterArgs)))
+	}
+
+	pos := 0
+	for _, edit := range edits {
+		copySegment(pos, edit.start)
+		out.WriteString(edit.prefix)
+		if edit.predicate != "" {
+			writePredicate(edit.predicate, func(text string) { out.WriteString(text) }, func(name string) { bind(edit.table, name) })
+		}
+		out.WriteString(edit.suffix)
+		pos = edit.end
+	}
+	copySegment(pos, len(query))
+
+	if mysql {
+		outArgs = append(outArgs, args[used:]...)
+	} else {
+		outArgs = append(append(outArgs, args...), filterArgs...)
+	}
+
+	return out.String(), outArgs, missing
+}
+
+// literalMentionedTable returns the filtered table that the string literal text names as a
+// whole word, ignoring case, or "" if it names none.
+func literalMentionedTable(text string, filters map[string]string) string {
+	lower := strings.ToLower(text)
+	tables := make([]string, 0, len(filters))
+	for table := range filters {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	for _, table := range tables {
+		for offset := 0; ; {
+			i := strings.Index(lower[offset:], table)
+			if i < 0 {
+				break
+			}
+			start, end := offset+i, offset+i+len(table)
+			if (start == 0 || !isWordPart(lower[start-1])) && (end == len(lower) || !isWordPart(lower[end])) {
+				return table
+			}
+			offset = start + 1
+		}
+	}
+	return ""
+}
+
+// isRowFilterLiteral reports whether the rfOther token text is a string literal, including
+// typed literals such as E'...' and PostgreSQL dollar-quoted strings.
+func isRowFilterLiteral(text string) bool {
+	if strings.ContainsAny(text, `'"`) {
+		return true
+	}
+	return len(text) > 1 && text[0] == '$' && (text[1] < '0' || text[1] > '9')
+}
+
+// writePredicate writes predicate through text, calling param for every :name placeholder
+// outside string literals. PostgreSQL casts (::type) are not placeholders.
+func writePredicate(predicate string, text func(string), param func(string)) {
+	start := 0
+	for i := 0; i < len(predicate); i++ {
+		c := predicate[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			i = skipQuoted(predicate, i, false)
+		case c == ':' && i+1 < len(predicate) && predicate[i+1] == ':':
+			i++
+		case c == ':' && i+1 < len(predicate) && isWordStart(predicate[i+1]):
+			end := i + 1
+			for end < len(predicate) && isWordPart(predicate[end]) && predicate[end] != '$' {
+				end++
+			}
+			text(predicate[start:i])
+			param(predicate[i+1 : end])
+			start = end
+			i = end - 1
+		}
+	}
+	text(predicate[start:])
+}
+
+// rowFilterToken kinds.
+const (
+	rfWord        = iota // Unquoted identifier or keyword
+	rfIdentifier         // Quoted identifier
+	rfPunct              // One of ( ) , . ;
+	rfPlaceholder        // A ? parameter placeholder
+	rfOther              // Literal, number, operator or $n parameter
+)
+
+// rowFilterToken is a lexical token with its byte range in the query.
+type rowFilterToken struct {
+	kind       int
+	text       string
+	start, end int
+}
+
+// lexRowFilterSQL splits query into tokens, dropping comments. String literals, including
+// typed literals such as E'...' and PostgreSQL dollar-quoted bodies, are single tokens.
+// Double-quoted text is an identifier, except in MySQL where it is a string literal.
+func lexRowFilterSQL(query string, mysql bool) []rowFilterToken {
+	var tokens []rowFilterToken
+	add := func(kind, start, end int) {
+		tokens = append(tokens, rowFilterToken{kind: kind, text: query[start:end], start: start, end: end})
+	}
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return tokens
+			}
+			i += end + 3
+		case c == '\'' || (c == '"' && mysql):
+			i = skipQuoted(query, i, mysql || isEscapeStringPrefix(query, i))
+			add(rfOther, start, i+1)
+		case c == '"' || c == '`':
+			i = skipQuoted(query, i, false)
+			add(rfIdentifier, start, i+1)
+		case c == '$' && !mysql:
+			if tag, ok := dollarQuoteTag(query, i); ok {
+				end := strings.Index(query[i+len(tag):], tag)
+				if end < 0 {
+					i = len(query) - 1
+				} else {
+					i += len(tag) + end + len(tag) - 1
+				}
+			} else {
+				for i+1 < len(query) && query[i+1] >= '0' && query[i+1] <= '9' {
+					i++
+				}
+			}
+			add(rfOther, start, i+1)
+		case isWordStart(c):
+			for i+1 < len(query) && isWordPart(query[i+1]) {
+				i++
+			}
+			if i+1 < len(query) && query[i+1] == '\'' {
+				// Typed or prefixed literal such as E'...', X'...' or N'...'
+				i = skipQuoted(query, i+1, mysql || isEscapeStringPrefix(query, i+1))
+				add(rfOther, start, i+1)
+			} else {
+				add(rfWord, start, i+1)
+			}
+		case c >= '0' && c <= '9':
+			for i+1 < len(query) && (isWordPart(query[i+1]) || query[i+1] == '.') {
+				i++
+			}
+			add(rfOther, start, i+1)
+		case c == '(' || c == ')' || c == ',' || c == '.' || c == ';':
+			add(rfPunct, start, i+1)
+		case c == '?':
+			add(rfPlaceholder, start, i+1)
+		default:
+			add(rfOther, start, i+1)
+		}
+	}
+
+	return tokens
+}
+
+// placeholderOffsets returns the offsets of the ? placeholders among tokens.
+func placeholderOffsets(tokens []rowFilterToken) []int {
+	var offsets []int
+	for _, token := range tokens {
+		if token.kind == rfPlaceholder {
+			offsets = append(offsets, token.start)
+		}
+	}
+	return offsets
+}
+
+// rowFilterEdit replaces query[start:end] with prefix, the bound predicate and suffix.
+type rowFilterEdit struct {
+	start, end int
+	table      string // Filtered table, for error messages
+	prefix     string
+	predicate  string // Predicate with :name placeholders, or "" for none
+	suffix     string
+}
+
+// rowFilterScope tracks one parenthesized level (or the top level) of a statement.
+type rowFilterScope struct {
+	function      bool   // The level holds function arguments, where FROM is not a clause
+	expectTable   bool   // The next token is a table reference (FROM, JOIN, USING, or a comma in a FROM list)
+	fromList      bool   // Inside a FROM list, where a comma starts another table reference
+	deletePending bool   // DELETE seen; its FROM introduces the target table
+	targetNext    bool   // The next table reference is the UPDATE or DELETE target
+	insertNext    bool   // The next table reference is the INSERT target, which is not filtered
+	target        string // Filtered UPDATE or DELETE target, once seen
+	predicate     string // Predicate for target
+	whereEnd      int    // Offset of the condition following this level's WHERE keyword, or -1
+	injectAt      int    // Offset where the target predicate ends the WHERE clause, or -1
+	lastEnd       int    // End offset of the last token at this level
+}
+
+// rowFilterTableKeywords end a table reference instead of being read as its alias.
+var rowFilterTableKeywords = map[string]bool{
+	"WHERE": true, "JOIN": true, "INNER": true, "LEFT": true, "RIGHT": true, "FULL": true,
+	"CROSS": true, "NATURAL": true, "OUTER": true, "STRAIGHT_JOIN": true, "ON": true, "USING": true,
+	"GROUP": true, "ORDER": true, "LIMIT": true, "OFFSET": true, "HAVING": true, "UNION": true,
+	"INTERSECT": true, "EXCEPT": true, "SET": true, "RETURNING": true, "FOR": true, "WINDOW": true,
+	"FETCH": true, "USE": true, "FORCE": true, "IGNORE": true, "PARTITION": true, "TABLESAMPLE": true,
+	"WITH": true, "LOCK": true, "INTO": true, "VALUES": true, "SELECT": true,
+}
+
+// rowFilterNonFunctions are keywords that may directly precede a parenthesis without
+// making it a function call.
+var rowFilterNonFunctions = map[string]bool{
+	"IN": true, "EXISTS": true, "AS": true, "FROM": true, "JOIN": true, "ANY": true, "ALL": true,
+	"SOME": true, "LATERAL": true, "ON": true, "USING": true, "WHERE": true, "AND": true, "OR": true,
+	"NOT": true, "SELECT": true, "UNION": true, "INTERSECT": true, "EXCEPT": true, "THEN": true,
+	"ELSE": true, "WHEN": true, "CASE": true, "IS": true, "BY": true, "HAVING": true, "SET": true,
+	"RETURNING": true, "ARRAY": true, "VALUES": true, "WITH": true, "UPDATE": true,
+}
+
+// rowFilterQueryStarts are the keywords that start a subquery rather than a parenthesized
+// table reference after FROM, JOIN or a comma.
+var rowFilterQueryStarts = map[string]bool{"SELECT": true, "WITH": true, "VALUES": true, "TABLE": true}
+
+// rowFilterTableStatementAfter are the words after which TABLE starts a TABLE statement;
+// "" stands for the start of a statement or of a parenthesized level.
+var rowFilterTableStatementAfter = map[string]bool{"": true, "UNION": true, "INTERSECT": true, "EXCEPT": true, "ALL": true, "DISTINCT": true}
+
+// rowFilterExecutingFunctions run SQL passed to them as text, which row filters cannot
+// restrict. Functions whose name starts with DBLINK are rejected as well.
+var rowFilterExecutingFunctions = map[string]bool{"QUERY_TO_XML": true, "QUERY_TO_XMLSCHEMA": true, "QUERY_TO_XML_AND_XMLSCHEMA": true}
+
+// rowFilterInsertWords may directly precede the INTO of an INSERT or REPLACE statement.
+var rowFilterInsertWords = map[string]bool{"INSERT": true, "REPLACE": true, "IGNORE": true, "LOW_PRIORITY": true, "DELAYED": true, "HIGH_PRIORITY": true}
+
+// findRowFilterEdits walks the tokens of query and returns the edits applying filters,
+// ordered by position. It returns an error for a mention of a filtered table that is
+// neither filtered nor an INSERT target, such as "TRUNCATE orders", which would otherwise
+// expose the table's rows unrestricted. Mentions that are qualifiers (orders.id), function
+// calls or arguments are not table references and are allowed.
+//
+// SQL the rewriter cannot see is rejected as well: string literals naming a filtered table,
+// calls to functions that run SQL given as text (query_to_xml, dblink), PREPARE and
+// EXECUTE, DO blocks and CREATE FUNCTION or PROCEDURE. Views and existing functions that
+// read a filtered table are not detected; see DB_ROW_FILTER_PARAMS for enforcing the filter
+// with row-level security instead.
+func findRowFilterEdits(query string, tokens []rowFilterToken, filters map[string]string, dbType string) ([]rowFilterEdit, error) {
+	var edits []rowFilterEdit
+	newScope := func() *rowFilterScope { return &rowFilterScope{whereEnd: -1, injectAt: -1} }
+	scopes := []*rowFilterScope{newScope()}
+
+	finish := func(scope *rowFilterScope) {
+		if scope.target == "" {
+			return
+		}
+		end := scope.injectAt
+		if end < 0 {
+			end = scope.lastEnd
+		}
+		if scope.whereEnd >= 0 {
+			edits = append(edits,
+				rowFilterEdit{start: scope.whereEnd, end: scope.whereEnd, prefix: "("},
+				rowFilterEdit{start: end, end: end, table: scope.target, prefix: ") AND (", predicate: scope.predicate, suffix: ")"})
+		} else {
+			edits = append(edits, rowFilterEdit{start: end, end: end, table: scope.target, prefix: " WHERE ", predicate: scope.predicate})
+		}
+	}
+
+	previousWord := ""
+	statementWord := "" // First word of the current statement
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		scope := scopes[len(scopes)-1]
+		upper := ""
+		if token.kind == rfWord {
+			upper = strings.ToUpper(token.text)
+		}
+
+		// SQL run from text or stored code bypasses the rewriting
+		if token.kind == rfOther && isRowFilterLiteral(token.text) {
+			if table := literalMentionedTable(token.text, filters); table != "" {
+				return nil, fmt.Errorf("row filters cannot restrict table %s named in a string literal", table)
+			}
+		}
+		if token.kind == rfWord {
+			call := i+1 < len(tokens) && tokens[i+1].kind == rfPunct && tokens[i+1].text == "("
+			switch {
+			case call && (rowFilterExecutingFunctions[upper] || strings.HasPrefix(upper, "DBLINK")):
+				return nil, fmt.Errorf("row filters cannot restrict the SQL run by %s", token.text)
+			case upper == "EXECUTE" || upper == "PREPARE", upper == "DO" && statementWord == "":
+				return nil, fmt.Errorf("row filters cannot restrict the SQL run by %s statements", upper)
+			case (upper == "FUNCTION" || upper == "PROCEDURE") && statementWord == "CREATE":
+				return nil, fmt.Errorf("row filters cannot restrict the SQL run by CREATE %s statements", upper)
+			}
+			if statementWord == "" {
+				statementWord = upper
+			}
+		}
+
+		// Table reference
+		if (scope.expectTable || scope.targetNext || scope.insertNext) && (token.kind == rfWord || token.kind == rfIdentifier) {
+			if upper == "LATERAL" || upper == "ONLY" {
+				continue
+			}
+			if token.kind == rfWord && rowFilterTableKeywords[upper] {
+				scope.expectTable, scope.targetNext, scope.insertNext = false, false, false
+			} else {
+				last, next := readRowFilterName(tokens, i)
+				nameEnd := tokens[last].end
+				isFunction := next < len(tokens) && tokens[next].text == "("
+				if predicate, ok := filters[unquoteRowFilterName(tokens[last])]; ok && !isFunction && !scope.insertNext {
+					table := query[token.start:nameEnd]
+					if scope.targetNext {
+						scope.target, scope.predicate = table, predicate
+					} else {
+						suffix := ")"
+						if !hasRowFilterAlias(tokens, next) {
+							suffix += " AS " + quoteRowFilterName(unquoteRowFilterName(tokens[last]), dbType)
+						}
+						edits = append(edits, rowFilterEdit{
+							start: token.start, end: nameEnd, table: table,
+							prefix: "(SELECT * FROM " + table + " WHERE ", predicate: predicate, suffix: suffix,
+						})
+					}
+				}
+				scope.expectTable, scope.targetNext, scope.insertNext = false, false, false
+				scope.lastEnd = nameEnd
+				previousWord = ""
+				i = last
+				continue
+			}
+		}
+
+		// Any other mention of a filtered table cannot be restricted
+		if (token.kind == rfWord || token.kind == rfIdentifier) && !scope.function {
+			if _, ok := filters[unquoteRowFilterName(token)]; ok {
+				next := ""
+				if i+1 < len(tokens) && tokens[i+1].kind == rfPunct {
+					next = tokens[i+1].text
+				}
+				if next != "." && next != "(" {
+					return nil, fmt.Errorf("row filters cannot restrict this statement's use of table %s", token.text)
+				}
+			}
+		}
+
+		switch {
+		case token.text == "(" && token.kind == rfPunct:
+			function := previousWord != "" && !rowFilterNonFunctions[previousWord]
+			// A parenthesized table reference such as "FROM (orders CROSS JOIN customers)"
+			// holds further table references rather than a subquery
+			tableGroup := scope.expectTable && i+1 < len(tokens) &&
+				!(tokens[i+1].kind == rfWord && rowFilterQueryStarts[strings.ToUpper(tokens[i+1].text)])
+			scope.expectTable = false
+			scopes = append(scopes, newScope())
+			scopes[len(scopes)-1].function = function
+			if tableGroup {
+				scopes[len(scopes)-1].expectTable, scopes[len(scopes)-1].fromList = true, true
+			}
+		case token.text == ")" && token.kind == rfPunct:
+			if len(scopes) > 1 {
+				finish(scope)
+				scopes = scopes[:len(scopes)-1]
+			}
+			scopes[len(scopes)-1].lastEnd = token.end
+			previousWord = ""
+			continue
+		case token.text == ";" && token.kind == rfPunct:
+			for len(scopes) > 1 {
+				finish(scopes[len(scopes)-1])
+				scopes = scopes[:len(scopes)-1]
+			}
+			finish(scopes[0])
+			scopes[0] = newScope()
+			previousWord, statementWord = "", ""
+			continue
+		case token.text == "," && token.kind == rfPunct:
+			if scope.fromList && !scope.function {
+				scope.expectTable = true
+			}
+		case token.kind == rfWord:
+			switch upper {
+			case "FROM":
+				if scope.function {
+					break
+				}
+				if scope.deletePending {
+					scope.deletePending, scope.targetNext = false, true
+				} else {
+					scope.expectTable, scope.fromList = true, true
+				}
+			case "JOIN", "STRAIGHT_JOIN":
+				scope.expectTable = true
+			case "USING":
+				if scope.target != "" && scope.whereEnd < 0 {
+					// DELETE ... USING lists further tables to read
+					scope.expectTable, scope.fromList = true, true
+				}
+			case "UPDATE":
+				if previousWord != "FOR" && previousWord != "KEY" && previousWord != "DO" {
+					scope.targetNext = true
+				}
+			case "DELETE":
+				if previousWord != "ON" {
+					scope.deletePending = true
+				}
+			case "INTO":
+				if rowFilterInsertWords[previousWord] {
+					scope.insertNext = true
+				}
+			case "TABLE":
+				// TABLE orders is short for SELECT * FROM orders
+				if rowFilterTableStatementAfter[previousWord] && i+1 < len(tokens) {
+					last, _ := readRowFilterName(tokens, i+1)
+					if _, ok := filters[unquoteRowFilterName(tokens[last])]; ok {
+						edits = append(edits, rowFilterEdit{start: token.start, end: token.end, prefix: "SELECT * FROM"})
+						scope.expectTable = true
+					}
+				}
+			case "WHERE":
+				if scope.whereEnd < 0 {
+					scope.whereEnd = token.end
+					if i+1 < len(tokens) {
+						scope.whereEnd = tokens[i+1].start
+					}
+				}
+				scope.fromList = false
+			case "ORDER", "LIMIT", "RETURNING":
+				if scope.target != "" && scope.injectAt < 0 {
+					scope.injectAt = scope.lastEnd
+				}
+				scope.fromList = false
+			case "SELECT", "GROUP", "HAVING", "UNION", "INTERSECT", "EXCEPT", "ON", "SET", "WINDOW":
+				scope.fromList = false
+			}
+		}
+
+		if token.kind == rfWord {
+			previousWord = upper
+		} else {
+			previousWord = ""
+		}
+		scope.lastEnd = token.end
+	}
+
+	for len(scopes) > 1 {
+		finish(scopes[len(scopes)-1])
+		scopes = scopes[:len(scopes)-1]
+	}
+	finish(scopes[0])
+
+	sort.SliceStable(edits, func(a, b int) bool { return edits[a].start < edits[b].start })
+	return edits, nil
+}
+
+// readRowFilterName reads a possibly qualified name (schema.table) starting at tokens[i],
+// returning the index of its last part and of the token following it.
+func readRowFilterName(tokens []rowFilterToken, i int) (last, next int) {
+	last = i
+	for last+2 < len(tokens) && tokens[last+1].text == "." && tokens[last+1].kind == rfPunct &&
+		(tokens[last+2].kind == rfWord || tokens[last+2].kind == rfIdentifier) {
+		last += 2
+	}
+	return last, last + 1
+}
+
+// hasRowFilterAlias reports whether the table reference ending before tokens[next] has an alias.
+func hasRowFilterAlias(tokens []rowFilterToken, next int) bool {
+	if next >= len(tokens) {
+		return false
+	}
+	switch token := tokens[next]; token.kind {
+	case rfIdentifier:
+		return true
+	case rfWord:
+		upper := strings.ToUpper(token.text)
+		return upper == "AS" || !rowFilterTableKeywords[upper]
+	}
+	return false
+}
+
+// unquoteRowFilterName returns the lowercased name of an identifier token without quotes.
+func unquoteRowFilterName(token rowFilterToken) string {
+	name := token.text
+	if token.kind == rfIdentifier && len(name) >= 2 {
+		quote := name[:1]
+		name = strings.ReplaceAll(name[1:len(name)-1], quote+quote, quote)
+	}
+	return strings.ToLower(name)
+}
+
+// quoteRowFilterName quotes name as an identifier for the given database type.
+func quoteRowFilterName(name, dbType string) string {
+	if dbType == "mysql" {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,263 @@
+package security
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApplyRowFilters(t *testing.T) {
+	filters := map[string]string{
+		"orders":   "tenant_id = :tenant",
+		"invoices": "account_id = :tenant AND region = :region",
+	}
+	params := map[string]any{"tenant": 42, "region": "eu"}
+
+	tests := []struct {
+		name     string
+		dbType   string
+		query    string
+		args     []any
+		want     string
+		wantArgs []any
+	}{
+		{
+			name:     "select from filtered table",
+			dbType:   "postgres",
+			query:    "SELECT * FROM orders WHERE status = $1",
+			args:     []any{"open"},
+			want:     `SELECT * FROM (SELECT * FROM orders WHERE tenant_id = $2) AS "orders" WHERE status = $1`,
+			wantArgs: []any{"open", 42},
+		},
+		{
+			name:     "alias is kept",
+			dbType:   "postgres",
+			query:    "SELECT o.id FROM public.orders o",
+			want:     "SELECT o.id FROM (SELECT * FROM public.orders WHERE tenant_id = $1) o",
+			wantArgs: []any{42},
+		},
+		{
+			name:     "join of two filtered tables",
+			dbType:   "postgres",
+			query:    "SELECT * FROM orders AS o JOIN invoices i ON i.order_id = o.id",
+			want:     "SELECT * FROM (SELECT * FROM orders WHERE tenant_id = $1) AS o JOIN (SELECT * FROM invoices WHERE account_id = $2 AND region = $3) i ON i.order_id = o.id",
+			wantArgs: []any{42, 42, "eu"},
+		},
+		{
+			name:     "subquery and comma-separated FROM list",
+			dbType:   "postgres",
+			query:    "SELECT * FROM users u, orders WHERE u.id IN (SELECT user_id FROM orders)",
+			want:     `SELECT * FROM users u, (SELECT * FROM orders WHERE tenant_id = $1) AS "orders" WHERE u.id IN (SELECT user_id FROM (SELECT * FROM orders WHERE tenant_id = $2) AS "orders")`,
+			wantArgs: []any{42, 42},
+		},
+		{
+			name:     "update with WHERE clause",
+			dbType:   "postgres",
+			query:    "UPDATE orders SET status = $1 WHERE id = $2 OR id = $3 RETURNING id",
+			args:     []any{"shipped", 1, 2},
+			want:     "UPDATE orders SET status = $1 WHERE (id = $2 OR id = $3) AND (tenant_id = $4) RETURNING id",
+			wantArgs: []any{"shipped", 1, 2, 42},
+		},
+		{
+			name:     "delete without WHERE clause",
+			dbType:   "postgres",
+			query:    "DELETE FROM orders",
+			want:     "DELETE FROM orders WHERE tenant_id = $1",
+			wantArgs: []any{42},
+		},
+		{
+			name:     "literal containing the table name in a longer word",
+			dbType:   "postgres",
+			query:    "SELECT * FROM users WHERE name = 'preorders'",
+			want:     "SELECT * FROM users WHERE name = 'preorders'",
+			wantArgs: nil,
+		},
+		{
+			name:     "function named like a table",
+			dbType:   "postgres",
+			query:    "SELECT EXTRACT(YEAR FROM orders) FROM users",
+			want:     "SELECT EXTRACT(YEAR FROM orders) FROM users",
+			wantArgs: nil,
+		},
+		{
+			name:     "insert target is not filtered",
+			dbType:   "postgres",
+			query:    "INSERT INTO orders (id) VALUES ($1)",
+			args:     []any{7},
+			want:     "INSERT INTO orders (id) VALUES ($1)",
+			wantArgs: []any{7},
+		},
+		{
+			name:     "parenthesized table reference",
+			dbType:   "postgres",
+			query:    "SELECT * FROM (orders)",
+			want:     `SELECT * FROM ((SELECT * FROM orders WHERE tenant_id = $1) AS "orders")`,
+			wantArgs: []any{42},
+		},
+		{
+			name:     "parenthesized join",
+			dbType:   "postgres",
+			query:    "SELECT * FROM (orders CROSS JOIN customers)",
+			want:     `SELECT * FROM ((SELECT * FROM orders WHERE tenant_id = $1) AS "orders" CROSS JOIN customers)`,
+			wantArgs: []any{42},
+		},
+		{
+			name:     "nested parenthesized join after a comma",
+			dbType:   "postgres",
+			query:    "SELECT * FROM users u, ((invoices i JOIN orders o ON o.id = i.order_id))",
+			want:     "SELECT * FROM users u, (((SELECT * FROM invoices WHERE account_id = $1 AND region = $2) i JOIN (SELECT * FROM orders WHERE tenant_id = $3) o ON o.id = i.order_id))",
+			wantArgs: []any{42, "eu", 42},
+		},
+		{
+			name:     "TABLE statement",
+			dbType:   "postgres",
+			query:    "TABLE orders",
+			want:     `SELECT * FROM (SELECT * FROM orders WHERE tenant_id = $1) AS "orders"`,
+			wantArgs: []any{42},
+		},
+		{
+			name:     "TABLE statement in a union",
+			dbType:   "mysql",
+			query:    "TABLE users UNION TABLE orders ORDER BY id",
+			want:     "TABLE users UNION SELECT * FROM (SELECT * FROM orders WHERE tenant_id = ?) AS `orders` ORDER BY id",
+			wantArgs: []any{42},
+		},
+		{
+			name:     "insert from a filtered table",
+			dbType:   "postgres",
+			query:    "INSERT INTO orders SELECT * FROM orders",
+			want:     `INSERT INTO orders SELECT * FROM (SELECT * FROM orders WHERE tenant_id = $1) AS "orders"`,
+			wantArgs: []any{42},
+		},
+		{
+			name:     "qualified column of a filtered table",
+			dbType:   "postgres",
+			query:    "SELECT orders.id FROM orders",
+			want:     `SELECT orders.id FROM (SELECT * FROM orders WHERE tenant_id = $1) AS "orders"`,
+			wantArgs: []any{42},
+		},
+		{
+			name:     "mysql placeholders interleaved in statement order",
+			dbType:   "mysql",
+			query:    "SELECT * FROM users u JOIN `orders` o ON o.user_id = u.id WHERE u.id = ?",
+			args:     []any{5},
+			want:     "SELECT * FROM users u JOIN (SELECT * FROM `orders` WHERE tenant_id = ?) o ON o.user_id = u.id WHERE u.id = ?",
+			wantArgs: []any{42, 5},
+		},
+		{
+			name:     "mysql update with ORDER BY and LIMIT",
+			dbType:   "mysql",
+			query:    "UPDATE orders SET status = ? WHERE created_at < ? ORDER BY id LIMIT 10",
+			args:     []any{"stale", "2024-01-01"},
+			want:     "UPDATE orders SET status = ? WHERE (created_at < ?) AND (tenant_id = ?) ORDER BY id LIMIT 10",
+			wantArgs: []any{"stale", "2024-01-01", 42},
+		},
+		{
+			name:     "mysql delete without WHERE clause",
+			dbType:   "mysql",
+			query:    "DELETE FROM orders LIMIT 5",
+			want:     "DELETE FROM orders WHERE tenant_id = ? LIMIT 5",
+			wantArgs: []any{42},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotArgs, err := ApplyRowFilters(tt.query, tt.args, filters, params, tt.dbType)
+			if err != nil {
+				t.Fatalf("ApplyRowFilters() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ApplyRowFilters() query =\n  %s\nwant\n  %s", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("ApplyRowFilters() args = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestApplyRowFilters_MissingParameter(t *testing.T) {
+	filters := map[string]string{"orders": "tenant_id = :tenant"}
+
+	got, args, err := ApplyRowFilters("SELECT * FROM orders", nil, filters, nil, "postgres")
+	if err == nil || !strings.Contains(err.Error(), `"tenant"`) {
+		t.Errorf("Expected a missing parameter error, got %v", err)
+	}
+
+	// The filter is still applied, matching no rows
+	if want := `SELECT * FROM (SELECT * FROM orders WHERE tenant_id = $1) AS "orders"`; got != want {
+		t.Errorf("ApplyRowFilters() query = %s, want %s", got, want)
+	}
+	if !reflect.DeepEqual(args, []any{nil}) {
+		t.Errorf("ApplyRowFilters() args = %v, want [<nil>]", args)
+	}
+}
+
+func TestApplyRowFilters_UnfilteredMention(t *testing.T) {
+	filters := map[string]string{"orders": "tenant_id = :tenant"}
+	params := map[string]any{"tenant": 42}
+
+	tests := []struct {
+		name   string
+		dbType string
+		query  string
+	}{
+		{name: "truncate", dbType: "postgres", query: "TRUNCATE orders"},
+		{name: "ddl", dbType: "mysql", query: "ALTER TABLE `orders` ADD COLUMN note TEXT"},
+		{name: "common table expression named like a table", dbType: "postgres", query: "WITH orders AS (SELECT 1) SELECT * FROM orders"},
+		{name: "copy", dbType: "postgres", query: "COPY orders TO STDOUT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args, err := ApplyRowFilters(tt.query, []any{1}, filters, params, tt.dbType)
+			if err == nil || !strings.Contains(err.Error(), "orders") {
+				t.Errorf("Expected an error naming the table, got %v", err)
+			}
+			if got != "" || args != nil {
+				t.Errorf("ApplyRowFilters() = %q, %v, want an empty query", got, args)
+			}
+		})
+	}
+}
+
+func TestApplyRowFilters_Bypass(t *testing.T) {
+	filters := map[string]string{"orders": "tenant_id = :tenant"}
+	params := map[string]any{"tenant": 42}
+
+	tests := []struct {
+		name    string
+		dbType  string
+		query   string
+		wantErr string
+	}{
+		{name: "query_to_xml", dbType: "postgres", query: "SELECT query_to_xml('select * from orders', true, false, '')", wantErr: "query_to_xml"},
+		{name: "table_to_xml", dbType: "postgres", query: "SELECT table_to_xml('orders', true, false, '')", wantErr: "orders named in a string literal"},
+		{name: "query_to_xml with a built name", dbType: "postgres", query: "SELECT query_to_xml('select * from ' || 'ord' || 'ers', true, false, '')", wantErr: "query_to_xml"},
+		{name: "qualified query_to_xml_and_xmlschema", dbType: "postgres", query: "SELECT pg_catalog.query_to_xml_and_xmlschema($1, true, false, '')", wantErr: "query_to_xml_and_xmlschema"},
+		{name: "dblink", dbType: "postgres", query: "SELECT * FROM dblink('dbname=app', 'SELECT id FROM ord' || 'ers') AS t(id int)", wantErr: "dblink"},
+		{name: "dblink_exec", dbType: "postgres", query: "SELECT dblink_exec('DELETE FROM ' || $1)", wantErr: "dblink_exec"},
+		{name: "dollar-quoted literal", dbType: "postgres", query: "SELECT xmlparse(content $q$<a>Orders</a>$q$)", wantErr: "orders named in a string literal"},
+		{name: "escape string literal", dbType: "postgres", query: `SELECT E'orders\'s'`, wantErr: "orders named in a string literal"},
+		{name: "mysql double-quoted literal", dbType: "mysql", query: `SET @q = "SELECT * FROM orders"`, wantErr: "orders named in a string literal"},
+		{name: "mysql prepare", dbType: "mysql", query: "PREPARE stmt FROM @q", wantErr: "PREPARE"},
+		{name: "mysql execute", dbType: "mysql", query: "EXECUTE stmt", wantErr: "EXECUTE"},
+		{name: "postgres execute", dbType: "postgres", query: "SELECT 1; EXECUTE read_all(1)", wantErr: "EXECUTE"},
+		{name: "do block", dbType: "postgres", query: "DO $$BEGIN PERFORM 1; END$$", wantErr: "DO"},
+		{name: "create function", dbType: "postgres", query: "CREATE OR REPLACE FUNCTION f() RETURNS int LANGUAGE sql AS $$SELECT 1$$", wantErr: "CREATE FUNCTION"},
+		{name: "create procedure", dbType: "mysql", query: "CREATE PROCEDURE p() SELECT 1", wantErr: "CREATE PROCEDURE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args, err := ApplyRowFilters(tt.query, nil, filters, params, tt.dbType)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ApplyRowFilters() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			if got != "" || args != nil {
+				t.Errorf("ApplyRowFilters() = %q, %v, want an empty query", got, args)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package security
+
+import (
+	"strings"
+)
+
+// SplitStatements splits a semicolon-separated SQL script into its statements.
+// Semicolons inside string literals, quoted identifiers and comments do not end a
+// statement. For PostgreSQL (dbType "postgres"), dollar-quoted bodies such as
+// $$ ... $$ or $fn$ ... $fn$ are kept intact; for MySQL, backslash escapes inside
+// literals are honoured. Statements are returned trimmed, and empty statements
+// (e.g. from a trailing semicolon) are dropped. Comments are kept with the
+// statement they precede.
+func SplitStatements(script string, dbType string) []string {
+	postgres := dbType == "postgres"
+	mysql := dbType == "mysql"
+
+	var statements []string
+	start := 0
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '-' && i+1 < len(script) && script[i+1] == '-':
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(script) && script[i+1] == '*':
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end + 3
+			}
+		case c == '\'' || c == '"' || c == '`':
+			i = skipQuoted(script, i, mysql && c != '`')
+		case c == '$' && postgres:
+			if tag, ok := dollarQuoteTag(script, i); ok {
+				end := strings.Index(script[i+len(tag):], tag)
+				if end < 0 {
+					i = len(script)
+				} else {
+					i += len(tag) + end + len(tag) - 1
+				}
+			}
+		case c == ';':
+			statements = appendStatement(statements, script[start:i])
+			start = i + 1
+		}
+	}
+
+	if start < len(script) {
+		statements = appendStatement(statements, script[start:])
+	}
+	return statements
+}
+
+// appendStatement appends statement to statements if it contains anything but whitespace.
+func appendStatement(statements []string, statement string) []string {
+	if trimmed := strings.TrimSpace(statement); trimmed != "" {
+		statements = append(statements, trimmed)
+	}
+	return statements
+}
+
+// skipQuoted returns the index of the quote closing the quoted text that starts at
+// script[open], or the last index when it is unterminated. Doubled quotes are escaped
+// quotes; backslash escapes are only honoured when backslashEscapes is set.
+func skipQuoted(script string, open int, backslashEscapes bool) int {
+	quote := script[open]
+	for i := open + 1; i < len(script); i++ {
+		switch {
+		case backslashEscapes && script[i] == '\\':
+			i++
+		case script[i] == quote && i+1 < len(script) && script[i+1] == quote:
+			i++
+		case script[i] == quote:
+			return i
+		}
+	}
+	return len(script) - 1
+}
+
+// dollarQuoteTag reports whether a PostgreSQL dollar quote such as $$ or $body$ starts at
+// script[i], returning the full tag including both dollar signs. A tag may not start with
+// a digit, so positional parameters like $1 are not mistaken for quotes.
+func dollarQuoteTag(script string, i int) (string, bool) {
+	for j := i + 1; j < len(script); j++ {
+		c := script[j]
+		switch {
+		case c == '$':
+			return script[i : j+1], true
+		case c >= '0' && c <= '9':
+			if j == i+1 {
+				return "", false
+			}
+		case !isWordStart(c):
+			return "", false
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,94 @@
+package security
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		dbType string
+		want   []string
+	}{
+		{
+			name:   "simple statements",
+			script: "CREATE TABLE a (id int);\nINSERT INTO a VALUES (1);\n",
+			dbType: "postgres",
+			want:   []string{"CREATE TABLE a (id int)", "INSERT INTO a VALUES (1)"},
+		},
+		{
+			name:   "no trailing semicolon and empty statements",
+			script: "SELECT 1;;  ;SELECT 2",
+			dbType: "postgres",
+			want:   []string{"SELECT 1", "SELECT 2"},
+		},
+		{
+			name:   "semicolons in literals and identifiers",
+			script: `INSERT INTO notes (body) VALUES ('a; b', 'it''s; fine'); SELECT "odd;name" FROM t`,
+			dbType: "postgres",
+			want:   []string{`INSERT INTO notes (body) VALUES ('a; b', 'it''s; fine')`, `SELECT "odd;name" FROM t`},
+		},
+		{
+			name:   "semicolons in comments",
+			script: "-- setup; part one\nSELECT 1; /* a; b */ SELECT 2",
+			dbType: "postgres",
+			want:   []string{"-- setup; part one\nSELECT 1", "/* a; b */ SELECT 2"},
+		},
+		{
+			name: "dollar-quoted function body",
+			script: `CREATE FUNCTION touch() RETURNS trigger AS $$
+BEGIN
+  NEW.updated_at := now();
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER t BEFORE UPDATE ON users FOR EACH ROW EXECUTE FUNCTION touch();`,
+			dbType: "postgres",
+			want: []string{
+				"CREATE FUNCTION touch() RETURNS trigger AS $$\nBEGIN\n  NEW.updated_at := now();\n  RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql",
+				"CREATE TRIGGER t BEFORE UPDATE ON users FOR EACH ROW EXECUTE FUNCTION touch()",
+			},
+		},
+		{
+			name:   "tagged dollar quote containing $$",
+			script: "DO $body$ BEGIN RAISE NOTICE '$$;'; END; $body$; SELECT 1",
+			dbType: "postgres",
+			want:   []string{"DO $body$ BEGIN RAISE NOTICE '$$;'; END; $body$", "SELECT 1"},
+		},
+		{
+			name:   "positional parameters are not dollar quotes",
+			script: "SELECT $1; SELECT $2",
+			dbType: "postgres",
+			want:   []string{"SELECT $1", "SELECT $2"},
+		},
+		{
+			name:   "mysql backslash escapes",
+			script: `INSERT INTO t VALUES ('a\'; b'); SELECT 1`,
+			dbType: "mysql",
+			want:   []string{`INSERT INTO t VALUES ('a\'; b')`, "SELECT 1"},
+		},
+		{
+			name:   "mysql does not treat dollars as quotes",
+			script: "SELECT '$$'; SELECT 2",
+			dbType: "mysql",
+			want:   []string{"SELECT '$$'", "SELECT 2"},
+		},
+		{
+			name:   "empty script",
+			script: "  ; \n",
+			dbType: "postgres",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitStatements(tt.script, tt.dbType)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package security
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jhoffmann/go-database-mcp/internal/config"
 )
@@ -101,6 +103,116 @@ func TestQueryValidator_ValidateBasicSafety(t *testing.T) {
 	}
 }
 
+func TestQueryValidator_ConfigurablePatterns(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(cfg *config.DatabaseConfig)
+		query   string
+		wantErr bool
+	}{
+		{
+			name:    "defaults block comments",
+			setup:   func(cfg *config.DatabaseConfig) {},
+			query:   "SELECT * FROM notes -- trailing note",
+			wantErr: true,
+		},
+		{
+			name:    "allow comments permits double dash",
+			setup:   func(cfg *config.DatabaseConfig) { cfg.AllowComments = true },
+			query:   "SELECT * FROM notes -- trailing note",
+			wantErr: false,
+		},
+		{
+			name:    "allow comments permits block comments",
+			setup:   func(cfg *config.DatabaseConfig) { cfg.AllowComments = true },
+			query:   "SELECT /* planner hint */ * FROM notes",
+			wantErr: false,
+		},
+		{
+			name:    "allow comments keeps other defaults",
+			setup:   func(cfg *config.DatabaseConfig) { cfg.AllowComments = true },
+			query:   "SELECT LOAD_FILE('/etc/passwd')",
+			wantErr: true,
+		},
+		{
+			name:    "allowlisted default pattern",
+			setup:   func(cfg *config.DatabaseConfig) { cfg.AllowedPatterns = []string{"sp_"} },
+			query:   "SELECT sp_name FROM speakers",
+			wantErr: false,
+		},
+		{
+			name:    "configured blocked pattern",
+			setup:   func(cfg *config.DatabaseConfig) { cfg.BlockedPatterns = []string{"pg_sleep"} },
+			query:   "SELECT pg_sleep(100)",
+			wantErr: true,
+		},
+		{
+			name:    "unrelated query with configured patterns",
+			setup:   func(cfg *config.DatabaseConfig) { cfg.BlockedPatterns = []string{"pg_sleep"} },
+			query:   "SELECT id FROM users",
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig(nil)
+			tt.setup(cfg)
+			validator := NewQueryValidator(cfg)
+
+			err := validator.validateBasicSafety(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateBasicSafety() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildBlockedPatterns_DefaultsUnchanged(t *testing.T) {
+	patterns := buildBlockedPatterns(createTestConfig(nil))
+	if len(patterns) != len(defaultBlockedPatterns) {
+		t.Fatalf("Expected %d default patterns, got %d", len(defaultBlockedPatterns), len(patterns))
+	}
+	for i := range patterns {
+		if patterns[i] != defaultBlockedPatterns[i] {
+			t.Errorf("Pattern %d = %+v, want %+v", i, patterns[i], defaultBlockedPatterns[i])
+		}
+	}
+}
+
+func TestQueryValidator_ValidateBasicSafety_StringLiterals(t *testing.T) {
+	tests := []struct {
+		name    string
+		dbType  string
+		query   string
+		wantErr bool
+	}{
+		{name: "double dash in LIKE pattern", dbType: "postgres", query: "SELECT * FROM logs WHERE msg LIKE '%--%'", wantErr: false},
+		{name: "block comment markers in literal", dbType: "postgres", query: "SELECT * FROM logs WHERE msg = '/* not a comment */'", wantErr: false},
+		{name: "comment markers in double-quoted literal", dbType: "mysql", query: `SELECT * FROM logs WHERE msg = "a -- b"`, wantErr: false},
+		{name: "doubled quote inside literal", dbType: "postgres", query: "SELECT * FROM logs WHERE msg = 'it''s -- fine'", wantErr: false},
+		{name: "mysql backslash-escaped quote inside literal", dbType: "mysql", query: `SELECT * FROM logs WHERE msg = 'it\'s -- fine'`, wantErr: false},
+		{name: "comment after literal", dbType: "postgres", query: "SELECT * FROM users WHERE name = 'admin' -- AND active", wantErr: true},
+		{name: "block comment after literal", dbType: "postgres", query: "SELECT * FROM users WHERE name = 'a' /* x */", wantErr: true},
+		{name: "postgres backslash does not escape quote", dbType: "postgres", query: `SELECT * FROM users WHERE name = 'a\' -- '`, wantErr: true},
+		{name: "dangerous function outside literal", dbType: "mysql", query: "SELECT LOAD_FILE('/etc/passwd')", wantErr: true},
+		{name: "dangerous function name inside literal", dbType: "mysql", query: "SELECT * FROM docs WHERE body = 'call LOAD_FILE here'", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig(nil)
+			cfg.Type = tt.dbType
+			validator := NewQueryValidator(cfg)
+
+			err := validator.validateBasicSafety(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateBasicSafety(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestQueryValidator_ValidateDatabaseAccess(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -281,7 +393,7 @@ func TestQueryValidator_ValidateQueryComplexity(t *testing.T) {
 		},
 		{
 			name:    "query too long",
-			query:   generateLongQuery(60000), // > 50KB
+			query:   generateLongQuery(config.DefaultMaxQueryLength + 1),
 			wantErr: true,
 			errMsg:  "query complexity limit exceeded: query too long",
 		},
@@ -303,6 +415,46 @@ func TestQueryValidator_ValidateQueryComplexity(t *testing.T) {
 	}
 }
 
+func TestQueryValidator_ValidateQueryComplexity_ConfiguredLimits(t *testing.T) {
+	var joins strings.Builder
+	joins.WriteString("SELECT * FROM t0")
+	for i := 1; i <= 15; i++ {
+		fmt.Fprintf(&joins, " JOIN t%d ON t%d.id = t%d.id", i, i-1, i)
+	}
+	fifteenJoins := joins.String()
+	twoSubqueries := "SELECT * FROM t1 WHERE id IN (SELECT id FROM t2 WHERE id IN (SELECT id FROM t3))"
+
+	tests := []struct {
+		name          string
+		maxJoins      int
+		maxSubqueries int
+		query         string
+		wantErr       string
+	}{
+		{name: "15 joins rejected by default", query: fifteenJoins, wantErr: "too many JOINs (15 > 10, DB_MAX_JOINS)"},
+		{name: "15 joins allowed with MaxJoins=20", maxJoins: 20, query: fifteenJoins},
+		{name: "15 joins rejected with MaxJoins=12", maxJoins: 12, query: fifteenJoins, wantErr: "too many JOINs (15 > 12, DB_MAX_JOINS)"},
+		{name: "subqueries allowed by default", query: twoSubqueries},
+		{name: "subqueries rejected with MaxSubqueries=1", maxSubqueries: 1, query: twoSubqueries, wantErr: "too many subqueries (2 > 1, DB_MAX_SUBQUERIES)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig(nil)
+			cfg.MaxJoins = tt.maxJoins
+			cfg.MaxSubqueries = tt.maxSubqueries
+
+			err := NewQueryValidator(cfg).validateQueryComplexity(tt.query)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("validateQueryComplexity() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("validateQueryComplexity() error = %v, want to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestQueryValidator_ValidateQuery_Integration(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -460,3 +612,412 @@ func BenchmarkQueryValidator_ValidateDatabaseAccess(b *testing.B) {
 		validator.validateDatabaseAccess(query)
 	}
 }
+
+func TestQueryValidator_ValidateQueryType(t *testing.T) {
+	tests := []struct {
+		name      string
+		readOnly  bool
+		queryType string
+		wantErr   bool
+	}{
+		{name: "select in read-only mode", readOnly: true, queryType: "select", wantErr: false},
+		{name: "insert in read-only mode", readOnly: true, queryType: "insert", wantErr: true},
+		{name: "update in read-only mode", readOnly: true, queryType: "update", wantErr: true},
+		{name: "delete in read-only mode", readOnly: true, queryType: "delete", wantErr: true},
+		{name: "ddl in read-only mode", readOnly: true, queryType: "ddl", wantErr: true},
+		{name: "insert in read-write mode", readOnly: false, queryType: "insert", wantErr: false},
+		{name: "ddl in read-write mode", readOnly: false, queryType: "ddl", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig(nil)
+			cfg.ReadOnly = tt.readOnly
+			validator := NewQueryValidator(cfg)
+
+			err := validator.ValidateQueryType(tt.queryType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateQueryType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err.Error() != "read-only mode: DML and DDL statements are not permitted" {
+				t.Errorf("Unexpected error message: %v", err)
+			}
+		})
+	}
+}
+
+func TestQueryValidator_ValidateQueryType_AllowedStatements(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowed   []string
+		readOnly  bool
+		queryType string
+		wantErr   string
+	}{
+		{name: "unset permits everything", queryType: "ddl"},
+		{name: "listed type", allowed: []string{"select", "insert"}, queryType: "insert"},
+		{name: "unlisted type", allowed: []string{"select", "insert"}, queryType: "delete", wantErr: "statement type 'delete' is not permitted"},
+		{name: "case-insensitive list", allowed: []string{"SELECT"}, queryType: "select"},
+		{name: "read-only mode still applies", allowed: []string{"select", "insert"}, readOnly: true, queryType: "insert", wantErr: "read-only mode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig(nil)
+			cfg.AllowedStatements = tt.allowed
+			cfg.ReadOnly = tt.readOnly
+
+			err := NewQueryValidator(cfg).ValidateQueryType(tt.queryType)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("ValidateQueryType() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("ValidateQueryType() error = %v, want to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQueryValidator_ValidateQuery_ReadOnly(t *testing.T) {
+	cfg := createTestConfig(nil)
+	cfg.ReadOnly = true
+	validator := NewQueryValidator(cfg)
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{name: "select allowed", query: "SELECT * FROM users", wantErr: false},
+		{name: "cte select allowed", query: "WITH recent AS (SELECT id FROM users) SELECT * FROM recent", wantErr: false},
+		{name: "cte delete rejected", query: "WITH old AS (SELECT id FROM users) DELETE FROM users WHERE id IN (SELECT id FROM old)", wantErr: true},
+		{name: "insert rejected", query: "INSERT INTO users (name) VALUES ('a')", wantErr: true},
+		{name: "update rejected", query: "update users set name = 'b'", wantErr: true},
+		{name: "delete rejected", query: "DELETE FROM users", wantErr: true},
+		{name: "create rejected", query: "CREATE TABLE t (id INT)", wantErr: true},
+		{name: "truncate rejected", query: "TRUNCATE users", wantErr: true},
+		{name: "unknown statement rejected", query: "GRANT ALL ON users TO bob", wantErr: true},
+		{name: "select into rejected", query: "SELECT * INTO users_copy FROM users", wantErr: true},
+		{name: "select into variable rejected", query: "SELECT name FROM users LIMIT 1 INTO @name", wantErr: true},
+		{name: "nextval rejected", query: "SELECT nextval('users_id_seq')", wantErr: true},
+		{name: "qualified terminate backend rejected", query: "SELECT pg_catalog.pg_terminate_backend(123)", wantErr: true},
+		{name: "keywords inside literals allowed", query: "SELECT 'into nextval(1)' AS note FROM users", wantErr: false},
+		{name: "column named like a function allowed", query: "SELECT nextval FROM counters", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateQuery(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "read-only mode") {
+				t.Errorf("Expected read-only error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestQueryValidator_ValidateQueryComplexity_IgnoresLiteralsAndComments(t *testing.T) {
+	manySelects := strings.Repeat("SELECT ", 8)
+	manyJoins := strings.Repeat("JOIN ", 12)
+
+	tests := []struct {
+		name    string
+		dbType  string
+		query   string
+		wantErr bool
+	}{
+		{
+			name:  "select keywords inside a literal",
+			query: "SELECT '" + manySelects + "' AS text",
+		},
+		{
+			name:  "join keywords inside a literal",
+			query: "SELECT * FROM notes WHERE body = '" + manyJoins + "'",
+		},
+		{
+			name:  "keywords inside comments",
+			query: "SELECT 1 -- " + manySelects + "\n/* " + manyJoins + " */",
+		},
+		{
+			name:  "identifiers containing keywords",
+			query: "SELECT selected_at, joined_at FROM t1 JOIN t2 ON t1.id = t2.id WHERE t1.selection IN (SELECT rejoin FROM t3)",
+		},
+		{
+			name:   "mysql backslash escape does not end the literal",
+			dbType: "mysql",
+			query:  "SELECT 'it\\'s " + manySelects + "' AS text",
+		},
+		{
+			name:    "real subqueries after a literal are counted",
+			query:   "SELECT 'x' FROM t WHERE a IN (SELECT a FROM t WHERE a IN (SELECT a FROM t WHERE a IN (SELECT a FROM t WHERE a IN (SELECT a FROM t WHERE a IN (SELECT a FROM t WHERE a IN (SELECT a FROM t))))))",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig(nil)
+			cfg.AllowComments = true
+			if tt.dbType != "" {
+				cfg.Type = tt.dbType
+			}
+			validator := NewQueryValidator(cfg)
+
+			err := validator.validateQueryComplexity(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateQueryComplexity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStripCommentsAndLiterals(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		mysql    bool
+		expected string
+	}{
+		{"no comments or literals", "SELECT A FROM T", false, "SELECT A FROM T"},
+		{"line comment", "SELECT 1 -- NOTE\nFROM T", false, "SELECT 1  \nFROM T"},
+		{"trailing line comment", "SELECT 1 -- NOTE", false, "SELECT 1  "},
+		{"block comment", "SELECT /* HINT */ 1", false, "SELECT   1"},
+		{"unterminated block comment", "SELECT 1 /* HINT", false, "SELECT 1  "},
+		{"comment markers inside literal", "SELECT '-- /* X */'", false, "SELECT ''"},
+		{"quote inside comment", "-- IT'S\nSELECT 1", false, " \nSELECT 1"},
+		{"doubled quote", "SELECT 'IT''S' -- X", false, "SELECT ''  "},
+		{"backslash escape", `SELECT 'IT\'S' -- X`, true, "SELECT ''  "},
+		{"mysql backslash escape in double quotes", `SELECT "A\" -- B" -- X`, true, `SELECT ""  `},
+		{"mysql backtick identifier", "SELECT `A--B`, `C``D` FROM T -- X", true, "SELECT ``, `` FROM T  "},
+		{"postgres backslash is not an escape", `SELECT 'C:\' -- X`, false, "SELECT ''  "},
+		{"postgres escape string", `SELECT E'IT\'S -- NOT A COMMENT' -- X`, false, "SELECT E''  "},
+		{"postgres backtick is not a quote", "SELECT `A -- X", false, "SELECT `A  "},
+		{"postgres dollar quote", "SELECT $$IT'S -- NOT A COMMENT$$ -- X", false, "SELECT $$$$  "},
+		{"postgres tagged dollar quote", "SELECT $FN$ $$ ' $FN$, $1 -- X", false, "SELECT $FN$$FN$, $1  "},
+		{"dollar inside identifier", "SELECT A$B$ -- X", false, "SELECT A$B$  "},
+		{"mysql has no dollar quotes", "SELECT $$ -- X", true, "SELECT $$  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripCommentsAndLiterals(tt.query, tt.mysql); got != tt.expected {
+				t.Errorf("stripCommentsAndLiterals(%q) = %q, want %q", tt.query, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDetermineQueryType(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"SELECT 1", "select"},
+		{"with cte as (select 1) select * from cte", "select"},
+		{"INSERT INTO t VALUES (1)", "insert"},
+		{"UPDATE t SET a = 1", "update"},
+		{"DELETE FROM t", "delete"},
+		{"DROP TABLE t", "ddl"},
+		{"/* hint */ SELECT 1", "select"},
+		{"-- note\nDELETE FROM t", "delete"},
+		{"VACUUM", "ddl"},
+		{"SELECT 'DELETE FROM x'", "select"},
+		{"/* multi\nline\ncomment */ UPDATE t SET a = 1", "update"},
+		{"-- SELECT\n/* SELECT */ DELETE FROM t", "delete"},
+		{"-- it's a note\nINSERT INTO t VALUES ('--')", "insert"},
+		{"SELECTED_ROWS", "ddl"},
+		{"WITH t AS (SELECT id FROM orders WHERE stale) DELETE FROM orders WHERE id IN (SELECT id FROM t)", "delete"},
+		{"WITH t AS (SELECT id FROM orders) SELECT * FROM t", "select"},
+		{"WITH RECURSIVE t (n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM t) SELECT n FROM t", "select"},
+		{"WITH a AS (SELECT 1), b AS (SELECT * FROM a) INSERT INTO totals SELECT * FROM b", "insert"},
+		{"with t as materialized (select 1) update orders set total = 0", "update"},
+		{"WITH d AS (DELETE FROM orders RETURNING *) SELECT count(*) FROM d", "delete"},
+		{"WITH t AS (SELECT 'DELETE FROM x' AS q) SELECT * FROM t", "select"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := DetermineQueryType(tt.query, "postgres"); got != tt.expected {
+				t.Errorf("DetermineQueryType(%q) = %s, want %s", tt.query, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDetermineQueryType_Dialects(t *testing.T) {
+	tests := []struct {
+		name     string
+		dbType   string
+		query    string
+		expected string
+	}{
+		{
+			name:     "mysql backslash escape",
+			dbType:   "mysql",
+			query:    `WITH t AS (SELECT 'it\'s ) DELETE' AS q) SELECT * FROM t`,
+			expected: "select",
+		},
+		{
+			// Without backslash escapes the literal ends early, leaving DELETE outside it
+			name:     "postgres standard string",
+			dbType:   "postgres",
+			query:    `WITH t AS (SELECT 'it\'s ) DELETE' AS q) SELECT * FROM t`,
+			expected: "delete",
+		},
+		{
+			name:     "postgres escape string",
+			dbType:   "postgres",
+			query:    `WITH t AS (SELECT E'it\'s ) DELETE' AS q) SELECT * FROM t`,
+			expected: "select",
+		},
+		{
+			name:     "postgres dollar quote",
+			dbType:   "postgres",
+			query:    "WITH t AS (SELECT $q$ ) DELETE FROM t $q$ AS q) SELECT * FROM t",
+			expected: "select",
+		},
+		{
+			name:     "mysql backtick identifier",
+			dbType:   "mysql",
+			query:    "WITH `t(` AS (SELECT 1) DELETE FROM orders",
+			expected: "delete",
+		},
+		{
+			name:     "leading comment with backslash",
+			dbType:   "mysql",
+			query:    `/* it\'s */ UPDATE t SET a = 1`,
+			expected: "update",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetermineQueryType(tt.query, tt.dbType); got != tt.expected {
+				t.Errorf("DetermineQueryType(%q, %s) = %s, want %s", tt.query, tt.dbType, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestQueryValidator_RedactSensitive(t *testing.T) {
+	validator := NewQueryValidator(createTestConfig(nil))
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		missing string
+	}{
+		{
+			name:    "configured password",
+			input:   "login failed with testpass",
+			missing: "testpass",
+		},
+		{
+			name:  "password literal",
+			input: "ALTER USER app WITH PASSWORD 'hunter2'",
+			want:  "ALTER USER app WITH PASSWORD '[REDACTED]'",
+		},
+		{
+			name:  "identified by literal",
+			input: "CREATE USER 'app'@'%' IDENTIFIED BY 's3cret'",
+			want:  "CREATE USER 'app'@'%' IDENTIFIED BY '[REDACTED]'",
+		},
+		{
+			name:  "mysql DSN",
+			input: "invalid DSN: app:other-secret@tcp(db:3306)/shop?tls=false",
+			want:  "invalid DSN: app:[REDACTED]@tcp(db:3306)/shop?tls=false",
+		},
+		{
+			name:  "mysql DSN with default address",
+			input: "dial failed for app:s3cr3t@unix(/tmp/mysql.sock)/shop",
+			want:  "dial failed for app:[REDACTED]@unix(/tmp/mysql.sock)/shop",
+		},
+		{
+			name:  "connection URL",
+			input: `parse "postgres://app:p%40ss@db:5432/shop": invalid port`,
+			want:  `parse "postgres://app:[REDACTED]@db:5432/shop": invalid port`,
+		},
+		{
+			name:  "postgres keyword DSN",
+			input: "missing option in host=db user=app password=hunter2 dbname=shop",
+			want:  "missing option in host=db user=app password=[REDACTED] dbname=shop",
+		},
+		{
+			name:  "plain query unchanged",
+			input: "SELECT id FROM users",
+			want:  "SELECT id FROM users",
+		},
+		{
+			name:  "time and address unchanged",
+			input: "connection reset at 12:30 by db:5432",
+			want:  "connection reset at 12:30 by db:5432",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validator.RedactSensitive(tt.input)
+			if tt.want != "" && got != tt.want {
+				t.Errorf("RedactSensitive() = %q, want %q", got, tt.want)
+			}
+			if tt.missing != "" && strings.Contains(got, tt.missing) {
+				t.Errorf("RedactSensitive() = %q, should not contain %q", got, tt.missing)
+			}
+		})
+	}
+}
+
+func TestQueryValidator_ValidateQuery_MaxLength(t *testing.T) {
+	cfg := createTestConfig(nil)
+	cfg.MaxQueryLength = 64
+	validator := NewQueryValidator(cfg)
+
+	if err := validator.ValidateQuery("SELECT id FROM users"); err != nil {
+		t.Errorf("Expected short query to pass, got %v", err)
+	}
+
+	err := validator.ValidateQuery("SELECT id FROM users WHERE name = '" + strings.Repeat("x", 64) + "'")
+	if err == nil || !strings.Contains(err.Error(), "DB_MAX_QUERY_LENGTH") {
+		t.Errorf("Expected query length error, got %v", err)
+	}
+
+	// Without a configured limit the default applies
+	validator = NewQueryValidator(createTestConfig(nil))
+	if err := validator.ValidateQuery("SELECT '" + strings.Repeat("x", config.DefaultMaxQueryLength) + "'"); err == nil {
+		t.Error("Expected query above the default limit to be rejected")
+	}
+}
+
+func TestQueryValidator_ValidateQuery_AdversarialInputTime(t *testing.T) {
+	cfg := createTestConfig(nil)
+	// A classic catastrophic-backtracking regex, supplied as a blocked pattern; patterns are
+	// matched as literal text, so it cannot be used to slow down validation
+	cfg.BlockedPatterns = []string{"(A+)+$"}
+	validator := NewQueryValidator(cfg)
+
+	size := 256 << 10
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "pattern bait", query: "SELECT '" + strings.Repeat("a", size) + "!'"},
+		{name: "whitespace after FROM", query: "SELECT 1 FROM" + strings.Repeat(" ", size) + "x"},
+		{name: "repeated qualified prefixes", query: "SELECT 1 " + strings.Repeat("FROM a . ", size/9)},
+		{name: "repeated USE separators", query: "USE" + strings.Repeat(" ;", size/2)},
+		{name: "nested parentheses", query: "SELECT " + strings.Repeat("(", size/2) + strings.Repeat(")", size/2)},
+		{name: "unterminated literal", query: "SELECT '" + strings.Repeat("''", size/2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			_ = validator.ValidateQuery(tt.query)
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("ValidateQuery() took %s on a %d byte query", elapsed, len(tt.query))
+			}
+		})
+	}
+}
@@ -5,14 +5,24 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
+	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/jhoffmann/go-database-mcp/internal/config"
 	"github.com/jhoffmann/go-database-mcp/internal/database"
 	"github.com/jhoffmann/go-database-mcp/internal/handlers"
+	"github.com/jhoffmann/go-database-mcp/internal/logging"
+	"github.com/jhoffmann/go-database-mcp/internal/metrics"
+	"github.com/jhoffmann/go-database-mcp/internal/schema"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -21,9 +31,26 @@ import (
 // It wraps the MCP server implementation with database-specific configuration
 // and provides lifecycle management.
 type Server struct {
-	config    *config.Config    // Database configuration
-	server    *mcp.Server       // MCP server instance
-	dbManager *database.Manager // Database manager
+	config    *config.Config         // Database configuration
+	server    *mcp.Server            // MCP server instance
+	dbManager *database.Manager      // Database manager
+	audit     *handlers.AuditLogger  // Query audit log (nil when disabled)
+	metrics   *metrics.Metrics       // Prometheus metrics (nil when disabled)
+	stats     *handlers.SessionStats // Query counters reported by the session_stats tool
+
+	mu     sync.RWMutex // Guards active
+	active string       // Name of the connection used by tool calls
+
+	listenMu    sync.Mutex                                        // Serializes subscribing to and unsubscribing from NOTIFY channels
+	notifyMu    sync.Mutex                                        // Guards subscribers and watched
+	subscribers map[notifyChannel]map[*mcp.ServerSession]struct{} // Sessions subscribed to each NOTIFY channel
+	watched     map[*mcp.ServerSession]bool                       // Sessions whose end unsubscribes them from their channels
+}
+
+// notifyChannel identifies a NOTIFY channel of one database connection.
+type notifyChannel struct {
+	db   database.Database
+	name string
 }
 
 // NewServer creates a new Database MCP Server instance with the given configuration.
@@ -42,14 +69,40 @@ func NewServer(cfg *config.Config) (*Server, error) {
 		return nil, err
 	}
 
+	// Register additional named connections; the default one is owned by the manager itself
+	for name, connCfg := range cfg.Connections {
+		if name == config.DefaultConnection {
+			continue
+		}
+		if err := dbManager.AddConnection(name, connCfg); err != nil {
+			return nil, err
+		}
+	}
+
 	server := &Server{
 		config:    cfg,
 		server:    mcpServer,
 		dbManager: dbManager,
+		stats:     handlers.NewSessionStats(),
+		active:    config.DefaultConnection,
+	}
+
+	if cfg.Server.AuditLog {
+		audit, err := handlers.OpenAuditLogger(cfg.Server.AuditLogPath)
+		if err != nil {
+			return nil, err
+		}
+		server.audit = audit
+	}
+
+	if cfg.Server.MetricsPort > 0 {
+		server.metrics = metrics.New(server.connectionPools)
 	}
 
 	// Register MCP tools
 	server.registerTools()
+	server.registerResources()
+	mcpServer.AddReceivingMiddleware(logging.ToolCallMiddleware(slog.Default()))
 
 	return server, nil
 }
@@ -58,21 +111,61 @@ func NewServer(cfg *config.Config) (*Server, error) {
 func (s *Server) registerTools() {
 	// Query tool - Execute SQL queries with result formatting
 	type QueryArgs struct {
-		Query  string `json:"query" jsonschema:"the SQL query to execute"`
-		Args   []any  `json:"args,omitempty" jsonschema:"parameters for the query"`
-		Format string `json:"format,omitempty" jsonschema:"output format (json or table)"`
+		Query       string   `json:"query" jsonschema:"the SQL query to execute"`
+		Args        []any    `json:"args,omitempty" jsonschema:"parameters for the query"`
+		Format      string   `json:"format,omitempty" jsonschema:"output format (json, yaml, table, or ndjson with one JSON object per row)"`
+		Formats     []string `json:"formats,omitempty" jsonschema:"return the result in each of these formats as separate labeled sections; overrides format"`
+		Typed       bool     `json:"typed,omitempty" jsonschema:"return each value as {type, value} with its Go and database type"`
+		ColumnTypes bool     `json:"column_types,omitempty" jsonschema:"include the database type and nullability of each result column"`
+		MaxRows     int      `json:"max_rows,omitempty" jsonschema:"page a SELECT without its own LIMIT: return at most this many rows per page"`
+		Page        int      `json:"page,omitempty" jsonschema:"page to return when max_rows is set, starting at 1; the result reports next_page while more rows remain"`
+		ColumnOrder []string `json:"column_order,omitempty" jsonschema:"result columns to list first, in this order (e.g. [\"id\"]); unlisted columns follow in their original order"`
 	}
 
 	mcp.AddTool(s.server, &mcp.Tool{
 		Name:        "query",
 		Description: "Execute SQL queries with parameter binding and result formatting",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args QueryArgs) (*mcp.CallToolResult, any, error) {
-		if s.dbManager.GetDatabase() == nil {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
 			return nil, nil, fmt.Errorf("database not connected")
 		}
 
-		handler := handlers.NewQueryHandler(s.dbManager.GetDatabase(), &s.config.Database)
-		result, err := handler.ExecuteQuery(ctx, args.Query, args.Args...)
+		handler := handlers.NewQueryHandler(db, dbConfig)
+		handler.SetTypedValues(args.Typed)
+		handler.SetColumnTypes(args.ColumnTypes)
+		handler.SetPagination(args.MaxRows, args.Page)
+		handler.SetColumnOrder(args.ColumnOrder)
+		handler.SetSessionStats(s.stats)
+		if s.audit != nil {
+			handler.SetAuditLogger(s.audit, clientName(req))
+		}
+
+		if len(args.Formats) > 0 {
+			// All formats are validated before the query runs
+			result, sections, err := handler.ExecuteAndFormatAll(ctx, args.Query, args.Formats, args.Args...)
+			if err != nil {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+					},
+				}, nil, nil
+			}
+
+			content := make([]mcp.Content, 0, len(sections))
+			for _, section := range sections {
+				content = append(content, &mcp.TextContent{Text: fmt.Sprintf("=== %s ===\n%s", section.Format, section.Text)})
+			}
+			return &mcp.CallToolResult{Content: content}, result, nil
+		}
+
+		format := args.Format
+		if format == "" {
+			format = "json"
+		}
+
+		// The format is validated before the query runs, so a bad format never executes the statement
+		result, formatted, err := handler.ExecuteAndFormat(ctx, args.Query, format, args.Args...)
 		if err != nil {
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{
@@ -81,12 +174,170 @@ func (s *Server) registerTools() {
 			}, nil, nil
 		}
 
-		format := args.Format
-		if format == "" {
-			format = "json"
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: formatted},
+			},
+		}, result, nil
+	})
+
+	// Execute script tool
+	type ExecuteScriptArgs struct {
+		Script string `json:"script" jsonschema:"semicolon-separated SQL statements to run in one transaction"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "execute_script",
+		Description: "Run a multi-statement SQL script in a single transaction, rolling back every statement if any fails",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ExecuteScriptArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewQueryHandler(db, dbConfig)
+		handler.SetSessionStats(s.stats)
+		if s.audit != nil {
+			handler.SetAuditLogger(s.audit, clientName(req))
+		}
+
+		result, err := handler.ExecuteScript(ctx, args.Script)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Script committed: %d statements executed, %d rows affected in %s",
+					result.StatementCount, result.RowsAffected, result.ExecutionTime)},
+			},
+		}, result, nil
+	})
+
+	// Execute batch tool
+	type ExecuteBatchArgs struct {
+		Queries     []string `json:"queries" jsonschema:"SQL statements to execute in order inside one transaction"`
+		StopOnError bool     `json:"stop_on_error,omitempty" jsonschema:"stop at the first failing statement and roll back the batch; otherwise failed statements are undone individually and the rest committed"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "execute_batch",
+		Description: "Execute a list of SQL statements in order inside one transaction, reporting the result or error of each statement",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ExecuteBatchArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewQueryHandler(db, dbConfig)
+		handler.SetSessionStats(s.stats)
+		if s.audit != nil {
+			handler.SetAuditLogger(s.audit, clientName(req))
+		}
+
+		result, err := handler.ExecuteBatch(ctx, args.Queries, args.StopOnError)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		outcome := "committed"
+		if !result.Committed {
+			outcome = "rolled back"
+		}
+		text := fmt.Sprintf("Batch %s: %d of %d statements succeeded", outcome, result.SuccessCount, result.TotalStatements)
+		if result.RolledBack > 0 {
+			text += fmt.Sprintf("; %d statements executed before the failure were rolled back and have no effect", result.RolledBack)
+		}
+		for _, batchErr := range result.Errors {
+			text += "\nError in " + batchErr
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: text},
+			},
+		}, result, nil
+	})
+
+	// Insert rows tool
+	type InsertRowsArgs struct {
+		TableName string           `json:"table_name" jsonschema:"name of the table to insert into"`
+		Rows      []map[string]any `json:"rows" jsonschema:"rows to insert, each mapping column names to values; every row must set the same columns; at most DB_MAX_INSERT_ROWS (default 1000) rows"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "insert_rows",
+		Description: "Insert rows given as column-to-value objects with a parameterized multi-row INSERT, split into several statements in one transaction beyond 65535 parameters, returning the rows affected and any generated IDs",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args InsertRowsArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewQueryHandler(db, dbConfig)
+		handler.SetSessionStats(s.stats)
+		if s.audit != nil {
+			handler.SetAuditLogger(s.audit, clientName(req))
+		}
+
+		result, err := handler.InsertRows(ctx, args.TableName, args.Rows)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
 		}
 
-		formatted, err := handler.FormatResult(*result, format)
+		text := result.Message
+		switch {
+		case len(result.GeneratedIDs) > 0:
+			text += fmt.Sprintf(" Generated IDs: %v", result.GeneratedIDs)
+		case result.FirstInsertID != nil:
+			text += fmt.Sprintf(" First insert ID: %d", *result.FirstInsertID)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: text},
+			},
+		}, result, nil
+	})
+
+	// Query columns tool
+	type QueryColumnsArgs struct {
+		Query string `json:"query" jsonschema:"SELECT query whose result columns to describe"`
+		Args  []any  `json:"args,omitempty" jsonschema:"parameters for the query"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "query_columns",
+		Description: "Get the result column names and types of a SELECT query without returning any rows",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args QueryColumnsArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewQueryHandler(db, dbConfig)
+		result, err := handler.QueryColumns(ctx, args.Query, args.Args...)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
 		if err != nil {
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{
@@ -97,21 +348,30 @@ func (s *Server) registerTools() {
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
-				&mcp.TextContent{Text: formatted},
+				&mcp.TextContent{Text: string(jsonData)},
 			},
 		}, result, nil
 	})
 
 	// List tables tool
+	type ListTablesArgs struct {
+		SchemaName string `json:"schema_name,omitempty" jsonschema:"PostgreSQL schema to list, instead of the configured DB_SCHEMA (* for every schema)"`
+	}
+
 	mcp.AddTool(s.server, &mcp.Tool{
 		Name:        "list_tables",
 		Description: "List all tables in the current database",
-	}, func(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
-		if s.dbManager.GetDatabase() == nil {
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListTablesArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
 			return nil, nil, fmt.Errorf("database not connected")
 		}
 
-		handler := handlers.NewSchemaHandler(s.dbManager.GetDatabase(), &s.config.Database)
+		if args.SchemaName != "" {
+			ctx = database.WithSchema(ctx, args.SchemaName)
+		}
+
+		handler := handlers.NewSchemaHandler(db, dbConfig)
 		result, err := handler.ListTables(ctx)
 		if err != nil {
 			return &mcp.CallToolResult{
@@ -133,11 +393,12 @@ func (s *Server) registerTools() {
 		Name:        "list_databases",
 		Description: "List all available databases on the server",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
-		if s.dbManager.GetDatabase() == nil {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
 			return nil, nil, fmt.Errorf("database not connected")
 		}
 
-		handler := handlers.NewSchemaHandler(s.dbManager.GetDatabase(), &s.config.Database)
+		handler := handlers.NewSchemaHandler(db, dbConfig)
 		result, err := handler.ListDatabases(ctx)
 		if err != nil {
 			return &mcp.CallToolResult{
@@ -156,19 +417,43 @@ func (s *Server) registerTools() {
 
 	// Describe table tool
 	type DescribeTableArgs struct {
-		TableName string `json:"table_name" jsonschema:"name of the table to describe"`
+		TableName  string `json:"table_name" jsonschema:"name of the table to describe"`
+		SchemaName string `json:"schema_name,omitempty" jsonschema:"PostgreSQL schema containing the table, instead of the configured DB_SCHEMA"`
+		Stats      bool   `json:"stats,omitempty" jsonschema:"include approximate per-column null fraction and distinct count from planner statistics"`
+		Format     string `json:"format,omitempty" jsonschema:"include the full schema in this format (json or yaml)"`
 	}
 
 	mcp.AddTool(s.server, &mcp.Tool{
 		Name:        "describe_table",
 		Description: "Get detailed schema information about a specific table",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args DescribeTableArgs) (*mcp.CallToolResult, any, error) {
-		if s.dbManager.GetDatabase() == nil {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
 			return nil, nil, fmt.Errorf("database not connected")
 		}
 
-		handler := handlers.NewSchemaHandler(s.dbManager.GetDatabase(), &s.config.Database)
-		result, err := handler.DescribeTable(ctx, args.TableName)
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		if args.Format != "" {
+			if err := handler.ValidateFormat(args.Format); err != nil {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+					},
+				}, nil, nil
+			}
+		}
+
+		if args.SchemaName != "" {
+			ctx = database.WithSchema(ctx, args.SchemaName)
+		}
+
+		var result *handlers.TableSchemaResult
+		var err error
+		if args.Stats {
+			result, err = handler.DescribeTableWithStats(ctx, args.TableName)
+		} else {
+			result, err = handler.DescribeTable(ctx, args.TableName)
+		}
 		if err != nil {
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{
@@ -177,31 +462,50 @@ func (s *Server) registerTools() {
 			}, nil, nil
 		}
 
+		kind := "Table"
+		if result.Schema.IsView() {
+			kind = "View"
+		}
+
+		text := fmt.Sprintf("%s %s has %d columns, %d indexes and %d foreign keys",
+			kind, result.Schema.TableName, len(result.Schema.Columns), len(result.Schema.Indexes),
+			len(result.Schema.ForeignKeys))
+
+		if args.Format != "" {
+			formatted, err := handler.FormatResult(result, args.Format)
+			if err != nil {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						&mcp.TextContent{Text: fmt.Sprintf("Error formatting result: %v", err)},
+					},
+				}, nil, nil
+			}
+			text += "\n\n" + formatted
+		}
+
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
-				&mcp.TextContent{Text: fmt.Sprintf("Table %s has %d columns and %d indexes",
-					result.Schema.TableName, len(result.Schema.Columns), len(result.Schema.Indexes))},
+				&mcp.TextContent{Text: text},
 			},
 		}, result, nil
 	})
 
-	// Get table data tool
-	type GetTableDataArgs struct {
-		TableName string `json:"table_name" jsonschema:"name of the table to get data from"`
-		Limit     int    `json:"limit,omitempty" jsonschema:"maximum number of rows to return"`
-		Offset    int    `json:"offset,omitempty" jsonschema:"number of rows to skip"`
+	// Search tables tool
+	type SearchArgs struct {
+		Pattern string `json:"pattern" jsonschema:"case-insensitive substring to search for"`
 	}
 
 	mcp.AddTool(s.server, &mcp.Tool{
-		Name:        "get_table_data",
-		Description: "Retrieve paginated data from a specific table",
-	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetTableDataArgs) (*mcp.CallToolResult, any, error) {
-		if s.dbManager.GetDatabase() == nil {
+		Name:        "search_tables",
+		Description: "Find tables whose name contains a keyword, across all allowed databases; exact and prefix matches are listed first",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args SearchArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
 			return nil, nil, fmt.Errorf("database not connected")
 		}
 
-		handler := handlers.NewSchemaHandler(s.dbManager.GetDatabase(), &s.config.Database)
-		result, err := handler.GetTableData(ctx, args.TableName, args.Limit, args.Offset)
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.SearchTables(ctx, args.Pattern)
 		if err != nil {
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{
@@ -212,27 +516,23 @@ func (s *Server) registerTools() {
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
-				&mcp.TextContent{Text: fmt.Sprintf("Retrieved %d rows from %s (total: %d)",
-					len(result.Data.Rows), result.Data.TableName, result.Data.Total)},
+				&mcp.TextContent{Text: fmt.Sprintf("Found %d tables matching %q: %v", result.Count, result.Pattern, result.Tables)},
 			},
 		}, result, nil
 	})
 
-	// Explain query tool
-	type ExplainQueryArgs struct {
-		Query string `json:"query" jsonschema:"SQL query to explain"`
-	}
-
+	// Search columns tool
 	mcp.AddTool(s.server, &mcp.Tool{
-		Name:        "explain_query",
-		Description: "Get the execution plan for a SQL query",
-	}, func(ctx context.Context, req *mcp.CallToolRequest, args ExplainQueryArgs) (*mcp.CallToolResult, any, error) {
-		if s.dbManager.GetDatabase() == nil {
+		Name:        "search_columns",
+		Description: "Find columns whose name contains a keyword, across all allowed databases; exact and prefix matches are listed first",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args SearchArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
 			return nil, nil, fmt.Errorf("database not connected")
 		}
 
-		handler := handlers.NewSchemaHandler(s.dbManager.GetDatabase(), &s.config.Database)
-		result, err := handler.ExplainQuery(ctx, args.Query)
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.SearchColumns(ctx, args.Pattern)
 		if err != nil {
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{
@@ -241,24 +541,34 @@ func (s *Server) registerTools() {
 			}, nil, nil
 		}
 
+		columns, err := json.MarshalIndent(result.Columns, "", "  ")
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error formatting result: %v", err)},
+				},
+			}, nil, nil
+		}
+
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
-				&mcp.TextContent{Text: fmt.Sprintf("Execution plan for query:\n%s", result.Plan)},
+				&mcp.TextContent{Text: fmt.Sprintf("Found %d columns matching %q:\n%s", result.Count, result.Pattern, columns)},
 			},
 		}, result, nil
 	})
 
-	// Connection info tool
+	// List views tool
 	mcp.AddTool(s.server, &mcp.Tool{
-		Name:        "connection_info",
-		Description: "Get information about the current database connection",
+		Name:        "list_views",
+		Description: "List all views in the current database",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
-		if s.dbManager.GetDatabase() == nil {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
 			return nil, nil, fmt.Errorf("database not connected")
 		}
 
-		handler := handlers.NewAdminHandler(s.dbManager.GetDatabase())
-		result, err := handler.GetConnectionInfo(ctx)
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.ListViews(ctx)
 		if err != nil {
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{
@@ -269,62 +579,1566 @@ func (s *Server) registerTools() {
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
-				&mcp.TextContent{Text: fmt.Sprintf("Driver: %s, Connected: %v, Ping: %s",
-					result.Driver, result.Connected, result.PingTime)},
+				&mcp.TextContent{Text: fmt.Sprintf("Found %d views: %v", result.Count, result.Views)},
 			},
 		}, result, nil
 	})
-}
 
-// Start begins serving MCP requests using stdio transport.
-// It establishes database connections and starts the MCP server to handle client requests.
-// The server will run until the context is cancelled or an error occurs.
-func (s *Server) Start(ctx context.Context) error {
-	// Connect to database
-	log.Printf("Connecting to database...")
-	if err := s.dbManager.Connect(ctx); err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+	// Describe view tool
+	type DescribeViewArgs struct {
+		ViewName string `json:"view_name" jsonschema:"name of the view to describe"`
 	}
 
-	log.Printf("Database connected successfully")
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "describe_view",
+		Description: "Get the definition and columns of a specific view",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args DescribeViewArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
 
-	transport := &mcp.StdioTransport{}
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.DescribeView(ctx, args.ViewName)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
 
-	log.Printf("Starting Database MCP Server...")
-	log.Printf("Database type: %s", s.config.Database.Type)
-	log.Printf("Database host: %s:%d", s.config.Database.Host, s.config.Database.Port)
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("View %s has %d columns:\n%s",
+					result.Schema.ViewName, len(result.Schema.Columns), result.Schema.Definition)},
+			},
+		}, result, nil
+	})
 
-	return s.server.Run(ctx, transport)
-}
+	// List stored procedures tool
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "list_stored_procedures",
+		Description: "List the stored functions and procedures in the current database with their argument and return types",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
 
-// main is the entry point for the Database MCP Server.
-// It loads configuration, initializes the server, and handles graceful shutdown
-// on SIGINT and SIGTERM signals.
-func main() {
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
-	log.Println("Starting Database MCP Server...")
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.ListFunctions(ctx)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
 
-	cfg, err := config.Load()
-	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
-	}
+		functions, err := json.MarshalIndent(result.Functions, "", "  ")
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error formatting result: %v", err)},
+				},
+			}, nil, nil
+		}
 
-	log.Printf("Configuration loaded successfully")
-	log.Printf("Database type: %s", cfg.Database.Type)
-	log.Printf("Database host: %s:%d", cfg.Database.Host, cfg.Database.Port)
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Found %d stored functions and procedures:\n%s", result.Count, functions)},
+			},
+		}, result, nil
+	})
 
-	server, err := NewServer(cfg)
-	if err != nil {
-		log.Fatalf("Failed to create server: %v", err)
+	// Describe stored procedure tool
+	type DescribeStoredProcedureArgs struct {
+		Name string `json:"name" jsonschema:"name of the stored function or procedure to describe"`
 	}
 
-	ctx, cancel := signal.NotifyContext(context.Background(),
-		syscall.SIGINT, syscall.SIGTERM)
-	defer cancel()
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "describe_stored_procedure",
+		Description: "Get the arguments, return type and full source code of a stored function or procedure",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args DescribeStoredProcedureArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.DescribeFunction(ctx, args.Name)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		schema := result.Schema
+		text := fmt.Sprintf("%s %s(%s)", schema.Type, schema.Name, strings.Join(schema.ArgumentTypes, ", "))
+		if schema.ReturnType != "" {
+			text += " returns " + schema.ReturnType
+		}
+		if schema.Language != "" {
+			text += fmt.Sprintf(" [%s]", schema.Language)
+		}
+		if schema.Definition != "" {
+			text += ":\n" + schema.Definition
+		} else {
+			text += "\nThe source is not available to the current user."
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: text},
+			},
+		}, result, nil
+	})
+
+	// Get foreign keys tool
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "get_foreign_keys",
+		Description: "List all foreign key relationships between tables in the current database",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.ListForeignKeys(ctx)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
 
-	if err := server.Start(ctx); err != nil {
-		log.Fatalf("Server error: %v", err)
+		relationships, err := json.MarshalIndent(result.ForeignKeys, "", "  ")
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error formatting result: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Found %d foreign key relationships:\n%s", result.Count, relationships)},
+			},
+		}, result, nil
+	})
+
+	// Check constraints tool
+	type CheckConstraintsArgs struct {
+		TableName string `json:"table_name" jsonschema:"name of the table whose constraints to list"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "check_constraints",
+		Description: "List the primary key, foreign key, unique, CHECK and NOT NULL constraints of a table, including each CHECK expression",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args CheckConstraintsArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.GetConstraints(ctx, args.TableName)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		constraints, err := json.MarshalIndent(result.Constraints, "", "  ")
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error formatting result: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Found %d constraints on table %s:\n%s", result.Count, result.TableName, constraints)},
+			},
+		}, result, nil
+	})
+
+	// Table statistics tool
+	type TableStatisticsArgs struct {
+		TableName string `json:"table_name" jsonschema:"name of the table to profile"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "table_statistics",
+		Description: "Profile each column of a table: null and distinct counts, min/max values and average string length",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args TableStatisticsArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.GetColumnStatistics(ctx, args.TableName)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		statistics, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error formatting result: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Statistics for %d columns of %s:\n%s", result.Count, result.TableName, statistics)},
+			},
+		}, result, nil
+	})
+
+	// Profile table tool
+	type ProfileTableArgs struct {
+		TableName         string `json:"table_name" jsonschema:"name of the table to profile"`
+		MaxDistinctValues int    `json:"max_distinct_values,omitempty" jsonschema:"number of most common values to return per column (default 20, max 100)"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "profile_table",
+		Description: "Profile the value distribution of each column of a table: null percentage, distinct count, most common values with their frequencies, and min/max for numeric and date columns",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ProfileTableArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.ProfileTable(ctx, args.TableName, args.MaxDistinctValues)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		profile, err := json.MarshalIndent(result.Columns, "", "  ")
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error formatting result: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Profile of %d columns of %s (%d rows):\n%s", len(result.Columns), result.TableName, result.RowCount, profile)},
+			},
+		}, result, nil
+	})
+
+	// Column values tool
+	type ColumnValuesArgs struct {
+		TableName  string `json:"table_name" jsonschema:"name of the table"`
+		ColumnName string `json:"column_name" jsonschema:"name of the column whose distinct values to return"`
+		Limit      int    `json:"limit,omitempty" jsonschema:"maximum number of values to return (default 100, max 1000)"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "column_values",
+		Description: "List the distinct values of a column, sorted, with the number of distinct values and whether the list was truncated",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ColumnValuesArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.GetColumnValues(ctx, args.TableName, args.ColumnName, args.Limit)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		values, err := json.MarshalIndent(result.Values.Values, "", "  ")
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error formatting result: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		summary := fmt.Sprintf("%s.%s has %d distinct values", result.Values.TableName, result.Values.ColumnName, result.Values.DistinctCount)
+		if result.Values.Truncated {
+			summary += fmt.Sprintf(" (showing the first %d)", result.Count)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("%s:\n%s", summary, values)},
+			},
+		}, result, nil
+	})
+
+	// CREATE TABLE statement tools: generate_create_table_ddl and show_create_table share one handler
+	type CreateTableArgs struct {
+		TableName string `json:"table_name" jsonschema:"name of the table to return the CREATE TABLE statement of"`
+	}
+
+	showCreateTable := func(ctx context.Context, req *mcp.CallToolRequest, args CreateTableArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.ShowCreateTable(ctx, args.TableName)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: result.DDL},
+			},
+		}, result, nil
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "generate_create_table_ddl",
+		Description: "Generate the CREATE TABLE statement, including constraints and indexes, that recreates a table (same as show_create_table)",
+	}, showCreateTable)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "show_create_table",
+		Description: "Show the CREATE TABLE statement of a table (SHOW CREATE TABLE on MySQL, rebuilt from the catalog on PostgreSQL)",
+	}, showCreateTable)
+
+	// Generate INSERT template tool
+	type GenerateInsertTemplateArgs struct {
+		TableName string `json:"table_name" jsonschema:"name of the table to generate an INSERT statement for"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "generate_insert_template",
+		Description: "Generate a parameterized INSERT statement for a table, listing each parameter's column and type",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GenerateInsertTemplateArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.GenerateInsertTemplate(ctx, args.TableName)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		parameters, err := json.MarshalIndent(result.Parameters, "", "  ")
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error formatting result: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("%s\n\nParameters:\n%s\n\nOptional columns can be left out of the INSERT. "+
+					"Remove the comments before running the statement unless DB_ALLOW_COMMENTS is enabled.", result.Statement, parameters)},
+			},
+		}, result, nil
+	})
+
+	// Table size tool
+	type GetTableSizeArgs struct {
+		TableName string `json:"table_name" jsonschema:"name of the table to measure"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "get_table_size",
+		Description: "Get the storage used by a table: data size, index size, total size and estimated row count",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetTableSizeArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.GetTableSize(ctx, args.TableName)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Table %s: %s total (data %s, indexes %s), about %d rows",
+					result.TableName, handlers.FormatBytes(result.TotalSizeBytes), handlers.FormatBytes(result.DataSizeBytes),
+					handlers.FormatBytes(result.IndexSizeBytes), result.RowEstimate)},
+			},
+		}, result, nil
+	})
+
+	// Database size tool
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "get_database_size",
+		Description: "Get the storage used by every table in the current database, largest first, with overall totals",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.GetDatabaseSize(ctx)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		var text strings.Builder
+		fmt.Fprintf(&text, "Database size: %s total (data %s, indexes %s) across %d tables, about %d rows",
+			handlers.FormatBytes(result.TotalSizeBytes), handlers.FormatBytes(result.DataSizeBytes),
+			handlers.FormatBytes(result.IndexSizeBytes), result.TableCount, result.RowEstimate)
+		for _, table := range result.Tables {
+			fmt.Fprintf(&text, "\n- %s: %s (data %s, indexes %s)", table.TableName, handlers.FormatBytes(table.TotalSizeBytes),
+				handlers.FormatBytes(table.DataSizeBytes), handlers.FormatBytes(table.IndexSizeBytes))
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: text.String()},
+			},
+		}, result, nil
+	})
+
+	// Get table data tool
+	type GetTableDataArgs struct {
+		TableName  string   `json:"table_name" jsonschema:"name of the table to get data from"`
+		SchemaName string   `json:"schema_name,omitempty" jsonschema:"PostgreSQL schema containing the table, instead of the configured DB_SCHEMA"`
+		Limit      int      `json:"limit,omitempty" jsonschema:"maximum number of rows to return"`
+		Offset     int      `json:"offset,omitempty" jsonschema:"number of rows to skip"`
+		Cursor     string   `json:"cursor,omitempty" jsonschema:"next_cursor from the previous page, to continue after its last row by primary key instead of using offset"`
+		Format     string   `json:"format,omitempty" jsonschema:"include the rows in this format (json or yaml)"`
+		Columns    []string `json:"columns,omitempty" jsonschema:"only return these columns, in this order (default: all columns)"`
+
+		FilterColumn   string `json:"filter_column,omitempty" jsonschema:"only return rows where this column matches the filter"`
+		FilterOperator string `json:"filter_operator,omitempty" jsonschema:"filter comparison: =, >, <, LIKE or IS NULL (default =)"`
+		FilterValue    string `json:"filter_value,omitempty" jsonschema:"value to compare filter_column against (ignored for IS NULL)"`
+
+		OrderBy  string `json:"order_by,omitempty" jsonschema:"sort rows by this column for stable pagination"`
+		OrderDir string `json:"order_dir,omitempty" jsonschema:"sort direction for order_by: ASC (default) or DESC"`
+
+		EstimateCount bool `json:"estimate_count,omitempty" jsonschema:"report an approximate total from table statistics instead of counting every row (faster on large tables; not available with a filter)"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "get_table_data",
+		Description: "Retrieve paginated data from a specific table",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetTableDataArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		if args.Format != "" {
+			if err := handler.ValidateFormat(args.Format); err != nil {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+					},
+				}, nil, nil
+			}
+		}
+
+		if args.SchemaName != "" {
+			ctx = database.WithSchema(ctx, args.SchemaName)
+		}
+
+		opts := database.TableDataOptions{
+			Columns:       args.Columns,
+			Cursor:        args.Cursor,
+			EstimateCount: args.EstimateCount,
+			OrderBy:       args.OrderBy,
+			OrderDir:      args.OrderDir,
+		}
+		if args.FilterColumn == "" && (args.FilterOperator != "" || args.FilterValue != "") {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: "Error: filter_column is required when filter_operator or filter_value is set"},
+				},
+			}, nil, nil
+		}
+		if args.FilterColumn != "" {
+			opts.Filter = &database.TableFilter{
+				Column:   args.FilterColumn,
+				Operator: args.FilterOperator,
+				Value:    args.FilterValue,
+			}
+			if opts.Filter.Operator == "" {
+				opts.Filter.Operator = "="
+			}
+		}
+
+		result, err := handler.GetTableData(ctx, args.TableName, args.Limit, args.Offset, opts)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		total := fmt.Sprintf("%d", result.Data.Total)
+		if result.Data.CountIsEstimate {
+			total = "~" + total + " estimated"
+		}
+		text := fmt.Sprintf("Retrieved %d rows from %s (total: %s)",
+			len(result.Data.Rows), result.Data.TableName, total)
+		if result.Data.NextCursor != nil {
+			text += fmt.Sprintf("\nNext cursor: %s", *result.Data.NextCursor)
+		}
+
+		if args.Format != "" {
+			formatted, err := handler.FormatResult(result, args.Format)
+			if err != nil {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						&mcp.TextContent{Text: fmt.Sprintf("Error formatting result: %v", err)},
+					},
+				}, nil, nil
+			}
+			text += "\n\n" + formatted
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: text},
+			},
+		}, result, nil
+	})
+
+	// Analyze query tool
+	type AnalyzeQueryArgs struct {
+		Query string `json:"query" jsonschema:"SQL query to analyze"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "analyze_query",
+		Description: "Statically determine the operation type and the tables a SQL query reads from and writes to, without executing it",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args AnalyzeQueryArgs) (*mcp.CallToolResult, any, error) {
+		result, err := handlers.AnalyzeQuery(args.Query)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		text := fmt.Sprintf("Operation: %s\nReads: %s\nWrites: %s", result.Operation,
+			formatTableList(result.Reads), formatTableList(result.Writes))
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: text},
+			},
+		}, result, nil
+	})
+
+	// Validate query tool
+	type ValidateQueryArgs struct {
+		Query string `json:"query" jsonschema:"SQL query to validate"`
+		Args  []any  `json:"args,omitempty" jsonschema:"parameters the query would be run with; only used to rewrite ? placeholders as the query tool does"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "validate_query",
+		Description: "Check whether a SQL query passes security validation and can be prepared by the database, without executing it",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ValidateQueryArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewQueryHandler(db, dbConfig)
+		result, err := handler.ValidateOnly(ctx, args.Query, args.Args...)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		text := fmt.Sprintf("Valid %s query: it would be accepted.", result.Type)
+		if !result.Valid {
+			text = fmt.Sprintf("Invalid %s query: rejected by %s check: %s", result.Type, result.Stage, result.Error)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: text},
+			},
+		}, result, nil
+	})
+
+	// Explain query tool
+	type ExplainQueryArgs struct {
+		Query   string `json:"query" jsonschema:"SQL query to explain"`
+		Analyze bool   `json:"analyze,omitempty" jsonschema:"Execute the query to report actual row counts and timings (EXPLAIN ANALYZE); DML runs in a transaction that is rolled back"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "explain_query",
+		Description: "Get the execution plan for a SQL query",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ExplainQueryArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewSchemaHandler(db, dbConfig)
+		result, err := handler.ExplainQuery(ctx, args.Query, args.Analyze)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		planLabel := "Execution plan"
+		if result.Analyzed {
+			planLabel = "Analyzed execution plan"
+		}
+		if result.Summary != nil {
+			planLabel = fmt.Sprintf("Summary: %s\n\n%s", result.Summary, planLabel)
+		}
+		if result.Warning != "" {
+			planLabel = fmt.Sprintf("Warning: %s\n\n%s", result.Warning, planLabel)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("%s for query:\n%s", planLabel, result.Plan)},
+			},
+		}, result, nil
+	})
+
+	// Table bloat tool
+	type TableBloatArgs struct {
+		TableName string `json:"table_name,omitempty" jsonschema:"table to check (default: every table)"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "table_bloat",
+		Description: "Estimate dead tuple bloat from PostgreSQL statistics and recommend VACUUM where needed",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args TableBloatArgs) (*mcp.CallToolResult, any, error) {
+		db, _ := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewAdminHandler(db)
+		result, err := handler.GetTableBloat(ctx, args.TableName)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		var text strings.Builder
+		fmt.Fprintf(&text, "%d of %d tables need vacuuming", result.NeedsVacuum, len(result.Tables))
+		for _, table := range result.Tables {
+			fmt.Fprintf(&text, "\n- %s: %d dead / %d live tuples (%.1f%% dead). %s",
+				table.TableName, table.DeadTuples, table.LiveTuples, table.DeadRatio*100, table.Recommendation)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: text.String()},
+			},
+		}, result, nil
+	})
+
+	// Index usage tool
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "get_index_usage",
+		Description: "Report how often each index has been scanned and highlight never-used indexes as candidates for removal",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+		db, _ := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewAdminHandler(db)
+		result, err := handler.GetIndexUsage(ctx)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		var text strings.Builder
+		fmt.Fprintf(&text, "%d of %d indexes have never been scanned and are candidates for removal (%s)",
+			result.UnusedCount, len(result.Indexes), handlers.FormatBytes(result.UnusedBytes))
+		for _, index := range result.Indexes {
+			fmt.Fprintf(&text, "\n- %s.%s: %d scans, %s", index.TableName, index.IndexName, index.IndexScans, handlers.FormatBytes(index.SizeBytes))
+			switch {
+			case index.RemovalCandidate:
+				text.WriteString(". Candidate for removal")
+			case index.IndexScans == 0:
+				text.WriteString(". Never scanned, but enforces uniqueness")
+			}
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: text.String()},
+			},
+		}, result, nil
+	})
+
+	// Session stats tool
+	type SessionStatsArgs struct {
+		Reset bool `json:"reset,omitempty" jsonschema:"reset the counters after reporting them"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "session_stats",
+		Description: "Report the queries executed since the server started or the counters were last reset: totals by type, rows, errors and execution time",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args SessionStatsArgs) (*mcp.CallToolResult, any, error) {
+		result := s.stats.Snapshot()
+		if args.Reset {
+			s.stats.Reset()
+		}
+
+		byType, err := json.MarshalIndent(result.QueriesByType, "", "  ")
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error formatting result: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		text := fmt.Sprintf("Since %s: %d queries (%d failed), %d rows, %s total execution time\nQueries by type:\n%s",
+			result.Since.Format(time.RFC3339), result.TotalQueries, result.ErrorCount, result.TotalRows, result.TotalDuration, byType)
+		if args.Reset {
+			text += "\nCounters have been reset."
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: text},
+			},
+		}, result, nil
+	})
+
+	// Listen channel tool
+	type ListenChannelArgs struct {
+		Channel string `json:"channel" jsonschema:"PostgreSQL NOTIFY channel to listen on (case-sensitive)"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "listen_channel",
+		Description: "Listen on a PostgreSQL NOTIFY channel and forward its notifications to this client as log messages",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListenChannelArgs) (*mcp.CallToolResult, any, error) {
+		db, _ := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		channel := args.Channel
+		if err := s.subscribe(ctx, req.Session, notifyChannel{db: db, name: channel}); err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Listening on channel %s. Notifications are sent as %q log messages once a logging level is set.",
+					channel, notificationLogger)},
+			},
+		}, nil, nil
+	})
+
+	// Unlisten channel tool
+	type UnlistenChannelArgs struct {
+		Channel string `json:"channel" jsonschema:"PostgreSQL NOTIFY channel to stop listening on"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "unlisten_channel",
+		Description: "Stop forwarding the notifications of a PostgreSQL NOTIFY channel subscribed with listen_channel",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args UnlistenChannelArgs) (*mcp.CallToolResult, any, error) {
+		db, _ := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		if err := s.unsubscribe(ctx, req.Session, notifyChannel{db: db, name: args.Channel}); err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Stopped listening on channel %s", args.Channel)},
+			},
+		}, nil, nil
+	})
+
+	// Switch connection tool
+	type SwitchConnectionArgs struct {
+		ConnectionName string `json:"connection_name" jsonschema:"name of the configured connection to use for subsequent tool calls"`
+	}
+
+	type SwitchConnectionResult struct {
+		Connection string   `json:"connection"`
+		Type       string   `json:"type"`
+		Database   string   `json:"database"`
+		Available  []string `json:"available"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "switch_connection",
+		Description: "Change the database connection used by subsequent tool calls",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args SwitchConnectionArgs) (*mcp.CallToolResult, any, error) {
+		manager, err := s.switchConnection(args.ConnectionName)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		result := SwitchConnectionResult{
+			Connection: args.ConnectionName,
+			Type:       manager.Config().Type,
+			Database:   manager.Config().Database,
+			Available:  s.dbManager.ConnectionNames(),
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Switched to connection %s (%s database %s)",
+					result.Connection, result.Type, result.Database)},
+			},
+		}, result, nil
+	})
+
+	// Compare schemas tool
+	type CompareSchemasArgs struct {
+		Source string `json:"source,omitempty" jsonschema:"name of the connection to compare from, e.g. staging (default: the active connection)"`
+		Target string `json:"target" jsonschema:"name of the connection to compare against, e.g. production"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "compare_schemas",
+		Description: "Compare the tables of two configured connections, listing tables missing from either side and columns (type, nullable, default) and indexes that differ",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args CompareSchemasArgs) (*mcp.CallToolResult, any, error) {
+		result, err := s.compareSchemas(ctx, args.Source, args.Target)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: result.Summary()},
+			},
+		}, result, nil
+	})
+
+	// Connection info tool
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "connection_info",
+		Description: "Get information about the current database connection, including the server version and connection pool statistics",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+		db, _ := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewAdminHandler(db)
+		result, err := handler.GetConnectionInfo(ctx)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		text := fmt.Sprintf("Driver: %s, Connected: %v, Ping: %s, Isolation: %s, Server read-only: %v",
+			result.Driver, result.Connected, result.PingTime, result.IsolationLevel, result.ServerReadOnly)
+		if result.ServerVersion != "" {
+			text += fmt.Sprintf("\nServer version: %s", result.ServerVersion)
+		}
+		if pool := result.Pool; pool != nil {
+			text += fmt.Sprintf("\nPool: %d open (%d in use, %d idle) of max %d, %d waits totalling %s",
+				pool.OpenConnections, pool.InUse, pool.Idle, pool.MaxOpenConnections, pool.WaitCount, pool.WaitDuration)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: text},
+			},
+		}, result, nil
+	})
+
+	// Server settings tool
+	type ServerSettingsArgs struct {
+		Prefix string `json:"prefix,omitempty" jsonschema:"only return settings whose name starts with this prefix (case-insensitive), e.g. autovacuum or innodb_"`
+		Limit  int    `json:"limit,omitempty" jsonschema:"maximum number of settings to return (default and maximum 200)"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "server_settings",
+		Description: "List the database server's configuration parameters (pg_settings or SHOW VARIABLES), optionally filtered by name prefix, with values that may hold credentials redacted",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ServerSettingsArgs) (*mcp.CallToolResult, any, error) {
+		db, _ := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewAdminHandler(db)
+		result, err := handler.GetServerSettings(ctx, args.Prefix, args.Limit)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		settings, err := json.MarshalIndent(result.Settings, "", "  ")
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error formatting result: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		text := fmt.Sprintf("Found %d settings:\n%s", result.Count, settings)
+		if result.Truncated {
+			text = fmt.Sprintf("Showing %d of %d settings; narrow the prefix to see the rest:\n%s", result.Count, result.Total, settings)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: text},
+			},
+		}, result, nil
+	})
+
+	// Server info tool
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "get_server_info",
+		Description: "Get the database server version, character set or encoding, current database and user, uptime and connection limit",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+		db, _ := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewAdminHandler(db)
+		result, err := handler.GetServerInfo(ctx)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error formatting result: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: string(jsonData)},
+			},
+		}, result, nil
+	})
+
+	// Active connections tool
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "get_active_connections",
+		Description: "List the database sessions currently running a statement, with their user, client, state, duration and the first 200 characters of the statement",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+		db, _ := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewAdminHandler(db)
+		result, err := handler.GetActiveConnections(ctx)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		connections, err := json.MarshalIndent(result.Connections, "", "  ")
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error formatting result: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Found %d active connections:\n%s", result.Count, connections)},
+			},
+		}, result, nil
+	})
+
+	// Kill connection tool
+	type KillConnectionArgs struct {
+		PID int64 `json:"pid" jsonschema:"process ID of the session to terminate, as listed by get_active_connections"`
+	}
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "kill_connection",
+		Description: "Terminate a database session by process ID, e.g. a stuck query or one holding locks; not permitted in read-only mode",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args KillConnectionArgs) (*mcp.CallToolResult, any, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewAdminHandler(db)
+		result, err := handler.KillConnection(ctx, args.PID, dbConfig.ReadOnly)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Terminated connection %d", result.PID)},
+			},
+		}, result, nil
+	})
+
+	type ConnectionStatusResult struct {
+		Active      string                      `json:"active"`
+		Connections []database.ConnectionStatus `json:"connections"`
+	}
+
+	// Connection status tool
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "connection_status",
+		Description: "Report whether each configured database is connected and, if not, how many connection attempts were made and the last connection error",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+		s.mu.RLock()
+		result := ConnectionStatusResult{Active: s.active}
+		s.mu.RUnlock()
+		if result.Active == "" {
+			result.Active = config.DefaultConnection
+		}
+
+		var lines []string
+		for _, name := range s.dbManager.ConnectionNames() {
+			manager, err := s.dbManager.Connection(name)
+			if err != nil {
+				continue
+			}
+
+			status := manager.Status()
+			status.Name = name
+			result.Connections = append(result.Connections, status)
+
+			line := fmt.Sprintf("%s (%s): connected", name, status.Type)
+			if !status.Connected {
+				line = fmt.Sprintf("%s (%s): not connected after %d attempts", name, status.Type, status.Attempts)
+				if status.LastError != "" {
+					line += ", last error: " + status.LastError
+				}
+			}
+			lines = append(lines, line)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Active connection: %s\n%s", result.Active, strings.Join(lines, "\n"))},
+			},
+		}, result, nil
+	})
+
+	// Connection pool stats tool
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "connection_pool_stats",
+		Description: "Get connection pool usage and wait statistics, to tell whether slow responses come from pool exhaustion",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+		db, _ := s.activeConnection()
+		if db == nil {
+			return nil, nil, fmt.Errorf("database not connected")
+		}
+
+		handler := handlers.NewAdminHandler(db)
+		result, err := handler.GetPoolStats(ctx)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
+				},
+			}, nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: result.Summary()},
+			},
+		}, result, nil
+	})
+}
+
+// schemaResourceURI identifies the resource holding the schema of every table.
+const schemaResourceURI = "schema://tables"
+
+// registerResources registers the MCP resources exposed by the server.
+func (s *Server) registerResources() {
+	s.server.AddResource(&mcp.Resource{
+		URI:         schemaResourceURI,
+		Name:        "database_schema",
+		Description: "Schema of every table in the current database (columns, indexes and foreign keys) as one JSON document",
+		MIMEType:    "application/json",
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		db, dbConfig := s.activeConnection()
+		if db == nil {
+			return nil, fmt.Errorf("database not connected")
+		}
+
+		result, err := handlers.NewSchemaHandler(db, dbConfig).GetDatabaseSchema(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		document, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to format schema: %w", err)
+		}
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{URI: schemaResourceURI, MIMEType: "application/json", Text: string(document)},
+			},
+		}, nil
+	})
+}
+
+// activeConnection returns the database and configuration of the connection
+// selected with switch_connection. The database is nil until Start has connected.
+// The configuration is a copy with ReadOnly set when read-only mode applies, including
+// when it was enabled by DB_AUTO_READ_ONLY for a read-only server.
+func (s *Server) activeConnection() (database.Database, *config.DatabaseConfig) {
+	s.mu.RLock()
+	name := s.active
+	s.mu.RUnlock()
+
+	manager, err := s.dbManager.Connection(name)
+	if err != nil {
+		return nil, nil
+	}
+
+	db := manager.GetDatabase()
+	if db != nil && s.metrics != nil {
+		db = s.metrics.Wrap(db)
+	}
+	cfg := *manager.Config()
+	cfg.ReadOnly = manager.ReadOnly()
+	return db, &cfg
+}
+
+// compareSchemas compares the schema of the target connection against that of the source
+// connection, which defaults to the active connection.
+func (s *Server) compareSchemas(ctx context.Context, sourceName, targetName string) (*schema.SchemaDiff, error) {
+	if targetName == "" {
+		return nil, fmt.Errorf("target connection is required")
+	}
+
+	source, err := s.connectionDatabase(sourceName)
+	if err != nil {
+		return nil, fmt.Errorf("source: %w", err)
+	}
+	target, err := s.connectionDatabase(targetName)
+	if err != nil {
+		return nil, fmt.Errorf("target: %w", err)
+	}
+	return schema.CompareSchemas(ctx, source, target)
+}
+
+// notificationLogger is the logger name of the log messages carrying NOTIFY payloads.
+const notificationLogger = "postgres_notify"
+
+// subscribe forwards the notifications of channel to session. The database listens on the
+// channel once, when its first session subscribes, and the session is unsubscribed from all
+// of its channels when it ends.
+func (s *Server) subscribe(ctx context.Context, session *mcp.ServerSession, channel notifyChannel) error {
+	s.listenMu.Lock()
+	defer s.listenMu.Unlock()
+
+	s.notifyMu.Lock()
+	listening := len(s.subscribers[channel]) > 0
+	s.notifyMu.Unlock()
+
+	if !listening {
+		err := channel.db.ListenForNotifications(ctx, channel.name, func(payload string) {
+			s.forwardNotification(channel, payload)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	s.notifyMu.Lock()
+	defer s.notifyMu.Unlock()
+	if s.subscribers == nil {
+		s.subscribers = make(map[notifyChannel]map[*mcp.ServerSession]struct{})
+		s.watched = make(map[*mcp.ServerSession]bool)
+	}
+	if s.subscribers[channel] == nil {
+		s.subscribers[channel] = make(map[*mcp.ServerSession]struct{})
+	}
+	s.subscribers[channel][session] = struct{}{}
+
+	if !s.watched[session] {
+		s.watched[session] = true
+		go s.unsubscribeOnClose(session)
+	}
+	return nil
+}
+
+// unsubscribe stops forwarding the notifications of channel to session. The database stops
+// listening on the channel when its last session unsubscribes.
+func (s *Server) unsubscribe(ctx context.Context, session *mcp.ServerSession, channel notifyChannel) error {
+	s.listenMu.Lock()
+	defer s.listenMu.Unlock()
+
+	s.notifyMu.Lock()
+	sessions := s.subscribers[channel]
+	_, subscribed := sessions[session]
+	delete(sessions, session)
+	last := subscribed && len(sessions) == 0
+	if last {
+		delete(s.subscribers, channel)
+	}
+	s.notifyMu.Unlock()
+
+	if !subscribed {
+		return fmt.Errorf("not listening on channel %s", channel.name)
+	}
+	if last {
+		return channel.db.Unlisten(ctx, channel.name)
+	}
+	return nil
+}
+
+// unsubscribeOnClose waits for session to end and then unsubscribes it from its channels.
+func (s *Server) unsubscribeOnClose(session *mcp.ServerSession) {
+	session.Wait()
+
+	s.notifyMu.Lock()
+	delete(s.watched, session)
+	var channels []notifyChannel
+	for channel, sessions := range s.subscribers {
+		if _, ok := sessions[session]; ok {
+			channels = append(channels, channel)
+		}
+	}
+	s.notifyMu.Unlock()
+
+	for _, channel := range channels {
+		if err := s.unsubscribe(context.Background(), session, channel); err != nil {
+			slog.Warn("Failed to stop listening on channel", "channel", channel.name, "error", err)
+		}
+	}
+}
+
+// forwardNotification sends a notification received on a NOTIFY channel to the sessions
+// subscribed to it as an MCP log message whose data holds the channel and payload. Clients
+// only receive log messages after setting a logging level.
+func (s *Server) forwardNotification(channel notifyChannel, payload string) {
+	s.notifyMu.Lock()
+	sessions := make([]*mcp.ServerSession, 0, len(s.subscribers[channel]))
+	for session := range s.subscribers[channel] {
+		sessions = append(sessions, session)
+	}
+	s.notifyMu.Unlock()
+
+	for _, session := range sessions {
+		err := session.Log(context.Background(), &mcp.LoggingMessageParams{
+			Level:  "info",
+			Logger: notificationLogger,
+			Data:   map[string]string{"channel": channel.name, "payload": payload},
+		})
+		if err != nil {
+			slog.Warn("Failed to forward notification", "channel", channel.name, "error", err)
+		}
+	}
+}
+
+// connectionDatabase returns the database of the named connection, or of the active
+// connection when name is empty. Returns an error if the connection is unknown or not connected.
+func (s *Server) connectionDatabase(name string) (database.Database, error) {
+	if name == "" {
+		s.mu.RLock()
+		name = s.active
+		s.mu.RUnlock()
+	}
+
+	manager, err := s.dbManager.Connection(name)
+	if err != nil {
+		return nil, err
+	}
+
+	db := manager.GetDatabase()
+	if db == nil {
+		return nil, fmt.Errorf("connection %q is not connected", name)
+	}
+	if s.metrics != nil {
+		db = s.metrics.Wrap(db)
+	}
+	return db, nil
+}
+
+// connectionPools returns the database of every connection, keyed by connection name,
+// for reporting connection pool metrics.
+func (s *Server) connectionPools() map[string]database.Database {
+	pools := make(map[string]database.Database)
+	for _, name := range s.dbManager.ConnectionNames() {
+		if manager, err := s.dbManager.Connection(name); err == nil {
+			pools[name] = manager.GetDatabase()
+		}
+	}
+	return pools
+}
+
+// serveMetrics serves Prometheus metrics on /metrics at the configured port until ctx is done.
+func (s *Server) serveMetrics(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", s.metrics.Handler())
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", s.config.Server.MetricsPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		httpServer.Close()
+	}()
+
+	slog.Info("Serving metrics", "addr", httpServer.Addr+"/metrics")
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		slog.Error("Metrics server failed", "error", err)
+	}
+}
+
+// switchConnection makes the named connection the one used by subsequent tool calls.
+// Returns an error if no connection with that name is configured.
+func (s *Server) switchConnection(name string) (*database.Manager, error) {
+	if name == "" {
+		return nil, fmt.Errorf("connection name is required")
+	}
+
+	manager, err := s.dbManager.Connection(name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.active = name
+	s.mu.Unlock()
+
+	slog.Info("Switched active connection", "connection", name, "db_type", manager.Config().Type)
+	return manager, nil
+}
+
+// Start begins serving MCP requests using stdio transport.
+// It establishes database connections and starts the MCP server to handle client requests.
+// The server will run until the context is cancelled or an error occurs.
+func (s *Server) Start(ctx context.Context) error {
+	s.connect(ctx)
+
+	if s.metrics != nil {
+		go s.serveMetrics(ctx)
+	}
+
+	transport := &mcp.StdioTransport{}
+
+	slog.Info("Serving MCP requests on stdio")
+
+	return s.server.Run(ctx, transport)
+}
+
+// connect establishes the database connections. A connection that cannot be established
+// does not stop the server: it is retried in the background, and connection_status
+// reports its last error until it recovers, while tools using it report that the
+// database is not connected.
+func (s *Server) connect(ctx context.Context) {
+	dbAttrs := []any{"db_type", s.config.Database.Type, "db_host", s.config.Database.Host, "db_port", s.config.Database.Port}
+
+	slog.Info("Connecting to database", append(dbAttrs, "dsn", s.dbManager.SafeDSN())...)
+	if err := s.dbManager.Connect(ctx); err != nil {
+		slog.Error("Failed to connect to database; retrying in the background", append(dbAttrs, "error", err)...)
+		s.dbManager.Reconnect(ctx)
+		return
+	}
+
+	slog.Info("Database connected successfully",
+		append(dbAttrs, "connections", strings.Join(s.dbManager.ConnectionNames(), ", "))...)
+}
+
+// Close releases resources held by the server, such as database connections and the audit log file.
+func (s *Server) Close() error {
+	err := s.dbManager.Close()
+	if s.audit != nil {
+		if auditErr := s.audit.Close(); auditErr != nil && err == nil {
+			err = auditErr
+		}
+	}
+	return err
+}
+
+// clientName returns the name and version the MCP client reported during
+// initialization, or an empty string if it is not available.
+func clientName(req *mcp.CallToolRequest) string {
+	if req == nil || req.Session == nil {
+		return ""
+	}
+
+	params := req.Session.InitializeParams()
+	if params == nil || params.ClientInfo == nil {
+		return ""
+	}
+
+	if params.ClientInfo.Version == "" {
+		return params.ClientInfo.Name
+	}
+	return params.ClientInfo.Name + "/" + params.ClientInfo.Version
+}
+
+// formatTableList joins table names for display, or returns "(none)" for an empty list.
+func formatTableList(tables []string) string {
+	if len(tables) == 0 {
+		return "(none)"
+	}
+	return strings.Join(tables, ", ")
+}
+
+// main is the entry point for the Database MCP Server.
+// It loads configuration, initializes the server, and handles graceful shutdown
+// on SIGINT and SIGTERM signals.
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	// Configuration errors are reported in text format, since LOG_FORMAT is not known yet
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// The format and level are validated when configuration is loaded
+	logFormat, _ := config.ParseLogFormat(cfg.Server.LogFormat)
+	logLevel, _ := config.ParseLogLevel(cfg.Server.LogLevel)
+	logging.Setup(logFormat, logLevel, os.Stderr)
+
+	slog.Info("Starting Database MCP Server",
+		"db_type", cfg.Database.Type, "db_host", cfg.Database.Host, "db_port", cfg.Database.Port, "log_format", logFormat)
+
+	server, err := NewServer(cfg)
+	if err != nil {
+		slog.Error("Failed to create server", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(),
+		syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	err = server.Start(ctx)
+
+	// Close explicitly rather than deferring, since os.Exit skips deferred calls
+	slog.Info("Closing database connections")
+	if closeErr := server.Close(); closeErr != nil {
+		slog.Error("Failed to close server resources", "error", closeErr)
+	}
+
+	if err != nil {
+		slog.Error("Server error", "error", err)
+		os.Exit(1)
 	}
 
-	log.Println("Server stopped gracefully")
+	slog.Info("Server stopped gracefully")
 }
@@ -1,9 +1,20 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/jhoffmann/go-database-mcp/internal/config"
+	"github.com/jhoffmann/go-database-mcp/internal/database"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 func TestNewServer(t *testing.T) {
@@ -210,3 +221,397 @@ func TestNewServer_ConnectionPoolSettings(t *testing.T) {
 		t.Errorf("Expected MaxIdleConns = 10, got %d", server.config.Database.MaxIdleConns)
 	}
 }
+
+func TestServer_SwitchConnection(t *testing.T) {
+	primary := config.DatabaseConfig{
+		Type:     "postgres",
+		Host:     "localhost",
+		Port:     5432,
+		Database: "app",
+		Username: "testuser",
+	}
+	analytics := primary
+	analytics.Type = "mysql"
+	analytics.Port = 3306
+	analytics.Database = "analytics"
+
+	cfg := &config.Config{
+		Database: primary,
+		Connections: map[string]config.DatabaseConfig{
+			config.DefaultConnection: primary,
+			"analytics":              analytics,
+		},
+	}
+
+	server, err := NewServer(cfg)
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+
+	// Databases are only available after Start connects, but the active config is tracked already
+	if _, dbConfig := server.activeConnection(); dbConfig == nil || dbConfig.Database != "app" {
+		t.Errorf("Expected default connection to be active, got %+v", dbConfig)
+	}
+
+	manager, err := server.switchConnection("analytics")
+	if err != nil {
+		t.Fatalf("switchConnection(analytics) failed: %v", err)
+	}
+	if manager.Config().Type != "mysql" {
+		t.Errorf("Expected analytics connection type mysql, got %s", manager.Config().Type)
+	}
+	if _, dbConfig := server.activeConnection(); dbConfig.Database != "analytics" {
+		t.Errorf("Expected analytics connection to be active, got %s", dbConfig.Database)
+	}
+
+	if _, err := server.switchConnection("missing"); err == nil {
+		t.Error("switchConnection(missing) expected error, got nil")
+	}
+	if _, err := server.switchConnection(""); err == nil {
+		t.Error("switchConnection(\"\") expected error, got nil")
+	}
+
+	// A failed switch keeps the previous connection active
+	if _, dbConfig := server.activeConnection(); dbConfig.Database != "analytics" {
+		t.Errorf("Expected analytics connection to remain active, got %s", dbConfig.Database)
+	}
+
+	if _, err := server.switchConnection(config.DefaultConnection); err != nil {
+		t.Fatalf("switchConnection(default) failed: %v", err)
+	}
+	if _, dbConfig := server.activeConnection(); dbConfig.Database != "app" {
+		t.Errorf("Expected default connection to be active, got %s", dbConfig.Database)
+	}
+}
+
+func TestServer_SchemaResource(t *testing.T) {
+	server, err := NewServer(&config.Config{Database: config.DatabaseConfig{Type: "postgres", Host: "localhost", Port: 5432, Database: "app", Username: "testuser"}})
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server Connect() failed: %v", err)
+	}
+	defer serverSession.Close()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client Connect() failed: %v", err)
+	}
+	defer session.Close()
+
+	resources, err := session.ListResources(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResources() failed: %v", err)
+	}
+	if len(resources.Resources) != 1 || resources.Resources[0].URI != schemaResourceURI || resources.Resources[0].MIMEType != "application/json" {
+		t.Errorf("Unexpected resources %+v", resources.Resources)
+	}
+
+	// Reading requires a connection, which only Start establishes
+	if _, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: schemaResourceURI}); err == nil || !strings.Contains(err.Error(), "database not connected") {
+		t.Errorf("Expected not connected error, got %v", err)
+	}
+}
+
+func TestServer_CompareSchemas_Errors(t *testing.T) {
+	primary := config.DatabaseConfig{
+		Type:     "postgres",
+		Host:     "localhost",
+		Port:     5432,
+		Database: "app",
+		Username: "testuser",
+	}
+	staging := primary
+	staging.Database = "app_staging"
+
+	server, err := NewServer(&config.Config{
+		Database: primary,
+		Connections: map[string]config.DatabaseConfig{
+			config.DefaultConnection: primary,
+			"staging":                staging,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		source  string
+		target  string
+		wantErr string
+	}{
+		{name: "missing target", source: "staging", wantErr: "target connection is required"},
+		{name: "unknown source", source: "missing", target: "staging", wantErr: "source: unknown connection: missing"},
+		{name: "not connected", target: "staging", wantErr: `source: connection "default" is not connected`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.compareSchemas(context.Background(), tt.source, tt.target)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("compareSchemas() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// notifyingDatabase is a database recording LISTEN and UNLISTEN, whose notify method
+// delivers a notification to the handler of its channel.
+type notifyingDatabase struct {
+	database.Database
+
+	mu        sync.Mutex
+	handlers  map[string]func(payload string)
+	listens   []string
+	unlistens []string
+}
+
+func (d *notifyingDatabase) Connect(ctx context.Context) error                  { return nil }
+func (d *notifyingDatabase) IsServerReadOnly(ctx context.Context) (bool, error) { return false, nil }
+func (d *notifyingDatabase) Close() error                                       { return nil }
+
+func (d *notifyingDatabase) ListenForNotifications(ctx context.Context, channel string, handler func(payload string)) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[channel] = handler
+	d.listens = append(d.listens, channel)
+	return nil
+}
+
+func (d *notifyingDatabase) Unlisten(ctx context.Context, channel string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.handlers, channel)
+	d.unlistens = append(d.unlistens, channel)
+	return nil
+}
+
+func (d *notifyingDatabase) notify(channel, payload string) {
+	d.mu.Lock()
+	handler := d.handlers[channel]
+	d.mu.Unlock()
+	if handler != nil {
+		handler(payload)
+	}
+}
+
+func (d *notifyingDatabase) calls() (listens, unlistens []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.Clone(d.listens), slices.Clone(d.unlistens)
+}
+
+func TestServer_ForwardNotification(t *testing.T) {
+	server, err := NewServer(&config.Config{Database: config.DatabaseConfig{Type: "postgres", Host: "localhost", Port: 5432, Database: "app", Username: "testuser"}})
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	defer server.Close()
+
+	db := &notifyingDatabase{handlers: make(map[string]func(payload string))}
+	server.dbManager.SetDatabaseFactory(func(config.DatabaseConfig) (database.Database, error) { return db, nil })
+	ctx := context.Background()
+	server.connect(ctx)
+
+	// connectClient connects a client session that listens on the given channels
+	connectClient := func(channels ...string) (*mcp.ClientSession, chan *mcp.LoggingMessageParams) {
+		t.Helper()
+		serverTransport, clientTransport := mcp.NewInMemoryTransports()
+		if _, err := server.server.Connect(ctx, serverTransport, nil); err != nil {
+			t.Fatalf("server Connect() failed: %v", err)
+		}
+
+		messages := make(chan *mcp.LoggingMessageParams, 10)
+		client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{
+			LoggingMessageHandler: func(ctx context.Context, req *mcp.LoggingMessageRequest) {
+				messages <- req.Params
+			},
+		})
+		session, err := client.Connect(ctx, clientTransport, nil)
+		if err != nil {
+			t.Fatalf("client Connect() failed: %v", err)
+		}
+		if err := session.SetLoggingLevel(ctx, &mcp.SetLoggingLevelParams{Level: "info"}); err != nil {
+			t.Fatalf("SetLoggingLevel() failed: %v", err)
+		}
+		for _, channel := range channels {
+			result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "listen_channel", Arguments: map[string]any{"channel": channel}})
+			if err != nil || result.IsError {
+				t.Fatalf("CallTool(listen_channel, %s) failed: %v %+v", channel, err, result)
+			}
+		}
+		return session, messages
+	}
+
+	expectNotification := func(messages chan *mcp.LoggingMessageParams, channel, payload string) {
+		t.Helper()
+		select {
+		case msg := <-messages:
+			if msg.Logger != notificationLogger {
+				t.Errorf("Expected logger %q, got %q", notificationLogger, msg.Logger)
+			}
+			data, ok := msg.Data.(map[string]any)
+			if !ok || data["channel"] != channel || data["payload"] != payload {
+				t.Errorf("Unexpected notification data %#v, want channel %s", msg.Data, channel)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for the notification on %s", channel)
+		}
+	}
+
+	first, firstMessages := connectClient("orders")
+	defer first.Close()
+	second, secondMessages := connectClient("orders", "invoices")
+
+	// Each notification reaches only the sessions listening on its channel
+	db.notify("orders", `{"id":42}`)
+	expectNotification(firstMessages, "orders", `{"id":42}`)
+	expectNotification(secondMessages, "orders", `{"id":42}`)
+
+	db.notify("invoices", `{"id":7}`)
+	expectNotification(secondMessages, "invoices", `{"id":7}`)
+	select {
+	case msg := <-firstMessages:
+		t.Errorf("Expected no notification for the session not listening on invoices, got %#v", msg.Data)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if listens, _ := db.calls(); !slices.Equal(listens, []string{"orders", "invoices"}) {
+		t.Errorf("Expected each channel to be listened on once, got %v", listens)
+	}
+
+	// Disconnecting the second session stops listening on the channel it alone listened on
+	second.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, unlistens := db.calls()
+		if slices.Equal(unlistens, []string{"invoices"}) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the invoices channel to be unlistened, got %v", unlistens)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// The last session unsubscribing from orders stops listening on it
+	result, err := first.CallTool(ctx, &mcp.CallToolParams{Name: "unlisten_channel", Arguments: map[string]any{"channel": "orders"}})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool(unlisten_channel) failed: %v %+v", err, result)
+	}
+	if _, unlistens := db.calls(); !slices.Equal(unlistens, []string{"invoices", "orders"}) {
+		t.Errorf("Expected orders to be unlistened after its last session, got %v", unlistens)
+	}
+
+	result, err = first.CallTool(ctx, &mcp.CallToolParams{Name: "unlisten_channel", Arguments: map[string]any{"channel": "orders"}})
+	if err != nil || len(result.Content) == 0 || !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "not listening on channel orders") {
+		t.Errorf("Expected unlistening twice to report the channel is not listened on, got %v %+v", err, result)
+	}
+}
+
+// unreachableDatabase is a database whose connection attempts fail until reachable is set.
+type unreachableDatabase struct {
+	database.Database
+	reachable *atomic.Bool
+}
+
+func (d *unreachableDatabase) Connect(ctx context.Context) error {
+	if !d.reachable.Load() {
+		return fmt.Errorf("dial tcp 10.0.0.1:5432: connection refused")
+	}
+	return nil
+}
+
+func (d *unreachableDatabase) IsServerReadOnly(ctx context.Context) (bool, error) { return false, nil }
+func (d *unreachableDatabase) Close() error                                       { return nil }
+
+func TestServer_ConnectionStatus_FailedThenRecovered(t *testing.T) {
+	cfg := config.DatabaseConfig{
+		Type:                 "postgres",
+		Host:                 "localhost",
+		Port:                 5432,
+		Database:             "app",
+		Username:             "testuser",
+		ConnectRetryInterval: 10 * time.Millisecond,
+	}
+	server, err := NewServer(&config.Config{Database: cfg})
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	defer server.Close()
+
+	var reachable atomic.Bool
+	server.dbManager.SetDatabaseFactory(func(config.DatabaseConfig) (database.Database, error) {
+		return &unreachableDatabase{reachable: &reachable}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server Connect() failed: %v", err)
+	}
+	defer serverSession.Close()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client Connect() failed: %v", err)
+	}
+	defer session.Close()
+
+	type connectionStatusResult struct {
+		Connections []database.ConnectionStatus `json:"connections"`
+	}
+	status := func() connectionStatusResult {
+		t.Helper()
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "connection_status"})
+		if err != nil {
+			t.Fatalf("CallTool(connection_status) failed: %v", err)
+		}
+		data, err := json.Marshal(result.StructuredContent)
+		if err != nil {
+			t.Fatalf("Failed to encode the structured result: %v", err)
+		}
+		var status connectionStatusResult
+		if err := json.Unmarshal(data, &status); err != nil {
+			t.Fatalf("Failed to decode the structured result: %v", err)
+		}
+		if len(status.Connections) != 1 {
+			t.Fatalf("Expected one connection, got %+v", status)
+		}
+		return status
+	}
+
+	// The failed connection leaves the server up and is reported with its error
+	server.connect(ctx)
+	failed := status().Connections[0]
+	if failed.Connected || failed.Attempts == 0 || !strings.Contains(failed.LastError, "connection refused") {
+		t.Errorf("Expected a failed connection with its last error, got %+v", failed)
+	}
+
+	// Once the database is reachable, the background retries recover the connection
+	reachable.Store(true)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		recovered := status().Connections[0]
+		if recovered.Connected {
+			if recovered.LastError != "" || recovered.Attempts <= failed.Attempts {
+				t.Errorf("Expected a recovered connection after further attempts, got %+v", recovered)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the connection to recover, last status %+v", recovered)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}